@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/mail"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,10 +17,15 @@ import (
 	"github.com/nats-io/nats.go"
 	liblogger "github.com/Ecom-micro-template/lib-common-go/logger"
 	libmiddleware "github.com/Ecom-micro-template/lib-common-go/middleware"
+	"github.com/Ecom-micro-template/service-support/internal/application"
 	"github.com/Ecom-micro-template/service-support/internal/config"
+	"github.com/Ecom-micro-template/service-support/internal/domain/assignment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/email"
 	"github.com/Ecom-micro-template/service-support/internal/events"
 	"github.com/Ecom-micro-template/service-support/internal/handlers"
 	"github.com/Ecom-micro-template/service-support/internal/infrastructure/persistence"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/storage"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -71,32 +78,249 @@ func main() {
 	sqlDB.SetConnMaxLifetime(time.Hour)
 	zapLogger.Info("Database connected")
 
-	// Initialize NATS
-	natsClient, err = nats.Connect(cfg.NatsURL)
+	// Initialize NATS. The client keeps trying to connect in the background,
+	// so events queue in the outbox while NATS is down and are relayed once
+	// it is back.
+	natsClient, err = nats.Connect(cfg.NatsURL,
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ConnectHandler(func(*nats.Conn) {
+			zapLogger.Info("NATS connected")
+		}),
+		nats.ReconnectHandler(func(*nats.Conn) {
+			zapLogger.Info("NATS reconnected")
+		}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			zapLogger.Warn("NATS disconnected (events will stay queued in the outbox)", zap.Error(err))
+		}),
+	)
 	if err != nil {
-		zapLogger.Warn("NATS connection failed (events will be disabled)", zap.Error(err))
-	} else {
+		zapLogger.Warn("NATS client could not be created (events will stay queued in the outbox)", zap.Error(err))
+		natsClient = nil
+	} else if natsClient.IsConnected() {
 		zapLogger.Info("NATS connected")
-		eventPublisher = events.NewPublisher(natsClient)
 	}
+	eventPublisher = events.NewPublisher(natsClient)
 
 	// Initialize repositories
 	ticketRepo := persistence.NewTicketRepository(db)
-	messageRepo := persistence.NewMessageRepository(db)
 	categoryRepo := persistence.NewCategoryRepository(db)
 	cannedResponseRepo := persistence.NewCannedResponseRepository(db)
+	calendarRepo := persistence.NewSLACalendarRepository(db)
+	policyRepo := persistence.NewSLAPolicyRepository(db)
+	workflowRepo := persistence.NewWorkflowRepository(db)
+	agentRepo := persistence.NewAgentRepository(db)
+	customerRepo := persistence.NewCustomerRepository(db)
+	teamRepo := persistence.NewTeamRepository(db)
+	routingRuleRepo := persistence.NewRoutingRuleRepository(db)
+	triggerRuleRepo := persistence.NewTriggerRuleRepository(db)
+	triggerFiringLog := persistence.NewTriggerFiringLog(db)
+	automationPolicyRepo := persistence.NewAutomationPolicyRepository(db)
+	ticketLinkRepo := persistence.NewTicketLinkRepository(db)
+	mentionRepo := persistence.NewTicketMentionRepository(db)
+	attachmentRepo := persistence.NewAttachmentRepository(db)
+	attachmentDownloadLog := persistence.NewAttachmentDownloadLog(db)
+	notificationTemplateRepo := persistence.NewNotificationTemplateRepository(db)
+	notificationDeliveryRepo := persistence.NewNotificationDeliveryRepository(db)
+	outboxRepo := persistence.NewOutboxRepository(db)
+	locker := persistence.NewAdvisoryLocker(db)
+	transactor := persistence.NewTransactor(db)
+	numberSequence := persistence.NewTicketNumberSequence(db)
+
+	// Initialize application services
+	numberer, err := application.NewTicketNumberer(numberSequence, cfg.TicketNumber.Format, cfg.TicketNumber.BrandFormats)
+	if err != nil {
+		zapLogger.Fatal("Invalid ticket number format", zap.Error(err))
+	}
+	strategy, err := assignment.NewStrategy(cfg.Assignment.Strategy)
+	if err != nil {
+		zapLogger.Fatal("Invalid assignment strategy", zap.Error(err))
+	}
+	var assigner *application.Assigner
+	if strategy != nil {
+		assigner = application.NewAssigner(agentRepo, teamRepo, routingRuleRepo, ticketRepo, strategy, zapLogger)
+		zapLogger.Info("Automatic assignment enabled", zap.String("strategy", strategy.Name()))
+	}
+	triggers := application.NewTriggerEngine(triggerRuleRepo, triggerFiringLog, cannedResponseRepo)
+	// Sign per-ticket reply addresses
+	var replyAddresses *email.ReplyAddresses
+	if cfg.InboundEmail.ReplySecret != "" {
+		replyAddress := cfg.InboundEmail.ReplyAddress
+		if replyAddress == "" && len(cfg.InboundEmail.Addresses) > 0 {
+			replyAddress = cfg.InboundEmail.Addresses[0]
+		}
+		replyAddresses, err = email.NewReplyAddresses(replyAddress, []byte(cfg.InboundEmail.ReplySecret))
+		if err != nil {
+			zapLogger.Fatal("Invalid email reply address", zap.Error(err))
+		}
+	}
 
-	// Initialize handlers
-	ticketHandler := handlers.NewTicketHandler(ticketRepo, messageRepo, zapLogger)
-	adminHandler := handlers.NewAdminHandler(ticketRepo, messageRepo, categoryRepo, cannedResponseRepo, zapLogger)
-
-	// Wire event publisher
-	if eventPublisher != nil {
-		ticketHandler.SetEventPublisher(eventPublisher)
-		adminHandler.SetEventPublisher(eventPublisher)
-		zapLogger.Info("Event publisher wired to handlers")
+	notifier := application.NewNotifier(notificationTemplateRepo, notificationDeliveryRepo, ticketRepo, customerRepo, replyAddresses, application.NotifierConfig{
+		Domain:        cfg.InboundEmail.Domain,
+		DefaultLocale: cfg.Notification.DefaultLocale,
+		TicketURL:     cfg.Notification.TicketURL,
+		SurveyURL:     cfg.Notification.SurveyURL,
+	}, zapLogger)
+	// Without SMTP nothing sends the notifications, so none are queued
+	var ticketNotifier *application.Notifier
+	if cfg.Notification.SMTPAddr != "" {
+		ticketNotifier = notifier
+	}
+	ticketService := application.NewTicketService(ticketRepo, transactor, categoryRepo, calendarRepo, policyRepo, workflowRepo, teamRepo, agentRepo, customerRepo, mentionRepo, attachmentRepo, numberer, assigner, triggers, ticketNotifier, zapLogger)
+	linkService := application.NewLinkService(ticketLinkRepo, ticketService, zapLogger)
+
+	// Store attachment contents locally or in an S3-compatible bucket
+	var blobs attachment.BlobStore
+	switch cfg.Attachment.Storage {
+	case "s3":
+		blobs, err = storage.NewS3BlobStore(storage.S3Config{
+			Endpoint:  cfg.Attachment.S3Endpoint,
+			Region:    cfg.Attachment.S3Region,
+			Bucket:    cfg.Attachment.S3Bucket,
+			AccessKey: cfg.Attachment.S3AccessKey,
+			SecretKey: cfg.Attachment.S3SecretKey,
+			PathStyle: cfg.Attachment.S3PathStyle,
+		}, nil)
+	case "local":
+		blobs, err = storage.NewLocalBlobStore(cfg.Attachment.Dir)
+	default:
+		err = fmt.Errorf("unknown attachment storage %q", cfg.Attachment.Storage)
+	}
+	if err != nil {
+		zapLogger.Fatal("Failed to open attachment storage", zap.Error(err))
+	}
+	// Links must not be forgeable by whoever holds the JWT secret, so
+	// without a secret of their own they are disabled
+	var linkSigner *attachment.Signer
+	if cfg.Attachment.LinkSecret == "" {
+		zapLogger.Warn("ATTACHMENT_LINK_SECRET is not set, attachment download links are disabled")
+	} else {
+		linkSigner, err = attachment.NewSigner([]byte(cfg.Attachment.LinkSecret), cfg.Attachment.LinkTTL)
+		if err != nil {
+			zapLogger.Fatal("Invalid attachment link secret", zap.Error(err))
+		}
+	}
+	attachmentService := application.NewAttachmentService(attachmentRepo, blobs, attachmentDownloadLog, ticketService, linkSigner, attachment.Limits{
+		MaxFileSize:   cfg.Attachment.MaxFileSize,
+		MaxTicketSize: cfg.Attachment.MaxTicketSize,
+		AllowedTypes:  cfg.Attachment.AllowedTypes,
+	}, zapLogger)
+	zapLogger.Info("Attachment storage ready", zap.String("storage", cfg.Attachment.Storage))
+
+	// Background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// Relay outbox events to NATS. The relay runs even while NATS is down
+	// and picks up the backlog once the client connects.
+	relay := events.NewRelay(outboxRepo, eventPublisher, events.RelayConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+	}, zapLogger)
+	go relay.Run(workerCtx)
+	zapLogger.Info("Outbox relay started")
+
+	// Keep the agent and customer directories in sync with the identity
+	// service. The subscription is replayed whenever the client (re)connects.
+	var userConsumer *events.UserConsumer
+	if natsClient != nil {
+		userSync := events.UserEventHandlers{
+			application.NewAgentSync(agentRepo, zapLogger),
+			application.NewCustomerSync(customerRepo, zapLogger),
+		}
+		userConsumer = events.NewUserConsumer(natsClient, cfg.UserEvents.Subject, cfg.UserEvents.Queue, userSync, zapLogger)
+		if err := userConsumer.Start(); err != nil {
+			zapLogger.Warn("Failed to subscribe to user events", zap.Error(err))
+		} else {
+			zapLogger.Info("User event consumer started", zap.String("subject", cfg.UserEvents.Subject))
+		}
+	}
+
+	// Record SLA breaches
+	slaMonitor := application.NewSLAMonitor(ticketService, locker, application.SLAMonitorConfig{
+		Interval:         cfg.SLA.MonitorInterval,
+		WarningThreshold: cfg.SLA.WarningThreshold,
+	}, zapLogger)
+	go slaMonitor.Run(workerCtx)
+
+	// Close, remind and flag idle tickets
+	automations := application.NewAutomationScheduler(ticketService, automationPolicyRepo, locker, application.AutomationConfig{
+		Interval:  cfg.Automation.Interval,
+		BatchSize: cfg.Automation.BatchSize,
+	}, zapLogger)
+	go automations.Run(workerCtx)
+
+	// Email customers about their tickets
+	if cfg.Notification.SMTPAddr != "" {
+		fromAddress := cfg.Notification.FromAddress
+		if fromAddress == "" && len(cfg.InboundEmail.Addresses) > 0 {
+			fromAddress = cfg.InboundEmail.Addresses[0]
+		}
+		if fromAddress == "" {
+			zapLogger.Fatal("NOTIFY_FROM_ADDRESS is required to send notifications")
+		}
+		mailer := email.NewSMTPMailer(email.SMTPMailerConfig{
+			Addr:      cfg.Notification.SMTPAddr,
+			Username:  cfg.Notification.SMTPUsername,
+			Password:  cfg.Notification.SMTPPassword,
+			HelloName: cfg.InboundEmail.Domain,
+		})
+		sender := application.NewNotificationSender(notificationDeliveryRepo, mailer, application.NotificationSenderConfig{
+			From:         mail.Address{Name: cfg.Notification.FromName, Address: fromAddress},
+			PollInterval: cfg.Notification.PollInterval,
+			BatchSize:    cfg.Notification.BatchSize,
+			MaxAttempts:  cfg.Notification.MaxAttempts,
+		}, zapLogger)
+		go sender.Run(workerCtx)
+		zapLogger.Info("Notification sender started", zap.String("smtp_addr", cfg.Notification.SMTPAddr))
 	}
 
+	// Turn inbound email into tickets and replies
+	inboundEmail := application.NewInboundEmail(ticketService, notificationDeliveryRepo, attachmentService, replyAddresses, application.InboundEmailConfig{
+		Addresses: cfg.InboundEmail.Addresses,
+	}, zapLogger)
+	if cfg.InboundEmail.SMTPAddr != "" {
+		smtpServer := email.NewSMTPServer(email.SMTPConfig{
+			Addr:       cfg.InboundEmail.SMTPAddr,
+			Domain:     cfg.InboundEmail.Domain,
+			Recipients: cfg.InboundEmail.Addresses,
+			MaxSize:    cfg.InboundEmail.MaxSize,
+		}, inboundEmail, zapLogger)
+		go func() {
+			if err := smtpServer.ListenAndServe(workerCtx); err != nil {
+				zapLogger.Error("Inbound SMTP server stopped", zap.Error(err))
+			}
+		}()
+		zapLogger.Info("Inbound SMTP server started", zap.String("addr", cfg.InboundEmail.SMTPAddr))
+	}
+	if cfg.InboundEmail.MaildirPath != "" {
+		maildir, err := email.NewMaildir(cfg.InboundEmail.MaildirPath)
+		if err != nil {
+			zapLogger.Fatal("Failed to open inbound maildir", zap.Error(err))
+		}
+		poller := email.NewPoller(maildir, inboundEmail, email.PollerConfig{
+			Interval: cfg.InboundEmail.PollInterval,
+		}, zapLogger)
+		go poller.Run(workerCtx)
+		zapLogger.Info("Inbound mailbox poller started", zap.String("maildir", cfg.InboundEmail.MaildirPath))
+	}
+
+	// Initialize handlers
+	ticketHandler := handlers.NewTicketHandler(ticketService, ticketRepo, categoryRepo, agentRepo, zapLogger)
+	adminHandler := handlers.NewAdminHandler(ticketService, linkService, ticketRepo, categoryRepo, agentRepo, cannedResponseRepo, zapLogger)
+	slaHandler := handlers.NewSLAHandler(calendarRepo, policyRepo, categoryRepo, zapLogger)
+	automationHandler := handlers.NewAutomationHandler(automationPolicyRepo, categoryRepo, zapLogger)
+	workflowHandler := handlers.NewWorkflowHandler(workflowRepo, categoryRepo, zapLogger)
+	agentHandler := handlers.NewAgentHandler(agentRepo, ticketRepo, zapLogger)
+	teamHandler := handlers.NewTeamHandler(teamRepo, ticketService, ticketRepo, categoryRepo, agentRepo, zapLogger)
+	routingHandler := handlers.NewRoutingHandler(routingRuleRepo, assigner, ticketRepo, categoryRepo, teamRepo, zapLogger)
+	linkHandler := handlers.NewLinkHandler(linkService, ticketService, categoryRepo, agentRepo, zapLogger)
+	mentionHandler := handlers.NewMentionHandler(ticketService, zapLogger)
+	triggerHandler := handlers.NewTriggerHandler(triggerRuleRepo, ticketService, categoryRepo, teamRepo, agentRepo, cannedResponseRepo, zapLogger)
+	notificationHandler := handlers.NewNotificationHandler(notificationTemplateRepo, notifier, ticketRepo, zapLogger)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService, strings.TrimSuffix(cfg.Attachment.PublicURL, "/")+"/api/v1/support/attachments", zapLogger)
+
 	// Setup router
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -160,20 +384,10 @@ func main() {
 			support.POST("/contact", ticketHandler.SubmitContactForm)
 
 			// Categories (public - for contact form dropdown)
-			support.GET("/categories", func(c *gin.Context) {
-				categories, err := categoryRepo.List(c.Request.Context(), true)
-				if err != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{
-						"success": false,
-						"error":   gin.H{"message": "Failed to retrieve categories"},
-					})
-					return
-				}
-				c.JSON(http.StatusOK, gin.H{
-					"success": true,
-					"data":    categories,
-				})
-			})
+			support.GET("/categories", ticketHandler.ListCategories)
+
+			// Attachment downloads (signed link required)
+			support.GET("/attachments/:attachment_id/download", attachmentHandler.Download)
 
 			// Authenticated customer routes
 			authed := support.Group("")
@@ -183,7 +397,11 @@ func main() {
 				authed.GET("/tickets", ticketHandler.List)
 				authed.GET("/tickets/:id", ticketHandler.GetByID)
 				authed.POST("/tickets/:id/messages", ticketHandler.AddMessage)
+				authed.POST("/tickets/:id/attachments", attachmentHandler.Upload)
+				authed.DELETE("/tickets/:id/attachments/:attachment_id", attachmentHandler.Delete)
+				authed.GET("/tickets/:id/attachments/:attachment_id/link", attachmentHandler.Link)
 				authed.POST("/tickets/:id/rate", ticketHandler.RateTicket)
+				authed.PUT("/tickets/:id/cc", ticketHandler.SetCC)
 			}
 		}
 
@@ -200,7 +418,36 @@ func main() {
 			admin.GET("/tickets/:id", adminHandler.GetTicket)
 			admin.PUT("/tickets/:id", adminHandler.UpdateTicket)
 			admin.POST("/tickets/:id/reply", adminHandler.ReplyToTicket)
+			admin.POST("/tickets/:id/merge", adminHandler.MergeTickets)
+			admin.POST("/tickets/:id/split", adminHandler.SplitTicket)
 			admin.PUT("/tickets/:id/assign", adminHandler.AssignTicket)
+			admin.DELETE("/tickets/:id/assign", adminHandler.UnassignTicket)
+			admin.PUT("/tickets/:id/team", adminHandler.AssignTicketTeam)
+			admin.GET("/tickets/:id/routing", routingHandler.ExplainRouting)
+			admin.POST("/tickets/:id/resolve", linkHandler.ResolveIncident)
+
+			// Linked tickets
+			admin.GET("/tickets/:id/links", linkHandler.ListLinks)
+			admin.POST("/tickets/:id/links", linkHandler.CreateLink)
+			admin.DELETE("/tickets/:id/links/:link_id", linkHandler.DeleteLink)
+
+			// Ticket watchers
+			admin.POST("/tickets/:id/watchers", adminHandler.WatchTicket)
+			admin.DELETE("/tickets/:id/watchers/:agent_id", adminHandler.UnwatchTicket)
+
+			// Files uploaded to the ticket
+			admin.GET("/tickets/:id/attachments", attachmentHandler.List)
+			admin.POST("/tickets/:id/attachments", attachmentHandler.AdminUpload)
+			admin.DELETE("/tickets/:id/attachments/:attachment_id", attachmentHandler.AdminDelete)
+			admin.GET("/tickets/:id/attachments/:attachment_id/link", attachmentHandler.AdminLink)
+			admin.GET("/tickets/:id/attachments/:attachment_id/downloads", attachmentHandler.ListDownloads)
+
+			// Customer notifications sent about the ticket
+			admin.GET("/tickets/:id/notifications", notificationHandler.ListDeliveries)
+
+			// Mentions of the signed-in agent
+			admin.GET("/mentions", mentionHandler.ListMine)
+			admin.POST("/mentions/:id/read", mentionHandler.MarkRead)
 
 			// Category management
 			admin.GET("/categories", adminHandler.ListCategories)
@@ -213,6 +460,77 @@ func main() {
 			admin.POST("/canned-responses", adminHandler.CreateCannedResponse)
 			admin.PUT("/canned-responses/:id", adminHandler.UpdateCannedResponse)
 			admin.DELETE("/canned-responses/:id", adminHandler.DeleteCannedResponse)
+
+			// SLA calendars
+			admin.GET("/sla/calendars", slaHandler.ListCalendars)
+			admin.POST("/sla/calendars", slaHandler.CreateCalendar)
+			admin.GET("/sla/calendars/:id", slaHandler.GetCalendar)
+			admin.PUT("/sla/calendars/:id", slaHandler.UpdateCalendar)
+			admin.DELETE("/sla/calendars/:id", slaHandler.DeleteCalendar)
+			admin.POST("/sla/calendars/:id/holidays", slaHandler.AddHoliday)
+			admin.DELETE("/sla/calendars/:id/holidays/:date", slaHandler.RemoveHoliday)
+
+			// SLA policies
+			admin.GET("/sla/policies", slaHandler.ListPolicies)
+			admin.POST("/sla/policies", slaHandler.CreatePolicy)
+			admin.PUT("/sla/policies/:id", slaHandler.UpdatePolicy)
+			admin.DELETE("/sla/policies/:id", slaHandler.DeletePolicy)
+
+			// Time-based automation policies
+			admin.GET("/automation/policies", automationHandler.ListPolicies)
+			admin.POST("/automation/policies", automationHandler.CreatePolicy)
+			admin.PUT("/automation/policies/:id", automationHandler.UpdatePolicy)
+			admin.DELETE("/automation/policies/:id", automationHandler.DeletePolicy)
+
+			// Customer notification templates
+			admin.GET("/notifications/templates", notificationHandler.ListTemplates)
+			admin.POST("/notifications/templates", notificationHandler.CreateTemplate)
+			admin.POST("/notifications/templates/preview", notificationHandler.PreviewTemplate)
+			admin.GET("/notifications/templates/:id", notificationHandler.GetTemplate)
+			admin.PUT("/notifications/templates/:id", notificationHandler.UpdateTemplate)
+			admin.DELETE("/notifications/templates/:id", notificationHandler.DeleteTemplate)
+
+			// Ticket workflows
+			admin.GET("/workflows", workflowHandler.ListWorkflows)
+			admin.POST("/workflows", workflowHandler.CreateWorkflow)
+			admin.GET("/workflows/:id", workflowHandler.GetWorkflow)
+			admin.PUT("/workflows/:id", workflowHandler.UpdateWorkflow)
+			admin.DELETE("/workflows/:id", workflowHandler.DeleteWorkflow)
+
+			// Agent directory
+			admin.GET("/agents", agentHandler.ListAgents)
+			admin.POST("/agents", agentHandler.CreateAgent)
+			admin.PUT("/agents/me/status", agentHandler.SetMyStatus)
+			admin.GET("/agents/:id", agentHandler.GetAgent)
+			admin.PUT("/agents/:id", agentHandler.UpdateAgent)
+			admin.DELETE("/agents/:id", agentHandler.DeleteAgent)
+			admin.PUT("/agents/:id/status", agentHandler.SetAgentStatus)
+
+			// Teams and their queues
+			admin.GET("/teams", teamHandler.ListTeams)
+			admin.POST("/teams", teamHandler.CreateTeam)
+			admin.GET("/teams/:id", teamHandler.GetTeam)
+			admin.PUT("/teams/:id", teamHandler.UpdateTeam)
+			admin.DELETE("/teams/:id", teamHandler.DeleteTeam)
+			admin.POST("/teams/:id/members", teamHandler.AddTeamMember)
+			admin.DELETE("/teams/:id/members/:agent_id", teamHandler.RemoveTeamMember)
+			admin.GET("/queues/:team", teamHandler.GetQueue)
+
+			// Skills-based routing
+			admin.GET("/routing-rules", routingHandler.ListRoutingRules)
+			admin.POST("/routing-rules", routingHandler.CreateRoutingRule)
+			admin.GET("/routing-rules/:id", routingHandler.GetRoutingRule)
+			admin.PUT("/routing-rules/:id", routingHandler.UpdateRoutingRule)
+			admin.DELETE("/routing-rules/:id", routingHandler.DeleteRoutingRule)
+
+			// Trigger rules
+			admin.GET("/triggers", triggerHandler.ListTriggerRules)
+			admin.POST("/triggers", triggerHandler.CreateTriggerRule)
+			admin.POST("/triggers/dry-run", triggerHandler.DryRunTriggers)
+			admin.GET("/triggers/firings", triggerHandler.ListTriggerFirings)
+			admin.GET("/triggers/:id", triggerHandler.GetTriggerRule)
+			admin.PUT("/triggers/:id", triggerHandler.UpdateTriggerRule)
+			admin.DELETE("/triggers/:id", triggerHandler.DeleteTriggerRule)
 		}
 	}
 
@@ -240,6 +558,14 @@ func main() {
 
 	zapLogger.Info("Shutting down server...")
 
+	stopWorkers()
+
+	if userConsumer != nil {
+		if err := userConsumer.Stop(); err != nil {
+			zapLogger.Warn("Failed to drain user events", zap.Error(err))
+		}
+	}
+
 	if natsClient != nil {
 		natsClient.Close()
 		zapLogger.Info("NATS connection closed")
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+	"go.uber.org/zap"
+)
+
+// AgentSync keeps the agent directory in line with the identity service.
+// Staff users are added as offline agents and deleted users are removed.
+// Users who are deactivated or leave staff roles are set offline, so they
+// get no new tickets but their name still shows on their old ones.
+// Support-specific profile fields such as teams and signature are left
+// alone.
+type AgentSync struct {
+	agents agent.Repository
+	logger *zap.Logger
+}
+
+var _ events.UserEventHandler = (*AgentSync)(nil)
+
+// NewAgentSync creates a new agent sync
+func NewAgentSync(agents agent.Repository, logger *zap.Logger) *AgentSync {
+	return &AgentSync{agents: agents, logger: logger}
+}
+
+// HandleUserEvent applies a user event to the agent directory. Events of
+// unknown type or for unknown users are ignored.
+func (s *AgentSync) HandleUserEvent(ctx context.Context, event events.UserEvent) error {
+	id, err := uuid.Parse(event.UserID)
+	if err != nil {
+		s.logger.Warn("Ignoring user event without a valid user ID", zap.String("user_id", event.UserID))
+		return nil
+	}
+
+	switch event.Type {
+	case events.UserDeleted:
+		if err := s.agents.Delete(ctx, id); err != nil && !errors.Is(err, agent.ErrAgentNotFound) {
+			return err
+		}
+		return nil
+	case events.UserCreated, events.UserUpdated, events.UserRoleChanged, events.UserDeactivated:
+	default:
+		return nil
+	}
+
+	leaving := event.Type == events.UserDeactivated ||
+		(event.IsActive != nil && !*event.IsActive) ||
+		(event.Role != "" && !agent.IsStaffRole(event.Role))
+
+	a, err := s.agents.FindByID(ctx, id)
+	switch {
+	case errors.Is(err, agent.ErrAgentNotFound):
+		if leaving || !agent.IsStaffRole(event.Role) {
+			return nil
+		}
+		name := event.DisplayName()
+		if name == "" {
+			name = event.Email
+		}
+		a, err = agent.NewAgent(agent.AgentParams{
+			ID:    id,
+			Name:  name,
+			Email: event.Email,
+			Role:  event.Role,
+		})
+		if err != nil {
+			return err
+		}
+		s.logger.Info("Adding agent from user event", zap.String("agent_id", id.String()))
+	case err != nil:
+		return err
+	default:
+		a.SyncIdentity(event.DisplayName(), event.Email, event.Role)
+		if leaving {
+			if err := a.SetStatus(agent.StatusOffline); err != nil {
+				return err
+			}
+		}
+	}
+	return s.agents.Save(ctx, a)
+}
@@ -0,0 +1,279 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/assignment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// Assigner routes unassigned tickets to an agent. The first routing rule
+// that matches the ticket picks the available agent best at the skills it
+// requires, or queues the ticket for its fallback team when no agent
+// qualifies. Tickets no rule matches go to the agent the assignment
+// strategy picks. The pool is every online agent of the ticket's team, or
+// every online agent in the agent directory for tickets without a team.
+type Assigner struct {
+	agents   agent.Repository
+	teams    team.Repository
+	rules    routing.Repository
+	tickets  ticket.Repository
+	strategy assignment.Strategy
+	logger   *zap.Logger
+}
+
+// NewAssigner creates an assigner for the routing rules and strategy.
+func NewAssigner(agents agent.Repository, teams team.Repository, rules routing.Repository, tickets ticket.Repository, strategy assignment.Strategy, logger *zap.Logger) *Assigner {
+	return &Assigner{
+		agents:   agents,
+		teams:    teams,
+		rules:    rules,
+		tickets:  tickets,
+		strategy: strategy,
+		logger:   logger,
+	}
+}
+
+// Strategy returns the name of the assigner's strategy.
+func (a *Assigner) Strategy() string {
+	return a.strategy.Name()
+}
+
+// RuleEvaluation is the outcome of one routing rule for a ticket.
+type RuleEvaluation struct {
+	Rule       *routing.Rule
+	Matched    bool
+	Mismatches []string
+}
+
+// CandidateEvaluation is how an agent of the pool fared. Qualified and
+// Score are only set when a rule matched.
+type CandidateEvaluation struct {
+	Agent       *agent.Agent
+	OpenTickets int
+	Eligible    bool // online with spare capacity
+	Qualified   bool // has the matched rule's skills
+	Score       int
+}
+
+// RoutingExplanation describes how the assigner routes a ticket: the
+// ticket's attributes, every rule it was checked against, the agents
+// considered and the outcome. AgentID is nil when the ticket stays
+// unassigned; FallbackTeamID is set when it is queued for a team.
+type RoutingExplanation struct {
+	Strategy       string
+	Attributes     routing.Attributes
+	Rules          []RuleEvaluation
+	MatchedRule    *routing.Rule
+	Candidates     []CandidateEvaluation
+	AgentID        *uuid.UUID
+	FallbackTeamID *uuid.UUID
+	Reason         string
+}
+
+// Explain reports how the ticket would be routed now, without changing it.
+func (a *Assigner) Explain(ctx context.Context, t *ticket.Ticket) (*RoutingExplanation, error) {
+	explanation, _, err := a.route(ctx, t, nil)
+	return explanation, err
+}
+
+// Assign routes the ticket, leaving out the excluded agent, and returns the
+// agent it was assigned to. It returns nil when no agent is available or
+// qualifies; the ticket then stays unassigned, in the matched rule's
+// fallback team queue if it has one. The pick is recorded on the agent with
+// Record once the ticket is saved.
+func (a *Assigner) Assign(ctx context.Context, t *ticket.Ticket, exclude *uuid.UUID) (*agent.Agent, error) {
+	explanation, picked, err := a.route(ctx, t, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if explanation.FallbackTeamID != nil {
+		if err := t.AssignTeam(explanation.FallbackTeamID); err != nil {
+			return nil, err
+		}
+		a.logger.Info("No qualified agent available, ticket queued for the fallback team",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("rule", explanation.MatchedRule.Name()),
+			zap.String("team_id", explanation.FallbackTeamID.String()))
+		return nil, nil
+	}
+	if picked == nil {
+		return nil, nil
+	}
+	if err := t.AutoAssign(picked.ID(), explanation.Reason); err != nil {
+		return nil, err
+	}
+	return picked, nil
+}
+
+// route works out where the ticket goes and returns the picked agent, if
+// any, with the explanation.
+func (a *Assigner) route(ctx context.Context, t *ticket.Ticket, exclude *uuid.UUID) (*RoutingExplanation, *agent.Agent, error) {
+	explanation := &RoutingExplanation{
+		Strategy:   a.strategy.Name(),
+		Attributes: routing.AttributesOf(t),
+		Rules:      make([]RuleEvaluation, 0),
+		Candidates: make([]CandidateEvaluation, 0),
+	}
+
+	rules, err := a.rules.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rule := range rules {
+		mismatches := rule.Mismatches(explanation.Attributes)
+		matched := len(mismatches) == 0 && explanation.MatchedRule == nil
+		if matched {
+			explanation.MatchedRule = rule
+		}
+		explanation.Rules = append(explanation.Rules, RuleEvaluation{Rule: rule, Matched: matched, Mismatches: mismatches})
+	}
+
+	agents, load, err := a.pool(ctx, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates := make([]assignment.Candidate, 0, len(agents))
+	for _, ag := range agents {
+		if exclude != nil && ag.ID() == *exclude {
+			continue
+		}
+		candidates = append(candidates, assignment.Candidate{Agent: ag, OpenTickets: load[ag.ID()]})
+	}
+
+	if rule := explanation.MatchedRule; rule != nil {
+		picked := a.pickSkilled(rule, candidates, explanation)
+		if picked == nil {
+			explanation.FallbackTeamID = rule.FallbackTeamID()
+			explanation.Reason = fmt.Sprintf("rule %q: no qualified agent available", rule.Name())
+			if t.TeamID() != nil && rule.FallbackTeamID() != nil && *t.TeamID() == *rule.FallbackTeamID() {
+				explanation.FallbackTeamID = nil
+			}
+		}
+		return explanation, picked, nil
+	}
+
+	for _, c := range candidates {
+		explanation.Candidates = append(explanation.Candidates, CandidateEvaluation{
+			Agent:       c.Agent,
+			OpenTickets: c.OpenTickets,
+			Eligible:    c.Agent.IsAvailable() && c.Agent.HasCapacityFor(c.OpenTickets),
+		})
+	}
+
+	var req assignment.Request
+	if a.strategy.Name() == assignment.StrategySticky {
+		req.PreviousAgentID, err = a.tickets.LastAssignee(ctx, t.CustomerID(), t.GuestEmail())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	decision, ok := a.strategy.Pick(req, candidates)
+	if !ok {
+		explanation.Reason = "no agent available"
+		return explanation, nil, nil
+	}
+	explanation.AgentID = &decision.AgentID
+	explanation.Reason = decision.Reason
+	for _, ag := range agents {
+		if ag.ID() == decision.AgentID {
+			return explanation, ag, nil
+		}
+	}
+	return explanation, nil, nil
+}
+
+// pool returns the online agents the ticket may go to with their number of
+// active tickets.
+func (a *Assigner) pool(ctx context.Context, t *ticket.Ticket) ([]*agent.Agent, map[uuid.UUID]int, error) {
+	filter := agent.Filter{Status: agent.StatusOnline}
+	if t.TeamID() != nil {
+		tm, err := a.teams.FindByID(ctx, *t.TeamID())
+		if err != nil {
+			return nil, nil, err
+		}
+		filter.Team = tm.Key()
+	}
+
+	agents, err := a.agents.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(agents) == 0 {
+		return agents, nil, nil
+	}
+
+	load, err := a.tickets.CountActiveByAssignee(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return agents, load, nil
+}
+
+// pickSkilled picks the eligible agent that qualifies for the rule with the
+// highest skill score, preferring fewer open tickets on a tie. Every
+// candidate is recorded in the explanation.
+func (a *Assigner) pickSkilled(rule *routing.Rule, candidates []assignment.Candidate, explanation *RoutingExplanation) *agent.Agent {
+	var best *CandidateEvaluation
+	for _, c := range candidates {
+		score, qualified := rule.Score(c.Agent)
+		explanation.Candidates = append(explanation.Candidates, CandidateEvaluation{
+			Agent:       c.Agent,
+			OpenTickets: c.OpenTickets,
+			Eligible:    c.Agent.IsAvailable() && c.Agent.HasCapacityFor(c.OpenTickets),
+			Qualified:   qualified,
+			Score:       score,
+		})
+	}
+
+	// Best candidates first, so the explanation reads as a ranking
+	sort.SliceStable(explanation.Candidates, func(i, j int) bool {
+		x, y := explanation.Candidates[i], explanation.Candidates[j]
+		if x.Score != y.Score {
+			return x.Score > y.Score
+		}
+		if x.OpenTickets != y.OpenTickets {
+			return x.OpenTickets < y.OpenTickets
+		}
+		return x.Agent.ID().String() < y.Agent.ID().String()
+	})
+	for i := range explanation.Candidates {
+		if c := &explanation.Candidates[i]; c.Eligible && c.Qualified {
+			best = c
+			break
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	id := best.Agent.ID()
+	explanation.AgentID = &id
+	explanation.Reason = fmt.Sprintf("rule %q: best skill match (score %d) with %d open tickets",
+		rule.Name(), best.Score, best.OpenTickets)
+	return best.Agent
+}
+
+// Record notes the assignment on the agent so round-robin moves past it.
+// Only the assignment time is written, so a profile or status change made
+// since the agent was loaded is kept. Failures are logged; they only affect
+// whose turn is next.
+func (a *Assigner) Record(ctx context.Context, ag *agent.Agent) {
+	now := time.Now()
+	ag.RecordAssignment(now)
+	if err := a.agents.RecordAssignment(ctx, ag.ID(), now); err != nil {
+		a.logger.Warn("Failed to record agent assignment",
+			zap.String("agent_id", ag.ID().String()),
+			zap.Error(err))
+	}
+}
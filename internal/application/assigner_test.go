@@ -0,0 +1,424 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/assignment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/memory"
+	"go.uber.org/zap"
+)
+
+// routedAgent is an agent of the assigner tests with the number of open
+// tickets it holds.
+type routedAgent struct {
+	name   string
+	status agent.Status
+	teams  []string
+	skills []agent.Skill
+	open   int
+}
+
+// skill returns the skills of an agent with the one skill at the level.
+func skill(name string, level int) []agent.Skill {
+	return []agent.Skill{{Name: name, Level: level}}
+}
+
+func TestAssignerRouting(t *testing.T) {
+	returnsTeam, err := team.NewTeam(team.TeamParams{Key: "returns", Name: "Returns"})
+	if err != nil {
+		t.Fatalf("NewTeam: %v", err)
+	}
+	returnsID := returnsTeam.ID()
+
+	refunds := routing.RuleParams{
+		Name:           "Refunds",
+		Position:       2,
+		Enabled:        true,
+		Condition:      routing.Condition{Tags: []string{"refund"}},
+		RequiredSkills: []routing.SkillRequirement{{Name: "refunds", MinLevel: 3}},
+		FallbackTeamID: &returnsID,
+	}
+	vip := routing.RuleParams{
+		Name:           "VIP",
+		Position:       1,
+		Enabled:        true,
+		Condition:      routing.Condition{Tags: []string{"vip"}},
+		RequiredSkills: []routing.SkillRequirement{{Name: "vip", MinLevel: 1}},
+	}
+	malay := routing.RuleParams{
+		Name:           "Malay",
+		Position:       3,
+		Enabled:        true,
+		Condition:      routing.Condition{Languages: []string{routing.LanguageMalay}},
+		RequiredSkills: []routing.SkillRequirement{{Name: "malay", MinLevel: 2}},
+	}
+	disabled := func(p routing.RuleParams) routing.RuleParams {
+		p.Enabled = false
+		return p
+	}
+
+	tests := []struct {
+		name         string
+		rules        []routing.RuleParams
+		agents       []routedAgent
+		tags         []string
+		message      string
+		ticketTeam   bool
+		exclude      string
+		wantAgent    string
+		wantRule     string
+		wantFallback bool
+		wantReason   string
+	}{
+		{
+			name:  "best skill score wins",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 3)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 5), open: 1},
+				{name: "Chen", status: agent.StatusAway, skills: skill("refunds", 5)},
+			},
+			tags:       []string{"refund"},
+			wantAgent:  "Ben",
+			wantRule:   "Refunds",
+			wantReason: `rule "Refunds": best skill match (score 5) with 1 open tickets`,
+		},
+		{
+			name:  "equal scores go to the agent with fewer open tickets",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 4), open: 2},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 4), open: 1},
+			},
+			tags:      []string{"refund"},
+			wantAgent: "Ben",
+			wantRule:  "Refunds",
+		},
+		{
+			name:  "full agents are passed over",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 3)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 5), open: 3},
+			},
+			tags:      []string{"refund"},
+			wantAgent: "Aina",
+			wantRule:  "Refunds",
+		},
+		{
+			name:  "the first matching rule by position routes",
+			rules: []routing.RuleParams{refunds, vip},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 5)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("vip", 1), open: 2},
+			},
+			tags:      []string{"refund", "vip"},
+			wantAgent: "Ben",
+			wantRule:  "VIP",
+		},
+		{
+			name:  "disabled rules are skipped",
+			rules: []routing.RuleParams{refunds, disabled(vip)},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 5)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("vip", 1)},
+			},
+			tags:      []string{"refund", "vip"},
+			wantAgent: "Aina",
+			wantRule:  "Refunds",
+		},
+		{
+			name:  "rule on the detected language",
+			rules: []routing.RuleParams{malay},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("malay", 4), open: 2},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("malay", 1)},
+			},
+			message:   "Bila barang saya akan sampai? Belum terima lagi.",
+			wantAgent: "Aina",
+			wantRule:  "Malay",
+		},
+		{
+			name:  "no qualified agent queues the ticket for the fallback team",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 2)},
+				{name: "Ben", status: agent.StatusOffline, skills: skill("refunds", 5)},
+			},
+			tags:         []string{"refund"},
+			wantRule:     "Refunds",
+			wantFallback: true,
+			wantReason:   `rule "Refunds": no qualified agent available`,
+		},
+		{
+			name:  "no qualified agent and no fallback team leaves the ticket unassigned",
+			rules: []routing.RuleParams{vip},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 5)},
+			},
+			tags:     []string{"vip"},
+			wantRule: "VIP",
+		},
+		{
+			name:  "ticket already in the fallback team stays queued",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, teams: []string{"returns"}, skills: skill("refunds", 1)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 5)},
+			},
+			tags:       []string{"refund"},
+			ticketTeam: true,
+			wantRule:   "Refunds",
+		},
+		{
+			name:  "team tickets go to the team's agents",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, teams: []string{"returns"}, skills: skill("refunds", 3), open: 2},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 5)},
+			},
+			tags:       []string{"refund"},
+			ticketTeam: true,
+			wantAgent:  "Aina",
+			wantRule:   "Refunds",
+		},
+		{
+			name:  "the excluded agent is left out",
+			rules: []routing.RuleParams{refunds},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, skills: skill("refunds", 3)},
+				{name: "Ben", status: agent.StatusOnline, skills: skill("refunds", 5)},
+			},
+			tags:      []string{"refund"},
+			exclude:   "Ben",
+			wantAgent: "Aina",
+			wantRule:  "Refunds",
+		},
+		{
+			name:  "no matching rule falls back to the strategy",
+			rules: []routing.RuleParams{refunds, vip},
+			agents: []routedAgent{
+				{name: "Aina", status: agent.StatusOnline, open: 2},
+				{name: "Ben", status: agent.StatusOnline, open: 1},
+				{name: "Chen", status: agent.StatusOffline},
+			},
+			tags:       []string{"shipping"},
+			wantAgent:  "Ben",
+			wantReason: "least loaded: 1 open tickets",
+		},
+		{
+			name:       "nobody online",
+			rules:      []routing.RuleParams{refunds},
+			agents:     []routedAgent{{name: "Aina", status: agent.StatusAway}},
+			wantReason: "no agent available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			agents := memory.NewAgentRepository()
+			teams := memory.NewTeamRepository()
+			rules := memory.NewRoutingRuleRepository()
+			tickets := memory.NewTicketRepository()
+			if err := teams.Save(ctx, returnsTeam); err != nil {
+				t.Fatalf("Save team: %v", err)
+			}
+			for _, p := range tt.rules {
+				rule, err := routing.NewRule(p)
+				if err != nil {
+					t.Fatalf("NewRule: %v", err)
+				}
+				if err := rules.Save(ctx, rule); err != nil {
+					t.Fatalf("Save rule: %v", err)
+				}
+			}
+			ids := make(map[string]uuid.UUID)
+			names := make(map[uuid.UUID]string)
+			for _, ra := range tt.agents {
+				ag := agent.Reconstitute(agent.ReconstituteParams{
+					ID:                   uuid.New(),
+					Name:                 ra.name,
+					Email:                strings.ToLower(ra.name) + "@shop.test",
+					Teams:                ra.teams,
+					Skills:               ra.skills,
+					Status:               string(ra.status),
+					MaxConcurrentTickets: 3,
+				})
+				if err := agents.Save(ctx, ag); err != nil {
+					t.Fatalf("Save agent: %v", err)
+				}
+				ids[ra.name], names[ag.ID()] = ag.ID(), ra.name
+				for i := 0; i < ra.open; i++ {
+					storeAssigned(t, tickets, ag.ID())
+				}
+			}
+
+			tk := routedTicket(t, tt.tags, tt.message)
+			if tt.ticketTeam {
+				if err := tk.AssignTeam(&returnsID); err != nil {
+					t.Fatalf("AssignTeam: %v", err)
+				}
+			}
+			var exclude *uuid.UUID
+			if tt.exclude != "" {
+				id := ids[tt.exclude]
+				exclude = &id
+			}
+
+			assigner := NewAssigner(agents, teams, rules, tickets, assignment.LeastLoaded{}, zap.NewNop())
+			explanation, picked, err := assigner.route(ctx, tk, exclude)
+			if err != nil {
+				t.Fatalf("route: %v", err)
+			}
+
+			gotRule := ""
+			if explanation.MatchedRule != nil {
+				gotRule = explanation.MatchedRule.Name()
+			}
+			if gotRule != tt.wantRule {
+				t.Fatalf("matched rule = %q, want %q", gotRule, tt.wantRule)
+			}
+			gotAgent := ""
+			if picked != nil {
+				gotAgent = names[picked.ID()]
+			}
+			if gotAgent != tt.wantAgent {
+				t.Fatalf("picked %q, want %q (%s)", gotAgent, tt.wantAgent, explanation.Reason)
+			}
+			if (explanation.AgentID != nil) != (picked != nil) {
+				t.Fatalf("explanation agent = %v, picked %v", explanation.AgentID, picked)
+			}
+			if (explanation.FallbackTeamID != nil) != tt.wantFallback {
+				t.Fatalf("fallback team = %v, want fallback %v", explanation.FallbackTeamID, tt.wantFallback)
+			}
+			if tt.wantReason != "" && explanation.Reason != tt.wantReason {
+				t.Fatalf("reason = %q, want %q", explanation.Reason, tt.wantReason)
+			}
+			if len(explanation.Rules) != len(tt.rules) {
+				t.Fatalf("explained %d rules, want %d", len(explanation.Rules), len(tt.rules))
+			}
+		})
+	}
+}
+
+func TestAssignerAssign(t *testing.T) {
+	ctx := context.Background()
+	returnsTeam, err := team.NewTeam(team.TeamParams{Key: "returns", Name: "Returns"})
+	if err != nil {
+		t.Fatalf("NewTeam: %v", err)
+	}
+	returnsID := returnsTeam.ID()
+	teams := memory.NewTeamRepository()
+	if err := teams.Save(ctx, returnsTeam); err != nil {
+		t.Fatalf("Save team: %v", err)
+	}
+	rules := memory.NewRoutingRuleRepository()
+	rule, err := routing.NewRule(routing.RuleParams{
+		Name:           "Refunds",
+		Enabled:        true,
+		Condition:      routing.Condition{Tags: []string{"refund"}},
+		RequiredSkills: []routing.SkillRequirement{{Name: "refunds", MinLevel: 3}},
+		FallbackTeamID: &returnsID,
+	})
+	if err != nil {
+		t.Fatalf("NewRule: %v", err)
+	}
+	if err := rules.Save(ctx, rule); err != nil {
+		t.Fatalf("Save rule: %v", err)
+	}
+	agents := memory.NewAgentRepository()
+	ag := agent.Reconstitute(agent.ReconstituteParams{
+		ID:     uuid.New(),
+		Name:   "Aina",
+		Skills: skill("refunds", 4),
+		Status: string(agent.StatusOnline),
+	})
+	if err := agents.Save(ctx, ag); err != nil {
+		t.Fatalf("Save agent: %v", err)
+	}
+	assigner := NewAssigner(agents, teams, rules, memory.NewTicketRepository(), assignment.RoundRobin{}, zap.NewNop())
+
+	t.Run("assigns the picked agent with the reason", func(t *testing.T) {
+		tk := routedTicket(t, []string{"refund"}, "")
+		picked, err := assigner.Assign(ctx, tk, nil)
+		if err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		if picked == nil || tk.AssignedTo() == nil || *tk.AssignedTo() != ag.ID() {
+			t.Fatalf("assigned to %v, want Aina", tk.AssignedTo())
+		}
+		if !strings.Contains(tk.AssignmentReason(), "best skill match") {
+			t.Fatalf("assignment reason = %q, want the rule's reason", tk.AssignmentReason())
+		}
+	})
+
+	t.Run("queues the ticket for the fallback team", func(t *testing.T) {
+		tk := routedTicket(t, []string{"refund"}, "")
+		picked, err := assigner.Assign(ctx, tk, &[]uuid.UUID{ag.ID()}[0])
+		if err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		if picked != nil || tk.AssignedTo() != nil {
+			t.Fatalf("assigned to %v, want nobody", tk.AssignedTo())
+		}
+		if tk.TeamID() == nil || *tk.TeamID() != returnsID {
+			t.Fatalf("team = %v, want the fallback team", tk.TeamID())
+		}
+	})
+}
+
+// routedTicket returns an open ticket with the tags and, if given, a
+// customer message.
+func routedTicket(t *testing.T, tags []string, message string) *ticket.Ticket {
+	t.Helper()
+	tk := ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:           uuid.New(),
+		TicketNumber: "TKT-20261016-0001",
+		GuestEmail:   "jane@example.com",
+		Subject:      "Help",
+		Status:       string(shared.StatusOpen),
+		Priority:     string(shared.PriorityNormal),
+		Tags:         tags,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	})
+	if message != "" {
+		msg := ticket.CreateCustomerMessage(tk.ID(), nil, "Jane", "jane@example.com", message)
+		if err := tk.AddMessage(msg); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+	}
+	return tk
+}
+
+// storeAssigned saves an open ticket assigned to the agent, adding to its
+// load.
+func storeAssigned(t *testing.T, tickets *memory.TicketRepository, agentID uuid.UUID) {
+	t.Helper()
+	id := uuid.New()
+	tk := ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:           id,
+		TicketNumber: fmt.Sprintf("TKT-20261016-%s", id.String()[:4]),
+		GuestEmail:   "other@example.com",
+		Subject:      "Other ticket",
+		Status:       string(shared.StatusInProgress),
+		Priority:     string(shared.PriorityNormal),
+		AssignedTo:   &agentID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	})
+	if err := tickets.Save(context.Background(), tk); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
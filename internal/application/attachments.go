@@ -0,0 +1,520 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// sniffLength is how much of a file is read to detect its content type.
+const sniffLength = 3072
+
+// ErrLinksDisabled is returned for download links while no signer is
+// configured.
+var ErrLinksDisabled = errors.New("attachment download links are disabled")
+
+// AttachmentService stores the files uploaded to tickets or received by
+// email. Contents go to the blob store under keys of our choosing; their
+// metadata, including the content type detected from the bytes and a
+// SHA-256 checksum, goes to the repository. Files are downloaded through
+// signed, expiring links, and each download is logged; without a signer
+// links are disabled. It implements AttachmentStore.
+type AttachmentService struct {
+	attachments attachment.Repository
+	blobs       attachment.BlobStore
+	downloads   attachment.DownloadLog
+	tickets     *TicketService
+	signer      *attachment.Signer
+	limits      attachment.Limits
+	logger      *zap.Logger
+}
+
+var _ AttachmentStore = (*AttachmentService)(nil)
+
+// NewAttachmentService creates a new attachment service. A MaxFileSize or
+// AllowedTypes left zero takes its default; a MaxTicketSize of zero leaves
+// tickets unbounded.
+func NewAttachmentService(
+	attachments attachment.Repository,
+	blobs attachment.BlobStore,
+	downloads attachment.DownloadLog,
+	tickets *TicketService,
+	signer *attachment.Signer,
+	limits attachment.Limits,
+	logger *zap.Logger,
+) *AttachmentService {
+	defaults := attachment.DefaultLimits()
+	if limits.MaxFileSize <= 0 {
+		limits.MaxFileSize = defaults.MaxFileSize
+	}
+	if len(limits.AllowedTypes) == 0 {
+		limits.AllowedTypes = defaults.AllowedTypes
+	}
+	return &AttachmentService{
+		attachments: attachments,
+		blobs:       blobs,
+		downloads:   downloads,
+		tickets:     tickets,
+		signer:      signer,
+		limits:      limits,
+		logger:      logger,
+	}
+}
+
+// Limits returns the limits uploads are held to.
+func (s *AttachmentService) Limits() attachment.Limits {
+	return s.limits
+}
+
+// UploadAttachmentCommand contains a file uploaded to a ticket. Size is the
+// declared length of Content.
+type UploadAttachmentCommand struct {
+	TicketID   uuid.UUID
+	UploadedBy uuid.UUID
+	Name       string
+	Content    io.Reader
+	Size       int64
+	// IsStaff marks the uploader as support staff, who may upload to any
+	// ticket.
+	IsStaff bool
+}
+
+// Upload stores a file for a ticket, to be posted in a message by its ID.
+// Whoever may reply to the ticket may upload to it. The file must fit the
+// size limits and its detected content type must be allowed.
+func (s *AttachmentService) Upload(ctx context.Context, cmd UploadAttachmentCommand) (*attachment.Attachment, error) {
+	t, err := s.tickets.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	uploaderType, err := replySender(t, cmd.UploadedBy, cmd.IsStaff)
+	if err != nil {
+		return nil, err
+	}
+	if !t.AcceptsMessages() {
+		return nil, ticket.ErrCannotModify
+	}
+	if cmd.Size <= 0 {
+		return nil, fmt.Errorf("%w: file is empty", attachment.ErrInvalidAttachment)
+	}
+	if cmd.Size > s.limits.MaxFileSize {
+		return nil, fmt.Errorf("%w of %d bytes", attachment.ErrFileTooLarge, s.limits.MaxFileSize)
+	}
+	if err := s.checkQuota(ctx, t.ID(), cmd.Size); err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	uploadedBy := cmd.UploadedBy
+	a, err := s.store(ctx, cmd.Content, cmd.Size, attachment.AttachmentParams{
+		ID:           id,
+		TicketID:     t.ID(),
+		Name:         cmd.Name,
+		StorageKey:   "tickets/" + t.ID().String() + "/" + id.String(),
+		UploadedBy:   &uploadedBy,
+		UploaderType: uploaderType.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Attachment uploaded",
+		zap.String("ticket_id", t.ID().String()),
+		zap.String("attachment_id", a.ID().String()),
+		zap.String("content_type", a.ContentType()),
+		zap.Int64("size", a.Size()))
+	return a, nil
+}
+
+// Store keeps a file received by email for the ticket its message is
+// posted to and returns it as a ticket attachment. The file is held to the
+// limits of uploads, the ticket's quota included. The declared content
+// type is ignored in favour of the detected one.
+func (s *AttachmentService) Store(ctx context.Context, ticketID uuid.UUID, name, contentType string, data []byte) (ticket.Attachment, error) {
+	size := int64(len(data))
+	if size == 0 {
+		return ticket.Attachment{}, fmt.Errorf("%w: file is empty", attachment.ErrInvalidAttachment)
+	}
+	if size > s.limits.MaxFileSize {
+		return ticket.Attachment{}, fmt.Errorf("%w of %d bytes", attachment.ErrFileTooLarge, s.limits.MaxFileSize)
+	}
+	if err := s.checkQuota(ctx, ticketID, size); err != nil {
+		return ticket.Attachment{}, err
+	}
+
+	id := uuid.New()
+	a, err := s.store(ctx, bytes.NewReader(data), size, attachment.AttachmentParams{
+		ID:           id,
+		TicketID:     ticketID,
+		Name:         name,
+		StorageKey:   "tickets/" + ticketID.String() + "/" + id.String(),
+		UploaderType: string(shared.SenderCustomer),
+	})
+	if err != nil {
+		return ticket.Attachment{}, err
+	}
+	return a.Ticket(), nil
+}
+
+// Discard removes files kept by Store for a message that was not posted.
+// Failures only leave orphaned files behind, so they are logged rather
+// than returned.
+func (s *AttachmentService) Discard(ctx context.Context, attachments []ticket.Attachment) {
+	for _, ta := range attachments {
+		id, err := uuid.Parse(ta.ID)
+		if err != nil {
+			continue
+		}
+		a, err := s.attachments.FindByID(ctx, id)
+		if errors.Is(err, attachment.ErrAttachmentNotFound) {
+			// Listed only
+			continue
+		}
+		if err == nil && a.IsPosted() {
+			continue
+		}
+		if err == nil {
+			err = s.attachments.Delete(ctx, id)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to discard email attachment", zap.String("attachment_id", ta.ID), zap.Error(err))
+			continue
+		}
+		s.deleteBlob(ctx, a.StorageKey())
+	}
+}
+
+// List returns the files stored for a ticket, oldest first.
+func (s *AttachmentService) List(ctx context.Context, ticketID uuid.UUID) ([]*attachment.Attachment, error) {
+	if _, err := s.tickets.tickets.FindByID(ctx, ticketID); err != nil {
+		return nil, err
+	}
+	return s.attachments.ListByTicket(ctx, ticketID)
+}
+
+// Delete removes an upload of the ticket not yet posted in a message.
+// Customers may only remove their own uploads.
+func (s *AttachmentService) Delete(ctx context.Context, ticketID, id, actorID uuid.UUID, isStaff bool) error {
+	a, err := s.attachments.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.TicketID() != ticketID {
+		return attachment.ErrAttachmentNotFound
+	}
+	if !isStaff && (a.UploadedBy() == nil || *a.UploadedBy() != actorID) {
+		return ErrAccessDenied
+	}
+	if a.IsPosted() {
+		return attachment.ErrAlreadyPosted
+	}
+
+	if err := s.attachments.Delete(ctx, a.ID()); err != nil {
+		return err
+	}
+	s.deleteBlob(ctx, a.StorageKey())
+	return nil
+}
+
+// IssueLinkCommand contains a request for a link to download an
+// attachment of a ticket.
+type IssueLinkCommand struct {
+	TicketID     uuid.UUID
+	AttachmentID uuid.UUID
+	UserID       uuid.UUID
+	// IsStaff marks the user as support staff, who may download any
+	// attachment of any ticket.
+	IsStaff bool
+}
+
+// IssueLink signs a link for the user to download an attachment of the
+// ticket. Customers only get links for their own tickets, and never for
+// files posted in internal notes; they may also download their uploads
+// not posted yet. Attachments the user may not see are not found.
+func (s *AttachmentService) IssueLink(ctx context.Context, cmd IssueLinkCommand) (attachment.Link, error) {
+	if s.signer == nil {
+		return attachment.Link{}, ErrLinksDisabled
+	}
+	t, err := s.tickets.tickets.FindByID(ctx, cmd.TicketID)
+	if err != nil {
+		return attachment.Link{}, err
+	}
+	a, err := s.attachments.FindByID(ctx, cmd.AttachmentID)
+	if err != nil {
+		return attachment.Link{}, err
+	}
+	if a.TicketID() != t.ID() {
+		return attachment.Link{}, attachment.ErrAttachmentNotFound
+	}
+
+	userType := shared.SenderAgent
+	if !cmd.IsStaff {
+		if t.CustomerID() == nil || *t.CustomerID() != cmd.UserID {
+			return attachment.Link{}, ErrAccessDenied
+		}
+		if !customerCanSee(t, a, cmd.UserID) {
+			return attachment.Link{}, attachment.ErrAttachmentNotFound
+		}
+		userType = shared.SenderCustomer
+	}
+	return s.signer.Sign(a.ID(), cmd.UserID, userType, time.Now()), nil
+}
+
+// DownloadCommand contains a download through a link and where it came
+// from, for the audit log.
+type DownloadCommand struct {
+	Link      attachment.Link
+	IPAddress string
+	UserAgent string
+}
+
+// Download verifies the link and opens the attachment's content for
+// streaming; the caller closes it. The download is logged before any
+// content is returned, and refused if it cannot be.
+func (s *AttachmentService) Download(ctx context.Context, cmd DownloadCommand) (*attachment.Attachment, io.ReadCloser, error) {
+	if s.signer == nil {
+		return nil, nil, ErrLinksDisabled
+	}
+	if err := s.signer.Verify(cmd.Link, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	a, err := s.attachments.FindByID(ctx, cmd.Link.AttachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.blobs.Open(ctx, a.StorageKey())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.downloads.Record(ctx, attachment.NewDownload(a, cmd.Link, cmd.IPAddress, cmd.UserAgent)); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return a, content, nil
+}
+
+// Downloads returns a page of the download log of a ticket's attachment,
+// newest first, and the total number of matches.
+func (s *AttachmentService) Downloads(ctx context.Context, ticketID uuid.UUID, filter attachment.DownloadFilter) ([]attachment.Download, int64, error) {
+	if filter.AttachmentID != nil {
+		a, err := s.attachments.FindByID(ctx, *filter.AttachmentID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if a.TicketID() != ticketID {
+			return nil, 0, attachment.ErrAttachmentNotFound
+		}
+	} else if _, err := s.tickets.tickets.FindByID(ctx, ticketID); err != nil {
+		return nil, 0, err
+	}
+	filter.TicketID = &ticketID
+	return s.downloads.List(ctx, filter)
+}
+
+// customerCanSee checks if the ticket's customer may see the attachment:
+// it is posted in a message of the ticket other than an internal note, or
+// is their own upload not posted yet.
+func customerCanSee(t *ticket.Ticket, a *attachment.Attachment, customerID uuid.UUID) bool {
+	if !a.IsPosted() {
+		return a.UploadedBy() != nil && *a.UploadedBy() == customerID
+	}
+	for _, msg := range t.Messages() {
+		if msg.ID() == *a.MessageID() {
+			return !msg.IsInternal()
+		}
+	}
+	return false
+}
+
+// store detects the content type of the file, checks it against the
+// limits and writes it to the blob store while checksumming it, then saves
+// its metadata. The content is removed again if anything fails.
+func (s *AttachmentService) store(ctx context.Context, content io.Reader, size int64, params attachment.AttachmentParams) (*attachment.Attachment, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	if n == 0 {
+		return nil, fmt.Errorf("%w: file is empty", attachment.ErrInvalidAttachment)
+	}
+	contentType := detectContentType(head)
+	if err := s.limits.CheckFile(int64(n), contentType); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	body := io.TeeReader(
+		io.LimitReader(io.MultiReader(bytes.NewReader(head), content), s.limits.MaxFileSize+1),
+		io.MultiWriter(hash, counter),
+	)
+	if err := s.blobs.Put(ctx, params.StorageKey, body, size, contentType); err != nil {
+		s.deleteBlob(ctx, params.StorageKey)
+		if counter.n > s.limits.MaxFileSize {
+			return nil, fmt.Errorf("%w of %d bytes", attachment.ErrFileTooLarge, s.limits.MaxFileSize)
+		}
+		return nil, err
+	}
+
+	params.ContentType = contentType
+	params.Size = counter.n
+	params.Checksum = hex.EncodeToString(hash.Sum(nil))
+	a, err := s.checkStored(ctx, size, params)
+	if err == nil {
+		err = s.attachments.Save(ctx, a)
+	}
+	if err != nil {
+		s.deleteBlob(ctx, params.StorageKey)
+		return nil, err
+	}
+	return a, nil
+}
+
+// checkStored checks the file as written: its length must match the
+// declared size, and the ticket's files must still fit the quota now that
+// concurrent uploads have landed.
+func (s *AttachmentService) checkStored(ctx context.Context, size int64, params attachment.AttachmentParams) (*attachment.Attachment, error) {
+	if params.Size > s.limits.MaxFileSize {
+		return nil, fmt.Errorf("%w of %d bytes", attachment.ErrFileTooLarge, s.limits.MaxFileSize)
+	}
+	if params.Size != size {
+		return nil, fmt.Errorf("%w: received %d of %d bytes", attachment.ErrInvalidAttachment, params.Size, size)
+	}
+	if params.TicketID != uuid.Nil {
+		if err := s.checkQuota(ctx, params.TicketID, params.Size); err != nil {
+			return nil, err
+		}
+	}
+	return attachment.NewAttachment(params)
+}
+
+// checkQuota checks if a file of the size fits on the ticket.
+func (s *AttachmentService) checkQuota(ctx context.Context, ticketID uuid.UUID, size int64) error {
+	used, err := s.attachments.TicketUsage(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	return s.limits.CheckTicket(used, size)
+}
+
+// deleteBlob removes stored content. Failures only leave an orphaned
+// blob behind, so they are logged rather than returned.
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+		s.logger.Warn("Failed to delete attachment content", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// detectContentType returns the media type of a file from its first bytes,
+// without parameters such as the charset.
+func detectContentType(head []byte) string {
+	detected := mimetype.Detect(head).String()
+	if mediaType, _, err := mime.ParseMediaType(detected); err == nil {
+		return mediaType
+	}
+	return detected
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// replySender returns who a sender replies to the ticket as: its customer,
+// or an agent if they are staff. Others may only reply to guest tickets.
+func replySender(t *ticket.Ticket, senderID uuid.UUID, isStaff bool) (shared.SenderType, error) {
+	if t.CustomerID() != nil && *t.CustomerID() == senderID {
+		return shared.SenderCustomer, nil
+	}
+	if isStaff {
+		return shared.SenderAgent, nil
+	}
+	if t.CustomerID() != nil {
+		return "", ErrAccessDenied
+	}
+	return shared.SenderCustomer, nil
+}
+
+// uploadedAttachments returns the uploads to post in a message of the
+// ticket. Each must have been uploaded to the ticket and not posted yet.
+func (s *TicketService) uploadedAttachments(ctx context.Context, ticketID uuid.UUID, ids []uuid.UUID) ([]ticket.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if s.attachments == nil {
+		return nil, attachment.ErrAttachmentNotFound
+	}
+
+	attachments := make([]ticket.Attachment, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		a, err := s.attachments.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if a.TicketID() != ticketID {
+			return nil, attachment.ErrAttachmentNotFound
+		}
+		if a.IsPosted() {
+			return nil, attachment.ErrAlreadyPosted
+		}
+		attachments = append(attachments, a.Ticket())
+	}
+	return attachments, nil
+}
+
+// postAttachments records the message the stored files among its
+// attachments were posted in. The message is already saved, so failures
+// are logged rather than returned.
+func (s *TicketService) postAttachments(ctx context.Context, msg ticket.Message) {
+	if s.attachments == nil {
+		return
+	}
+	for _, ta := range msg.Attachments() {
+		id, err := uuid.Parse(ta.ID)
+		if err != nil {
+			continue
+		}
+		a, err := s.attachments.FindByID(ctx, id)
+		if errors.Is(err, attachment.ErrAttachmentNotFound) {
+			continue
+		}
+		if err == nil {
+			err = a.Post(msg.TicketID(), msg.ID())
+		}
+		if err == nil {
+			err = s.attachments.Save(ctx, a)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to post attachment",
+				zap.String("ticket_id", msg.TicketID().String()),
+				zap.String("attachment_id", ta.ID),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/memory"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/storage"
+	"go.uber.org/zap"
+)
+
+// attachmentService returns an attachment service for the environment's
+// tickets, keeping files in a temporary directory.
+func (e *testEnv) attachmentService(t *testing.T, signer *attachment.Signer, limits attachment.Limits) *AttachmentService {
+	t.Helper()
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+	return NewAttachmentService(e.tickets.Attachments(), blobs, memory.NewAttachmentDownloadLog(), e.service, signer, limits, zap.NewNop())
+}
+
+// upload stores a text file on the ticket as staff.
+func upload(t *testing.T, s *AttachmentService, ticketID uuid.UUID, content string) *attachment.Attachment {
+	t.Helper()
+	a, err := s.Upload(context.Background(), UploadAttachmentCommand{
+		TicketID:   ticketID,
+		UploadedBy: uuid.New(),
+		Name:       "notes.txt",
+		Content:    strings.NewReader(content),
+		Size:       int64(len(content)),
+		IsStaff:    true,
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	return a
+}
+
+func TestAttachmentLinks(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "jane@example.com")
+
+	t.Run("signed links download the file", func(t *testing.T) {
+		signer, err := attachment.NewSigner([]byte("secret"), 0)
+		if err != nil {
+			t.Fatalf("NewSigner: %v", err)
+		}
+		s := env.attachmentService(t, signer, attachment.Limits{})
+		a := upload(t, s, tk.ID(), "Tracking number 12345")
+
+		link, err := s.IssueLink(ctx, IssueLinkCommand{TicketID: tk.ID(), AttachmentID: a.ID(), UserID: uuid.New(), IsStaff: true})
+		if err != nil {
+			t.Fatalf("IssueLink: %v", err)
+		}
+		_, content, err := s.Download(ctx, DownloadCommand{Link: link})
+		if err != nil {
+			t.Fatalf("Download: %v", err)
+		}
+		defer content.Close()
+		if got, _ := io.ReadAll(content); string(got) != "Tracking number 12345" {
+			t.Fatalf("content = %q", got)
+		}
+	})
+
+	t.Run("links are disabled without a signer", func(t *testing.T) {
+		s := env.attachmentService(t, nil, attachment.Limits{})
+		a := upload(t, s, tk.ID(), "Tracking number 12345")
+
+		_, err := s.IssueLink(ctx, IssueLinkCommand{TicketID: tk.ID(), AttachmentID: a.ID(), UserID: uuid.New(), IsStaff: true})
+		if !errors.Is(err, ErrLinksDisabled) {
+			t.Fatalf("IssueLink error = %v, want ErrLinksDisabled", err)
+		}
+		_, _, err = s.Download(ctx, DownloadCommand{Link: attachment.Link{AttachmentID: a.ID(), Signature: "forged"}})
+		if !errors.Is(err, ErrLinksDisabled) {
+			t.Fatalf("Download error = %v, want ErrLinksDisabled", err)
+		}
+	})
+}
+
+func TestAttachmentServiceLimits(t *testing.T) {
+	env := newTestEnv(t)
+
+	got := env.attachmentService(t, nil, attachment.Limits{}).Limits()
+	defaults := attachment.DefaultLimits()
+	if got.MaxFileSize != defaults.MaxFileSize || len(got.AllowedTypes) != len(defaults.AllowedTypes) {
+		t.Fatalf("limits = %+v, want the default file size and types", got)
+	}
+	if got.MaxTicketSize != 0 {
+		t.Fatalf("max ticket size = %d, want tickets unbounded", got.MaxTicketSize)
+	}
+}
@@ -0,0 +1,274 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"go.uber.org/zap"
+)
+
+// automationLock is the lock name that keeps one automation scheduler active
+// at a time.
+const automationLock = "support.automations"
+
+// AutomationConfig tunes the automation scheduler.
+type AutomationConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// AutomationResult summarises one scheduler pass.
+type AutomationResult struct {
+	Closed   int
+	Resolved int
+	Reminded int
+	Flagged  int
+}
+
+func (r AutomationResult) total() int {
+	return r.Closed + r.Resolved + r.Reminded + r.Flagged
+}
+
+// AutomationScheduler periodically applies the time-based automations of
+// the automation policies: resolved tickets are closed, customers of pending
+// tickets are reminded and their tickets resolved, and in-progress tickets
+// without agent activity are flagged as stale. Each change is recorded in
+// the status history under the system actor and published through the
+// outbox; status changes also run the status_changed trigger rules.
+type AutomationScheduler struct {
+	service  *TicketService
+	policies automation.Repository
+	locker   Locker
+	config   AutomationConfig
+	logger   *zap.Logger
+}
+
+// NewAutomationScheduler creates a new automation scheduler
+func NewAutomationScheduler(service *TicketService, policies automation.Repository, locker Locker, config AutomationConfig, logger *zap.Logger) *AutomationScheduler {
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Minute
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	return &AutomationScheduler{
+		service:  service,
+		policies: policies,
+		locker:   locker,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// Run applies the automations every interval until the context is cancelled.
+func (a *AutomationScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := a.Check(ctx)
+		if err != nil && ctx.Err() == nil {
+			a.logger.Error("Automation pass failed", zap.Error(err))
+		}
+		if result.total() > 0 {
+			a.logger.Info("Automation pass completed",
+				zap.Int("closed", result.Closed),
+				zap.Int("resolved", result.Resolved),
+				zap.Int("reminded", result.Reminded),
+				zap.Int("flagged", result.Flagged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check applies the automations that are due. Each policy covers the
+// tickets of its category; the global policy covers the tickets of every
+// other category. It does nothing when another replica holds the lock.
+func (a *AutomationScheduler) Check(ctx context.Context) (AutomationResult, error) {
+	var result AutomationResult
+
+	unlock, acquired, err := a.locker.TryLock(ctx, automationLock)
+	if err != nil || !acquired {
+		return result, err
+	}
+	defer unlock()
+
+	policies, err := a.policies.List(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	var scoped []uuid.UUID
+	for _, p := range policies {
+		if !p.IsGlobal() {
+			scoped = append(scoped, *p.CategoryID())
+		}
+	}
+
+	for _, p := range policies {
+		scope := ticket.IdleQuery{CategoryID: p.CategoryID()}
+		if p.IsGlobal() {
+			scope.ExcludeCategoryIDs = scoped
+		}
+		if err := a.apply(ctx, p.Schedule(), scope, &result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// apply runs the automations of one schedule over the tickets of the scope.
+// Pending tickets due to be resolved are resolved before reminders go out,
+// so their customers are not reminded as well.
+func (a *AutomationScheduler) apply(ctx context.Context, s automation.Schedule, scope ticket.IdleQuery, result *AutomationResult) error {
+	now := time.Now()
+
+	if s.AutoCloseAfter > 0 {
+		query := scope
+		query.Status = shared.StatusResolved
+		query.IdleBefore = now.Add(-s.AutoCloseAfter)
+		notes := fmt.Sprintf("Closed after %s resolved", formatIdle(s.AutoCloseAfter))
+		n, err := a.sweep(ctx, query, func(t *ticket.Ticket) ([]trigger.Firing, bool) {
+			return a.changeStatus(ctx, t, shared.StatusClosed, notes)
+		})
+		result.Closed += n
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.PendingResolveAfter > 0 {
+		query := scope
+		query.Status = shared.StatusPending
+		query.IdleBefore = now.Add(-s.PendingResolveAfter)
+		notes := fmt.Sprintf("Resolved after %s without a customer reply", formatIdle(s.PendingResolveAfter))
+		n, err := a.sweep(ctx, query, func(t *ticket.Ticket) ([]trigger.Firing, bool) {
+			return a.changeStatus(ctx, t, shared.StatusResolved, notes)
+		})
+		result.Resolved += n
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.PendingReminderAfter > 0 {
+		query := scope
+		query.Status = shared.StatusPending
+		query.IdleBefore = now.Add(-s.PendingReminderAfter)
+		query.NotReminded = true
+		notes := fmt.Sprintf("Customer reminded after %s pending", formatIdle(s.PendingReminderAfter))
+		n, err := a.sweep(ctx, query, func(t *ticket.Ticket) ([]trigger.Firing, bool) {
+			return nil, t.RemindPending(now, notes)
+		})
+		result.Reminded += n
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.StaleAfter > 0 {
+		query := scope
+		query.Status = shared.StatusInProgress
+		query.IdleBefore = now.Add(-s.StaleAfter)
+		query.NotStale = true
+		notes := fmt.Sprintf("Flagged as stale after %s without agent activity", formatIdle(s.StaleAfter))
+		n, err := a.sweep(ctx, query, func(t *ticket.Ticket) ([]trigger.Firing, bool) {
+			return nil, t.FlagStale(now, notes)
+		})
+		result.Flagged += n
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweep applies act to the tickets matching the query, a batch at a time,
+// and saves those it changed. Tickets act leaves alone are skipped; each
+// batch resumes after the last ticket of the one before, so tickets that
+// went idle at the same time are neither skipped nor listed twice.
+func (a *AutomationScheduler) sweep(ctx context.Context, query ticket.IdleQuery, act func(t *ticket.Ticket) ([]trigger.Firing, bool)) (int, error) {
+	changed := 0
+	for {
+		idle, err := a.service.tickets.ListIdle(ctx, query, a.config.BatchSize)
+		if err != nil {
+			return changed, err
+		}
+		if len(idle) == 0 {
+			return changed, nil
+		}
+		// Acting restarts the idle clock, so note where the batch ended first
+		last := idle[len(idle)-1]
+		next := ticket.IdleCursor{IdleSince: last.IdleSince(), ID: last.ID()}
+
+		for _, t := range idle {
+			a.service.useWorkflow(t, a.service.workflow(ctx, t.CategoryID()))
+			useAccount(ctx, a.service.customers, t, a.logger)
+			firings, ok := act(t)
+			if !ok {
+				continue
+			}
+			if err := a.service.saveFired(ctx, t, firings); err != nil {
+				// Someone touched the ticket since it was listed, so it may
+				// not be idle any more; the next run looks at it again
+				if errors.Is(err, ticket.ErrConflict) {
+					continue
+				}
+				return changed, err
+			}
+			changed++
+		}
+
+		if len(idle) < a.config.BatchSize {
+			return changed, nil
+		}
+		query.After = &next
+	}
+}
+
+// changeStatus moves the ticket to the status as the system actor and runs
+// the status_changed trigger rules. Tickets whose workflow does not allow
+// the move are logged and left alone.
+func (a *AutomationScheduler) changeStatus(ctx context.Context, t *ticket.Ticket, status shared.TicketStatus, notes string) ([]trigger.Firing, bool) {
+	if err := t.ChangeStatus(status, nil, ticket.SystemActor, notes); err != nil {
+		a.logger.Warn("Automation could not change ticket status",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("from", string(t.Status())),
+			zap.String("to", string(status)),
+			zap.Error(err))
+		return nil, false
+	}
+	return a.service.runTriggers(ctx, trigger.EventStatusChanged, t, nil), true
+}
+
+// formatIdle renders an automation delay in whole days, hours or minutes.
+func formatIdle(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	default:
+		return plural(int(d/time.Minute), "minute")
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
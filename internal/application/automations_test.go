@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/memory"
+	"go.uber.org/zap"
+)
+
+func TestAutomationSchedulerKeepsPausedTime(t *testing.T) {
+	ctx := context.Background()
+	e := newTestEnv(t)
+	now := time.Now()
+	createdAt := now.Add(-10 * time.Hour)
+	idleSince := now.Add(-3 * time.Hour)
+	statusAt := func(from, to shared.TicketStatus, at time.Time) ticket.StatusHistory {
+		return ticket.ReconstituteStatusHistory(ticket.StatusHistoryParams{
+			ID:         uuid.New(),
+			FromStatus: string(from),
+			ToStatus:   string(to),
+			CreatedAt:  at,
+		})
+	}
+	// Pending for four hours, so due two hours from now
+	deadline := createdAt.Add(12 * time.Hour)
+	tk := ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:               uuid.New(),
+		TicketNumber:     "TKT-20261016-0001",
+		GuestEmail:       "jane@example.com",
+		Subject:          "Where is my order?",
+		Status:           string(shared.StatusInProgress),
+		Priority:         string(shared.PriorityNormal),
+		ResolutionTarget: 8 * time.Hour,
+		SLADeadline:      &deadline,
+		StatusHistory: []ticket.StatusHistory{
+			statusAt(shared.StatusOpen, shared.StatusPending, createdAt.Add(time.Hour)),
+			statusAt(shared.StatusPending, shared.StatusInProgress, createdAt.Add(5*time.Hour)),
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: idleSince,
+		IdleSince: idleSince,
+	})
+	if err := e.tickets.Save(ctx, tk); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	policies := memory.NewAutomationPolicyRepository()
+	policy, err := automation.NewPolicy(automation.PolicyParams{
+		Name:     "Default",
+		Schedule: automation.Schedule{StaleAfter: 2 * time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy: %v", err)
+	}
+	if err := policies.Save(ctx, policy); err != nil {
+		t.Fatalf("Save policy: %v", err)
+	}
+
+	scheduler := NewAutomationScheduler(e.service, policies, memory.NewLocker(), AutomationConfig{}, zap.NewNop())
+	result, err := scheduler.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if result.Flagged != 1 {
+		t.Fatalf("flagged = %d, want 1", result.Flagged)
+	}
+
+	got := e.reload(t, tk.ID())
+	if got.StaleAt() == nil {
+		t.Fatal("ticket should be flagged as stale")
+	}
+	if d := got.SLADeadline(); d == nil || d.Sub(deadline).Abs() > time.Second {
+		t.Fatalf("deadline = %v, want %v with the pending period left out", d, deadline)
+	}
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+	"go.uber.org/zap"
+)
+
+// CustomerSync keeps the customer directory in line with the identity
+// service, so account tickets reach their customer at the current email
+// address. Every user with an email address is recorded, staff included,
+// as anyone may open a ticket; deleted users are removed.
+type CustomerSync struct {
+	customers customer.Repository
+	logger    *zap.Logger
+}
+
+var _ events.UserEventHandler = (*CustomerSync)(nil)
+
+// NewCustomerSync creates a new customer sync
+func NewCustomerSync(customers customer.Repository, logger *zap.Logger) *CustomerSync {
+	return &CustomerSync{customers: customers, logger: logger}
+}
+
+// HandleUserEvent applies a user event to the customer directory. Events of
+// unknown type are ignored.
+func (s *CustomerSync) HandleUserEvent(ctx context.Context, event events.UserEvent) error {
+	id, err := uuid.Parse(event.UserID)
+	if err != nil {
+		s.logger.Warn("Ignoring user event without a valid user ID", zap.String("user_id", event.UserID))
+		return nil
+	}
+
+	switch event.Type {
+	case events.UserDeleted:
+		if err := s.customers.Delete(ctx, id); err != nil && !errors.Is(err, customer.ErrCustomerNotFound) {
+			return err
+		}
+		return nil
+	case events.UserCreated, events.UserUpdated, events.UserRoleChanged, events.UserDeactivated:
+	default:
+		return nil
+	}
+
+	c, err := s.customers.FindByID(ctx, id)
+	switch {
+	case errors.Is(err, customer.ErrCustomerNotFound):
+		if event.Email == "" {
+			return nil
+		}
+		c, err = customer.NewCustomer(customer.CustomerParams{
+			ID:    id,
+			Email: event.Email,
+			Name:  event.DisplayName(),
+		})
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		c.SyncIdentity(event.Email, event.DisplayName())
+	}
+	return s.customers.Save(ctx, c)
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/memory"
+	"go.uber.org/zap"
+)
+
+// testEnv is a TicketService on in-memory repositories, with the
+// repositories the tests look into.
+type testEnv struct {
+	service    *TicketService
+	tickets    *memory.TicketRepository
+	deliveries *memory.NotificationDeliveryRepository
+	categories *memory.CategoryRepository
+	teams      *memory.TeamRepository
+	agents     *memory.AgentRepository
+	rules      *memory.TriggerRuleRepository
+	firings    *memory.TriggerFiringLog
+	responses  *memory.CannedResponseRepository
+	// stored counts the tickets saved directly, for their numbers
+	stored int
+}
+
+// newTestEnv creates a TicketService without automatic assignment or
+// notifications.
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	return buildTestEnv(t, false)
+}
+
+// newNotifiedTestEnv creates a TicketService without automatic assignment
+// that queues customer notifications in the environment's deliveries.
+func newNotifiedTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	return buildTestEnv(t, true)
+}
+
+func buildTestEnv(t *testing.T, notify bool) *testEnv {
+	t.Helper()
+	tickets := memory.NewTicketRepository()
+	numberer, err := NewTicketNumberer(memory.NewTicketNumberSequence(), "", nil)
+	if err != nil {
+		t.Fatalf("NewTicketNumberer: %v", err)
+	}
+	env := &testEnv{
+		tickets:    tickets,
+		deliveries: memory.NewNotificationDeliveryRepository(),
+		categories: memory.NewCategoryRepository(),
+		teams:      memory.NewTeamRepository(),
+		agents:     memory.NewAgentRepository(),
+		rules:      memory.NewTriggerRuleRepository(),
+		firings:    memory.NewTriggerFiringLog(),
+		responses:  memory.NewCannedResponseRepository(),
+	}
+	customers := memory.NewCustomerRepository()
+	triggers := NewTriggerEngine(env.rules, env.firings, env.responses)
+	var notifier *Notifier
+	if notify {
+		notifier = NewNotifier(memory.NewNotificationTemplateRepository(), env.deliveries, tickets, customers, nil, NotifierConfig{}, zap.NewNop())
+	}
+	env.service = NewTicketService(
+		tickets,
+		memory.NewTransactor(),
+		env.categories,
+		memory.NewSLACalendarRepository(),
+		memory.NewSLAPolicyRepository(),
+		memory.NewWorkflowRepository(),
+		env.teams,
+		env.agents,
+		customers,
+		memory.NewTicketMentionRepository(),
+		tickets.Attachments(),
+		numberer,
+		nil,
+		triggers,
+		notifier,
+		zap.NewNop(),
+	)
+	return env
+}
+
+// createTicket opens a guest ticket from the address.
+func (e *testEnv) createTicket(t *testing.T, guestEmail string) *ticket.Ticket {
+	t.Helper()
+	tk, err := e.service.CreateTicket(context.Background(), CreateTicketCommand{
+		GuestEmail: guestEmail,
+		GuestName:  "Jane",
+		Subject:    "Where is my order?",
+		Message:    "It has not arrived yet.",
+		Channel:    ticket.ChannelEmail,
+		MessageID:  "<question@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("CreateTicket: %v", err)
+	}
+	return tk
+}
+
+// reload returns the stored ticket.
+func (e *testEnv) reload(t *testing.T, id uuid.UUID) *ticket.Ticket {
+	t.Helper()
+	got, err := e.tickets.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	return got
+}
@@ -0,0 +1,395 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/email"
+	"go.uber.org/zap"
+)
+
+// Placeholders for mail without a subject or text
+const (
+	noSubject = "(no subject)"
+	noText    = "(no message text)"
+)
+
+// AttachmentStore keeps the files of inbound mail under the ticket they
+// are posted to and returns them as ticket attachments.
+type AttachmentStore interface {
+	Store(ctx context.Context, ticketID uuid.UUID, name, contentType string, data []byte) (ticket.Attachment, error)
+
+	// Discard removes the files kept for a message that was not posted.
+	Discard(ctx context.Context, attachments []ticket.Attachment)
+}
+
+// InboundEmailConfig holds the support addresses mail is received on.
+// They are never copied on tickets, and mail from them is refused to
+// avoid loops.
+type InboundEmailConfig struct {
+	Addresses []string
+}
+
+// InboundEmail turns received mail into tickets and customer replies. It
+// implements email.Handler.
+type InboundEmail struct {
+	tickets        *TicketService
+	sent           notification.DeliveryRepository
+	attachments    AttachmentStore
+	replyAddresses *email.ReplyAddresses
+	config         InboundEmailConfig
+	logger         *zap.Logger
+}
+
+var _ email.Handler = (*InboundEmail)(nil)
+
+// NewInboundEmail creates a new inbound email handler. Replies are
+// threaded by the notifications in sent they answer. Without an attachment
+// store, attachments are listed by name, type and size only; without reply
+// addresses, replies are threaded by their headers alone.
+func NewInboundEmail(tickets *TicketService, sent notification.DeliveryRepository, attachments AttachmentStore, replyAddresses *email.ReplyAddresses, config InboundEmailConfig, logger *zap.Logger) *InboundEmail {
+	return &InboundEmail{
+		tickets:        tickets,
+		sent:           sent,
+		attachments:    attachments,
+		replyAddresses: replyAddresses,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// HandleEmail adds a message to the ticket the mail replies to, or opens a
+// ticket as the contact form does. Bounces, auto-replies and mail already
+// received are dropped.
+//
+// The ticket is found by a signed reply address the mail was sent to, then
+// by the notification about it the mail replies to. The From header is
+// easily forged, so a reply only joins its ticket when sent to the ticket's
+// reply address, or when it answers one of the ticket's notifications from
+// the ticket's contact or CC addresses, and the ticket can still take
+// messages; any other mail opens a new ticket. Attached files are kept
+// under the ticket the message is posted to, within its quota.
+func (h *InboundEmail) HandleEmail(ctx context.Context, msg *email.Message) error {
+	if reason, ok := msg.Automated(); ok {
+		h.logger.Info("Ignoring automated email",
+			zap.String("message_id", msg.MessageID),
+			zap.String("from", msg.FromAddress()),
+			zap.String("reason", reason))
+		return nil
+	}
+	from := msg.FromAddress()
+	if from == "" {
+		return fmt.Errorf("%w: no sender address", email.ErrRejected)
+	}
+	if h.isSupportAddress(from) {
+		return fmt.Errorf("%w: sent from a support address", email.ErrRejected)
+	}
+	if msg.MessageID != "" {
+		if _, err := h.tickets.FindThread(ctx, []string{msg.MessageID}); err == nil {
+			h.logger.Info("Ignoring email received before", zap.String("message_id", msg.MessageID))
+			return nil
+		} else if !errors.Is(err, ticket.ErrTicketNotFound) {
+			return err
+		}
+	}
+
+	if t, signed := h.findTicket(ctx, msg); t != nil {
+		attachments, err := h.storeAttachments(ctx, t.ID(), msg)
+		if err != nil {
+			return err
+		}
+		_, _, err = h.tickets.AddEmailReply(ctx, EmailReplyCommand{
+			TicketID:       t.ID(),
+			SenderName:     msg.FromName(),
+			SenderEmail:    from,
+			Content:        textOrPlaceholder(email.StripQuoted(msg.Text)),
+			Attachments:    attachments,
+			MessageID:      msg.MessageID,
+			InReplyTo:      msg.InReplyTo,
+			References:     msg.References,
+			ToReplyAddress: signed,
+			RepliesToSent:  !signed,
+		})
+		if err == nil {
+			return nil
+		}
+		h.discardAttachments(ctx, attachments)
+		if !errors.Is(err, ErrAccessDenied) && !errors.Is(err, ticket.ErrCannotModify) {
+			return err
+		}
+		h.logger.Info("Opening a new ticket for a reply the ticket cannot take",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("from", from),
+			zap.Error(err))
+	}
+
+	// The new ticket's ID is chosen here so its files can be kept under it
+	ticketID := uuid.New()
+	attachments, err := h.storeAttachments(ctx, ticketID, msg)
+	if err != nil {
+		return err
+	}
+	subject := msg.Subject
+	if subject == "" {
+		subject = noSubject
+	}
+	t, err := h.tickets.CreateTicket(ctx, CreateTicketCommand{
+		ID:          ticketID,
+		GuestEmail:  from,
+		GuestName:   msg.FromName(),
+		Subject:     subject,
+		Message:     textOrPlaceholder(email.StripSignature(msg.Text)),
+		Channel:     ticket.ChannelEmail,
+		Locale:      msg.Header.Get("Content-Language"),
+		CC:          h.copiedAddresses(msg, from),
+		Attachments: attachments,
+		MessageID:   msg.MessageID,
+		InReplyTo:   msg.InReplyTo,
+		References:  msg.References,
+	})
+	if err != nil {
+		h.discardAttachments(ctx, attachments)
+	}
+	if errors.Is(err, ticket.ErrInvalidTicket) {
+		return fmt.Errorf("%w: %v", email.ErrRejected, err)
+	}
+	if err != nil {
+		return err
+	}
+	h.logger.Info("Opened ticket from email",
+		zap.String("ticket_number", t.TicketNumber().Value()),
+		zap.String("message_id", msg.MessageID))
+	return nil
+}
+
+// findTicket returns the ticket the mail replies to, if any, and whether
+// it was sent to that ticket's signed reply address. Otherwise the ticket
+// is the one of a notification the mail replies to.
+func (h *InboundEmail) findTicket(ctx context.Context, msg *email.Message) (*ticket.Ticket, bool) {
+	if t := h.ticketByReplyAddress(ctx, msg); t != nil {
+		return t, true
+	}
+	return h.ticketBySent(ctx, msg), false
+}
+
+// ticketBySent returns the ticket of a notification the mail replies to,
+// if any. Message-IDs of other mail in the thread are ignored: whoever
+// sent that mail may have forged them.
+func (h *InboundEmail) ticketBySent(ctx context.Context, msg *email.Message) *ticket.Ticket {
+	ids := append([]string(nil), msg.References...)
+	if msg.InReplyTo != "" {
+		ids = append(ids, msg.InReplyTo)
+	}
+	if h.sent == nil || len(ids) == 0 {
+		return nil
+	}
+	d, err := h.sent.FindByMessageID(ctx, ids)
+	if err != nil {
+		if !errors.Is(err, notification.ErrDeliveryNotFound) {
+			h.logger.Warn("Failed to look up ticket from email headers", zap.String("message_id", msg.MessageID), zap.Error(err))
+		}
+		return nil
+	}
+	return h.lookup(ctx, d.TicketID().String())
+}
+
+// ticketByReplyAddress returns the ticket of a signed reply address the
+// mail was sent to, if any. Addresses with a wrong signature are ignored.
+func (h *InboundEmail) ticketByReplyAddress(ctx context.Context, msg *email.Message) *ticket.Ticket {
+	if h.replyAddresses == nil {
+		return nil
+	}
+	addresses := append([]string(nil), msg.Recipients...)
+	for _, addr := range append(append([]*mail.Address(nil), msg.To...), msg.Cc...) {
+		addresses = append(addresses, addr.Address)
+	}
+	for _, address := range addresses {
+		number, ok := h.replyAddresses.TicketNumber(address)
+		if !ok {
+			continue
+		}
+		if t := h.lookup(ctx, number); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+func (h *InboundEmail) lookup(ctx context.Context, ref string) *ticket.Ticket {
+	t, _, err := h.tickets.LookupTicket(ctx, ref)
+	if err != nil {
+		if !errors.Is(err, ticket.ErrTicketNotFound) {
+			h.logger.Warn("Failed to look up ticket from email", zap.String("ref", ref), zap.Error(err))
+		}
+		return nil
+	}
+	return t
+}
+
+// storeAttachments keeps the files of the message under the ticket. Files
+// refused by the store for their size or type, or for the ticket's quota,
+// are listed without being kept.
+func (h *InboundEmail) storeAttachments(ctx context.Context, ticketID uuid.UUID, msg *email.Message) ([]ticket.Attachment, error) {
+	attachments := make([]ticket.Attachment, 0, len(msg.Attachments))
+	for _, a := range msg.Attachments {
+		if h.attachments == nil {
+			attachments = append(attachments, listedAttachment(a))
+			continue
+		}
+		stored, err := h.attachments.Store(ctx, ticketID, a.Filename, a.ContentType, a.Data)
+		if errors.Is(err, attachment.ErrFileTooLarge) || errors.Is(err, attachment.ErrTypeNotAllowed) ||
+			errors.Is(err, attachment.ErrTicketQuotaExceeded) || errors.Is(err, attachment.ErrInvalidAttachment) {
+			h.logger.Info("Email attachment not kept",
+				zap.String("message_id", msg.MessageID),
+				zap.String("filename", a.Filename),
+				zap.Error(err))
+			attachments = append(attachments, listedAttachment(a))
+			continue
+		}
+		if err != nil {
+			h.discardAttachments(ctx, attachments)
+			return nil, err
+		}
+		attachments = append(attachments, stored)
+	}
+	return attachments, nil
+}
+
+// discardAttachments removes the files kept for a message that was not
+// posted.
+func (h *InboundEmail) discardAttachments(ctx context.Context, attachments []ticket.Attachment) {
+	if h.attachments != nil && len(attachments) > 0 {
+		h.attachments.Discard(ctx, attachments)
+	}
+}
+
+// listedAttachment lists a file of the message by name, type and size
+// only.
+func listedAttachment(a email.Attachment) ticket.Attachment {
+	return ticket.Attachment{
+		ID:       uuid.New().String(),
+		Name:     attachment.SanitizeName(a.Filename),
+		Size:     int64(len(a.Data)),
+		MimeType: a.ContentType,
+	}
+}
+
+// copiedAddresses returns the other people the message was sent to, less
+// the sender and the support addresses, for the ticket's CC list.
+func (h *InboundEmail) copiedAddresses(msg *email.Message, from string) []string {
+	var cc []string
+	for _, addr := range append(append([]*mail.Address(nil), msg.To...), msg.Cc...) {
+		address := strings.ToLower(addr.Address)
+		if address == from || h.isSupportAddress(address) {
+			continue
+		}
+		cc = append(cc, address)
+		if len(cc) == ticket.MaxCC {
+			break
+		}
+	}
+	return cc
+}
+
+func (h *InboundEmail) isSupportAddress(address string) bool {
+	address = email.BaseAddress(address)
+	for _, a := range h.config.Addresses {
+		if strings.EqualFold(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func textOrPlaceholder(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return noText
+	}
+	return text
+}
+
+// EmailReplyCommand contains a customer reply received by email.
+// ToReplyAddress marks mail sent to the ticket's signed reply address,
+// which only people sent mail about the ticket know. RepliesToSent marks
+// mail whose headers name a notification sent about the ticket.
+type EmailReplyCommand struct {
+	TicketID       uuid.UUID
+	SenderName     string
+	SenderEmail    string
+	Content        string
+	Attachments    []ticket.Attachment
+	MessageID      string
+	InReplyTo      string
+	References     []string
+	ToReplyAddress bool
+	RepliesToSent  bool
+}
+
+// FindThread finds the ticket holding a message with one of the
+// Message-IDs.
+func (s *TicketService) FindThread(ctx context.Context, messageIDs []string) (*ticket.Ticket, error) {
+	return s.tickets.FindByMessageID(ctx, messageIDs)
+}
+
+// AddEmailReply adds a customer message received by email to a ticket and
+// runs the trigger rules for replies. Only mail sent to the ticket's reply
+// address, or answering its notifications from its contact and CC
+// addresses, may reply.
+func (s *TicketService) AddEmailReply(ctx context.Context, cmd EmailReplyCommand) (*ticket.Ticket, ticket.Message, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, ticket.Message{}, err
+	}
+	if !cmd.ToReplyAddress && !(cmd.RepliesToSent && isTicketAddress(t, cmd.SenderEmail)) {
+		return nil, ticket.Message{}, ErrAccessDenied
+	}
+
+	msg := ticket.NewMessage(ticket.MessageParams{
+		TicketID:    t.ID(),
+		SenderType:  string(shared.SenderCustomer),
+		SenderID:    t.CustomerID(),
+		SenderName:  cmd.SenderName,
+		SenderEmail: cmd.SenderEmail,
+		Content:     cmd.Content,
+		Attachments: cmd.Attachments,
+		MessageID:   cmd.MessageID,
+		InReplyTo:   cmd.InReplyTo,
+		References:  cmd.References,
+	})
+	if err := t.AddMessage(msg); err != nil {
+		return nil, ticket.Message{}, err
+	}
+	firings := s.runTriggers(ctx, trigger.EventCustomerReplied, t, &msg)
+
+	if err := s.saveFired(ctx, t, firings); err != nil {
+		return nil, ticket.Message{}, err
+	}
+	s.postAttachments(ctx, msg)
+	return t, msg, nil
+}
+
+// isTicketAddress checks if the address is the ticket's contact or one of
+// its CC addresses.
+func isTicketAddress(t *ticket.Ticket, address string) bool {
+	if address == "" {
+		return false
+	}
+	if strings.EqualFold(t.ContactEmail(), address) {
+		return true
+	}
+	for _, cc := range t.CC() {
+		if strings.EqualFold(cc, address) {
+			return true
+		}
+	}
+	return false
+}
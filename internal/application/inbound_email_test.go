@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/email"
+	"go.uber.org/zap"
+)
+
+func TestInboundEmailThreading(t *testing.T) {
+	ctx := context.Background()
+	const notice = "<notice@support.example.com>"
+
+	tests := []struct {
+		name     string
+		from     string
+		to       func(number string, replies *email.ReplyAddresses) string
+		subject  func(number string) string
+		replyTo  string
+		wantJoin bool
+	}{
+		{
+			name:     "reply to a notification from the contact",
+			from:     "jane@example.com",
+			replyTo:  notice,
+			wantJoin: true,
+		},
+		{
+			name:     "reply to a notification from a CC address",
+			from:     "partner@example.com",
+			replyTo:  notice,
+			wantJoin: true,
+		},
+		{
+			name:    "reply to a notification from another address",
+			from:    "mallory@example.com",
+			replyTo: notice,
+		},
+		{
+			name:    "forged From replying to the customer's own message",
+			from:    "jane@example.com",
+			replyTo: "<question@example.com>",
+		},
+		{
+			name:    "forged From with the ticket number in the subject",
+			from:    "jane@example.com",
+			subject: func(number string) string { return "Re: [" + number + "] Where is my order?" },
+		},
+		{
+			name:     "sent to the ticket's reply address",
+			from:     "jane.doe@work.example.com",
+			to:       func(number string, replies *email.ReplyAddresses) string { return replies.Address(number) },
+			wantJoin: true,
+		},
+		{
+			name: "sent to a reply address with a forged signature",
+			from: "jane@example.com",
+			to: func(number string, replies *email.ReplyAddresses) string {
+				return "support+" + number + ".aaaaaaaaaaaaaaaa@example.com"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			replies, err := email.NewReplyAddresses("support@example.com", []byte("reply-secret"))
+			if err != nil {
+				t.Fatalf("NewReplyAddresses: %v", err)
+			}
+			handler := NewInboundEmail(env.service, env.deliveries, nil, replies, InboundEmailConfig{
+				Addresses: []string{"support@example.com"},
+			}, zap.NewNop())
+
+			tk := env.createTicket(t, "jane@example.com")
+			if err := tk.SetCC([]string{"partner@example.com"}); err != nil {
+				t.Fatalf("SetCC: %v", err)
+			}
+			if err := env.tickets.Save(ctx, tk); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			number := tk.TicketNumber().Value()
+			d, err := notification.NewDelivery(notification.DeliveryParams{
+				TicketID:  tk.ID(),
+				Event:     notification.EventTicketReceived,
+				Locale:    "en",
+				To:        "jane@example.com",
+				Subject:   "[" + number + "] Where is my order?",
+				Body:      "We received your request.",
+				MessageID: notice,
+				InReplyTo: "<question@example.com>",
+			})
+			if err != nil {
+				t.Fatalf("NewDelivery: %v", err)
+			}
+			if err := env.deliveries.Save(ctx, d); err != nil {
+				t.Fatalf("Save delivery: %v", err)
+			}
+
+			to := "support@example.com"
+			if tt.to != nil {
+				to = tt.to(number, replies)
+			}
+			subject := "Re: Where is my order?"
+			if tt.subject != nil {
+				subject = tt.subject(number)
+			}
+			msg := &email.Message{
+				From:      &mail.Address{Name: "Jane", Address: tt.from},
+				To:        []*mail.Address{{Address: to}},
+				Subject:   subject,
+				MessageID: "<reply@mail.example.com>",
+				InReplyTo: tt.replyTo,
+				Text:      "Please refund my order to this card.",
+			}
+			if err := handler.HandleEmail(ctx, msg); err != nil {
+				t.Fatalf("HandleEmail: %v", err)
+			}
+
+			got, err := env.service.FindThread(ctx, []string{msg.MessageID})
+			if err != nil {
+				t.Fatalf("FindThread: %v", err)
+			}
+			joined := got.ID() == tk.ID()
+			if joined != tt.wantJoin {
+				t.Fatalf("reply joined the ticket = %v, want %v", joined, tt.wantJoin)
+			}
+			if messages := len(env.reload(t, tk.ID()).Messages()); !tt.wantJoin && messages != 1 {
+				t.Fatalf("ticket messages = %d, want only the opening message", messages)
+			}
+		})
+	}
+}
+
+func TestAddEmailReplyAccess(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		cmd     EmailReplyCommand
+		wantErr error
+	}{
+		{
+			name:    "contact address alone",
+			cmd:     EmailReplyCommand{SenderEmail: "jane@example.com"},
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name: "contact address answering a notification",
+			cmd:  EmailReplyCommand{SenderEmail: "jane@example.com", RepliesToSent: true},
+		},
+		{
+			name:    "other address answering a notification",
+			cmd:     EmailReplyCommand{SenderEmail: "mallory@example.com", RepliesToSent: true},
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name: "any address at the reply address",
+			cmd:  EmailReplyCommand{SenderEmail: "mallory@example.com", ToReplyAddress: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			tk := env.createTicket(t, "jane@example.com")
+			cmd := tt.cmd
+			cmd.TicketID = tk.ID()
+			cmd.Content = "Any news?"
+
+			_, _, err := env.service.AddEmailReply(ctx, cmd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddEmailReply error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
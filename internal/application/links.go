@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"go.uber.org/zap"
+)
+
+// maxLinkDepth bounds how many parents are followed when checking a new
+// parent link for cycles.
+const maxLinkDepth = 20
+
+// LinkService runs the ticket link use cases: linking tickets and resolving
+// an incident parent together with its children.
+type LinkService struct {
+	links   link.Repository
+	tickets *TicketService
+	logger  *zap.Logger
+}
+
+// NewLinkService creates a new link service
+func NewLinkService(links link.Repository, tickets *TicketService, logger *zap.Logger) *LinkService {
+	return &LinkService{
+		links:   links,
+		tickets: tickets,
+		logger:  logger,
+	}
+}
+
+// LinkedTicket is a link read from one of its tickets, with the ticket at
+// the other end.
+type LinkedTicket struct {
+	Link     *link.Link
+	Relation link.Relation
+	Ticket   *ticket.Ticket
+}
+
+// LinkTicketsCommand contains the data for linking two tickets. Relation
+// is what the ticket is to the other one.
+type LinkTicketsCommand struct {
+	TicketID      uuid.UUID
+	OtherID       uuid.UUID
+	Relation      string
+	CreatedBy     *uuid.UUID
+	CreatedByName string
+}
+
+// LinkTickets links two existing tickets and returns the link as read from
+// the ticket. A ticket has at most one parent and cannot become an ancestor
+// of its own parent.
+func (s *LinkService) LinkTickets(ctx context.Context, cmd LinkTicketsCommand) (*LinkedTicket, error) {
+	relation, err := link.ParseRelation(cmd.Relation)
+	if err != nil {
+		return nil, err
+	}
+	l, err := link.NewLink(link.LinkParams{
+		TicketID:      cmd.TicketID,
+		OtherID:       cmd.OtherID,
+		Relation:      relation,
+		CreatedBy:     cmd.CreatedBy,
+		CreatedByName: cmd.CreatedByName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tickets.tickets.FindByID(ctx, cmd.TicketID); err != nil {
+		return nil, err
+	}
+	other, err := s.tickets.tickets.FindByID(ctx, cmd.OtherID)
+	if err != nil {
+		return nil, err
+	}
+	if l.Type() == link.TypeParent {
+		if err := s.checkParent(ctx, l.TicketID(), l.LinkedTicketID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.links.Save(ctx, l); err != nil {
+		return nil, err
+	}
+	return &LinkedTicket{Link: l, Relation: relation, Ticket: other}, nil
+}
+
+// UnlinkTickets removes a link of the ticket.
+func (s *LinkService) UnlinkTickets(ctx context.Context, ticketID, linkID uuid.UUID) error {
+	l, err := s.links.FindByID(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	if !l.Involves(ticketID) {
+		return link.ErrLinkNotFound
+	}
+	return s.links.Delete(ctx, linkID)
+}
+
+// TicketLinks returns the links of a ticket with the tickets they lead to.
+// Links to tickets that no longer exist are left out.
+func (s *LinkService) TicketLinks(ctx context.Context, ticketID uuid.UUID) ([]LinkedTicket, error) {
+	links, err := s.links.ListForTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := make([]LinkedTicket, 0, len(links))
+	for _, l := range links {
+		other, err := s.tickets.tickets.FindByID(ctx, l.Other(ticketID))
+		if errors.Is(err, ticket.ErrTicketNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		linked = append(linked, LinkedTicket{Link: l, Relation: l.RelationFor(ticketID), Ticket: other})
+	}
+	return linked, nil
+}
+
+// ResolveIncidentCommand contains the data for resolving a ticket and,
+// optionally, its children. Message, when set, is sent as an agent reply
+// on every ticket resolved, so each customer hears about the resolution.
+type ResolveIncidentCommand struct {
+	TicketID        uuid.UUID
+	Message         string
+	Notes           string
+	ResolveChildren bool
+	ResolvedBy      uuid.UUID
+	ResolvedByName  string
+	ResolvedByEmail string
+}
+
+// SkippedTicket is a child ticket a bulk resolution left alone.
+type SkippedTicket struct {
+	Ticket *ticket.Ticket
+	Reason string
+}
+
+// IncidentResolution is the outcome of resolving an incident.
+type IncidentResolution struct {
+	Ticket   *ticket.Ticket
+	Children []*ticket.Ticket
+	Skipped  []SkippedTicket
+}
+
+// ResolveIncident resolves a ticket and, when asked, each of its active
+// children. Every ticket is saved on its own; children that fail to
+// resolve are reported as skipped and the rest go on.
+func (s *LinkService) ResolveIncident(ctx context.Context, cmd ResolveIncidentCommand) (*IncidentResolution, error) {
+	parent, err := s.tickets.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolve(ctx, parent, cmd); err != nil {
+		return nil, err
+	}
+	result := &IncidentResolution{Ticket: parent}
+	if !cmd.ResolveChildren {
+		return result, nil
+	}
+
+	links, err := s.links.ListForTicket(ctx, parent.ID())
+	if err != nil {
+		return result, err
+	}
+	for _, l := range links {
+		if l.Type() != link.TypeParent || l.TicketID() != parent.ID() {
+			continue
+		}
+		child, err := s.tickets.load(ctx, l.LinkedTicketID())
+		if errors.Is(err, ticket.ErrTicketNotFound) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if !child.IsActive() {
+			result.Skipped = append(result.Skipped, SkippedTicket{Ticket: child, Reason: fmt.Sprintf("ticket is %s", child.Status())})
+			continue
+		}
+		if err := s.resolve(ctx, child, cmd); err != nil {
+			s.logger.Warn("Failed to resolve child ticket",
+				zap.String("ticket_id", child.ID().String()),
+				zap.String("parent_id", parent.ID().String()),
+				zap.Error(err))
+			result.Skipped = append(result.Skipped, SkippedTicket{Ticket: child, Reason: err.Error()})
+			continue
+		}
+		result.Children = append(result.Children, child)
+	}
+	return result, nil
+}
+
+// resolve sends the resolution message on the ticket, resolves it and runs
+// the trigger rules for status changes.
+func (s *LinkService) resolve(ctx context.Context, t *ticket.Ticket, cmd ResolveIncidentCommand) error {
+	if cmd.Message != "" {
+		msg := ticket.CreateAgentMessage(t.ID(), cmd.ResolvedBy, cmd.ResolvedByName, cmd.ResolvedByEmail, cmd.Message, false)
+		if err := t.AddMessage(msg); err != nil {
+			return err
+		}
+	}
+
+	previous := t.Status()
+	if err := t.ChangeStatus(shared.StatusResolved, &cmd.ResolvedBy, cmd.ResolvedByName, cmd.Notes); err != nil {
+		return err
+	}
+	var firings []trigger.Firing
+	if t.Status() != previous {
+		firings = s.tickets.runTriggers(ctx, trigger.EventStatusChanged, t, nil)
+	}
+
+	return s.tickets.saveFired(ctx, t, firings)
+}
+
+// checkParent checks that child may take parent as its parent: it has no
+// parent yet and is not an ancestor of parent.
+func (s *LinkService) checkParent(ctx context.Context, parentID, childID uuid.UUID) error {
+	if current, err := s.parentOf(ctx, childID); err != nil {
+		return err
+	} else if current != uuid.Nil {
+		return fmt.Errorf("%w: the ticket already has a parent", link.ErrLinkConflict)
+	}
+
+	ancestor := parentID
+	for depth := 0; ancestor != uuid.Nil && depth < maxLinkDepth; depth++ {
+		if ancestor == childID {
+			return fmt.Errorf("%w: a ticket cannot be a child of its own descendant", link.ErrLinkConflict)
+		}
+		next, err := s.parentOf(ctx, ancestor)
+		if err != nil {
+			return err
+		}
+		ancestor = next
+	}
+	return nil
+}
+
+// parentOf returns the parent of the ticket, or uuid.Nil if it has none.
+func (s *LinkService) parentOf(ctx context.Context, ticketID uuid.UUID) (uuid.UUID, error) {
+	links, err := s.links.ListForTicket(ctx, ticketID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, l := range links {
+		if parent, ok := l.ParentOf(ticketID); ok {
+			return parent, nil
+		}
+	}
+	return uuid.Nil, nil
+}
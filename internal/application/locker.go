@@ -0,0 +1,11 @@
+package application
+
+import "context"
+
+// Locker elects a single runner for background work that must not run
+// concurrently when several replicas of the service are deployed.
+type Locker interface {
+	// TryLock acquires the named lock without waiting. It reports false if
+	// another holder has the lock. The returned function releases it.
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
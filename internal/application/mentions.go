@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// Mentions returns a page of the mentions of an agent, newest first, and
+// the total number of matches.
+func (s *TicketService) Mentions(ctx context.Context, filter mention.Filter) ([]*mention.Mention, int64, error) {
+	return s.mentions.List(ctx, filter)
+}
+
+// MarkMentionRead marks a mention of the agent as seen. Mentions of other
+// agents are not found.
+func (s *TicketService) MarkMentionRead(ctx context.Context, id, agentID uuid.UUID) (*mention.Mention, error) {
+	m, err := s.mentions.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if m.AgentID() != agentID {
+		return nil, mention.ErrMentionNotFound
+	}
+	if m.IsRead() {
+		return m, nil
+	}
+
+	m.MarkRead()
+	if err := s.mentions.Save(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// mentionedAgents returns the agents mentioned in a note, less its author.
+// A handle that names no agent, or several, is an error.
+func (s *TicketService) mentionedAgents(ctx context.Context, content string, authorID uuid.UUID) ([]*agent.Agent, error) {
+	handles := mention.ParseHandles(content)
+	if len(handles) == 0 {
+		return nil, nil
+	}
+	agents, err := s.agents.List(ctx, agent.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := mention.Resolve(handles, agents)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := make([]*agent.Agent, 0, len(resolved))
+	seen := map[uuid.UUID]bool{authorID: true}
+	for _, a := range resolved {
+		if seen[a.ID()] {
+			continue
+		}
+		seen[a.ID()] = true
+		mentioned = append(mentioned, a)
+	}
+	return mentioned, nil
+}
+
+// recordMentions stores a mention of each agent in the note. The note is
+// already saved, so failures are logged rather than returned.
+func (s *TicketService) recordMentions(ctx context.Context, note ticket.Message, agents []*agent.Agent) {
+	for _, a := range agents {
+		m, err := mention.NewMention(mention.MentionParams{
+			TicketID:        note.TicketID(),
+			MessageID:       note.ID(),
+			AgentID:         a.ID(),
+			MentionedBy:     note.SenderID(),
+			MentionedByName: note.SenderName(),
+		})
+		if err == nil {
+			err = s.mentions.Save(ctx, m)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to record mention",
+				zap.String("ticket_id", note.TicketID().String()),
+				zap.String("agent_id", a.ID().String()),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// maxMergeHops bounds how many merged tickets a lookup follows.
+const maxMergeHops = 10
+
+// MergeTicketsCommand contains the data for merging tickets into a target.
+type MergeTicketsCommand struct {
+	TargetID     uuid.UUID
+	SourceIDs    []uuid.UUID
+	Force        bool
+	MergedBy     *uuid.UUID
+	MergedByName string
+}
+
+// MergeTickets merges the source tickets into the target. The moved
+// messages and history, the closed sources and the target are saved in one
+// transaction. Tickets of different customers are refused unless forced.
+func (s *TicketService) MergeTickets(ctx context.Context, cmd MergeTicketsCommand) (*ticket.Ticket, error) {
+	target, err := s.load(ctx, cmd.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(cmd.SourceIDs))
+	sources := make([]*ticket.Ticket, 0, len(cmd.SourceIDs))
+	for _, id := range cmd.SourceIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		source, err := s.load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	if err := ticket.Merge(target, sources, cmd.MergedBy, cmd.MergedByName, cmd.Force); err != nil {
+		return nil, err
+	}
+
+	s.refreshSLA(ctx, append([]*ticket.Ticket{target}, sources...)...)
+	if err := s.tickets.SaveMerged(ctx, target, sources); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// LookupTicket finds a ticket by its ID or ticket number. Lookups of a
+// merged ticket lead to the ticket it was merged into; the ticket asked
+// for is returned as well when it was redirected, nil otherwise.
+func (s *TicketService) LookupTicket(ctx context.Context, ref string) (*ticket.Ticket, *ticket.Ticket, error) {
+	var t *ticket.Ticket
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		t, err = s.tickets.FindByID(ctx, id)
+	} else {
+		t, err = s.tickets.FindByNumber(ctx, ref)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requested := t
+	for hops := 0; t.MergedInto() != nil; hops++ {
+		if hops == maxMergeHops {
+			return nil, nil, fmt.Errorf("ticket %s: too many merges to follow", requested.TicketNumber().Value())
+		}
+		if t, err = s.tickets.FindByID(ctx, *t.MergedInto()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if t == requested {
+		return t, nil, nil
+	}
+	return t, requested, nil
+}
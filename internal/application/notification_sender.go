@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/email"
+	"go.uber.org/zap"
+)
+
+// NotificationSenderConfig tunes the notification sender. From is the
+// sender of every notification.
+type NotificationSenderConfig struct {
+	From         mail.Address
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+}
+
+// DefaultNotificationSenderConfig returns the sender settings used when none
+// are configured.
+func DefaultNotificationSenderConfig() NotificationSenderConfig {
+	return NotificationSenderConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    50,
+		MaxAttempts:  8,
+		RetryBackoff: 30 * time.Second,
+		MaxBackoff:   time.Hour,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// NotificationSender mails queued notifications. Failed sends are retried
+// with exponential backoff; a notification the mail server rejects for good
+// or that fails MaxAttempts times is marked failed.
+type NotificationSender struct {
+	deliveries notification.DeliveryRepository
+	mailer     email.Mailer
+	config     NotificationSenderConfig
+	logger     *zap.Logger
+}
+
+// NewNotificationSender creates a new notification sender
+func NewNotificationSender(deliveries notification.DeliveryRepository, mailer email.Mailer, config NotificationSenderConfig, logger *zap.Logger) *NotificationSender {
+	defaults := DefaultNotificationSenderConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	return &NotificationSender{
+		deliveries: deliveries,
+		mailer:     mailer,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// Run sends queued notifications until the context is cancelled.
+func (s *NotificationSender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			claimed, err := s.SendDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.logger.Error("Failed to send notifications", zap.Error(err))
+			}
+			if claimed < s.config.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends one batch of due notifications and returns how many were
+// claimed.
+func (s *NotificationSender) SendDue(ctx context.Context) (int, error) {
+	deliveries, err := s.deliveries.ClaimDue(ctx, s.config.BatchSize, s.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range deliveries {
+		if sendErr := s.mailer.Send(ctx, s.message(d)); sendErr != nil {
+			s.fail(d, sendErr)
+		} else {
+			d.MarkSent()
+		}
+		if err := s.deliveries.Save(ctx, d); err != nil {
+			// The lease expires and the email is sent again.
+			s.logger.Error("Failed to record notification delivery",
+				zap.String("delivery_id", d.ID().String()),
+				zap.Error(err))
+		}
+	}
+	return len(deliveries), nil
+}
+
+func (s *NotificationSender) message(d *notification.Delivery) *email.Outgoing {
+	msg := &email.Outgoing{
+		From:       s.config.From,
+		To:         []string{d.To()},
+		Cc:         d.CC(),
+		ReplyTo:    d.ReplyTo(),
+		Subject:    d.Subject(),
+		Text:       d.Body(),
+		MessageID:  d.MessageID(),
+		InReplyTo:  d.InReplyTo(),
+		References: d.References(),
+	}
+	// Agent replies are written by a person; the rest must not trigger
+	// out-of-office replies.
+	if d.Event() != notification.EventAgentReplied {
+		msg.Headers = map[string]string{"Auto-Submitted": "auto-generated"}
+	}
+	return msg
+}
+
+func (s *NotificationSender) fail(d *notification.Delivery, sendErr error) {
+	attempts := d.Attempts() + 1
+	fields := []zap.Field{
+		zap.String("delivery_id", d.ID().String()),
+		zap.String("ticket_id", d.TicketID().String()),
+		zap.String("event", string(d.Event())),
+		zap.Int("attempts", attempts),
+		zap.Error(sendErr),
+	}
+
+	if email.IsPermanent(sendErr) || attempts >= s.config.MaxAttempts {
+		s.logger.Error("Notification failed", fields...)
+		d.MarkFailed(sendErr.Error())
+		return
+	}
+	s.logger.Warn("Failed to send notification", fields...)
+	d.MarkRetry(sendErr.Error(), time.Now().Add(s.backoff(attempts)))
+}
+
+// backoff returns the delay before the given attempt is retried.
+func (s *NotificationSender) backoff(attempts int) time.Duration {
+	delay := s.config.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.config.MaxBackoff {
+			return s.config.MaxBackoff
+		}
+	}
+	return delay
+}
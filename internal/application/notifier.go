@@ -0,0 +1,318 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/email"
+	"go.uber.org/zap"
+)
+
+// ticketNumberPlaceholder is replaced with the ticket number in the
+// configured ticket and survey links.
+const ticketNumberPlaceholder = "{ticket_number}"
+
+// NotifierConfig configures customer email notifications.
+type NotifierConfig struct {
+	// Domain is the domain of the Message-IDs of notification email.
+	Domain string
+	// DefaultLocale is tried after the ticket's own locale and language.
+	DefaultLocale string
+	// TicketURL and SurveyURL are the links put in notifications, with
+	// {ticket_number} standing for the ticket's number. Survey requests
+	// are only sent with a SurveyURL.
+	TicketURL string
+	SurveyURL string
+}
+
+// Notifier queues the emails customers get about their tickets: when a
+// ticket is received, an agent replies, the ticket is resolved and, once
+// closed, a satisfaction survey. Emails are rendered from the template of
+// the event in the ticket's locale and sent by the NotificationSender.
+type Notifier struct {
+	templates      notification.TemplateRepository
+	deliveries     notification.DeliveryRepository
+	tickets        ticket.Repository
+	customers      customer.Repository
+	replyAddresses *email.ReplyAddresses
+	config         NotifierConfig
+	logger         *zap.Logger
+}
+
+// NewNotifier creates a new notifier. With replyAddresses, customers reply
+// to a signed address of the ticket.
+func NewNotifier(
+	templates notification.TemplateRepository,
+	deliveries notification.DeliveryRepository,
+	tickets ticket.Repository,
+	customers customer.Repository,
+	replyAddresses *email.ReplyAddresses,
+	config NotifierConfig,
+	logger *zap.Logger,
+) *Notifier {
+	if config.Domain == "" {
+		config.Domain = "localhost"
+	}
+	config.DefaultLocale = shared.NormalizeLocale(config.DefaultLocale)
+	if config.DefaultLocale == "" {
+		config.DefaultLocale = notification.DefaultLocale
+	}
+	return &Notifier{
+		templates:      templates,
+		deliveries:     deliveries,
+		tickets:        tickets,
+		customers:      customers,
+		replyAddresses: replyAddresses,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// Notify queues the notifications the ticket's events call for, in the
+// transaction the ticket is saved in. Only failures to queue them are
+// returned: a notification whose template cannot be found or rendered is
+// logged and skipped. Tickets without a contact email get no
+// notifications.
+func (n *Notifier) Notify(ctx context.Context, t *ticket.Ticket, events []ticket.Event) error {
+	if t.ContactEmail() == "" {
+		return nil
+	}
+	for _, e := range events {
+		var err error
+		switch e := e.(type) {
+		case ticket.TicketCreatedEvent:
+			err = n.enqueue(ctx, t, notification.EventTicketReceived, n.templateData(t), nil)
+		case ticket.MessageAddedEvent:
+			if e.IsInternal || e.SenderType != string(shared.SenderAgent) {
+				continue
+			}
+			msg, ok := findMessage(t, e.MessageID)
+			if !ok {
+				continue
+			}
+			data := n.templateData(t)
+			data.AgentName = msg.SenderName()
+			data.Message = msg.Content()
+			err = n.enqueue(ctx, t, notification.EventAgentReplied, data, t.CC())
+		case ticket.TicketResolvedEvent:
+			err = n.enqueue(ctx, t, notification.EventTicketResolved, n.templateData(t), nil)
+		case ticket.TicketClosedEvent:
+			if n.config.SurveyURL == "" || t.SatisfactionRating() != nil {
+				continue
+			}
+			err = n.enqueue(ctx, t, notification.EventSurveyRequest, n.templateData(t), nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Template returns the template used for the event in the locale: the one
+// of the locale, else of its language, else of the default locale, else
+// the built-in template.
+func (n *Notifier) Template(ctx context.Context, event notification.Event, locale string) (*notification.Template, error) {
+	for _, candidate := range n.locales(locale) {
+		tmpl, err := n.templates.Find(ctx, event, candidate)
+		if err == nil {
+			return tmpl, nil
+		}
+		if !errors.Is(err, notification.ErrTemplateNotFound) {
+			return nil, err
+		}
+	}
+	if tmpl := notification.DefaultTemplate(event); tmpl != nil {
+		return tmpl, nil
+	}
+	return nil, notification.ErrTemplateNotFound
+}
+
+// PreviewCommand contains the data for previewing a notification. Subject
+// and Body default to the template used for the event and locale; the
+// ticket, if any, supplies the data instead of notification.SampleData.
+type PreviewCommand struct {
+	Event    string
+	Locale   string
+	Subject  string
+	Body     string
+	TicketID *uuid.UUID
+}
+
+// Preview renders a notification without queueing it.
+func (n *Notifier) Preview(ctx context.Context, cmd PreviewCommand) (notification.Rendered, error) {
+	event, err := notification.ParseEvent(cmd.Event)
+	if err != nil {
+		return notification.Rendered{}, err
+	}
+
+	data := notification.SampleData()
+	locale := cmd.Locale
+	if cmd.TicketID != nil {
+		t, err := n.tickets.FindByID(ctx, *cmd.TicketID)
+		if err != nil {
+			return notification.Rendered{}, err
+		}
+		useAccount(ctx, n.customers, t, n.logger)
+		data = n.templateData(t)
+		if msg, ok := lastAgentReply(t); ok {
+			data.AgentName = msg.SenderName()
+			data.Message = msg.Content()
+		}
+		if locale == "" {
+			locale = t.Locale()
+		}
+	}
+
+	subject, body := cmd.Subject, cmd.Body
+	if subject == "" || body == "" {
+		tmpl, err := n.Template(ctx, event, shared.NormalizeLocale(locale))
+		if err != nil {
+			return notification.Rendered{}, err
+		}
+		if subject == "" {
+			subject = tmpl.Subject()
+		}
+		if body == "" {
+			body = tmpl.Body()
+		}
+	}
+	return notification.Render(subject, body, data)
+}
+
+// Deliveries returns the notifications queued for a ticket, oldest first.
+func (n *Notifier) Deliveries(ctx context.Context, ticketID uuid.UUID) ([]*notification.Delivery, error) {
+	return n.deliveries.ListByTicket(ctx, ticketID)
+}
+
+// enqueue renders the event's template for the ticket and queues the
+// email to the ticket's contact, threaded under the customer's last email.
+// Templates that cannot be found or rendered are logged and skipped.
+func (n *Notifier) enqueue(ctx context.Context, t *ticket.Ticket, event notification.Event, data notification.TemplateData, cc []string) error {
+	fields := []zap.Field{
+		zap.String("ticket_id", t.ID().String()),
+		zap.String("event", string(event)),
+	}
+
+	tmpl, err := n.Template(ctx, event, t.Locale())
+	if err != nil {
+		n.logger.Error("Failed to find notification template", append(fields, zap.Error(err))...)
+		return nil
+	}
+	rendered, err := tmpl.Render(data)
+	if err != nil {
+		n.logger.Error("Failed to render notification", append(fields, zap.Error(err))...)
+		return nil
+	}
+
+	var replyTo string
+	if n.replyAddresses != nil {
+		replyTo = n.replyAddresses.Address(t.TicketNumber().Value())
+	}
+	inReplyTo, references := thread(t)
+
+	d, err := notification.NewDelivery(notification.DeliveryParams{
+		TicketID:   t.ID(),
+		Event:      event,
+		Locale:     tmpl.Locale(),
+		To:         t.ContactEmail(),
+		CC:         cc,
+		ReplyTo:    replyTo,
+		Subject:    rendered.Subject,
+		Body:       rendered.Body,
+		MessageID:  email.NewMessageID(n.config.Domain),
+		InReplyTo:  inReplyTo,
+		References: references,
+	})
+	if err != nil {
+		n.logger.Error("Failed to queue notification", append(fields, zap.Error(err))...)
+		return nil
+	}
+	if err := n.deliveries.Save(ctx, d); err != nil {
+		return fmt.Errorf("queue %s notification: %w", event, err)
+	}
+	return nil
+}
+
+func (n *Notifier) templateData(t *ticket.Ticket) notification.TemplateData {
+	return notification.TemplateData{
+		TicketNumber:  t.TicketNumber().Value(),
+		Subject:       t.Subject(),
+		CustomerName:  t.ContactName(),
+		CustomerEmail: t.ContactEmail(),
+		Status:        string(t.Status()),
+		Priority:      string(t.Priority()),
+		TicketURL:     ticketLink(n.config.TicketURL, t),
+		SurveyURL:     ticketLink(n.config.SurveyURL, t),
+	}
+}
+
+// locales returns the locales whose templates are tried, in order.
+func (n *Notifier) locales(locale string) []string {
+	var out []string
+	for _, candidate := range []string{
+		locale,
+		shared.LocaleLanguage(locale),
+		n.config.DefaultLocale,
+		shared.LocaleLanguage(n.config.DefaultLocale),
+		notification.DefaultLocale,
+	} {
+		if candidate == "" || containsString(out, candidate) {
+			continue
+		}
+		out = append(out, candidate)
+	}
+	return out
+}
+
+func ticketLink(link string, t *ticket.Ticket) string {
+	return strings.ReplaceAll(link, ticketNumberPlaceholder, url.PathEscape(t.TicketNumber().Value()))
+}
+
+// thread returns the In-Reply-To and References headers that put a
+// notification under the customer's last email about the ticket.
+func thread(t *ticket.Ticket) (string, []string) {
+	messages := t.Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if id := messages[i].MessageID(); id != "" {
+			return id, append(append([]string(nil), messages[i].References()...), id)
+		}
+	}
+	return "", nil
+}
+
+func findMessage(t *ticket.Ticket, id uuid.UUID) (ticket.Message, bool) {
+	for _, msg := range t.Messages() {
+		if msg.ID() == id {
+			return msg, true
+		}
+	}
+	return ticket.Message{}, false
+}
+
+func lastAgentReply(t *ticket.Ticket) (ticket.Message, bool) {
+	messages := t.Messages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].IsFromAgent() && !messages[i].IsInternal() {
+			return messages[i], true
+		}
+	}
+	return ticket.Message{}, false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// slaMonitorLock is the lock name that keeps one SLA monitor active at a time.
+const slaMonitorLock = "support.sla_monitor"
+
+// SLAMonitorConfig tunes the SLA breach monitor.
+type SLAMonitorConfig struct {
+	Interval time.Duration
+	// WarningThreshold is how long before the deadline an approaching breach
+	// warning is raised. Zero disables warnings.
+	WarningThreshold time.Duration
+	BatchSize        int
+}
+
+// SLACheckResult summarises one monitor pass.
+type SLACheckResult struct {
+	Warned   int
+	Breached int
+}
+
+// SLAMonitor periodically records SLA breaches and approaching-breach
+// warnings of the first response and resolution deadlines. Each is recorded
+// once per deadline and published through the outbox as
+// support.ticket.sla_breached or support.ticket.sla_warning.
+type SLAMonitor struct {
+	service *TicketService
+	locker  Locker
+	config  SLAMonitorConfig
+	logger  *zap.Logger
+}
+
+// NewSLAMonitor creates a new SLA monitor
+func NewSLAMonitor(service *TicketService, locker Locker, config SLAMonitorConfig, logger *zap.Logger) *SLAMonitor {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	return &SLAMonitor{
+		service: service,
+		locker:  locker,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// Run checks tickets every interval until the context is cancelled.
+func (m *SLAMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := m.Check(ctx)
+		if err != nil && ctx.Err() == nil {
+			m.logger.Error("SLA check failed", zap.Error(err))
+		}
+		if result.Warned > 0 || result.Breached > 0 {
+			m.logger.Info("SLA check completed",
+				zap.Int("warned", result.Warned),
+				zap.Int("breached", result.Breached))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check records due breaches and warnings. It does nothing when another
+// replica holds the monitor lock. Tickets are saved like any other change,
+// so their notifications are queued with them; each batch resumes after the
+// last ticket of the one before, so tickets left unchanged are not listed
+// again.
+func (m *SLAMonitor) Check(ctx context.Context) (SLACheckResult, error) {
+	var result SLACheckResult
+
+	unlock, acquired, err := m.locker.TryLock(ctx, slaMonitorLock)
+	if err != nil || !acquired {
+		return result, err
+	}
+	defer unlock()
+
+	now := time.Now()
+	query := ticket.SLADueQuery{Now: now, WarnBefore: now.Add(m.config.WarningThreshold)}
+	for {
+		due, err := m.service.tickets.ListSLADue(ctx, query, m.config.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(due) == 0 {
+			return result, nil
+		}
+		// Refreshing may move the deadlines, so note where the batch ended first
+		last := due[len(due)-1]
+		next := ticket.SLACursor{Deadline: last.EarliestSLADeadline(), ID: last.ID()}
+
+		for _, t := range due {
+			// Whether the ticket is still active depends on its workflow
+			m.service.useWorkflow(t, m.service.workflow(ctx, t.CategoryID()))
+			useAccount(ctx, m.service.customers, t, m.logger)
+			// Record against the deadlines save would store, or a stale
+			// deadline would clear the breach as it is recorded
+			m.service.refreshSLA(ctx, t)
+			breached := t.RecordSLABreach(now)
+			if !breached && (m.config.WarningThreshold <= 0 || !t.WarnSLAApproaching(now, m.config.WarningThreshold)) {
+				continue
+			}
+			if err := m.service.save(ctx, t); err != nil {
+				// Changed since it was listed; the next pass sees the new state
+				if errors.Is(err, ticket.ErrConflict) {
+					continue
+				}
+				return result, err
+			}
+			if breached {
+				result.Breached++
+			} else {
+				result.Warned++
+			}
+		}
+
+		if len(due) < m.config.BatchSize {
+			return result, nil
+		}
+		query.After = &next
+	}
+}
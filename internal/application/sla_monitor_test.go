@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/memory"
+	"go.uber.org/zap"
+)
+
+// storeDueTicket saves an open ticket created a while ago with an eight
+// hour resolution target and the given stored deadline, as an earlier
+// version of the ticket left it. Its first response is due in an hour.
+func (e *testEnv) storeDueTicket(t *testing.T, age time.Duration, deadline time.Time, history ...ticket.StatusHistory) *ticket.Ticket {
+	t.Helper()
+	e.stored++
+	createdAt := time.Now().Add(-age)
+	tk := ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:                  uuid.New(),
+		TicketNumber:        fmt.Sprintf("TKT-20261016-%04d", e.stored),
+		GuestEmail:          "jane@example.com",
+		Subject:             "Where is my order?",
+		Status:              string(shared.StatusOpen),
+		Priority:            string(shared.PriorityNormal),
+		FirstResponseTarget: age + time.Hour,
+		ResolutionTarget:    8 * time.Hour,
+		SLADeadline:         &deadline,
+		StatusHistory:       history,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+	})
+	if err := e.tickets.Save(context.Background(), tk); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return tk
+}
+
+func (e *testEnv) slaMonitor(batchSize int, warning time.Duration) *SLAMonitor {
+	return NewSLAMonitor(e.service, memory.NewLocker(), SLAMonitorConfig{BatchSize: batchSize, WarningThreshold: warning}, zap.NewNop())
+}
+
+func TestSLAMonitorPagesPastUnchangedTickets(t *testing.T) {
+	e := newTestEnv(t)
+	now := time.Now()
+
+	// Stored deadlines that have since moved: listed first, but not due
+	var stale []*ticket.Ticket
+	for i := 0; i < 3; i++ {
+		stale = append(stale, e.storeDueTicket(t, time.Hour, now.Add(-3*time.Hour)))
+	}
+	var breached []*ticket.Ticket
+	for i := 0; i < 3; i++ {
+		breached = append(breached, e.storeDueTicket(t, 10*time.Hour, now.Add(-2*time.Hour)))
+	}
+
+	result, err := e.slaMonitor(2, 0).Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if result.Breached != 3 || result.Warned != 0 {
+		t.Fatalf("result = %+v, want the three breaches past the unchanged batch", result)
+	}
+	for _, tk := range breached {
+		if e.reload(t, tk.ID()).SLABreachedAt() == nil {
+			t.Fatalf("breach of %s not recorded", tk.TicketNumber().Value())
+		}
+	}
+	for _, tk := range stale {
+		if e.reload(t, tk.ID()).SLABreachedAt() != nil {
+			t.Fatalf("breach recorded against the stale deadline of %s", tk.TicketNumber().Value())
+		}
+	}
+}
+
+func TestSLAMonitorKeepsPausedTime(t *testing.T) {
+	e := newTestEnv(t)
+	now := time.Now()
+	createdAt := now.Add(-10 * time.Hour)
+	history := []ticket.StatusHistory{
+		ticket.ReconstituteStatusHistory(ticket.StatusHistoryParams{
+			ID:         uuid.New(),
+			FromStatus: string(shared.StatusOpen),
+			ToStatus:   string(shared.StatusPending),
+			CreatedAt:  createdAt.Add(time.Hour),
+		}),
+		ticket.ReconstituteStatusHistory(ticket.StatusHistoryParams{
+			ID:         uuid.New(),
+			FromStatus: string(shared.StatusPending),
+			ToStatus:   string(shared.StatusOpen),
+			CreatedAt:  createdAt.Add(5 * time.Hour),
+		}),
+	}
+	// Pending for four hours, so due two hours from now
+	tk := e.storeDueTicket(t, 10*time.Hour, now.Add(2*time.Hour), history...)
+
+	result, err := e.slaMonitor(10, 3*time.Hour).Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if result.Warned != 1 || result.Breached != 0 {
+		t.Fatalf("result = %+v, want a warning and no breach", result)
+	}
+	got := e.reload(t, tk.ID())
+	if got.SLAWarnedAt() == nil || got.SLABreachedAt() != nil {
+		t.Fatal("warning should be recorded without a breach")
+	}
+	if d := got.SLADeadline(); d == nil || d.Sub(createdAt.Add(12*time.Hour)).Abs() > time.Second {
+		t.Fatalf("deadline = %v, want twelve hours after creation", d)
+	}
+}
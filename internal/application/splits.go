@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+// SplitTicketCommand contains the data for splitting messages out of a
+// ticket into a new one.
+type SplitTicketCommand struct {
+	TicketID   uuid.UUID
+	MessageIDs []uuid.UUID
+	Subject    string
+	CategoryID *uuid.UUID
+	Priority   string // defaults to the priority of the ticket
+}
+
+// SplitTicket opens a ticket for the same customer with the selected
+// messages of a ticket. The new ticket is set up as by CreateTicket for its
+// category and priority, and both tickets get a message pointing at the
+// other. The new ticket and the changed one are saved in one transaction,
+// with the notifications their events call for.
+func (s *TicketService) SplitTicket(ctx context.Context, cmd SplitTicketCommand) (*ticket.Ticket, error) {
+	source, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	// Check before a ticket number is taken
+	if err := source.CanSplit(cmd.MessageIDs); err != nil {
+		return nil, err
+	}
+
+	priority := string(source.Priority())
+	if cmd.Priority != "" {
+		if _, err := shared.ParseTicketPriority(cmd.Priority); err != nil {
+			return nil, err
+		}
+		priority = cmd.Priority
+	}
+
+	cat, err := s.resolveCategory(ctx, cmd.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	number, err := s.numberer.Next(ctx, source.Brand())
+	if err != nil {
+		return nil, err
+	}
+
+	split, err := ticket.NewTicket(ticket.TicketParams{
+		TicketNumber: number.Value(),
+		CustomerID:   source.CustomerID(),
+		GuestEmail:   source.GuestEmail(),
+		GuestName:    source.GuestName(),
+		GuestPhone:   source.GuestPhone(),
+		CategoryID:   cmd.CategoryID,
+		Subject:      cmd.Subject,
+		Channel:      source.Channel(),
+		Locale:       source.Locale(),
+		Brand:        source.Brand(),
+		Priority:     priority,
+	})
+	if err != nil {
+		return nil, errors.Join(ticket.ErrInvalidTicket, err)
+	}
+	s.useWorkflow(split, s.workflow(ctx, split.CategoryID()))
+	useAccount(ctx, s.customers, split, s.logger)
+	split.SetSLATargets(s.slaTargets(ctx, cat, split.Priority()))
+	if tm := s.categoryTeam(ctx, split.CategoryID()); tm != nil {
+		id := tm.ID()
+		if err := split.AssignTeam(&id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ticket.Split(source, split, cmd.MessageIDs); err != nil {
+		return nil, err
+	}
+	firings := s.runTriggers(ctx, trigger.EventTicketCreated, split, nil)
+	var assignee *agent.Agent
+	if split.AssignedTo() == nil && split.IsActive() {
+		assignee = s.autoAssign(ctx, split, nil)
+	}
+
+	s.refreshSLA(ctx, source, split)
+	sourceEvents, splitEvents := source.PendingEvents(), split.PendingEvents()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tickets.SaveSplit(ctx, source, split); err != nil {
+			return err
+		}
+		if err := s.triggers.firings.Record(ctx, firings); err != nil {
+			return err
+		}
+		if s.notifier == nil {
+			return nil
+		}
+		if err := s.notifier.Notify(ctx, source, sourceEvents); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, split, splitEvents)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if assignee != nil {
+		s.assigner.Record(ctx, assignee)
+	}
+	return split, nil
+}
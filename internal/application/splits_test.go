@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+)
+
+func TestSplitTicketNotifies(t *testing.T) {
+	ctx := context.Background()
+	env := newNotifiedTestEnv(t)
+	source := env.createTicket(t, "jane@example.com")
+	source, _, err := env.service.ReplyToTicket(ctx, ReplyToTicketCommand{
+		TicketID:   source.ID(),
+		SenderID:   uuid.New(),
+		SenderName: "Agent",
+		Content:    "About your second question, the refund is on its way.",
+		IsStaff:    true,
+	})
+	if err != nil {
+		t.Fatalf("ReplyToTicket: %v", err)
+	}
+	messages := source.Messages()
+
+	split, err := env.service.SplitTicket(ctx, SplitTicketCommand{
+		TicketID:   source.ID(),
+		MessageIDs: []uuid.UUID{messages[len(messages)-1].ID()},
+		Subject:    "Refund",
+	})
+	if err != nil {
+		t.Fatalf("SplitTicket: %v", err)
+	}
+
+	deliveries, err := env.deliveries.ListByTicket(ctx, split.ID())
+	if err != nil {
+		t.Fatalf("ListByTicket: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Event() != notification.EventTicketReceived {
+		t.Fatalf("split ticket has %d notifications, want the ticket received email", len(deliveries))
+	}
+}
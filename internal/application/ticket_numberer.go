@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// TicketNumberer allocates ticket numbers from a per-day sequence in the
+// number format of the ticket's brand.
+type TicketNumberer struct {
+	sequence      ticket.NumberSequence
+	defaultFormat shared.TicketNumberFormat
+	brandFormats  map[string]shared.TicketNumberFormat
+}
+
+// NewTicketNumberer creates a numberer from the default pattern and the
+// patterns of brands that number their tickets differently, e.g.
+// {"acme": "ACME-{date}-{seq:5}"}. An empty default pattern means
+// shared.DefaultTicketNumberPattern.
+func NewTicketNumberer(sequence ticket.NumberSequence, pattern string, brandPatterns map[string]string) (*TicketNumberer, error) {
+	if pattern == "" {
+		pattern = shared.DefaultTicketNumberPattern
+	}
+	defaultFormat, err := shared.ParseTicketNumberFormat(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	brandFormats := make(map[string]shared.TicketNumberFormat, len(brandPatterns))
+	for brand, p := range brandPatterns {
+		f, err := shared.ParseTicketNumberFormat(p)
+		if err != nil {
+			return nil, fmt.Errorf("brand %q: %w", brand, err)
+		}
+		brandFormats[strings.ToLower(brand)] = f
+	}
+
+	return &TicketNumberer{
+		sequence:      sequence,
+		defaultFormat: defaultFormat,
+		brandFormats:  brandFormats,
+	}, nil
+}
+
+// Next allocates the next ticket number of the brand. Unknown and empty
+// brands use the default format.
+func (n *TicketNumberer) Next(ctx context.Context, brand string) (shared.TicketNumber, error) {
+	format, ok := n.brandFormats[strings.ToLower(brand)]
+	if !ok {
+		format = n.defaultFormat
+	}
+
+	now := time.Now()
+	seq, err := n.sequence.Next(ctx, format.Scope(), now)
+	if err != nil {
+		return shared.TicketNumber{}, fmt.Errorf("allocate ticket number: %w", err)
+	}
+	return format.Format(now, seq), nil
+}
@@ -0,0 +1,802 @@
+// Package application contains the use cases that drive the domain aggregates.
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAccessDenied is returned when the actor may not act on the ticket.
+	ErrAccessDenied = errors.New("access denied")
+
+	// ErrAgentNotInTeam is returned when a ticket in a team's queue is
+	// assigned to an agent outside the team.
+	ErrAgentNotInTeam = errors.New("agent is not a member of the ticket's team")
+)
+
+// TicketService runs ticket use cases against the Ticket aggregate.
+type TicketService struct {
+	tickets     ticket.Repository
+	transactor  Transactor
+	categories  category.Repository
+	calendars   sla.Repository
+	policies    sla.PolicyRepository
+	workflows   workflow.Repository
+	teams       team.Repository
+	agents      agent.Repository
+	customers   customer.Repository
+	mentions    mention.Repository
+	attachments attachment.Repository
+	numberer    *TicketNumberer
+	assigner    *Assigner
+	triggers    *TriggerEngine
+	notifier    *Notifier
+	logger      *zap.Logger
+}
+
+// NewTicketService creates a new ticket service. A nil assigner leaves
+// tickets for manual assignment; a nil notifier sends customers no email;
+// without an attachment repository, uploads cannot be posted.
+func NewTicketService(
+	tickets ticket.Repository,
+	transactor Transactor,
+	categories category.Repository,
+	calendars sla.Repository,
+	policies sla.PolicyRepository,
+	workflows workflow.Repository,
+	teams team.Repository,
+	agents agent.Repository,
+	customers customer.Repository,
+	mentions mention.Repository,
+	attachments attachment.Repository,
+	numberer *TicketNumberer,
+	assigner *Assigner,
+	triggers *TriggerEngine,
+	notifier *Notifier,
+	logger *zap.Logger,
+) *TicketService {
+	return &TicketService{
+		tickets:     tickets,
+		transactor:  transactor,
+		categories:  categories,
+		calendars:   calendars,
+		policies:    policies,
+		workflows:   workflows,
+		teams:       teams,
+		agents:      agents,
+		customers:   customers,
+		mentions:    mentions,
+		attachments: attachments,
+		numberer:    numberer,
+		assigner:    assigner,
+		triggers:    triggers,
+		notifier:    notifier,
+		logger:      logger,
+	}
+}
+
+// CreateTicketCommand contains the data for opening a ticket.
+type CreateTicketCommand struct {
+	// ID is the ID to open the ticket under; zero picks a new one.
+	ID          uuid.UUID
+	CustomerID  *uuid.UUID
+	GuestEmail  string
+	GuestName   string
+	GuestPhone  string
+	CategoryID  *uuid.UUID
+	Subject     string
+	Message     string
+	Channel     string // defaults to web
+	Locale      string
+	Priority    string
+	OrderID     *uuid.UUID
+	OrderNumber string
+	// CC are extra addresses copied on replies to the ticket.
+	CC          []string
+	Attachments []ticket.Attachment
+	// MessageID, InReplyTo and References are the threading headers of a
+	// ticket opened by email.
+	MessageID  string
+	InReplyTo  string
+	References []string
+	// Brand selects the ticket number format; empty uses the default.
+	Brand string
+}
+
+// CreateTicket opens a ticket with the customer's initial message. The
+// category must exist and be active; it and the priority set the SLA targets,
+// the category's workflow governs the ticket's statuses and the ticket joins
+// the queue of the category's team. Trigger rules for new tickets run next;
+// if they leave the ticket unassigned and there is an assigner, the ticket
+// is routed to an agent (of its team) straight away.
+func (s *TicketService) CreateTicket(ctx context.Context, cmd CreateTicketCommand) (*ticket.Ticket, error) {
+	if cmd.Priority != "" {
+		if _, err := shared.ParseTicketPriority(cmd.Priority); err != nil {
+			return nil, err
+		}
+	}
+
+	cat, err := s.resolveCategory(ctx, cmd.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	number, err := s.numberer.Next(ctx, cmd.Brand)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := ticket.NewTicket(ticket.TicketParams{
+		ID:           cmd.ID,
+		TicketNumber: number.Value(),
+		CustomerID:   cmd.CustomerID,
+		GuestEmail:   cmd.GuestEmail,
+		GuestName:    cmd.GuestName,
+		GuestPhone:   cmd.GuestPhone,
+		CategoryID:   cmd.CategoryID,
+		Subject:      cmd.Subject,
+		Channel:      cmd.Channel,
+		Locale:       cmd.Locale,
+		Brand:        cmd.Brand,
+		Priority:     cmd.Priority,
+		OrderID:      cmd.OrderID,
+		OrderNumber:  cmd.OrderNumber,
+		CC:           cmd.CC,
+	})
+	if err != nil {
+		return nil, errors.Join(ticket.ErrInvalidTicket, err)
+	}
+	s.useWorkflow(t, s.workflow(ctx, t.CategoryID()))
+	useAccount(ctx, s.customers, t, s.logger)
+	t.SetSLATargets(s.slaTargets(ctx, cat, t.Priority()))
+	if tm := s.categoryTeam(ctx, t.CategoryID()); tm != nil {
+		id := tm.ID()
+		if err := t.AssignTeam(&id); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := ticket.NewMessage(ticket.MessageParams{
+		TicketID:    t.ID(),
+		SenderType:  string(shared.SenderCustomer),
+		SenderID:    cmd.CustomerID,
+		SenderName:  cmd.GuestName,
+		SenderEmail: cmd.GuestEmail,
+		Content:     cmd.Message,
+		Attachments: cmd.Attachments,
+		MessageID:   cmd.MessageID,
+		InReplyTo:   cmd.InReplyTo,
+		References:  cmd.References,
+	})
+	if err := t.AddMessage(msg); err != nil {
+		return nil, err
+	}
+	firings := s.runTriggers(ctx, trigger.EventTicketCreated, t, &msg)
+	var assignee *agent.Agent
+	if t.AssignedTo() == nil && t.IsActive() {
+		assignee = s.autoAssign(ctx, t, nil)
+	}
+
+	if err := s.saveFired(ctx, t, firings); err != nil {
+		return nil, err
+	}
+	s.postAttachments(ctx, msg)
+	if assignee != nil {
+		s.assigner.Record(ctx, assignee)
+	}
+	return t, nil
+}
+
+// ReplyToTicketCommand contains the data for adding a message to a ticket.
+type ReplyToTicketCommand struct {
+	TicketID    uuid.UUID
+	SenderID    uuid.UUID
+	SenderName  string
+	SenderEmail string
+	Content     string
+	// AttachmentIDs are files uploaded to the ticket to post in the
+	// message.
+	AttachmentIDs []uuid.UUID
+	IsInternal    bool
+	// IsStaff marks the sender as support staff. Staff reply as agents on
+	// tickets they do not own, guest tickets included.
+	IsStaff bool
+}
+
+// ReplyToTicket adds a customer or agent message to a ticket. Customer
+// replies run the trigger rules for replies. Agents mentioned with @handles
+// in an internal note must be known; each is recorded and told about it.
+// Attached files must have been uploaded to the ticket and not posted yet.
+func (s *TicketService) ReplyToTicket(ctx context.Context, cmd ReplyToTicketCommand) (*ticket.Ticket, ticket.Message, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, ticket.Message{}, err
+	}
+
+	senderType, err := replySender(t, cmd.SenderID, cmd.IsStaff)
+	if err != nil {
+		return nil, ticket.Message{}, err
+	}
+	if cmd.IsInternal && !senderType.IsAgent() {
+		return nil, ticket.Message{}, ErrAccessDenied
+	}
+	var mentioned []*agent.Agent
+	if cmd.IsInternal {
+		if mentioned, err = s.mentionedAgents(ctx, cmd.Content, cmd.SenderID); err != nil {
+			return nil, ticket.Message{}, err
+		}
+	}
+	attachments, err := s.uploadedAttachments(ctx, t.ID(), cmd.AttachmentIDs)
+	if err != nil {
+		return nil, ticket.Message{}, err
+	}
+
+	msg := ticket.NewMessage(ticket.MessageParams{
+		TicketID:    t.ID(),
+		SenderType:  string(senderType),
+		SenderID:    &cmd.SenderID,
+		SenderName:  cmd.SenderName,
+		SenderEmail: cmd.SenderEmail,
+		Content:     cmd.Content,
+		Attachments: attachments,
+		IsInternal:  cmd.IsInternal,
+	})
+	if err := t.AddMessage(msg); err != nil {
+		return nil, ticket.Message{}, err
+	}
+	for _, a := range mentioned {
+		if err := t.MentionAgent(msg.ID(), a.ID(), a.Name(), a.Email()); err != nil {
+			return nil, ticket.Message{}, err
+		}
+	}
+	var firings []trigger.Firing
+	if senderType.IsCustomer() {
+		firings = s.runTriggers(ctx, trigger.EventCustomerReplied, t, &msg)
+	}
+
+	if err := s.saveFired(ctx, t, firings); err != nil {
+		return nil, ticket.Message{}, err
+	}
+	s.recordMentions(ctx, msg, mentioned)
+	s.postAttachments(ctx, msg)
+	return t, msg, nil
+}
+
+// ChangeStatusCommand contains the data for a status change.
+type ChangeStatusCommand struct {
+	TicketID      uuid.UUID
+	Status        string
+	ChangedBy     *uuid.UUID
+	ChangedByName string
+	Notes         string
+}
+
+// ChangeStatus moves a ticket through the workflow of its category and
+// runs the trigger rules for status changes.
+func (s *TicketService) ChangeStatus(ctx context.Context, cmd ChangeStatusCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := t.Status()
+	if err := t.ChangeStatus(shared.TicketStatus(cmd.Status), cmd.ChangedBy, cmd.ChangedByName, cmd.Notes); err != nil {
+		return nil, err
+	}
+	var firings []trigger.Firing
+	if t.Status() != previous {
+		firings = s.runTriggers(ctx, trigger.EventStatusChanged, t, nil)
+	}
+
+	if err := s.saveFired(ctx, t, firings); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// AssignCommand contains the data for assigning a ticket to an agent.
+type AssignCommand struct {
+	TicketID  uuid.UUID
+	AgentID   uuid.UUID
+	ChangedBy *uuid.UUID
+}
+
+// Assign assigns a ticket to an agent. A ticket in a team's queue only goes
+// to members of the team.
+func (s *TicketService) Assign(ctx context.Context, cmd AssignCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMember(ctx, t.TeamID(), cmd.AgentID); err != nil {
+		return nil, err
+	}
+	if err := t.Assign(cmd.AgentID, cmd.ChangedBy); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UnassignCommand contains the data for taking a ticket off its agent.
+type UnassignCommand struct {
+	TicketID  uuid.UUID
+	ChangedBy *uuid.UUID
+}
+
+// Unassign takes a ticket off its agent. With an assigner the ticket is
+// routed to another agent; the previous one is not considered.
+func (s *TicketService) Unassign(ctx context.Context, cmd UnassignCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := t.AssignedTo()
+	if err := t.Unassign(cmd.ChangedBy); err != nil {
+		return nil, err
+	}
+	var assignee *agent.Agent
+	if t.IsActive() {
+		assignee = s.autoAssign(ctx, t, previous)
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	if assignee != nil {
+		s.assigner.Record(ctx, assignee)
+	}
+	return t, nil
+}
+
+// AssignTeamCommand contains the data for moving a ticket to a team's
+// queue. A nil TeamID takes the ticket out of all queues; AgentID optionally
+// assigns it to a member of the team at the same time.
+type AssignTeamCommand struct {
+	TicketID  uuid.UUID
+	TeamID    *uuid.UUID
+	AgentID   *uuid.UUID
+	ChangedBy *uuid.UUID
+}
+
+// AssignTeam moves a ticket to a team's queue. Without an agent the current
+// one keeps the ticket if they are a member of the team; otherwise the
+// ticket is unassigned and, with an assigner, routed within the team.
+func (s *TicketService) AssignTeam(ctx context.Context, cmd AssignTeamCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.TeamID != nil {
+		if _, err := s.teams.FindByID(ctx, *cmd.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.AssignTeam(cmd.TeamID); err != nil {
+		return nil, err
+	}
+
+	var assignee *agent.Agent
+	switch {
+	case cmd.AgentID != nil:
+		if err := s.checkMember(ctx, cmd.TeamID, *cmd.AgentID); err != nil {
+			return nil, err
+		}
+		if t.AssignedTo() == nil || *t.AssignedTo() != *cmd.AgentID {
+			if err := t.Assign(*cmd.AgentID, cmd.ChangedBy); err != nil {
+				return nil, err
+			}
+		}
+	case t.AssignedTo() != nil:
+		err := s.checkMember(ctx, cmd.TeamID, *t.AssignedTo())
+		if errors.Is(err, ErrAgentNotInTeam) || errors.Is(err, agent.ErrAgentNotFound) {
+			previous := t.AssignedTo()
+			if err := t.Unassign(cmd.ChangedBy); err != nil {
+				return nil, err
+			}
+			if t.IsActive() {
+				assignee = s.autoAssign(ctx, t, previous)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	case t.IsActive():
+		assignee = s.autoAssign(ctx, t, nil)
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	if assignee != nil {
+		s.assigner.Record(ctx, assignee)
+	}
+	return t, nil
+}
+
+// RateCommand contains a customer's satisfaction rating.
+type RateCommand struct {
+	TicketID   uuid.UUID
+	CustomerID uuid.UUID
+	Rating     int
+	Comment    string
+}
+
+// Rate records the customer's satisfaction with a resolved ticket of theirs.
+func (s *TicketService) Rate(ctx context.Context, cmd RateCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the customer who owns the ticket may rate it; guest tickets have
+	// no signed-in owner
+	if t.CustomerID() == nil || *t.CustomerID() != cmd.CustomerID {
+		return nil, ErrAccessDenied
+	}
+
+	if err := t.RateSatisfaction(cmd.Rating, cmd.Comment); err != nil {
+		return nil, errors.Join(ticket.ErrCannotModify, err)
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateTicketCommand contains the admin changes to a ticket. Empty fields
+// are left unchanged.
+type UpdateTicketCommand struct {
+	TicketID      uuid.UUID
+	Status        string
+	Priority      string
+	CategoryID    *uuid.UUID
+	AssignedTo    *uuid.UUID
+	Tags          []string
+	ChangedBy     *uuid.UUID
+	ChangedByName string
+}
+
+// UpdateTicket applies several admin changes to a ticket in one save. A
+// status change runs the trigger rules for status changes.
+func (s *TicketService) UpdateTicket(ctx context.Context, cmd UpdateTicketCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.AssignedTo != nil && (t.AssignedTo() == nil || *t.AssignedTo() != *cmd.AssignedTo) {
+		if err := s.checkMember(ctx, t.TeamID(), *cmd.AssignedTo); err != nil {
+			return nil, err
+		}
+		if err := t.Assign(*cmd.AssignedTo, cmd.ChangedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new priority or category brings new SLA targets; the deadlines are
+	// recomputed from the ticket's creation when it is saved.
+	slaChanged := false
+	if cmd.Priority != "" && cmd.Priority != string(t.Priority()) {
+		priority, err := shared.ParseTicketPriority(cmd.Priority)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.ChangePriority(priority); err != nil {
+			return nil, err
+		}
+		slaChanged = true
+	}
+	var cat *category.Category
+	if cmd.CategoryID != nil && (t.CategoryID() == nil || *t.CategoryID() != *cmd.CategoryID) {
+		cat, err = s.resolveCategory(ctx, cmd.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		t.SetCategory(*cmd.CategoryID)
+		s.useWorkflow(t, s.workflow(ctx, t.CategoryID()))
+		slaChanged = true
+	} else if slaChanged {
+		cat = s.findCategory(ctx, t.CategoryID())
+	}
+	if slaChanged {
+		t.SetSLATargets(s.slaTargets(ctx, cat, t.Priority()))
+	}
+	if cmd.Tags != nil {
+		t.SetTags(cmd.Tags)
+	}
+
+	// Status goes last so closing the ticket does not block the other changes
+	var firings []trigger.Firing
+	if cmd.Status != "" && cmd.Status != string(t.Status()) {
+		if err := t.ChangeStatus(shared.TicketStatus(cmd.Status), cmd.ChangedBy, cmd.ChangedByName, ""); err != nil {
+			return nil, err
+		}
+		firings = s.runTriggers(ctx, trigger.EventStatusChanged, t, nil)
+	}
+
+	if err := s.saveFired(ctx, t, firings); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SLAStatus reports the ticket's first response and resolution clocks in
+// the working time of its calendar. The ticket is put on its category's
+// workflow, which decides when the clocks run.
+func (s *TicketService) SLAStatus(ctx context.Context, t *ticket.Ticket) ticket.SLAStatus {
+	s.useWorkflow(t, s.workflow(ctx, t.CategoryID()))
+	return t.SLAStatus(s.calendar(ctx, t.CategoryID()), time.Now())
+}
+
+// SLAStatuses reports the SLA clocks of several tickets, loading each
+// category's calendar and workflow once.
+func (s *TicketService) SLAStatuses(ctx context.Context, tickets []*ticket.Ticket) map[uuid.UUID]ticket.SLAStatus {
+	type categoryRules struct {
+		calendar *sla.Calendar
+		workflow *workflow.Workflow
+	}
+
+	now := time.Now()
+	rules := make(map[uuid.UUID]categoryRules)
+	statuses := make(map[uuid.UUID]ticket.SLAStatus, len(tickets))
+	for _, t := range tickets {
+		// Tickets without a category share the uuid.Nil entry
+		var key uuid.UUID
+		if id := t.CategoryID(); id != nil {
+			key = *id
+		}
+		r, ok := rules[key]
+		if !ok {
+			r = categoryRules{
+				calendar: s.calendar(ctx, t.CategoryID()),
+				workflow: s.workflow(ctx, t.CategoryID()),
+			}
+			rules[key] = r
+		}
+		s.useWorkflow(t, r.workflow)
+		statuses[t.ID()] = t.SLAStatus(r.calendar, now)
+	}
+	return statuses
+}
+
+// slaTargets returns the SLA targets of a ticket. A policy for the category
+// and priority wins; otherwise the global policy of the priority (or the
+// priority defaults) applies, tightened to the category's SLA hours when
+// those are shorter.
+func (s *TicketService) slaTargets(ctx context.Context, cat *category.Category, priority shared.TicketPriority) sla.Targets {
+	var categoryID *uuid.UUID
+	if cat != nil {
+		id := cat.ID()
+		categoryID = &id
+	}
+
+	targets := sla.DefaultTargets(priority)
+	policy, err := s.policies.FindForTicket(ctx, categoryID, priority)
+	switch {
+	case err == nil && !policy.IsGlobal():
+		return policy.Targets()
+	case err == nil:
+		targets = policy.Targets()
+	case !errors.Is(err, sla.ErrPolicyNotFound):
+		s.logger.Warn("Failed to load SLA policy, using priority defaults", zap.Error(err))
+	}
+
+	if cat != nil && cat.SLAHours() > 0 {
+		hours := time.Duration(cat.SLAHours()) * time.Hour
+		if hours < targets.Resolution {
+			targets.Resolution = hours
+		}
+		if targets.FirstResponse > targets.Resolution {
+			targets.FirstResponse = targets.Resolution
+		}
+	}
+	return targets
+}
+
+// resolveCategory loads the category a ticket is being filed under. Unknown
+// and inactive categories are rejected.
+func (s *TicketService) resolveCategory(ctx context.Context, id *uuid.UUID) (*category.Category, error) {
+	if id == nil {
+		return nil, nil
+	}
+	cat, err := s.categories.FindByID(ctx, *id)
+	if err != nil {
+		return nil, err
+	}
+	if !cat.IsActive() {
+		return nil, category.ErrCategoryInactive
+	}
+	return cat, nil
+}
+
+// findCategory loads the category a ticket is already filed under, which
+// may since have been deactivated or deleted.
+func (s *TicketService) findCategory(ctx context.Context, id *uuid.UUID) *category.Category {
+	if id == nil {
+		return nil
+	}
+	cat, err := s.categories.FindByID(ctx, *id)
+	if err != nil {
+		if !errors.Is(err, category.ErrCategoryNotFound) {
+			s.logger.Warn("Failed to load ticket category", zap.Error(err))
+		}
+		return nil
+	}
+	return cat
+}
+
+// calendar returns the calendar that applies to the category. Without one
+// the SLA clocks run around the clock.
+func (s *TicketService) calendar(ctx context.Context, categoryID *uuid.UUID) *sla.Calendar {
+	calendar, err := s.calendars.FindForCategory(ctx, categoryID)
+	if err != nil && !errors.Is(err, sla.ErrCalendarNotFound) {
+		s.logger.Warn("Failed to load SLA calendar, using wall-clock time", zap.Error(err))
+	}
+	return calendar
+}
+
+// workflow returns the workflow that applies to the category. Without a
+// stored one, or when the stored one fails validation, the built-in
+// workflow applies.
+func (s *TicketService) workflow(ctx context.Context, categoryID *uuid.UUID) *workflow.Workflow {
+	wf, err := s.workflows.FindForCategory(ctx, categoryID)
+	if err != nil {
+		if !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			s.logger.Warn("Failed to load workflow, using the built-in workflow", zap.Error(err))
+		}
+		return workflow.Default()
+	}
+	return wf
+}
+
+// useWorkflow puts the ticket on the workflow. A ticket whose status the
+// workflow does not define, such as after its category changed, stays on
+// the built-in workflow so it can still be moved on.
+func (s *TicketService) useWorkflow(t *ticket.Ticket, wf *workflow.Workflow) {
+	if err := t.UseWorkflow(wf); err != nil {
+		s.logger.Warn("Ticket status is not part of its workflow, using the built-in workflow",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("status", string(t.Status())),
+			zap.String("workflow", wf.Name()))
+		_ = t.UseWorkflow(workflow.Default())
+	}
+}
+
+// useAccount gives a ticket opened from a customer account the account's
+// email address and name. Without a customer record, the ticket keeps the
+// contact details it was opened with.
+func useAccount(ctx context.Context, customers customer.Repository, t *ticket.Ticket, logger *zap.Logger) {
+	if t.CustomerID() == nil {
+		return
+	}
+	c, err := customers.FindByID(ctx, *t.CustomerID())
+	if err != nil {
+		if !errors.Is(err, customer.ErrCustomerNotFound) {
+			logger.Warn("Failed to load ticket customer",
+				zap.String("ticket_id", t.ID().String()),
+				zap.Error(err))
+		}
+		return
+	}
+	t.UseAccount(c.Email(), c.Name())
+}
+
+// categoryTeam returns the team whose queue new tickets of the category
+// join, or nil for none.
+func (s *TicketService) categoryTeam(ctx context.Context, categoryID *uuid.UUID) *team.Team {
+	if categoryID == nil {
+		return nil
+	}
+	tm, err := s.teams.FindForCategory(ctx, *categoryID)
+	if err != nil {
+		if !errors.Is(err, team.ErrTeamNotFound) {
+			s.logger.Warn("Failed to load category team, leaving the ticket without a team", zap.Error(err))
+		}
+		return nil
+	}
+	return tm
+}
+
+// checkMember checks that the agent belongs to the team. Any agent may
+// work tickets without a team.
+func (s *TicketService) checkMember(ctx context.Context, teamID *uuid.UUID, agentID uuid.UUID) error {
+	if teamID == nil {
+		return nil
+	}
+	tm, err := s.teams.FindByID(ctx, *teamID)
+	if err != nil {
+		return err
+	}
+	ag, err := s.agents.FindByID(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if !ag.InTeam(tm.Key()) {
+		return ErrAgentNotInTeam
+	}
+	return nil
+}
+
+// autoAssign routes the ticket with the assigner and returns the agent it
+// went to. Assignment failures leave the ticket unassigned for a lead to
+// triage instead of failing the use case.
+func (s *TicketService) autoAssign(ctx context.Context, t *ticket.Ticket, exclude *uuid.UUID) *agent.Agent {
+	if s.assigner == nil {
+		return nil
+	}
+
+	assignee, err := s.assigner.Assign(ctx, t, exclude)
+	if err != nil {
+		s.logger.Warn("Failed to auto-assign ticket, leaving it unassigned",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("strategy", s.assigner.Strategy()),
+			zap.Error(err))
+		return nil
+	}
+	if assignee == nil {
+		s.logger.Info("No agent available for auto-assignment",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("strategy", s.assigner.Strategy()))
+	}
+	return assignee
+}
+
+// load finds a ticket and puts it on its category's workflow.
+func (s *TicketService) load(ctx context.Context, id uuid.UUID) (*ticket.Ticket, error) {
+	t, err := s.tickets.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.useWorkflow(t, s.workflow(ctx, t.CategoryID()))
+	useAccount(ctx, s.customers, t, s.logger)
+	return t, nil
+}
+
+// save refreshes the SLA deadlines and persists the ticket. Its collected
+// events go to the outbox in the same transaction and are published by the
+// relay; the customer notifications they call for are queued in that
+// transaction too.
+func (s *TicketService) save(ctx context.Context, t *ticket.Ticket) error {
+	s.refreshSLA(ctx, t)
+	if s.notifier == nil {
+		return s.tickets.Save(ctx, t)
+	}
+	events := t.PendingEvents()
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tickets.Save(ctx, t); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, t, events)
+	})
+}
+
+// refreshSLA refreshes the SLA deadlines of tickets about to be saved.
+func (s *TicketService) refreshSLA(ctx context.Context, tickets ...*ticket.Ticket) {
+	now := time.Now()
+	for _, t := range tickets {
+		t.RefreshSLA(s.calendar(ctx, t.CategoryID()), now)
+	}
+}
@@ -0,0 +1,13 @@
+package application
+
+import "context"
+
+// Transactor runs a unit of work in one transaction. Repositories called
+// with the context handed to fn write through that transaction, so the
+// ticket, the trigger firings and the notifications of a use case are
+// stored together or not at all.
+type Transactor interface {
+	// WithinTransaction commits when fn returns nil and rolls back when it
+	// returns an error, which is returned.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"go.uber.org/zap"
+)
+
+// TriggerEngine holds what the ticket service needs to run trigger rules:
+// the rules, the log firings are recorded in and the canned responses
+// rules send.
+type TriggerEngine struct {
+	rules     trigger.Repository
+	firings   trigger.FiringLog
+	responses response.Repository
+}
+
+// NewTriggerEngine creates a trigger engine.
+func NewTriggerEngine(rules trigger.Repository, firings trigger.FiringLog, responses response.Repository) *TriggerEngine {
+	return &TriggerEngine{
+		rules:     rules,
+		firings:   firings,
+		responses: responses,
+	}
+}
+
+// TriggerEvaluation is the outcome of one trigger rule for a ticket.
+// Actions is only set when the rule matched.
+type TriggerEvaluation struct {
+	Rule    *trigger.Rule
+	Matched bool
+	Actions []trigger.ActionResult
+}
+
+// TriggerRun is the outcome of running trigger rules on a ticket. Ticket
+// has the actions of the matched rules applied.
+type TriggerRun struct {
+	Event  trigger.Event
+	Rules  []TriggerEvaluation
+	Ticket *ticket.Ticket
+}
+
+// DryRunTriggersCommand contains the data for trying trigger rules on a
+// ticket. Rule, when set, is tried on its own, enabled or not; otherwise
+// the enabled rules of the event are.
+type DryRunTriggersCommand struct {
+	TicketID uuid.UUID
+	Event    trigger.Event
+	Rule     *trigger.Rule
+}
+
+// DryRunTriggers runs trigger rules on a ticket as if the event had just
+// happened, without saving the ticket or logging firings. On customer
+// replies the customer's latest message is tested.
+func (s *TicketService) DryRunTriggers(ctx context.Context, cmd DryRunTriggersCommand) (*TriggerRun, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*trigger.Rule{cmd.Rule}
+	if cmd.Rule == nil {
+		rules, err = s.triggers.rules.ListForEvent(ctx, cmd.Event)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TriggerRun{
+		Event:  cmd.Event,
+		Rules:  s.evaluateTriggers(ctx, rules, t, nil, true),
+		Ticket: t,
+	}, nil
+}
+
+// ListTriggerFirings returns a page of the firing log.
+func (s *TicketService) ListTriggerFirings(ctx context.Context, filter trigger.FiringFilter) ([]trigger.Firing, int64, error) {
+	return s.triggers.firings.List(ctx, filter)
+}
+
+// runTriggers applies the enabled rules of the event to the ticket and
+// returns their firings, to be recorded with the ticket by saveFired. msg is
+// the message that caused the event, if any. Rules that fail to load are
+// logged and skipped so the use case goes on.
+func (s *TicketService) runTriggers(ctx context.Context, event trigger.Event, t *ticket.Ticket, msg *ticket.Message) []trigger.Firing {
+	rules, err := s.triggers.rules.ListForEvent(ctx, event)
+	if err != nil {
+		s.logger.Warn("Failed to load trigger rules, skipping them",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("event", string(event)),
+			zap.Error(err))
+		return nil
+	}
+
+	var firings []trigger.Firing
+	for _, e := range s.evaluateTriggers(ctx, rules, t, msg, false) {
+		if !e.Matched {
+			continue
+		}
+		firing := trigger.NewFiring(e.Rule, t.ID(), event, e.Actions)
+		firings = append(firings, firing)
+		s.logger.Info("Trigger rule fired",
+			zap.String("ticket_id", t.ID().String()),
+			zap.String("rule", e.Rule.Name()),
+			zap.String("event", string(event)),
+			zap.Bool("failed", firing.Failed()))
+	}
+	return firings
+}
+
+// saveFired saves the ticket and adds the firings of the triggers that
+// changed it to the log in one transaction, so the log never shows a firing
+// whose changes were not stored or misses one whose changes were.
+func (s *TicketService) saveFired(ctx context.Context, t *ticket.Ticket, firings []trigger.Firing) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.save(ctx, t); err != nil {
+			return err
+		}
+		return s.triggers.firings.Record(ctx, firings)
+	})
+}
+
+// evaluateTriggers tests the rules in order and applies the actions of
+// those that match. Each rule sees the changes of the rules before it. An
+// action that fails is recorded and the remaining actions still run.
+func (s *TicketService) evaluateTriggers(ctx context.Context, rules []*trigger.Rule, t *ticket.Ticket, msg *ticket.Message, dryRun bool) []TriggerEvaluation {
+	evaluations := make([]TriggerEvaluation, 0, len(rules))
+	for _, rule := range rules {
+		e := TriggerEvaluation{Rule: rule}
+		if rule.Matches(trigger.FactsOf(t, msg)) {
+			e.Matched = true
+			e.Actions = make([]trigger.ActionResult, 0, len(rule.Actions()))
+			for _, a := range rule.Actions() {
+				result := trigger.ActionResult{Type: a.Type, Value: a.Value}
+				if err := s.applyTriggerAction(ctx, rule, a, t, dryRun); err != nil {
+					result.Error = err.Error()
+				}
+				e.Actions = append(e.Actions, result)
+			}
+		}
+		evaluations = append(evaluations, e)
+	}
+	return evaluations
+}
+
+// applyTriggerAction applies one action of a rule to the ticket. In a dry
+// run nothing but the ticket is changed.
+func (s *TicketService) applyTriggerAction(ctx context.Context, rule *trigger.Rule, a trigger.Action, t *ticket.Ticket, dryRun bool) error {
+	actor := fmt.Sprintf("Trigger %q", rule.Name())
+
+	switch a.Type {
+	case trigger.ActionSetPriority:
+		priority := shared.TicketPriority(a.Value)
+		if priority == t.Priority() {
+			return nil
+		}
+		if err := t.ChangePriority(priority); err != nil {
+			return err
+		}
+		t.SetSLATargets(s.slaTargets(ctx, s.findCategory(ctx, t.CategoryID()), t.Priority()))
+		return nil
+
+	case trigger.ActionSetStatus:
+		return t.ChangeStatus(shared.TicketStatus(a.Value), nil, actor, "")
+
+	case trigger.ActionSetCategory:
+		id := a.TargetID()
+		if t.CategoryID() != nil && *t.CategoryID() == id {
+			return nil
+		}
+		cat, err := s.resolveCategory(ctx, &id)
+		if err != nil {
+			return err
+		}
+		t.SetCategory(id)
+		s.useWorkflow(t, s.workflow(ctx, t.CategoryID()))
+		t.SetSLATargets(s.slaTargets(ctx, cat, t.Priority()))
+		return nil
+
+	case trigger.ActionAddTag:
+		t.AddTag(a.Value)
+		return nil
+
+	case trigger.ActionRemoveTag:
+		t.RemoveTag(a.Value)
+		return nil
+
+	case trigger.ActionAssignAgent:
+		id := a.TargetID()
+		if t.AssignedTo() != nil && *t.AssignedTo() == id {
+			return nil
+		}
+		if _, err := s.agents.FindByID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.checkMember(ctx, t.TeamID(), id); err != nil {
+			return err
+		}
+		return t.AutoAssign(id, fmt.Sprintf("trigger %q", rule.Name()))
+
+	case trigger.ActionAssignTeam:
+		id := a.TargetID()
+		if _, err := s.teams.FindByID(ctx, id); err != nil {
+			return err
+		}
+		if err := t.AssignTeam(&id); err != nil {
+			return err
+		}
+		// The ticket waits in the team's queue when its agent is not a member
+		if t.AssignedTo() != nil {
+			err := s.checkMember(ctx, &id, *t.AssignedTo())
+			if errors.Is(err, ErrAgentNotInTeam) || errors.Is(err, agent.ErrAgentNotFound) {
+				return t.Unassign(nil)
+			}
+			return err
+		}
+		return nil
+
+	case trigger.ActionAddNote:
+		return t.AddMessage(ticket.NewMessage(ticket.MessageParams{
+			TicketID:   t.ID(),
+			SenderType: string(shared.SenderSystem),
+			SenderName: actor,
+			Content:    a.Value,
+			IsInternal: true,
+		}))
+
+	case trigger.ActionSendCannedResponse:
+		r, err := s.triggers.responses.FindByID(ctx, a.TargetID())
+		if err != nil {
+			return err
+		}
+		if !r.IsActive() {
+			return fmt.Errorf("canned response %q is not active", r.Title())
+		}
+		if err := t.AddMessage(ticket.NewMessage(ticket.MessageParams{
+			TicketID:   t.ID(),
+			SenderType: string(shared.SenderSystem),
+			SenderName: "System",
+			Content:    r.Content(),
+		})); err != nil {
+			return err
+		}
+		if !dryRun {
+			r.IncrementUsage()
+			if err := s.triggers.responses.Save(ctx, r); err != nil {
+				s.logger.Warn("Failed to count canned response usage", zap.Error(err))
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown action %q", a.Type)
+}
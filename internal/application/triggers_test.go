@@ -0,0 +1,403 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+// triggerFixtures are the records trigger actions refer to.
+type triggerFixtures struct {
+	billing, archived   uuid.UUID // active and inactive categories
+	returns             uuid.UUID // team Ben is a member of
+	alice, ben          uuid.UUID // agents
+	greeting, withdrawn uuid.UUID // active and inactive canned responses
+}
+
+func (e *testEnv) triggerFixtures(t *testing.T) triggerFixtures {
+	t.Helper()
+	ctx := context.Background()
+	var f triggerFixtures
+
+	for _, c := range []struct {
+		id     *uuid.UUID
+		name   string
+		active bool
+	}{{&f.billing, "Billing", true}, {&f.archived, "Archived", false}} {
+		cat, err := category.NewCategory(category.CategoryParams{Name: c.name, IsActive: c.active})
+		if err != nil {
+			t.Fatalf("NewCategory: %v", err)
+		}
+		if err := e.categories.Save(ctx, cat); err != nil {
+			t.Fatalf("Save category: %v", err)
+		}
+		*c.id = cat.ID()
+	}
+
+	tm, err := team.NewTeam(team.TeamParams{Key: "returns", Name: "Returns"})
+	if err != nil {
+		t.Fatalf("NewTeam: %v", err)
+	}
+	if err := e.teams.Save(ctx, tm); err != nil {
+		t.Fatalf("Save team: %v", err)
+	}
+	f.returns = tm.ID()
+
+	for _, a := range []struct {
+		id    *uuid.UUID
+		name  string
+		teams []string
+	}{{&f.alice, "Alice", nil}, {&f.ben, "Ben", []string{"returns"}}} {
+		ag, err := agent.NewAgent(agent.AgentParams{ID: uuid.New(), Name: a.name, Email: a.name + "@shop.test", Teams: a.teams})
+		if err != nil {
+			t.Fatalf("NewAgent: %v", err)
+		}
+		if err := e.agents.Save(ctx, ag); err != nil {
+			t.Fatalf("Save agent: %v", err)
+		}
+		*a.id = ag.ID()
+	}
+
+	for _, r := range []struct {
+		id     *uuid.UUID
+		title  string
+		active bool
+	}{{&f.greeting, "Greeting", true}, {&f.withdrawn, "Withdrawn", false}} {
+		cr, err := response.NewCannedResponse(response.CannedResponseParams{Title: r.title, Content: r.title + " from the support team", IsActive: r.active})
+		if err != nil {
+			t.Fatalf("NewCannedResponse: %v", err)
+		}
+		if err := e.responses.Save(ctx, cr); err != nil {
+			t.Fatalf("Save canned response: %v", err)
+		}
+		*r.id = cr.ID()
+	}
+	return f
+}
+
+func TestTriggerActions(t *testing.T) {
+	env := newTestEnv(t)
+	f := env.triggerFixtures(t)
+	lastMessage := func(tk *ticket.Ticket) ticket.Message {
+		messages := tk.Messages()
+		return messages[len(messages)-1]
+	}
+
+	tests := []struct {
+		name       string
+		actions    []trigger.Action
+		wantFailed []bool // per action
+		check      func(t *testing.T, tk *ticket.Ticket)
+	}{
+		{
+			name:    "set priority",
+			actions: []trigger.Action{{Type: trigger.ActionSetPriority, Value: "urgent"}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.Priority() != shared.PriorityUrgent {
+					t.Fatalf("priority = %s, want urgent", tk.Priority())
+				}
+			},
+		},
+		{
+			name:    "set status",
+			actions: []trigger.Action{{Type: trigger.ActionSetStatus, Value: "pending"}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.Status() != shared.StatusPending {
+					t.Fatalf("status = %s, want pending", tk.Status())
+				}
+			},
+		},
+		{
+			name:       "set status the workflow does not allow",
+			actions:    []trigger.Action{{Type: trigger.ActionSetStatus, Value: "closed"}},
+			wantFailed: []bool{true},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.Status() != shared.StatusOpen {
+					t.Fatalf("status = %s, want open", tk.Status())
+				}
+			},
+		},
+		{
+			name:    "set category",
+			actions: []trigger.Action{{Type: trigger.ActionSetCategory, Value: f.billing.String()}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.CategoryID() == nil || *tk.CategoryID() != f.billing {
+					t.Fatalf("category = %v, want billing", tk.CategoryID())
+				}
+			},
+		},
+		{
+			name:       "set inactive category",
+			actions:    []trigger.Action{{Type: trigger.ActionSetCategory, Value: f.archived.String()}},
+			wantFailed: []bool{true},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.CategoryID() != nil {
+					t.Fatalf("category = %v, want none", tk.CategoryID())
+				}
+			},
+		},
+		{
+			name: "add and remove tags",
+			actions: []trigger.Action{
+				{Type: trigger.ActionAddTag, Value: "vip"},
+				{Type: trigger.ActionAddTag, Value: "refund"},
+				{Type: trigger.ActionRemoveTag, Value: "vip"},
+			},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tags := tk.Tags(); len(tags) != 1 || tags[0] != "refund" {
+					t.Fatalf("tags = %v, want refund", tags)
+				}
+			},
+		},
+		{
+			name:    "assign agent",
+			actions: []trigger.Action{{Type: trigger.ActionAssignAgent, Value: f.alice.String()}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.AssignedTo() == nil || *tk.AssignedTo() != f.alice {
+					t.Fatalf("assigned to %v, want Alice", tk.AssignedTo())
+				}
+				if tk.AssignmentReason() != `trigger "Rule"` {
+					t.Fatalf("assignment reason = %q", tk.AssignmentReason())
+				}
+			},
+		},
+		{
+			name:       "assign unknown agent",
+			actions:    []trigger.Action{{Type: trigger.ActionAssignAgent, Value: uuid.NewString()}},
+			wantFailed: []bool{true},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.AssignedTo() != nil {
+					t.Fatalf("assigned to %v, want nobody", tk.AssignedTo())
+				}
+			},
+		},
+		{
+			name: "assign team keeps a member",
+			actions: []trigger.Action{
+				{Type: trigger.ActionAssignAgent, Value: f.ben.String()},
+				{Type: trigger.ActionAssignTeam, Value: f.returns.String()},
+			},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.TeamID() == nil || *tk.TeamID() != f.returns {
+					t.Fatalf("team = %v, want returns", tk.TeamID())
+				}
+				if tk.AssignedTo() == nil || *tk.AssignedTo() != f.ben {
+					t.Fatalf("assigned to %v, want Ben", tk.AssignedTo())
+				}
+			},
+		},
+		{
+			name: "assign team queues the ticket away from a non-member",
+			actions: []trigger.Action{
+				{Type: trigger.ActionAssignAgent, Value: f.alice.String()},
+				{Type: trigger.ActionAssignTeam, Value: f.returns.String()},
+			},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.TeamID() == nil || *tk.TeamID() != f.returns {
+					t.Fatalf("team = %v, want returns", tk.TeamID())
+				}
+				if tk.AssignedTo() != nil {
+					t.Fatalf("assigned to %v, want the team queue", tk.AssignedTo())
+				}
+			},
+		},
+		{
+			name: "assign agent outside the ticket's team",
+			actions: []trigger.Action{
+				{Type: trigger.ActionAssignTeam, Value: f.returns.String()},
+				{Type: trigger.ActionAssignAgent, Value: f.alice.String()},
+			},
+			wantFailed: []bool{false, true},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tk.AssignedTo() != nil {
+					t.Fatalf("assigned to %v, want nobody", tk.AssignedTo())
+				}
+			},
+		},
+		{
+			name:    "add note",
+			actions: []trigger.Action{{Type: trigger.ActionAddNote, Value: "Check the courier first"}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				msg := lastMessage(tk)
+				if !msg.IsInternal() || msg.SenderType() != shared.SenderSystem || msg.Content() != "Check the courier first" {
+					t.Fatalf("last message = %q (internal %v, %s), want the note", msg.Content(), msg.IsInternal(), msg.SenderType())
+				}
+			},
+		},
+		{
+			name:    "send canned response",
+			actions: []trigger.Action{{Type: trigger.ActionSendCannedResponse, Value: f.greeting.String()}},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				msg := lastMessage(tk)
+				if msg.IsInternal() || msg.Content() != "Greeting from the support team" {
+					t.Fatalf("last message = %q (internal %v), want the greeting", msg.Content(), msg.IsInternal())
+				}
+			},
+		},
+		{
+			name:       "send inactive canned response",
+			actions:    []trigger.Action{{Type: trigger.ActionSendCannedResponse, Value: f.withdrawn.String()}},
+			wantFailed: []bool{true},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if len(tk.Messages()) != 1 {
+					t.Fatalf("messages = %d, want the customer's only", len(tk.Messages()))
+				}
+			},
+		},
+		{
+			name: "a failed action does not stop the rest",
+			actions: []trigger.Action{
+				{Type: trigger.ActionSetStatus, Value: "closed"},
+				{Type: trigger.ActionAddTag, Value: "needs-review"},
+			},
+			wantFailed: []bool{true, false},
+			check: func(t *testing.T, tk *ticket.Ticket) {
+				if tags := tk.Tags(); len(tags) != 1 || tags[0] != "needs-review" {
+					t.Fatalf("tags = %v, want needs-review", tags)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := env.createTicket(t, "jane@example.com")
+			rule, err := trigger.NewRule(trigger.RuleParams{
+				Name:    "Rule",
+				Events:  []trigger.Event{trigger.EventTicketCreated},
+				Actions: tt.actions,
+			})
+			if err != nil {
+				t.Fatalf("NewRule: %v", err)
+			}
+
+			run, err := env.service.DryRunTriggers(context.Background(), DryRunTriggersCommand{
+				TicketID: tk.ID(),
+				Event:    trigger.EventTicketCreated,
+				Rule:     rule,
+			})
+			if err != nil {
+				t.Fatalf("DryRunTriggers: %v", err)
+			}
+			if len(run.Rules) != 1 || !run.Rules[0].Matched {
+				t.Fatalf("rule did not match: %+v", run.Rules)
+			}
+			results := run.Rules[0].Actions
+			if len(results) != len(tt.actions) {
+				t.Fatalf("%d action results, want %d", len(results), len(tt.actions))
+			}
+			for i, result := range results {
+				wantFailed := tt.wantFailed != nil && tt.wantFailed[i]
+				if (result.Error != "") != wantFailed {
+					t.Fatalf("action %d (%s) error = %q, want failed %v", i, result.Type, result.Error, wantFailed)
+				}
+			}
+			tt.check(t, run.Ticket)
+		})
+	}
+}
+
+func TestTriggersRunInOrder(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	f := env.triggerFixtures(t)
+
+	rules := []trigger.RuleParams{
+		{
+			// Runs second and sees the tag the first rule added
+			Name:      "Escalate VIPs",
+			Position:  2,
+			Enabled:   true,
+			Events:    []trigger.Event{trigger.EventTicketCreated},
+			Condition: trigger.Condition{Field: trigger.FieldTags, Operator: trigger.OperatorEquals, Value: "vip"},
+			Actions:   []trigger.Action{{Type: trigger.ActionSetPriority, Value: "high"}},
+		},
+		{
+			Name:      "Tag order questions",
+			Position:  1,
+			Enabled:   true,
+			Events:    []trigger.Event{trigger.EventTicketCreated},
+			Condition: trigger.Condition{Field: trigger.FieldSubject, Operator: trigger.OperatorContains, Value: "order"},
+			Actions: []trigger.Action{
+				{Type: trigger.ActionAddTag, Value: "vip"},
+				{Type: trigger.ActionSendCannedResponse, Value: f.greeting.String()},
+			},
+		},
+		{
+			Name:    "Disabled",
+			Enabled: false,
+			Events:  []trigger.Event{trigger.EventTicketCreated},
+			Actions: []trigger.Action{{Type: trigger.ActionAddTag, Value: "disabled"}},
+		},
+		{
+			Name:    "Other event",
+			Enabled: true,
+			Events:  []trigger.Event{trigger.EventCustomerReplied},
+			Actions: []trigger.Action{{Type: trigger.ActionAddTag, Value: "replied"}},
+		},
+		{
+			Name:      "Not matching",
+			Enabled:   true,
+			Events:    []trigger.Event{trigger.EventTicketCreated},
+			Condition: trigger.Condition{Field: trigger.FieldChannel, Operator: trigger.OperatorEquals, Value: "chat"},
+			Actions:   []trigger.Action{{Type: trigger.ActionAddTag, Value: "chat"}},
+		},
+	}
+	for _, p := range rules {
+		rule, err := trigger.NewRule(p)
+		if err != nil {
+			t.Fatalf("NewRule: %v", err)
+		}
+		if err := env.rules.Save(ctx, rule); err != nil {
+			t.Fatalf("Save rule: %v", err)
+		}
+	}
+
+	tk := env.reload(t, env.createTicket(t, "jane@example.com").ID())
+	if tags := tk.Tags(); len(tags) != 1 || tags[0] != "vip" {
+		t.Fatalf("tags = %v, want vip", tags)
+	}
+	if tk.Priority() != shared.PriorityHigh {
+		t.Fatalf("priority = %s, want high", tk.Priority())
+	}
+
+	firings, total, err := env.firings.List(ctx, trigger.FiringFilter{TicketID: &[]uuid.UUID{tk.ID()}[0]})
+	if err != nil {
+		t.Fatalf("List firings: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("%d firings, want the two matching rules", total)
+	}
+	for _, firing := range firings {
+		if firing.Failed() || firing.Event() != trigger.EventTicketCreated {
+			t.Fatalf("firing of %q failed %v on %s", firing.RuleName(), firing.Failed(), firing.Event())
+		}
+	}
+
+	// Unlike a dry run, the real run counts the canned response's use
+	greeting, err := env.responses.FindByID(ctx, f.greeting)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if greeting.UsageCount() != 1 {
+		t.Fatalf("usage count = %d, want 1", greeting.UsageCount())
+	}
+	if _, err := env.service.DryRunTriggers(ctx, DryRunTriggersCommand{TicketID: tk.ID(), Event: trigger.EventTicketCreated}); err != nil {
+		t.Fatalf("DryRunTriggers: %v", err)
+	}
+	if greeting, _ = env.responses.FindByID(ctx, f.greeting); greeting.UsageCount() != 1 {
+		t.Fatalf("usage count after a dry run = %d, want 1", greeting.UsageCount())
+	}
+	if _, err := env.service.DryRunTriggers(ctx, DryRunTriggersCommand{TicketID: uuid.New(), Event: trigger.EventTicketCreated}); !errors.Is(err, ticket.ErrTicketNotFound) {
+		t.Fatalf("DryRunTriggers error = %v, want ErrTicketNotFound", err)
+	}
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// WatchTicketCommand contains the data for an agent following a ticket.
+// AddedBy is the admin adding the watcher; agents other than the admin
+// must be known agents.
+type WatchTicketCommand struct {
+	TicketID uuid.UUID
+	AgentID  uuid.UUID
+	AddedBy  uuid.UUID
+}
+
+// WatchTicket makes an agent follow a ticket. Watchers are told about
+// replies and status changes of the ticket. Watching a ticket twice is a
+// no-op.
+func (s *TicketService) WatchTicket(ctx context.Context, cmd WatchTicketCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.AgentID != cmd.AddedBy {
+		if _, err := s.agents.FindByID(ctx, cmd.AgentID); err != nil {
+			return nil, err
+		}
+	}
+	if !t.Watch(cmd.AgentID) {
+		return t, nil
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UnwatchTicket stops an agent following a ticket. Unwatching a ticket the
+// agent does not follow is a no-op.
+func (s *TicketService) UnwatchTicket(ctx context.Context, ticketID, agentID uuid.UUID) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Unwatch(agentID) {
+		return t, nil
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SetTicketCCCommand contains the addresses a customer copies on a ticket.
+type SetTicketCCCommand struct {
+	TicketID   uuid.UUID
+	CustomerID uuid.UUID
+	Emails     []string
+	// IsStaff lets support staff change the addresses of any ticket.
+	IsStaff bool
+}
+
+// SetTicketCC replaces the addresses copied on a ticket. They are added to
+// the recipients of replies on the ticket.
+func (s *TicketService) SetTicketCC(ctx context.Context, cmd SetTicketCCCommand) (*ticket.Ticket, error) {
+	t, err := s.load(ctx, cmd.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cmd.IsStaff && (t.CustomerID() == nil || *t.CustomerID() != cmd.CustomerID) {
+		return nil, ErrAccessDenied
+	}
+	if err := t.SetCC(cmd.Emails); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
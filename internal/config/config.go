@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +17,33 @@ type Config struct {
 	// NATS
 	NatsURL string
 
+	// Outbox relay
+	Outbox OutboxConfig
+
+	// SLA monitor
+	SLA SLAConfig
+
+	// Time-based automations
+	Automation AutomationConfig
+
+	// Ticket numbering
+	TicketNumber TicketNumberConfig
+
+	// Automatic assignment
+	Assignment AssignmentConfig
+
+	// Identity service user events
+	UserEvents UserEventsConfig
+
+	// Inbound email
+	InboundEmail InboundEmailConfig
+
+	// Customer email notifications
+	Notification NotificationConfig
+
+	// Attachment storage
+	Attachment AttachmentConfig
+
 	// Service
 	ServicePort int
 	LogLevel    string
@@ -31,6 +60,103 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+}
+
+type SLAConfig struct {
+	MonitorInterval  time.Duration
+	WarningThreshold time.Duration
+}
+
+type AutomationConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// TicketNumberConfig holds the ticket number patterns. BrandFormats maps a
+// brand to its own pattern, e.g. "acme" to "ACME-{date}-{seq:5}".
+type TicketNumberConfig struct {
+	Format       string
+	BrandFormats map[string]string
+}
+
+// AssignmentConfig selects how new and unassigned tickets are routed:
+// "round_robin", "least_loaded", "sticky" or "manual", the default, which
+// leaves tickets for staff to assign.
+type AssignmentConfig struct {
+	Strategy string
+}
+
+// UserEventsConfig holds the NATS subject of the identity service's user
+// events and the queue group replicas share.
+type UserEventsConfig struct {
+	Subject string
+	Queue   string
+}
+
+// InboundEmailConfig holds how support mail is received: an SMTP listener
+// on SMTPAddr, a maildir polled at MaildirPath, or both. Empty values turn
+// either off. Addresses are the support addresses mail is taken for.
+// Replies go to per-ticket addresses under ReplyAddress signed with
+// ReplySecret; without a secret, they are threaded by headers and subject.
+type InboundEmailConfig struct {
+	SMTPAddr     string
+	Domain       string
+	Addresses    []string
+	MaxSize      int64
+	MaildirPath  string
+	PollInterval time.Duration
+	ReplyAddress string
+	ReplySecret  string
+}
+
+// NotificationConfig holds how customers are emailed about their tickets.
+// Notifications are queued regardless and sent through the SMTP server at
+// SMTPAddr; an empty address leaves them queued. FromAddress defaults to
+// the first inbound support address. TicketURL and SurveyURL may contain
+// {ticket_number}; without a SurveyURL no survey requests are sent.
+type NotificationConfig struct {
+	SMTPAddr      string
+	SMTPUsername  string
+	SMTPPassword  string
+	FromAddress   string
+	FromName      string
+	DefaultLocale string
+	TicketURL     string
+	SurveyURL     string
+	PollInterval  time.Duration
+	BatchSize     int
+	MaxAttempts   int
+}
+
+// AttachmentConfig holds where uploaded files are stored and what may be
+// uploaded. Storage is "local", keeping files under Dir, or "s3", keeping
+// them in an S3-compatible bucket; S3PathStyle suits stores such as MinIO.
+// Empty AllowedTypes allow images, PDFs, plain text and office documents;
+// a MaxTicketSize of zero leaves tickets unbounded. Download links are
+// signed with LinkSecret, and disabled while it is unset, last for LinkTTL
+// and point at PublicURL, the service's external address; without one
+// they are relative.
+type AttachmentConfig struct {
+	Storage       string
+	Dir           string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3PathStyle   bool
+	MaxFileSize   int64
+	MaxTicketSize int64
+	AllowedTypes  []string
+	LinkSecret    string
+	LinkTTL       time.Duration
+	PublicURL     string
+}
+
 func (d *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -56,6 +182,69 @@ func Load() *Config {
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 		Environment: getEnv("APP_ENV", "development"),
 		JWTSecret:   getEnv("JWT_SECRET", "default-secret-key"),
+		Outbox: OutboxConfig{
+			PollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:  getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 10),
+		},
+		SLA: SLAConfig{
+			MonitorInterval:  getEnvAsDuration("SLA_MONITOR_INTERVAL", time.Minute),
+			WarningThreshold: getEnvAsDuration("SLA_WARNING_THRESHOLD", 0),
+		},
+		Automation: AutomationConfig{
+			Interval:  getEnvAsDuration("AUTOMATION_INTERVAL", 5*time.Minute),
+			BatchSize: getEnvAsInt("AUTOMATION_BATCH_SIZE", 100),
+		},
+		TicketNumber: TicketNumberConfig{
+			Format:       getEnv("TICKET_NUMBER_FORMAT", "TKT-{date}-{seq:4}"),
+			BrandFormats: getEnvAsMap("TICKET_NUMBER_BRAND_FORMATS"),
+		},
+		Assignment: AssignmentConfig{
+			Strategy: getEnv("ASSIGNMENT_STRATEGY", "manual"),
+		},
+		UserEvents: UserEventsConfig{
+			Subject: getEnv("USER_EVENTS_SUBJECT", "user.>"),
+			Queue:   getEnv("USER_EVENTS_QUEUE", "service-support"),
+		},
+		InboundEmail: InboundEmailConfig{
+			SMTPAddr:     getEnv("INBOUND_SMTP_ADDR", ""),
+			Domain:       getEnv("INBOUND_EMAIL_DOMAIN", "localhost"),
+			Addresses:    getEnvAsList("INBOUND_EMAIL_ADDRESSES"),
+			MaxSize:      int64(getEnvAsInt("INBOUND_EMAIL_MAX_SIZE", 25<<20)),
+			MaildirPath:  getEnv("INBOUND_MAILDIR", ""),
+			PollInterval: getEnvAsDuration("INBOUND_POLL_INTERVAL", time.Minute),
+			ReplyAddress: getEnv("EMAIL_REPLY_ADDRESS", ""),
+			ReplySecret:  getEnv("EMAIL_REPLY_SECRET", ""),
+		},
+		Notification: NotificationConfig{
+			SMTPAddr:      getEnv("NOTIFY_SMTP_ADDR", ""),
+			SMTPUsername:  getEnv("NOTIFY_SMTP_USERNAME", ""),
+			SMTPPassword:  getEnv("NOTIFY_SMTP_PASSWORD", ""),
+			FromAddress:   getEnv("NOTIFY_FROM_ADDRESS", ""),
+			FromName:      getEnv("NOTIFY_FROM_NAME", "Customer Support"),
+			DefaultLocale: getEnv("NOTIFY_DEFAULT_LOCALE", "en"),
+			TicketURL:     getEnv("NOTIFY_TICKET_URL", ""),
+			SurveyURL:     getEnv("NOTIFY_SURVEY_URL", ""),
+			PollInterval:  getEnvAsDuration("NOTIFY_POLL_INTERVAL", 5*time.Second),
+			BatchSize:     getEnvAsInt("NOTIFY_BATCH_SIZE", 50),
+			MaxAttempts:   getEnvAsInt("NOTIFY_MAX_ATTEMPTS", 8),
+		},
+		Attachment: AttachmentConfig{
+			Storage:       getEnv("ATTACHMENT_STORAGE", "local"),
+			Dir:           getEnv("ATTACHMENT_DIR", "data/attachments"),
+			S3Endpoint:    getEnv("ATTACHMENT_S3_ENDPOINT", ""),
+			S3Region:      getEnv("ATTACHMENT_S3_REGION", "us-east-1"),
+			S3Bucket:      getEnv("ATTACHMENT_S3_BUCKET", ""),
+			S3AccessKey:   getEnv("ATTACHMENT_S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("ATTACHMENT_S3_SECRET_KEY", ""),
+			S3PathStyle:   getEnvAsBool("ATTACHMENT_S3_PATH_STYLE", false),
+			MaxFileSize:   int64(getEnvAsInt("ATTACHMENT_MAX_FILE_SIZE", 10<<20)),
+			MaxTicketSize: int64(getEnvAsInt("ATTACHMENT_MAX_TICKET_SIZE", 50<<20)),
+			AllowedTypes:  getEnvAsList("ATTACHMENT_ALLOWED_TYPES"),
+			LinkSecret:    getEnv("ATTACHMENT_LINK_SECRET", ""),
+			LinkTTL:       getEnvAsDuration("ATTACHMENT_LINK_TTL", 15*time.Minute),
+			PublicURL:     getEnv("ATTACHMENT_PUBLIC_URL", ""),
+		},
 	}
 }
 
@@ -74,3 +263,44 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsMap reads comma-separated key=value pairs, e.g. "a=1,b=2".
+func getEnvAsMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(k) != "" {
+			values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return values
+}
+
+// getEnvAsList reads a comma-separated list, e.g. "a,b".
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,372 @@
+// Package agent models the support staff tickets are assigned to.
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Agent entity
+var (
+	ErrAgentNotFound      = errors.New("agent not found")
+	ErrAgentAlreadyExists = errors.New("agent already exists")
+	ErrInvalidAgent       = errors.New("invalid agent data")
+)
+
+// StaffRoles are the user roles that may work support tickets.
+var StaffRoles = []string{"admin", "super_admin", "support", "manager"}
+
+// IsStaffRole checks if users with the role belong in the agent directory.
+func IsStaffRole(role string) bool {
+	for _, r := range StaffRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Status is an agent's presence.
+type Status string
+
+// Agent statuses
+const (
+	StatusOnline  Status = "online"
+	StatusAway    Status = "away"
+	StatusOffline Status = "offline"
+)
+
+// ParseStatus parses an agent status.
+func ParseStatus(s string) (Status, error) {
+	switch status := Status(s); status {
+	case StatusOnline, StatusAway, StatusOffline:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: unknown status %q", ErrInvalidAgent, s)
+	}
+}
+
+// Proficiency levels of a skill, from novice to expert.
+const (
+	MinSkillLevel = 1
+	MaxSkillLevel = 5
+)
+
+// Skill is something an agent is proficient in, such as "returns",
+// "payments" or the language "ms".
+type Skill struct {
+	Name  string
+	Level int
+}
+
+// Agent is a member of the support staff. Its ID is the agent's user ID.
+type Agent struct {
+	id                   uuid.UUID
+	name                 string
+	email                string
+	role                 string
+	teams                []string
+	skills               []Skill
+	status               Status
+	maxConcurrentTickets int // 0 means no limit
+	timezone             string
+	signature            string
+	lastAssignedAt       *time.Time
+	createdAt            time.Time
+	updatedAt            time.Time
+}
+
+// AgentParams contains parameters for creating an Agent.
+type AgentParams struct {
+	ID                   uuid.UUID
+	Name                 string
+	Email                string
+	Role                 string
+	Teams                []string
+	Skills               []Skill
+	Status               Status // defaults to offline
+	MaxConcurrentTickets int
+	Timezone             string // defaults to UTC
+	Signature            string
+}
+
+// NewAgent creates a new Agent entity.
+func NewAgent(params AgentParams) (*Agent, error) {
+	if params.ID == uuid.Nil {
+		return nil, errors.Join(ErrInvalidAgent, errors.New("user ID is required"))
+	}
+
+	status := params.Status
+	if status == "" {
+		status = StatusOffline
+	}
+	if _, err := ParseStatus(string(status)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	a := &Agent{
+		id:        params.ID,
+		status:    status,
+		createdAt: now,
+		updatedAt: now,
+	}
+	err := a.UpdateProfile(Profile{
+		Name:      params.Name,
+		Email:     params.Email,
+		Role:      params.Role,
+		Teams:     params.Teams,
+		Timezone:  params.Timezone,
+		Signature: params.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := a.SetMaxConcurrentTickets(params.MaxConcurrentTickets); err != nil {
+		return nil, err
+	}
+	if err := a.SetSkills(params.Skills); err != nil {
+		return nil, err
+	}
+	a.updatedAt = now
+	return a, nil
+}
+
+// ReconstituteParams contains the persisted state of an Agent.
+type ReconstituteParams struct {
+	ID                   uuid.UUID
+	Name                 string
+	Email                string
+	Role                 string
+	Teams                []string
+	Skills               []Skill
+	Status               string
+	MaxConcurrentTickets int
+	Timezone             string
+	Signature            string
+	LastAssignedAt       *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Reconstitute rebuilds an Agent from persisted state.
+func Reconstitute(params ReconstituteParams) *Agent {
+	teams := params.Teams
+	if teams == nil {
+		teams = make([]string, 0)
+	}
+	skills := params.Skills
+	if skills == nil {
+		skills = make([]Skill, 0)
+	}
+
+	return &Agent{
+		id:                   params.ID,
+		name:                 params.Name,
+		email:                params.Email,
+		role:                 params.Role,
+		teams:                teams,
+		skills:               skills,
+		status:               Status(params.Status),
+		maxConcurrentTickets: params.MaxConcurrentTickets,
+		timezone:             params.Timezone,
+		signature:            params.Signature,
+		lastAssignedAt:       params.LastAssignedAt,
+		createdAt:            params.CreatedAt,
+		updatedAt:            params.UpdatedAt,
+	}
+}
+
+// Getters
+func (a *Agent) ID() uuid.UUID              { return a.id }
+func (a *Agent) Name() string               { return a.name }
+func (a *Agent) Email() string              { return a.email }
+func (a *Agent) Role() string               { return a.role }
+func (a *Agent) Teams() []string            { return a.teams }
+func (a *Agent) Skills() []Skill            { return a.skills }
+func (a *Agent) Status() Status             { return a.status }
+func (a *Agent) MaxConcurrentTickets() int  { return a.maxConcurrentTickets }
+func (a *Agent) Timezone() string           { return a.timezone }
+func (a *Agent) Signature() string          { return a.signature }
+func (a *Agent) LastAssignedAt() *time.Time { return a.lastAssignedAt }
+func (a *Agent) CreatedAt() time.Time       { return a.createdAt }
+func (a *Agent) UpdatedAt() time.Time       { return a.updatedAt }
+
+// IsAvailable checks if the agent is online and can be given new tickets.
+func (a *Agent) IsAvailable() bool {
+	return a.status == StatusOnline
+}
+
+// InTeam checks if the agent is a member of the team.
+func (a *Agent) InTeam(team string) bool {
+	for _, t := range a.teams {
+		if t == team {
+			return true
+		}
+	}
+	return false
+}
+
+// SkillLevel returns the agent's proficiency in the skill, or 0 if the
+// agent does not have it.
+func (a *Agent) SkillLevel(name string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, s := range a.skills {
+		if s.Name == name {
+			return s.Level
+		}
+	}
+	return 0
+}
+
+// HasCapacityFor checks if the agent can take another ticket while holding
+// the given number of open tickets.
+func (a *Agent) HasCapacityFor(openTickets int) bool {
+	return a.maxConcurrentTickets == 0 || openTickets < a.maxConcurrentTickets
+}
+
+// --- Behavior Methods ---
+
+// Profile contains the editable details of an agent.
+type Profile struct {
+	Name      string
+	Email     string
+	Role      string
+	Teams     []string
+	Timezone  string // defaults to UTC
+	Signature string
+}
+
+// UpdateProfile replaces the agent's profile.
+func (a *Agent) UpdateProfile(p Profile) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.Join(ErrInvalidAgent, errors.New("name is required"))
+	}
+	timezone := p.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidAgent, timezone)
+	}
+
+	a.name = strings.TrimSpace(p.Name)
+	a.email = strings.TrimSpace(p.Email)
+	a.role = p.Role
+	a.teams = normalizeTeams(p.Teams)
+	a.timezone = timezone
+	a.signature = p.Signature
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SyncIdentity applies the name, email and role the identity service holds
+// for the agent's user, keeping the support-specific profile.
+func (a *Agent) SyncIdentity(name, email, role string) {
+	if name = strings.TrimSpace(name); name != "" {
+		a.name = name
+	}
+	if email = strings.TrimSpace(email); email != "" {
+		a.email = email
+	}
+	if role != "" {
+		a.role = role
+	}
+	a.updatedAt = time.Now()
+}
+
+// JoinTeam adds the agent to the team with the key.
+func (a *Agent) JoinTeam(team string) {
+	a.teams = normalizeTeams(append(append([]string(nil), a.teams...), team))
+	a.updatedAt = time.Now()
+}
+
+// LeaveTeam removes the agent from the team with the key.
+func (a *Agent) LeaveTeam(team string) {
+	teams := make([]string, 0, len(a.teams))
+	for _, t := range a.teams {
+		if t != team {
+			teams = append(teams, t)
+		}
+	}
+	a.teams = teams
+	a.updatedAt = time.Now()
+}
+
+// SetSkills replaces the agent's skills. Names are lower-cased; a skill
+// listed twice keeps its highest level.
+func (a *Agent) SetSkills(skills []Skill) error {
+	levels := make(map[string]int, len(skills))
+	for _, s := range skills {
+		name := strings.ToLower(strings.TrimSpace(s.Name))
+		if name == "" {
+			return errors.Join(ErrInvalidAgent, errors.New("skill name is required"))
+		}
+		if s.Level < MinSkillLevel || s.Level > MaxSkillLevel {
+			return fmt.Errorf("%w: skill %q level must be between %d and %d", ErrInvalidAgent, name, MinSkillLevel, MaxSkillLevel)
+		}
+		if s.Level > levels[name] {
+			levels[name] = s.Level
+		}
+	}
+
+	normalized := make([]Skill, 0, len(levels))
+	for name, level := range levels {
+		normalized = append(normalized, Skill{Name: name, Level: level})
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		return normalized[i].Name < normalized[j].Name
+	})
+	a.skills = normalized
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SetStatus sets the agent's presence. Only online agents are given new
+// tickets.
+func (a *Agent) SetStatus(status Status) error {
+	if _, err := ParseStatus(string(status)); err != nil {
+		return err
+	}
+	a.status = status
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// SetMaxConcurrentTickets sets the maximum number of open tickets; 0 means
+// no limit.
+func (a *Agent) SetMaxConcurrentTickets(max int) error {
+	if max < 0 {
+		return errors.Join(ErrInvalidAgent, errors.New("max concurrent tickets cannot be negative"))
+	}
+	a.maxConcurrentTickets = max
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// RecordAssignment notes when the agent was last given a ticket, which
+// round-robin assignment rotates on.
+func (a *Agent) RecordAssignment(at time.Time) {
+	a.lastAssignedAt = &at
+}
+
+// normalizeTeams lower-cases, trims and de-duplicates team keys.
+func normalizeTeams(teams []string) []string {
+	normalized := make([]string, 0, len(teams))
+	seen := make(map[string]bool, len(teams))
+	for _, t := range teams {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
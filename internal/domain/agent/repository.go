@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for agents.
+type Repository interface {
+	// FindByID loads an agent. Returns ErrAgentNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Agent, error)
+
+	// FindByIDs loads the agents with the given IDs. Unknown IDs are
+	// skipped.
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Agent, error)
+
+	// List returns the agents matching the filter, ordered by name.
+	List(ctx context.Context, filter Filter) ([]*Agent, error)
+
+	// Save creates or updates an agent.
+	Save(ctx context.Context, agent *Agent) error
+
+	// RecordAssignment sets when the agent was last given a ticket, leaving
+	// the rest of the stored agent untouched. Returns ErrAgentNotFound if
+	// none exists.
+	RecordAssignment(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// Delete removes an agent. Returns ErrAgentNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Filter represents filters for listing agents. Empty fields match all
+// agents.
+type Filter struct {
+	Status Status
+	Team   string
+}
@@ -0,0 +1,176 @@
+// Package assignment picks the agent a ticket is routed to.
+package assignment
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// ErrUnknownStrategy is returned for strategy names without an implementation.
+var ErrUnknownStrategy = errors.New("unknown assignment strategy")
+
+// Strategy names
+const (
+	StrategyManual      = "manual"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategySticky      = "sticky"
+)
+
+// Candidate is an agent that may receive the ticket, with its current load.
+type Candidate struct {
+	Agent       *agent.Agent
+	OpenTickets int
+}
+
+// eligible checks if the candidate is available and below capacity.
+func (c Candidate) eligible() bool {
+	return c.Agent.IsAvailable() && c.Agent.HasCapacityFor(c.OpenTickets)
+}
+
+// Request describes the ticket being assigned.
+type Request struct {
+	// PreviousAgentID is the agent of the customer's most recent assigned
+	// ticket, if any.
+	PreviousAgentID *uuid.UUID
+}
+
+// Decision is the agent a strategy picked and why.
+type Decision struct {
+	AgentID uuid.UUID
+	Reason  string
+}
+
+// Strategy picks an agent from the candidates. Strategies only pick
+// available candidates with spare capacity; ok is false if there is none.
+type Strategy interface {
+	Name() string
+	Pick(req Request, candidates []Candidate) (decision Decision, ok bool)
+}
+
+// NewStrategy returns the strategy of the given name. Manual assignment
+// has no strategy and returns nil.
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case StrategyManual, "":
+		return nil, nil
+	case StrategyRoundRobin:
+		return RoundRobin{}, nil
+	case StrategyLeastLoaded:
+		return LeastLoaded{}, nil
+	case StrategySticky:
+		return Sticky{Fallback: LeastLoaded{}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+	}
+}
+
+// RoundRobin rotates through the agents, picking the one that has waited
+// longest since its last assignment.
+type RoundRobin struct{}
+
+// Name returns the strategy name.
+func (RoundRobin) Name() string { return StrategyRoundRobin }
+
+// Pick returns the eligible agent assigned least recently.
+func (RoundRobin) Pick(req Request, candidates []Candidate) (Decision, bool) {
+	eligible := rotation(candidates)
+	if len(eligible) == 0 {
+		return Decision{}, false
+	}
+	return Decision{
+		AgentID: eligible[0].Agent.ID(),
+		Reason:  "round robin: next available agent in rotation",
+	}, true
+}
+
+// LeastLoaded picks the agent with the fewest open tickets. Ties go to the
+// agent assigned least recently.
+type LeastLoaded struct{}
+
+// Name returns the strategy name.
+func (LeastLoaded) Name() string { return StrategyLeastLoaded }
+
+// Pick returns the eligible agent with the fewest open tickets.
+func (LeastLoaded) Pick(req Request, candidates []Candidate) (Decision, bool) {
+	eligible := rotation(candidates)
+	if len(eligible) == 0 {
+		return Decision{}, false
+	}
+	best := eligible[0]
+	for _, c := range eligible[1:] {
+		if c.OpenTickets < best.OpenTickets {
+			best = c
+		}
+	}
+	return Decision{
+		AgentID: best.Agent.ID(),
+		Reason:  fmt.Sprintf("least loaded: %d open tickets", best.OpenTickets),
+	}, true
+}
+
+// Sticky routes a returning customer to the agent of their previous ticket
+// and falls back to another strategy when that agent cannot take it.
+type Sticky struct {
+	Fallback Strategy
+}
+
+// Name returns the strategy name.
+func (Sticky) Name() string { return StrategySticky }
+
+// Pick returns the customer's previous agent if eligible, otherwise the
+// fallback's pick.
+func (s Sticky) Pick(req Request, candidates []Candidate) (Decision, bool) {
+	if req.PreviousAgentID != nil {
+		for _, c := range candidates {
+			if c.Agent.ID() != *req.PreviousAgentID {
+				continue
+			}
+			if c.eligible() {
+				return Decision{
+					AgentID: c.Agent.ID(),
+					Reason:  "sticky: previous agent of the returning customer",
+				}, true
+			}
+			break
+		}
+	}
+
+	if s.Fallback == nil {
+		return Decision{}, false
+	}
+	decision, ok := s.Fallback.Pick(req, candidates)
+	if ok && req.PreviousAgentID != nil {
+		decision.Reason = "sticky fallback, previous agent unavailable; " + decision.Reason
+	}
+	return decision, ok
+}
+
+// rotation returns the eligible candidates, least recently assigned first.
+// Agents never assigned come first; remaining ties are broken by ID so the
+// order is stable.
+func rotation(candidates []Candidate) []Candidate {
+	eligible := make([]Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.eligible() {
+			eligible = append(eligible, c)
+		}
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		a, b := eligible[i].Agent.LastAssignedAt(), eligible[j].Agent.LastAssignedAt()
+		switch {
+		case a == nil && b != nil:
+			return true
+		case a != nil && b == nil:
+			return false
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		}
+		return eligible[i].Agent.ID().String() < eligible[j].Agent.ID().String()
+	})
+	return eligible
+}
@@ -0,0 +1,300 @@
+package assignment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// Agent IDs in the order ties are broken by ID
+var (
+	alice = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	bob   = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	carol = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+// candidate returns an agent with room for three tickets holding the open
+// tickets, last assigned one the given number of hours ago (never if zero).
+func candidate(id uuid.UUID, status agent.Status, open, hoursAgo int) Candidate {
+	var lastAssignedAt *time.Time
+	if hoursAgo > 0 {
+		at := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC).Add(-time.Duration(hoursAgo) * time.Hour)
+		lastAssignedAt = &at
+	}
+	return Candidate{
+		Agent: agent.Reconstitute(agent.ReconstituteParams{
+			ID:                   id,
+			Name:                 "Agent",
+			Status:               string(status),
+			MaxConcurrentTickets: 3,
+			LastAssignedAt:       lastAssignedAt,
+		}),
+		OpenTickets: open,
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   Strategy
+		previous   *uuid.UUID
+		candidates []Candidate
+		want       *uuid.UUID
+		wantReason string
+	}{
+		{
+			name:     "round robin: least recently assigned",
+			strategy: RoundRobin{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 0, 1),
+				candidate(bob, agent.StatusOnline, 2, 5),
+				candidate(carol, agent.StatusOnline, 1, 3),
+			},
+			want:       &bob,
+			wantReason: "round robin: next available agent in rotation",
+		},
+		{
+			name:     "round robin: never assigned goes first",
+			strategy: RoundRobin{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 0, 5),
+				candidate(bob, agent.StatusOnline, 0, 0),
+			},
+			want: &bob,
+		},
+		{
+			name:     "round robin: tie broken by ID",
+			strategy: RoundRobin{},
+			candidates: []Candidate{
+				candidate(carol, agent.StatusOnline, 0, 2),
+				candidate(bob, agent.StatusOnline, 0, 2),
+			},
+			want: &bob,
+		},
+		{
+			name:     "round robin: skips unavailable and full agents",
+			strategy: RoundRobin{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusAway, 0, 9),
+				candidate(bob, agent.StatusOnline, 3, 8),
+				candidate(carol, agent.StatusOnline, 2, 1),
+			},
+			want: &carol,
+		},
+		{
+			name:     "round robin: nobody eligible",
+			strategy: RoundRobin{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOffline, 0, 0),
+				candidate(bob, agent.StatusOnline, 3, 0),
+			},
+		},
+		{
+			name:       "round robin: no candidates",
+			strategy:   RoundRobin{},
+			candidates: nil,
+		},
+		{
+			name:     "least loaded: fewest open tickets",
+			strategy: LeastLoaded{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 2, 9),
+				candidate(bob, agent.StatusOnline, 0, 1),
+				candidate(carol, agent.StatusOnline, 1, 5),
+			},
+			want:       &bob,
+			wantReason: "least loaded: 0 open tickets",
+		},
+		{
+			name:     "least loaded: tie goes to the least recently assigned",
+			strategy: LeastLoaded{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 1, 1),
+				candidate(bob, agent.StatusOnline, 1, 4),
+				candidate(carol, agent.StatusOnline, 2, 9),
+			},
+			want: &bob,
+		},
+		{
+			name:     "least loaded: tie between agents never assigned broken by ID",
+			strategy: LeastLoaded{},
+			candidates: []Candidate{
+				candidate(carol, agent.StatusOnline, 1, 0),
+				candidate(alice, agent.StatusOnline, 1, 0),
+			},
+			want: &alice,
+		},
+		{
+			name:     "least loaded: idle agent offline",
+			strategy: LeastLoaded{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOffline, 0, 0),
+				candidate(bob, agent.StatusOnline, 2, 0),
+			},
+			want: &bob,
+		},
+		{
+			name:     "least loaded: everyone full",
+			strategy: LeastLoaded{},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 3, 0),
+				candidate(bob, agent.StatusOnline, 4, 0),
+			},
+		},
+		{
+			name:     "sticky: previous agent",
+			strategy: Sticky{Fallback: LeastLoaded{}},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 0, 0),
+				candidate(carol, agent.StatusOnline, 2, 0),
+			},
+			want:       &carol,
+			wantReason: "sticky: previous agent of the returning customer",
+		},
+		{
+			name:     "sticky: previous agent away falls back",
+			strategy: Sticky{Fallback: LeastLoaded{}},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 1, 0),
+				candidate(bob, agent.StatusOnline, 0, 0),
+				candidate(carol, agent.StatusAway, 0, 0),
+			},
+			want:       &bob,
+			wantReason: "sticky fallback, previous agent unavailable; least loaded: 0 open tickets",
+		},
+		{
+			name:     "sticky: previous agent full falls back",
+			strategy: Sticky{Fallback: RoundRobin{}},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 1, 2),
+				candidate(carol, agent.StatusOnline, 3, 9),
+			},
+			want:       &alice,
+			wantReason: "sticky fallback, previous agent unavailable; round robin: next available agent in rotation",
+		},
+		{
+			name:     "sticky: previous agent not in the pool falls back",
+			strategy: Sticky{Fallback: LeastLoaded{}},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 1, 0),
+			},
+			want: &alice,
+		},
+		{
+			name:     "sticky: new customer goes to the fallback",
+			strategy: Sticky{Fallback: LeastLoaded{}},
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 1, 0),
+				candidate(bob, agent.StatusOnline, 0, 0),
+			},
+			want:       &bob,
+			wantReason: "least loaded: 0 open tickets",
+		},
+		{
+			name:     "sticky: no fallback",
+			strategy: Sticky{},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusOnline, 0, 0),
+				candidate(carol, agent.StatusOffline, 0, 0),
+			},
+		},
+		{
+			name:     "sticky: nobody eligible",
+			strategy: Sticky{Fallback: LeastLoaded{}},
+			previous: &carol,
+			candidates: []Candidate{
+				candidate(alice, agent.StatusAway, 0, 0),
+				candidate(carol, agent.StatusOnline, 3, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, ok := tt.strategy.Pick(Request{PreviousAgentID: tt.previous}, tt.candidates)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("picked %s, want nobody", decision.AgentID)
+				}
+				return
+			}
+			if !ok || decision.AgentID != *tt.want {
+				t.Fatalf("picked %s (%v), want %s", decision.AgentID, ok, *tt.want)
+			}
+			if tt.wantReason != "" && decision.Reason != tt.wantReason {
+				t.Fatalf("reason = %q, want %q", decision.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRoundRobinRotates(t *testing.T) {
+	candidates := []Candidate{
+		candidate(alice, agent.StatusOnline, 0, 0),
+		candidate(bob, agent.StatusOnline, 0, 0),
+		candidate(carol, agent.StatusOnline, 0, 0),
+	}
+
+	var picked []uuid.UUID
+	for i := 0; i < 4; i++ {
+		decision, ok := RoundRobin{}.Pick(Request{}, candidates)
+		if !ok {
+			t.Fatal("round robin picked nobody")
+		}
+		picked = append(picked, decision.AgentID)
+		for _, c := range candidates {
+			if c.Agent.ID() == decision.AgentID {
+				c.Agent.RecordAssignment(time.Date(2026, time.October, 16, 12, i, 0, 0, time.UTC))
+			}
+		}
+	}
+
+	want := []uuid.UUID{alice, bob, carol, alice}
+	for i := range want {
+		if picked[i] != want[i] {
+			t.Fatalf("picked %v, want %v", picked, want)
+		}
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantErr  error
+	}{
+		{name: "manual"},
+		{name: ""},
+		{name: "round_robin", wantName: StrategyRoundRobin},
+		{name: "least_loaded", wantName: StrategyLeastLoaded},
+		{name: "sticky", wantName: StrategySticky},
+		{name: "random", wantErr: ErrUnknownStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewStrategy(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewStrategy error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantName == "" {
+				// Manual assignment runs no strategy
+				if strategy != nil {
+					t.Fatalf("NewStrategy = %s, want none", strategy.Name())
+				}
+				return
+			}
+			if strategy == nil || strategy.Name() != tt.wantName {
+				t.Fatalf("NewStrategy = %v, want %s", strategy, tt.wantName)
+			}
+		})
+	}
+}
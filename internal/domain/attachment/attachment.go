@@ -0,0 +1,197 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// Domain errors for Attachment entity
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrInvalidAttachment   = errors.New("invalid attachment data")
+	ErrFileTooLarge        = errors.New("file exceeds the attachment size limit")
+	ErrTicketQuotaExceeded = errors.New("ticket attachments exceed the size limit")
+	ErrTypeNotAllowed      = errors.New("file type is not allowed")
+	ErrAlreadyPosted       = errors.New("attachment is already posted in a message")
+	ErrBlobNotFound        = errors.New("attachment content not found")
+)
+
+// maxNameLength is the longest file name kept, in bytes.
+const maxNameLength = 255
+
+// Attachment is a file we store for a ticket: uploaded to the ticket and
+// then posted in one of its messages, or received with an email. Email
+// attachments are stored before their ticket is known, so their ticket is
+// set when they are posted.
+type Attachment struct {
+	id           uuid.UUID
+	ticketID     uuid.UUID
+	messageID    *uuid.UUID
+	name         string
+	contentType  string
+	size         int64
+	checksum     string
+	storageKey   string
+	uploadedBy   *uuid.UUID
+	uploaderType shared.SenderType
+	createdAt    time.Time
+}
+
+// AttachmentParams contains parameters for creating an Attachment.
+// ContentType is the type detected from the content, Checksum its
+// hex-encoded SHA-256 and StorageKey where the blob store keeps it.
+type AttachmentParams struct {
+	ID           uuid.UUID
+	TicketID     uuid.UUID
+	Name         string
+	ContentType  string
+	Size         int64
+	Checksum     string
+	StorageKey   string
+	UploadedBy   *uuid.UUID
+	UploaderType string
+}
+
+// NewAttachment creates a new Attachment entity, not yet posted in a
+// message.
+func NewAttachment(params AttachmentParams) (*Attachment, error) {
+	if params.Size <= 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidAttachment)
+	}
+	if params.ContentType == "" || params.Checksum == "" || params.StorageKey == "" {
+		return nil, fmt.Errorf("%w: content type, checksum and storage key are required", ErrInvalidAttachment)
+	}
+	uploaderType := shared.SenderCustomer
+	if params.UploaderType != "" {
+		parsed, err := shared.ParseSenderType(params.UploaderType)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAttachment, err)
+		}
+		uploaderType = parsed
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return &Attachment{
+		id:           id,
+		ticketID:     params.TicketID,
+		name:         SanitizeName(params.Name),
+		contentType:  params.ContentType,
+		size:         params.Size,
+		checksum:     params.Checksum,
+		storageKey:   params.StorageKey,
+		uploadedBy:   params.UploadedBy,
+		uploaderType: uploaderType,
+		createdAt:    time.Now(),
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of an Attachment.
+type ReconstituteParams struct {
+	ID           uuid.UUID
+	TicketID     uuid.UUID
+	MessageID    *uuid.UUID
+	Name         string
+	ContentType  string
+	Size         int64
+	Checksum     string
+	StorageKey   string
+	UploadedBy   *uuid.UUID
+	UploaderType string
+	CreatedAt    time.Time
+}
+
+// Reconstitute rebuilds an Attachment from persisted state.
+func Reconstitute(params ReconstituteParams) *Attachment {
+	return &Attachment{
+		id:           params.ID,
+		ticketID:     params.TicketID,
+		messageID:    params.MessageID,
+		name:         params.Name,
+		contentType:  params.ContentType,
+		size:         params.Size,
+		checksum:     params.Checksum,
+		storageKey:   params.StorageKey,
+		uploadedBy:   params.UploadedBy,
+		uploaderType: shared.SenderType(params.UploaderType),
+		createdAt:    params.CreatedAt,
+	}
+}
+
+// Getters
+func (a *Attachment) ID() uuid.UUID                   { return a.id }
+func (a *Attachment) TicketID() uuid.UUID             { return a.ticketID }
+func (a *Attachment) MessageID() *uuid.UUID           { return a.messageID }
+func (a *Attachment) Name() string                    { return a.name }
+func (a *Attachment) ContentType() string             { return a.contentType }
+func (a *Attachment) Size() int64                     { return a.size }
+func (a *Attachment) Checksum() string                { return a.checksum }
+func (a *Attachment) StorageKey() string              { return a.storageKey }
+func (a *Attachment) UploadedBy() *uuid.UUID          { return a.uploadedBy }
+func (a *Attachment) UploaderType() shared.SenderType { return a.uploaderType }
+func (a *Attachment) CreatedAt() time.Time            { return a.createdAt }
+
+// IsPosted checks if the attachment has been posted in a message.
+func (a *Attachment) IsPosted() bool {
+	return a.messageID != nil
+}
+
+// Ticket returns the attachment as listed on a ticket message.
+func (a *Attachment) Ticket() ticket.Attachment {
+	return ticket.Attachment{
+		ID:       a.id.String(),
+		Name:     a.name,
+		Size:     a.size,
+		MimeType: a.contentType,
+	}
+}
+
+// --- Behavior Methods ---
+
+// Post records the message of the ticket the attachment was posted in.
+func (a *Attachment) Post(ticketID, messageID uuid.UUID) error {
+	if a.messageID != nil {
+		return ErrAlreadyPosted
+	}
+	if a.ticketID != uuid.Nil && a.ticketID != ticketID {
+		return fmt.Errorf("%w: attachment belongs to another ticket", ErrInvalidAttachment)
+	}
+	a.ticketID = ticketID
+	a.messageID = &messageID
+	return nil
+}
+
+// SanitizeName reduces a client-supplied file name to its last path
+// element without control characters, e.g. "..\\invoice.pdf" to
+// "invoice.pdf".
+func SanitizeName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	for len(name) > maxNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
@@ -0,0 +1,79 @@
+package attachment
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// maxUserAgentLength is the longest User-Agent kept, in bytes.
+const maxUserAgentLength = 500
+
+// Download records that an attachment was downloaded through a link, by
+// whom and from where, for the audit log.
+type Download struct {
+	id           uuid.UUID
+	attachmentID uuid.UUID
+	ticketID     uuid.UUID
+	userID       uuid.UUID
+	userType     shared.SenderType
+	ipAddress    string
+	userAgent    string
+	downloadedAt time.Time
+}
+
+// NewDownload records a download of the attachment through the link.
+func NewDownload(a *Attachment, link Link, ipAddress, userAgent string) Download {
+	for len(userAgent) > maxUserAgentLength {
+		_, size := utf8.DecodeLastRuneInString(userAgent)
+		userAgent = userAgent[:len(userAgent)-size]
+	}
+	return Download{
+		id:           uuid.New(),
+		attachmentID: a.ID(),
+		ticketID:     a.TicketID(),
+		userID:       link.UserID,
+		userType:     link.UserType,
+		ipAddress:    ipAddress,
+		userAgent:    userAgent,
+		downloadedAt: time.Now(),
+	}
+}
+
+// DownloadParams contains the persisted state of a Download.
+type DownloadParams struct {
+	ID           uuid.UUID
+	AttachmentID uuid.UUID
+	TicketID     uuid.UUID
+	UserID       uuid.UUID
+	UserType     string
+	IPAddress    string
+	UserAgent    string
+	DownloadedAt time.Time
+}
+
+// ReconstituteDownload rebuilds a Download from persisted state.
+func ReconstituteDownload(params DownloadParams) Download {
+	return Download{
+		id:           params.ID,
+		attachmentID: params.AttachmentID,
+		ticketID:     params.TicketID,
+		userID:       params.UserID,
+		userType:     shared.SenderType(params.UserType),
+		ipAddress:    params.IPAddress,
+		userAgent:    params.UserAgent,
+		downloadedAt: params.DownloadedAt,
+	}
+}
+
+// Getters
+func (d Download) ID() uuid.UUID               { return d.id }
+func (d Download) AttachmentID() uuid.UUID     { return d.attachmentID }
+func (d Download) TicketID() uuid.UUID         { return d.ticketID }
+func (d Download) UserID() uuid.UUID           { return d.userID }
+func (d Download) UserType() shared.SenderType { return d.userType }
+func (d Download) IPAddress() string           { return d.ipAddress }
+func (d Download) UserAgent() string           { return d.userAgent }
+func (d Download) DownloadedAt() time.Time     { return d.downloadedAt }
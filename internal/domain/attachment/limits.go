@@ -0,0 +1,90 @@
+package attachment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default limits
+const (
+	DefaultMaxFileSize   int64 = 10 << 20
+	DefaultMaxTicketSize int64 = 50 << 20
+)
+
+// DefaultAllowedTypes returns the content types allowed when none are
+// configured: images, PDFs, plain text and office documents.
+func DefaultAllowedTypes() []string {
+	return []string{
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"image/webp",
+		"image/heic",
+		"application/pdf",
+		"text/plain",
+		"text/csv",
+		"message/rfc822",
+		"application/msword",
+		"application/vnd.ms-excel",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.text",
+		"application/vnd.oasis.opendocument.spreadsheet",
+	}
+}
+
+// Limits bound what may be stored: the size of one file, the total size
+// of a ticket's files and the content types allowed. A type ending in
+// "/*" allows the whole family, e.g. "image/*". A MaxTicketSize of zero
+// leaves tickets unbounded.
+type Limits struct {
+	MaxFileSize   int64
+	MaxTicketSize int64
+	AllowedTypes  []string
+}
+
+// DefaultLimits returns the limits used when none are configured.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxFileSize:   DefaultMaxFileSize,
+		MaxTicketSize: DefaultMaxTicketSize,
+		AllowedTypes:  DefaultAllowedTypes(),
+	}
+}
+
+// Allows checks if files of the content type may be stored.
+func (l Limits) Allows(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, allowed := range l.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == contentType {
+			return true
+		}
+		if family, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, family+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckFile checks a file of the size and detected content type against
+// the limits.
+func (l Limits) CheckFile(size int64, contentType string) error {
+	if size > l.MaxFileSize {
+		return fmt.Errorf("%w of %d bytes", ErrFileTooLarge, l.MaxFileSize)
+	}
+	if !l.Allows(contentType) {
+		return fmt.Errorf("%w: %s", ErrTypeNotAllowed, contentType)
+	}
+	return nil
+}
+
+// CheckTicket checks if a file of the size fits on a ticket already
+// holding used bytes of files.
+func (l Limits) CheckTicket(used, size int64) error {
+	if l.MaxTicketSize > 0 && used+size > l.MaxTicketSize {
+		return fmt.Errorf("%w of %d bytes", ErrTicketQuotaExceeded, l.MaxTicketSize)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for attachment metadata.
+type Repository interface {
+	// FindByID loads an attachment. Returns ErrAttachmentNotFound if none
+	// exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+
+	// ListByTicket returns the attachments of a ticket, oldest first.
+	ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*Attachment, error)
+
+	// TicketUsage returns the total size of the files stored for a ticket.
+	TicketUsage(ctx context.Context, ticketID uuid.UUID) (int64, error)
+
+	// Save creates or updates an attachment.
+	Save(ctx context.Context, attachment *Attachment) error
+
+	// Delete removes an attachment. Returns ErrAttachmentNotFound if none
+	// exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// BlobStore is the storage port for file contents, addressed by key.
+type BlobStore interface {
+	// Put stores size bytes read from content under the key.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+
+	// Open streams the content stored under the key. Returns
+	// ErrBlobNotFound if there is none.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under the key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// DownloadLog is the persistence port for the audit log of downloads.
+type DownloadLog interface {
+	// Record appends a download to the log.
+	Record(ctx context.Context, download Download) error
+
+	// List returns a page of downloads matching the filter, newest first,
+	// and the total number of matches.
+	List(ctx context.Context, filter DownloadFilter) ([]Download, int64, error)
+}
+
+// DownloadFilter represents filters for listing downloads.
+type DownloadFilter struct {
+	AttachmentID *uuid.UUID
+	TicketID     *uuid.UUID
+	Page         int
+	PerPage      int
+}
+
+// Normalize applies the default page and page size.
+func (f *DownloadFilter) Normalize() {
+	if f.PerPage <= 0 {
+		f.PerPage = 20
+	}
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+}
+
+// Offset returns the number of downloads to skip for the current page.
+func (f DownloadFilter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
@@ -0,0 +1,144 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Policy entity
+var (
+	ErrPolicyNotFound = errors.New("automation policy not found")
+	ErrInvalidPolicy  = errors.New("invalid automation policy data")
+	ErrPolicyConflict = errors.New("another automation policy already covers this category")
+)
+
+// Schedule sets how long tickets may sit idle before an automation acts on
+// them. A zero duration turns the automation off.
+type Schedule struct {
+	// AutoCloseAfter is how long a resolved ticket stays resolved before
+	// it is closed.
+	AutoCloseAfter time.Duration
+	// PendingReminderAfter is how long a pending ticket waits on the
+	// customer before they are reminded.
+	PendingReminderAfter time.Duration
+	// PendingResolveAfter is how long a pending ticket waits on the
+	// customer before it is resolved.
+	PendingResolveAfter time.Duration
+	// StaleAfter is how long an in-progress ticket may go without agent
+	// activity before it is flagged as stale.
+	StaleAfter time.Duration
+}
+
+// IsZero checks if every automation of the schedule is off.
+func (s Schedule) IsZero() bool {
+	return s == Schedule{}
+}
+
+// Policy sets the automation schedule of a category. A policy without a
+// category applies to every category that has no policy of its own.
+type Policy struct {
+	id         uuid.UUID
+	name       string
+	categoryID *uuid.UUID
+	schedule   Schedule
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// PolicyParams contains parameters for creating a Policy.
+type PolicyParams struct {
+	ID         uuid.UUID
+	Name       string
+	CategoryID *uuid.UUID
+	Schedule   Schedule
+}
+
+// NewPolicy creates a new Policy entity.
+func NewPolicy(params PolicyParams) (*Policy, error) {
+	if params.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := validateSchedule(params.Schedule); err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Policy{
+		id:         id,
+		name:       params.Name,
+		categoryID: params.CategoryID,
+		schedule:   params.Schedule,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// PolicyReconstituteParams contains the persisted state of a Policy.
+type PolicyReconstituteParams struct {
+	ID         uuid.UUID
+	Name       string
+	CategoryID *uuid.UUID
+	Schedule   Schedule
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ReconstitutePolicy rebuilds a Policy from persisted state.
+func ReconstitutePolicy(params PolicyReconstituteParams) *Policy {
+	return &Policy{
+		id:         params.ID,
+		name:       params.Name,
+		categoryID: params.CategoryID,
+		schedule:   params.Schedule,
+		createdAt:  params.CreatedAt,
+		updatedAt:  params.UpdatedAt,
+	}
+}
+
+// Getters
+func (p *Policy) ID() uuid.UUID          { return p.id }
+func (p *Policy) Name() string           { return p.name }
+func (p *Policy) CategoryID() *uuid.UUID { return p.categoryID }
+func (p *Policy) Schedule() Schedule     { return p.schedule }
+func (p *Policy) CreatedAt() time.Time   { return p.createdAt }
+func (p *Policy) UpdatedAt() time.Time   { return p.updatedAt }
+
+// IsGlobal checks if the policy applies to tickets of every category.
+func (p *Policy) IsGlobal() bool {
+	return p.categoryID == nil
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the policy name, scope and schedule.
+func (p *Policy) Update(name string, categoryID *uuid.UUID, schedule Schedule) error {
+	if err := validateSchedule(schedule); err != nil {
+		return err
+	}
+
+	if name != "" {
+		p.name = name
+	}
+	p.categoryID = categoryID
+	p.schedule = schedule
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func validateSchedule(s Schedule) error {
+	if s.AutoCloseAfter < 0 || s.PendingReminderAfter < 0 || s.PendingResolveAfter < 0 || s.StaleAfter < 0 {
+		return fmt.Errorf("%w: durations cannot be negative", ErrInvalidPolicy)
+	}
+	if s.PendingReminderAfter > 0 && s.PendingResolveAfter > 0 && s.PendingReminderAfter >= s.PendingResolveAfter {
+		return fmt.Errorf("%w: pending tickets must be reminded before they are resolved", ErrInvalidPolicy)
+	}
+	return nil
+}
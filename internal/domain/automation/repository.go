@@ -0,0 +1,28 @@
+package automation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for automation policies.
+type Repository interface {
+	// FindByID loads a policy. Returns ErrPolicyNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Policy, error)
+
+	// FindForCategory returns the policy attached to the category, falling
+	// back to the global policy. A nil category only matches the global
+	// policy. Returns ErrPolicyNotFound if neither exists.
+	FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*Policy, error)
+
+	// List returns all policies, the global one first.
+	List(ctx context.Context) ([]*Policy, error)
+
+	// Save creates or updates a policy. Returns ErrPolicyConflict if another
+	// policy has the same category.
+	Save(ctx context.Context, policy *Policy) error
+
+	// Delete removes a policy. Returns ErrPolicyNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
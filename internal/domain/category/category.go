@@ -11,6 +11,7 @@ import (
 var (
 	ErrCategoryNotFound = errors.New("category not found")
 	ErrInvalidCategory  = errors.New("invalid category data")
+	ErrCategoryInactive = errors.New("category is not active")
 )
 
 // Category represents a support ticket category.
@@ -70,6 +71,36 @@ func NewCategory(params CategoryParams) (*Category, error) {
 	}, nil
 }
 
+// ReconstituteParams contains the persisted state of a Category.
+type ReconstituteParams struct {
+	ID          uuid.UUID
+	Name        string
+	NameMS      string
+	Description string
+	Icon        string
+	SLAHours    int
+	Priority    int
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Reconstitute rebuilds a Category from persisted state.
+func Reconstitute(params ReconstituteParams) *Category {
+	return &Category{
+		id:          params.ID,
+		name:        params.Name,
+		nameMS:      params.NameMS,
+		description: params.Description,
+		icon:        params.Icon,
+		slaHours:    params.SLAHours,
+		priority:    params.Priority,
+		isActive:    params.IsActive,
+		createdAt:   params.CreatedAt,
+		updatedAt:   params.UpdatedAt,
+	}
+}
+
 // Getters
 func (c *Category) ID() uuid.UUID        { return c.id }
 func (c *Category) Name() string         { return c.name }
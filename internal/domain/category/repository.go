@@ -0,0 +1,22 @@
+package category
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for categories.
+type Repository interface {
+	// FindByID loads a category. Returns ErrCategoryNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Category, error)
+
+	// List returns categories ordered by display priority and name.
+	List(ctx context.Context, onlyActive bool) ([]*Category, error)
+
+	// Save creates or updates a category.
+	Save(ctx context.Context, category *Category) error
+
+	// Delete removes a category. Returns ErrCategoryNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,94 @@
+// Package customer models the registered customers known to support. Account
+// tickets only hold the customer's ID, so their contact details are looked up
+// here, kept in line with the identity service.
+package customer
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Customer entity
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidCustomer  = errors.New("invalid customer data")
+)
+
+// Customer is a registered user who may open tickets. Its ID is the
+// customer's user ID.
+type Customer struct {
+	id        uuid.UUID
+	email     string
+	name      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// CustomerParams contains parameters for creating a Customer.
+type CustomerParams struct {
+	ID    uuid.UUID
+	Email string
+	Name  string
+}
+
+// NewCustomer creates a new Customer entity.
+func NewCustomer(params CustomerParams) (*Customer, error) {
+	if params.ID == uuid.Nil {
+		return nil, errors.Join(ErrInvalidCustomer, errors.New("user ID is required"))
+	}
+	email := strings.TrimSpace(params.Email)
+	if email == "" {
+		return nil, errors.Join(ErrInvalidCustomer, errors.New("email is required"))
+	}
+
+	now := time.Now()
+	return &Customer{
+		id:        params.ID,
+		email:     email,
+		name:      strings.TrimSpace(params.Name),
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of a Customer.
+type ReconstituteParams struct {
+	ID        uuid.UUID
+	Email     string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Reconstitute rebuilds a Customer from persisted state.
+func Reconstitute(params ReconstituteParams) *Customer {
+	return &Customer{
+		id:        params.ID,
+		email:     params.Email,
+		name:      params.Name,
+		createdAt: params.CreatedAt,
+		updatedAt: params.UpdatedAt,
+	}
+}
+
+// Getters
+func (c *Customer) ID() uuid.UUID        { return c.id }
+func (c *Customer) Email() string        { return c.email }
+func (c *Customer) Name() string         { return c.name }
+func (c *Customer) CreatedAt() time.Time { return c.createdAt }
+func (c *Customer) UpdatedAt() time.Time { return c.updatedAt }
+
+// SyncIdentity applies the email and name the identity service holds for
+// the customer's user. Blank values keep the current ones.
+func (c *Customer) SyncIdentity(email, name string) {
+	if email = strings.TrimSpace(email); email != "" {
+		c.email = email
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		c.name = name
+	}
+	c.updatedAt = time.Now()
+}
@@ -0,0 +1,19 @@
+package customer
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for customers.
+type Repository interface {
+	// FindByID loads a customer. Returns ErrCustomerNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Customer, error)
+
+	// Save creates or updates a customer.
+	Save(ctx context.Context, customer *Customer) error
+
+	// Delete removes a customer. Returns ErrCustomerNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,193 @@
+package link
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Link entity
+var (
+	ErrLinkNotFound = errors.New("ticket link not found")
+	ErrInvalidLink  = errors.New("invalid ticket link data")
+	ErrLinkExists   = errors.New("tickets are already linked")
+	ErrLinkConflict = errors.New("ticket link conflicts with existing links")
+)
+
+// Type is the kind of relationship a link records. Links point from the
+// ticket to the linked ticket: a parent link points from the parent to its
+// child, a duplicate_of link from the duplicate to the original and a
+// blocks link from the blocking ticket to the blocked one. Related links
+// read the same both ways.
+type Type string
+
+// Link types
+const (
+	TypeParent      Type = "parent"
+	TypeRelated     Type = "related"
+	TypeDuplicateOf Type = "duplicate_of"
+	TypeBlocks      Type = "blocks"
+)
+
+// Relation is a link read from one of its tickets, naming what that ticket
+// is to the other: a ticket is the parent of its child, the duplicate_of
+// the original it repeats, and so on.
+type Relation string
+
+// Relations between linked tickets
+const (
+	RelationParent       Relation = "parent"
+	RelationChild        Relation = "child"
+	RelationRelated      Relation = "related"
+	RelationDuplicateOf  Relation = "duplicate_of"
+	RelationDuplicatedBy Relation = "duplicated_by"
+	RelationBlocks       Relation = "blocks"
+	RelationBlockedBy    Relation = "blocked_by"
+)
+
+// relations maps a relation to the link type and whether the link points
+// from the ticket to the other one.
+var relations = map[Relation]struct {
+	linkType Type
+	outgoing bool
+}{
+	RelationParent:       {TypeParent, true},
+	RelationChild:        {TypeParent, false},
+	RelationRelated:      {TypeRelated, true},
+	RelationDuplicateOf:  {TypeDuplicateOf, true},
+	RelationDuplicatedBy: {TypeDuplicateOf, false},
+	RelationBlocks:       {TypeBlocks, true},
+	RelationBlockedBy:    {TypeBlocks, false},
+}
+
+// ParseRelation parses a relation name.
+func ParseRelation(s string) (Relation, error) {
+	r := Relation(s)
+	if _, ok := relations[r]; !ok {
+		return "", fmt.Errorf("%w: unknown relation %q", ErrInvalidLink, s)
+	}
+	return r, nil
+}
+
+// Link records a relationship between two tickets.
+type Link struct {
+	id             uuid.UUID
+	ticketID       uuid.UUID
+	linkedTicketID uuid.UUID
+	linkType       Type
+	createdBy      *uuid.UUID
+	createdByName  string
+	createdAt      time.Time
+}
+
+// LinkParams contains parameters for creating a Link from one of its
+// tickets: Relation is what TicketID is to OtherID.
+type LinkParams struct {
+	ID            uuid.UUID
+	TicketID      uuid.UUID
+	OtherID       uuid.UUID
+	Relation      Relation
+	CreatedBy     *uuid.UUID
+	CreatedByName string
+}
+
+// NewLink creates a new Link entity.
+func NewLink(params LinkParams) (*Link, error) {
+	rel, ok := relations[params.Relation]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown relation %q", ErrInvalidLink, params.Relation)
+	}
+	if params.TicketID == uuid.Nil || params.OtherID == uuid.Nil {
+		return nil, fmt.Errorf("%w: both tickets are required", ErrInvalidLink)
+	}
+	if params.TicketID == params.OtherID {
+		return nil, fmt.Errorf("%w: a ticket cannot be linked to itself", ErrInvalidLink)
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	from, to := params.TicketID, params.OtherID
+	if !rel.outgoing {
+		from, to = to, from
+	}
+
+	return &Link{
+		id:             id,
+		ticketID:       from,
+		linkedTicketID: to,
+		linkType:       rel.linkType,
+		createdBy:      params.CreatedBy,
+		createdByName:  params.CreatedByName,
+		createdAt:      time.Now(),
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of a Link.
+type ReconstituteParams struct {
+	ID             uuid.UUID
+	TicketID       uuid.UUID
+	LinkedTicketID uuid.UUID
+	Type           string
+	CreatedBy      *uuid.UUID
+	CreatedByName  string
+	CreatedAt      time.Time
+}
+
+// Reconstitute rebuilds a Link from persisted state.
+func Reconstitute(params ReconstituteParams) *Link {
+	return &Link{
+		id:             params.ID,
+		ticketID:       params.TicketID,
+		linkedTicketID: params.LinkedTicketID,
+		linkType:       Type(params.Type),
+		createdBy:      params.CreatedBy,
+		createdByName:  params.CreatedByName,
+		createdAt:      params.CreatedAt,
+	}
+}
+
+// Getters
+func (l *Link) ID() uuid.UUID             { return l.id }
+func (l *Link) TicketID() uuid.UUID       { return l.ticketID }
+func (l *Link) LinkedTicketID() uuid.UUID { return l.linkedTicketID }
+func (l *Link) Type() Type                { return l.linkType }
+func (l *Link) CreatedBy() *uuid.UUID     { return l.createdBy }
+func (l *Link) CreatedByName() string     { return l.createdByName }
+func (l *Link) CreatedAt() time.Time      { return l.createdAt }
+
+// Involves checks if the ticket is one end of the link.
+func (l *Link) Involves(ticketID uuid.UUID) bool {
+	return l.ticketID == ticketID || l.linkedTicketID == ticketID
+}
+
+// Other returns the ticket at the other end of the link from ticketID.
+func (l *Link) Other(ticketID uuid.UUID) uuid.UUID {
+	if l.ticketID == ticketID {
+		return l.linkedTicketID
+	}
+	return l.ticketID
+}
+
+// RelationFor returns what ticketID is to the other ticket of the link.
+func (l *Link) RelationFor(ticketID uuid.UUID) Relation {
+	outgoing := l.ticketID == ticketID
+	for r, rel := range relations {
+		if rel.linkType == l.linkType && (rel.outgoing == outgoing || l.linkType == TypeRelated) {
+			return r
+		}
+	}
+	return RelationRelated
+}
+
+// ParentOf returns the parent of ticketID if the link makes it a child.
+func (l *Link) ParentOf(ticketID uuid.UUID) (uuid.UUID, bool) {
+	if l.linkType == TypeParent && l.linkedTicketID == ticketID {
+		return l.ticketID, true
+	}
+	return uuid.Nil, false
+}
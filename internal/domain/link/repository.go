@@ -0,0 +1,24 @@
+package link
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for ticket links.
+type Repository interface {
+	// FindByID loads a link. Returns ErrLinkNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Link, error)
+
+	// ListForTicket returns the links of a ticket in either direction,
+	// oldest first.
+	ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]*Link, error)
+
+	// Save creates a link. Returns ErrLinkExists if the tickets are already
+	// linked, in either direction.
+	Save(ctx context.Context, link *Link) error
+
+	// Delete removes a link. Returns ErrLinkNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,161 @@
+package mention
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// Domain errors for Mention entity
+var (
+	ErrMentionNotFound  = errors.New("mention not found")
+	ErrInvalidMention   = errors.New("invalid mention data")
+	ErrUnknownAgent     = errors.New("mentioned agent not found")
+	ErrAmbiguousMention = errors.New("mention matches more than one agent")
+)
+
+// Mention records that an agent was mentioned in an internal note.
+type Mention struct {
+	id              uuid.UUID
+	ticketID        uuid.UUID
+	messageID       uuid.UUID
+	agentID         uuid.UUID
+	mentionedBy     *uuid.UUID
+	mentionedByName string
+	readAt          *time.Time
+	createdAt       time.Time
+}
+
+// MentionParams contains parameters for creating a Mention.
+type MentionParams struct {
+	TicketID        uuid.UUID
+	MessageID       uuid.UUID
+	AgentID         uuid.UUID
+	MentionedBy     *uuid.UUID
+	MentionedByName string
+}
+
+// NewMention creates a new unread Mention entity.
+func NewMention(params MentionParams) (*Mention, error) {
+	if params.TicketID == uuid.Nil || params.MessageID == uuid.Nil || params.AgentID == uuid.Nil {
+		return nil, fmt.Errorf("%w: ticket, message and agent are required", ErrInvalidMention)
+	}
+
+	return &Mention{
+		id:              uuid.New(),
+		ticketID:        params.TicketID,
+		messageID:       params.MessageID,
+		agentID:         params.AgentID,
+		mentionedBy:     params.MentionedBy,
+		mentionedByName: params.MentionedByName,
+		createdAt:       time.Now(),
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of a Mention.
+type ReconstituteParams struct {
+	ID              uuid.UUID
+	TicketID        uuid.UUID
+	MessageID       uuid.UUID
+	AgentID         uuid.UUID
+	MentionedBy     *uuid.UUID
+	MentionedByName string
+	ReadAt          *time.Time
+	CreatedAt       time.Time
+}
+
+// Reconstitute rebuilds a Mention from persisted state.
+func Reconstitute(params ReconstituteParams) *Mention {
+	return &Mention{
+		id:              params.ID,
+		ticketID:        params.TicketID,
+		messageID:       params.MessageID,
+		agentID:         params.AgentID,
+		mentionedBy:     params.MentionedBy,
+		mentionedByName: params.MentionedByName,
+		readAt:          params.ReadAt,
+		createdAt:       params.CreatedAt,
+	}
+}
+
+// Getters
+func (m *Mention) ID() uuid.UUID           { return m.id }
+func (m *Mention) TicketID() uuid.UUID     { return m.ticketID }
+func (m *Mention) MessageID() uuid.UUID    { return m.messageID }
+func (m *Mention) AgentID() uuid.UUID      { return m.agentID }
+func (m *Mention) MentionedBy() *uuid.UUID { return m.mentionedBy }
+func (m *Mention) MentionedByName() string { return m.mentionedByName }
+func (m *Mention) ReadAt() *time.Time      { return m.readAt }
+func (m *Mention) CreatedAt() time.Time    { return m.createdAt }
+
+// IsRead checks if the agent has seen the mention.
+func (m *Mention) IsRead() bool {
+	return m.readAt != nil
+}
+
+// MarkRead marks the mention as seen. Marking it twice keeps the first
+// time.
+func (m *Mention) MarkRead() {
+	if m.readAt == nil {
+		now := time.Now()
+		m.readAt = &now
+	}
+}
+
+// handlePattern finds @handles that start a word, so email addresses in a
+// note are not taken for mentions. A handle is the local part of an
+// agent's email, or the whole address.
+var handlePattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9][\w.+-]*(?:@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)?)`)
+
+// ParseHandles returns the distinct handles mentioned in the content,
+// lower-cased, in order of first appearance.
+func ParseHandles(content string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, match := range handlePattern.FindAllStringSubmatch(content, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// Resolve finds the agent each handle names. Every handle must name
+// exactly one of the agents.
+func Resolve(handles []string, agents []*agent.Agent) ([]*agent.Agent, error) {
+	resolved := make([]*agent.Agent, 0, len(handles))
+	for _, handle := range handles {
+		var matches []*agent.Agent
+		for _, a := range agents {
+			if matchesHandle(a, handle) {
+				matches = append(matches, a)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("%w: @%s", ErrUnknownAgent, handle)
+		case 1:
+			resolved = append(resolved, matches[0])
+		default:
+			return nil, fmt.Errorf("%w: @%s; use the full email address", ErrAmbiguousMention, handle)
+		}
+	}
+	return resolved, nil
+}
+
+func matchesHandle(a *agent.Agent, handle string) bool {
+	email := strings.ToLower(a.Email())
+	if strings.Contains(handle, "@") {
+		return email == handle
+	}
+	local, _, ok := strings.Cut(email, "@")
+	return ok && local == handle
+}
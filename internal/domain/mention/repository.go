@@ -0,0 +1,43 @@
+package mention
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for mentions.
+type Repository interface {
+	// FindByID loads a mention. Returns ErrMentionNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Mention, error)
+
+	// List returns a page of mentions matching the filter, newest first,
+	// and the total number of matches.
+	List(ctx context.Context, filter Filter) ([]*Mention, int64, error)
+
+	// Save creates or updates a mention.
+	Save(ctx context.Context, mention *Mention) error
+}
+
+// Filter represents filters for listing mentions.
+type Filter struct {
+	AgentID    uuid.UUID
+	UnreadOnly bool
+	Page       int
+	PerPage    int
+}
+
+// Normalize applies the default page and page size.
+func (f *Filter) Normalize() {
+	if f.PerPage <= 0 {
+		f.PerPage = 20
+	}
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+}
+
+// Offset returns the number of mentions to skip for the current page.
+func (f Filter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
@@ -0,0 +1,69 @@
+package notification
+
+// DefaultLocale is the locale of the built-in templates.
+const DefaultLocale = "en"
+
+// defaultTemplates are the built-in English templates, used for events no
+// admin has written a template for.
+var defaultTemplates = map[Event]struct{ subject, body string }{
+	EventTicketReceived: {
+		subject: "[{{.TicketNumber}}] We received your request: {{.Subject}}",
+		body: `Hello{{with .CustomerName}} {{.}}{{end}},
+
+Thank you for contacting us. Your request has been received as ticket {{.TicketNumber}} and our team will get back to you soon.
+
+To add more information, simply reply to this email.
+{{- with .TicketURL}}
+
+You can follow your ticket at {{.}}
+{{- end}}
+`,
+	},
+	EventAgentReplied: {
+		subject: "[{{.TicketNumber}}] {{.Subject}}",
+		body: `{{.Message}}
+
+{{with .AgentName}}{{.}}
+{{end}}--
+Reply to this email to respond to ticket {{.TicketNumber}}.
+{{- with .TicketURL}}
+{{.}}
+{{- end}}
+`,
+	},
+	EventTicketResolved: {
+		subject: "[{{.TicketNumber}}] Your request has been resolved: {{.Subject}}",
+		body: `Hello{{with .CustomerName}} {{.}}{{end}},
+
+We have marked ticket {{.TicketNumber}} as resolved. If anything is still not right, reply to this email and we will reopen it.
+{{- with .TicketURL}}
+
+You can review your ticket at {{.}}
+{{- end}}
+`,
+	},
+	EventSurveyRequest: {
+		subject: "[{{.TicketNumber}}] How did we do?",
+		body: `Hello{{with .CustomerName}} {{.}}{{end}},
+
+Your ticket {{.TicketNumber}} ({{.Subject}}) is now closed. We would love to hear how we did.
+
+Rate your experience: {{.SurveyURL}}
+`,
+	},
+}
+
+// DefaultTemplate returns the built-in template of the event, or nil for
+// an unknown event. Built-in templates have no ID.
+func DefaultTemplate(event Event) *Template {
+	d, ok := defaultTemplates[event]
+	if !ok {
+		return nil
+	}
+	return &Template{
+		event:   event,
+		locale:  DefaultLocale,
+		subject: d.subject,
+		body:    d.body,
+	}
+}
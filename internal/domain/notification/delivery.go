@@ -0,0 +1,200 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Delivery entity
+var (
+	ErrDeliveryNotFound = errors.New("notification delivery not found")
+)
+
+// DeliveryStatus is where a notification email is in its sending.
+type DeliveryStatus string
+
+// Delivery statuses
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+)
+
+// Delivery is one notification email about a ticket, rendered when it was
+// queued, and the record of sending it.
+type Delivery struct {
+	id            uuid.UUID
+	ticketID      uuid.UUID
+	event         Event
+	locale        string
+	to            string
+	cc            []string
+	replyTo       string
+	subject       string
+	body          string
+	messageID     string
+	inReplyTo     string
+	references    []string
+	status        DeliveryStatus
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	sentAt        *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// DeliveryParams contains parameters for creating a Delivery.
+type DeliveryParams struct {
+	ID         uuid.UUID
+	TicketID   uuid.UUID
+	Event      Event
+	Locale     string
+	To         string
+	CC         []string
+	ReplyTo    string
+	Subject    string
+	Body       string
+	MessageID  string
+	InReplyTo  string
+	References []string
+}
+
+// NewDelivery creates a pending Delivery, due straight away.
+func NewDelivery(params DeliveryParams) (*Delivery, error) {
+	if params.TicketID == uuid.Nil {
+		return nil, errors.New("ticket is required")
+	}
+	if params.To == "" {
+		return nil, errors.New("recipient is required")
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Delivery{
+		id:            id,
+		ticketID:      params.TicketID,
+		event:         params.Event,
+		locale:        params.Locale,
+		to:            params.To,
+		cc:            params.CC,
+		replyTo:       params.ReplyTo,
+		subject:       params.Subject,
+		body:          params.Body,
+		messageID:     params.MessageID,
+		inReplyTo:     params.InReplyTo,
+		references:    params.References,
+		status:        DeliveryPending,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// DeliveryReconstituteParams contains the persisted state of a Delivery.
+type DeliveryReconstituteParams struct {
+	ID            uuid.UUID
+	TicketID      uuid.UUID
+	Event         string
+	Locale        string
+	To            string
+	CC            []string
+	ReplyTo       string
+	Subject       string
+	Body          string
+	MessageID     string
+	InReplyTo     string
+	References    []string
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ReconstituteDelivery rebuilds a Delivery from persisted state.
+func ReconstituteDelivery(params DeliveryReconstituteParams) *Delivery {
+	return &Delivery{
+		id:            params.ID,
+		ticketID:      params.TicketID,
+		event:         Event(params.Event),
+		locale:        params.Locale,
+		to:            params.To,
+		cc:            params.CC,
+		replyTo:       params.ReplyTo,
+		subject:       params.Subject,
+		body:          params.Body,
+		messageID:     params.MessageID,
+		inReplyTo:     params.InReplyTo,
+		references:    params.References,
+		status:        DeliveryStatus(params.Status),
+		attempts:      params.Attempts,
+		lastError:     params.LastError,
+		nextAttemptAt: params.NextAttemptAt,
+		sentAt:        params.SentAt,
+		createdAt:     params.CreatedAt,
+		updatedAt:     params.UpdatedAt,
+	}
+}
+
+// Getters
+func (d *Delivery) ID() uuid.UUID            { return d.id }
+func (d *Delivery) TicketID() uuid.UUID      { return d.ticketID }
+func (d *Delivery) Event() Event             { return d.event }
+func (d *Delivery) Locale() string           { return d.locale }
+func (d *Delivery) To() string               { return d.to }
+func (d *Delivery) CC() []string             { return d.cc }
+func (d *Delivery) ReplyTo() string          { return d.replyTo }
+func (d *Delivery) Subject() string          { return d.subject }
+func (d *Delivery) Body() string             { return d.body }
+func (d *Delivery) MessageID() string        { return d.messageID }
+func (d *Delivery) InReplyTo() string        { return d.inReplyTo }
+func (d *Delivery) References() []string     { return d.references }
+func (d *Delivery) Status() DeliveryStatus   { return d.status }
+func (d *Delivery) Attempts() int            { return d.attempts }
+func (d *Delivery) LastError() string        { return d.lastError }
+func (d *Delivery) NextAttemptAt() time.Time { return d.nextAttemptAt }
+func (d *Delivery) SentAt() *time.Time       { return d.sentAt }
+func (d *Delivery) CreatedAt() time.Time     { return d.createdAt }
+func (d *Delivery) UpdatedAt() time.Time     { return d.updatedAt }
+
+// IsPending checks if the delivery is still waiting to be sent.
+func (d *Delivery) IsPending() bool {
+	return d.status == DeliveryPending
+}
+
+// --- Behavior Methods ---
+
+// MarkSent records that the email was handed to the mail server.
+func (d *Delivery) MarkSent() {
+	now := time.Now()
+	d.status = DeliverySent
+	d.attempts++
+	d.lastError = ""
+	d.sentAt = &now
+	d.updatedAt = now
+}
+
+// MarkRetry records a failed attempt and schedules the next one.
+func (d *Delivery) MarkRetry(lastErr string, retryAt time.Time) {
+	d.attempts++
+	d.lastError = lastErr
+	d.nextAttemptAt = retryAt
+	d.updatedAt = time.Now()
+}
+
+// MarkFailed records the last failed attempt; the email is not retried.
+func (d *Delivery) MarkFailed(lastErr string) {
+	d.status = DeliveryFailed
+	d.attempts++
+	d.lastError = lastErr
+	d.updatedAt = time.Now()
+}
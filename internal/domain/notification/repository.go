@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateRepository is the persistence port for notification templates.
+type TemplateRepository interface {
+	// FindByID loads a template. Returns ErrTemplateNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Template, error)
+
+	// Find loads the template of the event in exactly the locale. Returns
+	// ErrTemplateNotFound if none exists.
+	Find(ctx context.Context, event Event, locale string) (*Template, error)
+
+	// List returns the templates matching the filter, ordered by event and
+	// locale.
+	List(ctx context.Context, filter TemplateFilter) ([]*Template, error)
+
+	// Save creates or updates a template. Returns ErrTemplateConflict if
+	// another template has the same event and locale.
+	Save(ctx context.Context, template *Template) error
+
+	// Delete removes a template. Returns ErrTemplateNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TemplateFilter narrows a template listing; empty fields match all.
+type TemplateFilter struct {
+	Event  Event
+	Locale string
+}
+
+// DeliveryRepository is the persistence port for the notification send
+// log.
+type DeliveryRepository interface {
+	// Save creates or updates a delivery and releases its lease.
+	Save(ctx context.Context, delivery *Delivery) error
+
+	// ListByTicket returns the deliveries of a ticket, oldest first.
+	ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*Delivery, error)
+
+	// FindByMessageID returns the delivery queued with one of the
+	// Message-IDs, or ErrDeliveryNotFound.
+	FindByMessageID(ctx context.Context, messageIDs []string) (*Delivery, error)
+
+	// ClaimDue leases up to limit pending deliveries whose next attempt is
+	// due, oldest first. Leased deliveries are not handed out again until
+	// they are saved or the lease expires, also not to other senders.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+}
@@ -0,0 +1,233 @@
+package notification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Domain errors for Template entity
+var (
+	ErrTemplateNotFound = errors.New("notification template not found")
+	ErrInvalidTemplate  = errors.New("invalid notification template data")
+	ErrTemplateConflict = errors.New("a notification template already exists for this event and locale")
+)
+
+// Event is a ticket event customers are emailed about.
+type Event string
+
+// Notification events
+const (
+	EventTicketReceived Event = "ticket_received"
+	EventAgentReplied   Event = "agent_replied"
+	EventTicketResolved Event = "ticket_resolved"
+	EventSurveyRequest  Event = "survey_request"
+)
+
+// AllEvents returns all notification events.
+func AllEvents() []Event {
+	return []Event{EventTicketReceived, EventAgentReplied, EventTicketResolved, EventSurveyRequest}
+}
+
+// ParseEvent parses a notification event.
+func ParseEvent(s string) (Event, error) {
+	for _, e := range AllEvents() {
+		if string(e) == s {
+			return e, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown event %q", ErrInvalidTemplate, s)
+}
+
+// TemplateData is what templates are rendered with. Message and AgentName
+// are set for agent replies; TicketURL and SurveyURL when configured.
+type TemplateData struct {
+	TicketNumber  string
+	Subject       string
+	CustomerName  string
+	CustomerEmail string
+	Status        string
+	Priority      string
+	AgentName     string
+	Message       string
+	TicketURL     string
+	SurveyURL     string
+}
+
+// SampleData returns the data templates are checked and previewed with.
+func SampleData() TemplateData {
+	return TemplateData{
+		TicketNumber:  "TKT-20261016-0042",
+		Subject:       "Where is my order?",
+		CustomerName:  "Jane Doe",
+		CustomerEmail: "jane@example.com",
+		Status:        string(shared.StatusInProgress),
+		Priority:      string(shared.PriorityNormal),
+		AgentName:     "Alex",
+		Message:       "Your order shipped today and should arrive on Friday.",
+		TicketURL:     "https://shop.example.com/support/tickets/TKT-20261016-0042",
+		SurveyURL:     "https://shop.example.com/support/tickets/TKT-20261016-0042/rate",
+	}
+}
+
+// Rendered is a rendered notification.
+type Rendered struct {
+	Subject string
+	Body    string
+}
+
+// Template is the subject and plain-text body of the email sent for an
+// event in a locale, written as Go text/templates over TemplateData.
+type Template struct {
+	id        uuid.UUID
+	event     Event
+	locale    string
+	subject   string
+	body      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// TemplateParams contains parameters for creating a Template.
+type TemplateParams struct {
+	ID      uuid.UUID
+	Event   string
+	Locale  string
+	Subject string
+	Body    string
+}
+
+// NewTemplate creates a new Template entity. The subject and body must
+// render with SampleData.
+func NewTemplate(params TemplateParams) (*Template, error) {
+	event, err := ParseEvent(params.Event)
+	if err != nil {
+		return nil, err
+	}
+	locale := shared.NormalizeLocale(params.Locale)
+	if locale == "" {
+		return nil, fmt.Errorf("%w: invalid locale %q", ErrInvalidTemplate, params.Locale)
+	}
+	if err := validateContent(params.Subject, params.Body); err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Template{
+		id:        id,
+		event:     event,
+		locale:    locale,
+		subject:   params.Subject,
+		body:      params.Body,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// TemplateReconstituteParams contains the persisted state of a Template.
+type TemplateReconstituteParams struct {
+	ID        uuid.UUID
+	Event     string
+	Locale    string
+	Subject   string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReconstituteTemplate rebuilds a Template from persisted state.
+func ReconstituteTemplate(params TemplateReconstituteParams) *Template {
+	return &Template{
+		id:        params.ID,
+		event:     Event(params.Event),
+		locale:    params.Locale,
+		subject:   params.Subject,
+		body:      params.Body,
+		createdAt: params.CreatedAt,
+		updatedAt: params.UpdatedAt,
+	}
+}
+
+// Getters
+func (t *Template) ID() uuid.UUID        { return t.id }
+func (t *Template) Event() Event         { return t.event }
+func (t *Template) Locale() string       { return t.locale }
+func (t *Template) Subject() string      { return t.subject }
+func (t *Template) Body() string         { return t.body }
+func (t *Template) CreatedAt() time.Time { return t.createdAt }
+func (t *Template) UpdatedAt() time.Time { return t.updatedAt }
+
+// IsBuiltIn checks if the template is a built-in default rather than one
+// stored by an admin.
+func (t *Template) IsBuiltIn() bool {
+	return t.id == uuid.Nil
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the subject and body.
+func (t *Template) Update(subject, body string) error {
+	if err := validateContent(subject, body); err != nil {
+		return err
+	}
+	t.subject = subject
+	t.body = body
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// Render renders the template with the data.
+func (t *Template) Render(data TemplateData) (Rendered, error) {
+	return Render(t.subject, t.body, data)
+}
+
+// Render renders a subject and body with the data. Line breaks in the
+// subject are folded into spaces.
+func Render(subject, body string, data TemplateData) (Rendered, error) {
+	renderedSubject, err := execute("subject", subject, data)
+	if err != nil {
+		return Rendered{}, err
+	}
+	renderedBody, err := execute("body", body, data)
+	if err != nil {
+		return Rendered{}, err
+	}
+	return Rendered{
+		Subject: strings.Join(strings.Fields(renderedSubject), " "),
+		Body:    renderedBody,
+	}, nil
+}
+
+func execute(name, text string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, name, err)
+	}
+	return buf.String(), nil
+}
+
+func validateContent(subject, body string) error {
+	if strings.TrimSpace(subject) == "" {
+		return fmt.Errorf("%w: subject is required", ErrInvalidTemplate)
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidTemplate)
+	}
+	_, err := Render(subject, body, SampleData())
+	return err
+}
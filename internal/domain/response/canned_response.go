@@ -67,6 +67,36 @@ func NewCannedResponse(params CannedResponseParams) (*CannedResponse, error) {
 	}, nil
 }
 
+// ReconstituteParams contains the persisted state of a CannedResponse.
+type ReconstituteParams struct {
+	ID         uuid.UUID
+	Title      string
+	Content    string
+	CategoryID *uuid.UUID
+	Shortcut   string
+	IsActive   bool
+	UsageCount int
+	CreatedBy  *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Reconstitute rebuilds a CannedResponse from persisted state.
+func Reconstitute(params ReconstituteParams) *CannedResponse {
+	return &CannedResponse{
+		id:         params.ID,
+		title:      params.Title,
+		content:    params.Content,
+		categoryID: params.CategoryID,
+		shortcut:   params.Shortcut,
+		isActive:   params.IsActive,
+		usageCount: params.UsageCount,
+		createdBy:  params.CreatedBy,
+		createdAt:  params.CreatedAt,
+		updatedAt:  params.UpdatedAt,
+	}
+}
+
 // Getters
 func (r *CannedResponse) ID() uuid.UUID          { return r.id }
 func (r *CannedResponse) Title() string          { return r.title }
@@ -0,0 +1,27 @@
+package response
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for canned responses.
+type Repository interface {
+	// FindByID loads a canned response. Returns ErrResponseNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*CannedResponse, error)
+
+	// FindByShortcut loads an active canned response by its shortcut.
+	// Returns ErrResponseNotFound if none exists.
+	FindByShortcut(ctx context.Context, shortcut string) (*CannedResponse, error)
+
+	// List returns canned responses ordered by usage and title. When a
+	// category is given, responses without a category are included too.
+	List(ctx context.Context, categoryID *uuid.UUID, onlyActive bool) ([]*CannedResponse, error)
+
+	// Save creates or updates a canned response.
+	Save(ctx context.Context, response *CannedResponse) error
+
+	// Delete removes a canned response. Returns ErrResponseNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
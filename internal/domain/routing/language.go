@@ -0,0 +1,74 @@
+package routing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages DetectLanguage recognises.
+const (
+	LanguageEnglish = "en"
+	LanguageMalay   = "ms"
+	LanguageChinese = "zh"
+)
+
+// Common words that tell Malay and English text apart. Words both languages
+// use, such as "ok", are left out.
+var (
+	malayWords = wordSet("yang", "dan", "saya", "tidak", "untuk", "dengan", "ini", "itu", "ada", "boleh",
+		"sudah", "belum", "barang", "pesanan", "terima", "kasih", "tolong", "bila", "mana", "kenapa",
+		"apa", "sampai", "lagi", "dari", "kami", "anda", "encik", "puan", "bayaran", "wang")
+	englishWords = wordSet("the", "and", "is", "are", "my", "not", "for", "with", "this", "that",
+		"have", "has", "was", "order", "please", "when", "where", "why", "what", "received",
+		"yet", "from", "we", "you", "payment", "refund", "thanks", "thank", "hello", "hi")
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// DetectLanguage guesses the language of customer text from its script and
+// common words. It returns "zh" for mostly Han text, "ms" or "en" when
+// enough common words of one of them are found, and "" when unsure.
+func DetectLanguage(text string) string {
+	var han, letters int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.IsLetter(r):
+			letters++
+		}
+	}
+	if han > 0 && han*2 >= letters {
+		return LanguageChinese
+	}
+
+	var malay, english int
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		if malayWords[w] {
+			malay++
+		}
+		if englishWords[w] {
+			english++
+		}
+	}
+
+	switch {
+	case malay+english < 2:
+		return ""
+	case malay > english:
+		return LanguageMalay
+	case english > malay:
+		return LanguageEnglish
+	default:
+		return ""
+	}
+}
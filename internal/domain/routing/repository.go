@@ -0,0 +1,22 @@
+package routing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for routing rules.
+type Repository interface {
+	// FindByID loads a rule. Returns ErrRuleNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+
+	// List returns all rules in evaluation order: by position, then name.
+	List(ctx context.Context) ([]*Rule, error)
+
+	// Save creates or updates a rule.
+	Save(ctx context.Context, rule *Rule) error
+
+	// Delete removes a rule. Returns ErrRuleNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,278 @@
+// Package routing matches tickets to the agent skills they need.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// Domain errors for Rule aggregate
+var (
+	ErrRuleNotFound = errors.New("routing rule not found")
+	ErrInvalidRule  = errors.New("invalid routing rule")
+)
+
+// Attributes are the ticket details routing rules match on.
+type Attributes struct {
+	CategoryID *uuid.UUID
+	Tags       []string
+	Language   string // detected from the customer's messages, "" if unsure
+	HasOrder   bool
+}
+
+// AttributesOf collects the routing attributes of a ticket. The language is
+// detected from the subject and the customer's messages.
+func AttributesOf(t *ticket.Ticket) Attributes {
+	text := []string{t.Subject()}
+	for _, m := range t.Messages() {
+		if m.IsFromCustomer() {
+			text = append(text, m.Content())
+		}
+	}
+
+	return Attributes{
+		CategoryID: t.CategoryID(),
+		Tags:       t.Tags(),
+		Language:   DetectLanguage(strings.Join(text, "\n")),
+		HasOrder:   t.OrderID() != nil || t.OrderNumber() != "",
+	}
+}
+
+// Condition selects the tickets a rule applies to. Every condition that is
+// set must hold; a list holds when the ticket has any of its values.
+type Condition struct {
+	CategoryIDs []uuid.UUID
+	Tags        []string
+	Languages   []string
+	HasOrder    *bool
+}
+
+// SkillRequirement is a skill an agent needs at least the given level of.
+type SkillRequirement struct {
+	Name     string
+	MinLevel int
+}
+
+// Rule is the aggregate root for routing rules. Rules are evaluated by
+// position and the first enabled rule that matches a ticket routes it to the
+// available agent best at the required skills. When no agent qualifies the
+// ticket waits in the fallback team's queue.
+type Rule struct {
+	id             uuid.UUID
+	name           string
+	position       int
+	enabled        bool
+	condition      Condition
+	requiredSkills []SkillRequirement
+	fallbackTeamID *uuid.UUID
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// RuleParams contains parameters for creating or updating a Rule.
+type RuleParams struct {
+	ID             uuid.UUID
+	Name           string
+	Position       int
+	Enabled        bool
+	Condition      Condition
+	RequiredSkills []SkillRequirement
+	FallbackTeamID *uuid.UUID
+}
+
+// NewRule creates a new Rule aggregate.
+func NewRule(params RuleParams) (*Rule, error) {
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	r := &Rule{id: id, createdAt: now}
+	if err := r.Update(params); err != nil {
+		return nil, err
+	}
+	r.updatedAt = now
+	return r, nil
+}
+
+// ReconstituteParams contains the persisted state of a Rule.
+type ReconstituteParams struct {
+	ID             uuid.UUID
+	Name           string
+	Position       int
+	Enabled        bool
+	Condition      Condition
+	RequiredSkills []SkillRequirement
+	FallbackTeamID *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Reconstitute rebuilds a Rule from persisted state.
+func Reconstitute(params ReconstituteParams) *Rule {
+	return &Rule{
+		id:             params.ID,
+		name:           params.Name,
+		position:       params.Position,
+		enabled:        params.Enabled,
+		condition:      params.Condition,
+		requiredSkills: params.RequiredSkills,
+		fallbackTeamID: params.FallbackTeamID,
+		createdAt:      params.CreatedAt,
+		updatedAt:      params.UpdatedAt,
+	}
+}
+
+// Getters
+func (r *Rule) ID() uuid.UUID                      { return r.id }
+func (r *Rule) Name() string                       { return r.name }
+func (r *Rule) Position() int                      { return r.position }
+func (r *Rule) IsEnabled() bool                    { return r.enabled }
+func (r *Rule) Condition() Condition               { return r.condition }
+func (r *Rule) RequiredSkills() []SkillRequirement { return r.requiredSkills }
+func (r *Rule) FallbackTeamID() *uuid.UUID         { return r.fallbackTeamID }
+func (r *Rule) CreatedAt() time.Time               { return r.createdAt }
+func (r *Rule) UpdatedAt() time.Time               { return r.updatedAt }
+
+// Mismatches lists why the rule does not apply to a ticket with the given
+// attributes. It is empty when the rule matches.
+func (r *Rule) Mismatches(a Attributes) []string {
+	var reasons []string
+	if !r.enabled {
+		reasons = append(reasons, "rule is disabled")
+	}
+
+	c := r.condition
+	if len(c.CategoryIDs) > 0 && !hasCategory(c.CategoryIDs, a.CategoryID) {
+		reasons = append(reasons, "category does not match")
+	}
+	if len(c.Tags) > 0 && !hasAny(c.Tags, a.Tags) {
+		reasons = append(reasons, fmt.Sprintf("ticket has none of the tags %s", strings.Join(c.Tags, ", ")))
+	}
+	if len(c.Languages) > 0 && !hasAny(c.Languages, []string{a.Language}) {
+		language := a.Language
+		if language == "" {
+			language = "unknown"
+		}
+		reasons = append(reasons, fmt.Sprintf("language %s is not one of %s", language, strings.Join(c.Languages, ", ")))
+	}
+	if c.HasOrder != nil && *c.HasOrder != a.HasOrder {
+		if *c.HasOrder {
+			reasons = append(reasons, "ticket has no order")
+		} else {
+			reasons = append(reasons, "ticket has an order")
+		}
+	}
+	return reasons
+}
+
+// Matches checks if the rule applies to a ticket with the given attributes.
+func (r *Rule) Matches(a Attributes) bool {
+	return len(r.Mismatches(a)) == 0
+}
+
+// Score rates an agent against the required skills: the sum of the agent's
+// levels in them. The agent qualifies when every skill meets its minimum.
+func (r *Rule) Score(ag *agent.Agent) (int, bool) {
+	score := 0
+	for _, req := range r.requiredSkills {
+		level := ag.SkillLevel(req.Name)
+		if level < req.MinLevel {
+			return 0, false
+		}
+		score += level
+	}
+	return score, true
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the rule's definition.
+func (r *Rule) Update(params RuleParams) error {
+	if strings.TrimSpace(params.Name) == "" {
+		return errors.Join(ErrInvalidRule, errors.New("name is required"))
+	}
+	if len(params.RequiredSkills) == 0 && params.FallbackTeamID == nil {
+		return errors.Join(ErrInvalidRule, errors.New("a required skill or a fallback team is required"))
+	}
+
+	skills := make([]SkillRequirement, 0, len(params.RequiredSkills))
+	seen := make(map[string]bool, len(params.RequiredSkills))
+	for _, s := range params.RequiredSkills {
+		name := strings.ToLower(strings.TrimSpace(s.Name))
+		if name == "" {
+			return errors.Join(ErrInvalidRule, errors.New("skill name is required"))
+		}
+		if s.MinLevel < agent.MinSkillLevel || s.MinLevel > agent.MaxSkillLevel {
+			return fmt.Errorf("%w: skill %q minimum level must be between %d and %d", ErrInvalidRule, name, agent.MinSkillLevel, agent.MaxSkillLevel)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: skill %q is listed twice", ErrInvalidRule, name)
+		}
+		seen[name] = true
+		skills = append(skills, SkillRequirement{Name: name, MinLevel: s.MinLevel})
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].Name < skills[j].Name
+	})
+
+	r.name = strings.TrimSpace(params.Name)
+	r.position = params.Position
+	r.enabled = params.Enabled
+	r.condition = Condition{
+		CategoryIDs: params.Condition.CategoryIDs,
+		Tags:        normalize(params.Condition.Tags),
+		Languages:   normalize(params.Condition.Languages),
+		HasOrder:    params.Condition.HasOrder,
+	}
+	r.requiredSkills = skills
+	r.fallbackTeamID = params.FallbackTeamID
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func hasCategory(ids []uuid.UUID, id *uuid.UUID) bool {
+	if id == nil {
+		return false
+	}
+	for _, c := range ids {
+		if c == *id {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAny(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(w, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalize lower-cases, trims and de-duplicates values.
+func normalize(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		normalized = append(normalized, v)
+	}
+	return normalized
+}
@@ -0,0 +1,191 @@
+package routing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestRuleMismatches(t *testing.T) {
+	billing, shipping := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		disabled  bool
+		condition Condition
+		attrs     Attributes
+		want      []string
+	}{
+		{
+			name:  "no condition matches every ticket",
+			attrs: Attributes{},
+		},
+		{
+			name:      "every condition holds",
+			condition: Condition{CategoryIDs: []uuid.UUID{shipping, billing}, Tags: []string{"Refund", "vip"}, Languages: []string{"ms"}, HasOrder: boolPtr(true)},
+			attrs:     Attributes{CategoryID: &billing, Tags: []string{"urgent", "REFUND"}, Language: "ms", HasOrder: true},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			attrs:    Attributes{},
+			want:     []string{"rule is disabled"},
+		},
+		{
+			name:      "other category",
+			condition: Condition{CategoryIDs: []uuid.UUID{billing}},
+			attrs:     Attributes{CategoryID: &shipping},
+			want:      []string{"category does not match"},
+		},
+		{
+			name:      "no category",
+			condition: Condition{CategoryIDs: []uuid.UUID{billing}},
+			attrs:     Attributes{},
+			want:      []string{"category does not match"},
+		},
+		{
+			name:      "none of the tags",
+			condition: Condition{Tags: []string{"refund", "vip"}},
+			attrs:     Attributes{Tags: []string{"urgent"}},
+			want:      []string{"ticket has none of the tags refund, vip"},
+		},
+		{
+			name:      "language not detected",
+			condition: Condition{Languages: []string{"ms", "zh"}},
+			attrs:     Attributes{},
+			want:      []string{"language unknown is not one of ms, zh"},
+		},
+		{
+			name:      "order required",
+			condition: Condition{HasOrder: boolPtr(true)},
+			attrs:     Attributes{},
+			want:      []string{"ticket has no order"},
+		},
+		{
+			name:      "order excluded",
+			condition: Condition{HasOrder: boolPtr(false)},
+			attrs:     Attributes{HasOrder: true},
+			want:      []string{"ticket has an order"},
+		},
+		{
+			name:      "every failing condition is listed",
+			disabled:  true,
+			condition: Condition{Tags: []string{"vip"}, Languages: []string{"en"}},
+			attrs:     Attributes{Language: "ms"},
+			want:      []string{"rule is disabled", "ticket has none of the tags vip", "language ms is not one of en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewRule(RuleParams{
+				Name:           "Rule",
+				Enabled:        !tt.disabled,
+				Condition:      tt.condition,
+				RequiredSkills: []SkillRequirement{{Name: "refunds", MinLevel: 1}},
+			})
+			if err != nil {
+				t.Fatalf("NewRule: %v", err)
+			}
+			got := rule.Mismatches(tt.attrs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Mismatches = %q, want %q", got, tt.want)
+			}
+			if matched := rule.Matches(tt.attrs); matched != (len(tt.want) == 0) {
+				t.Fatalf("Matches = %v, want %v", matched, !matched)
+			}
+		})
+	}
+}
+
+func TestRuleScore(t *testing.T) {
+	rule, err := NewRule(RuleParams{
+		Name:    "Malay refunds",
+		Enabled: true,
+		RequiredSkills: []SkillRequirement{
+			{Name: " Refunds ", MinLevel: 2},
+			{Name: "malay", MinLevel: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRule: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		skills        []agent.Skill
+		wantScore     int
+		wantQualified bool
+	}{
+		{name: "at the minimum", skills: []agent.Skill{{Name: "refunds", Level: 2}, {Name: "malay", Level: 3}}, wantScore: 5, wantQualified: true},
+		{name: "above the minimum", skills: []agent.Skill{{Name: "refunds", Level: 5}, {Name: "malay", Level: 4}}, wantScore: 9, wantQualified: true},
+		{name: "other skills do not count", skills: []agent.Skill{{Name: "refunds", Level: 2}, {Name: "malay", Level: 3}, {Name: "billing", Level: 5}}, wantScore: 5, wantQualified: true},
+		{name: "one skill below the minimum", skills: []agent.Skill{{Name: "refunds", Level: 5}, {Name: "malay", Level: 2}}},
+		{name: "skill missing", skills: []agent.Skill{{Name: "refunds", Level: 5}}},
+		{name: "no skills"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag := agent.Reconstitute(agent.ReconstituteParams{ID: uuid.New(), Name: "Agent", Skills: tt.skills})
+			score, qualified := rule.Score(ag)
+			if score != tt.wantScore || qualified != tt.wantQualified {
+				t.Fatalf("Score = %d %v, want %d %v", score, qualified, tt.wantScore, tt.wantQualified)
+			}
+		})
+	}
+}
+
+func TestNewRuleValidation(t *testing.T) {
+	team := uuid.New()
+
+	tests := []struct {
+		name    string
+		params  RuleParams
+		wantErr bool
+	}{
+		{name: "skills", params: RuleParams{Name: "Refunds", RequiredSkills: []SkillRequirement{{Name: "refunds", MinLevel: 1}}}},
+		{name: "fallback team only", params: RuleParams{Name: "VIP", FallbackTeamID: &team}},
+		{name: "no name", params: RuleParams{Name: " ", FallbackTeamID: &team}, wantErr: true},
+		{name: "neither skills nor team", params: RuleParams{Name: "Empty"}, wantErr: true},
+		{name: "level too low", params: RuleParams{Name: "Refunds", RequiredSkills: []SkillRequirement{{Name: "refunds", MinLevel: 0}}}, wantErr: true},
+		{name: "level too high", params: RuleParams{Name: "Refunds", RequiredSkills: []SkillRequirement{{Name: "refunds", MinLevel: 6}}}, wantErr: true},
+		{name: "skill twice", params: RuleParams{Name: "Refunds", RequiredSkills: []SkillRequirement{{Name: "refunds", MinLevel: 1}, {Name: "Refunds", MinLevel: 2}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRule(tt.params)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("NewRule error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidRule) {
+				t.Fatalf("NewRule error = %v, want ErrInvalidRule", err)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := map[string]string{
+		"Where is my order? I have not received it yet.":   LanguageEnglish,
+		"Bila barang saya akan sampai? Belum terima lagi.": LanguageMalay,
+		"我的订单什么时候到?":                                       LanguageChinese,
+		"订单还没有到 order 12345":                               LanguageChinese,
+		"Hi":                                               "",
+		"12345 !!!":                                        "",
+		"the dan":                                          "",
+	}
+	for text, want := range tests {
+		if got := DetectLanguage(text); got != want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
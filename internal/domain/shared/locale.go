@@ -0,0 +1,31 @@
+package shared
+
+import "strings"
+
+// NormalizeLocale turns a language tag into the lower-case, hyphenated
+// form tickets and templates are keyed by, e.g. "pt_BR" into "pt-br".
+// Anything that is not a language tag yields "".
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if locale == "" || len(locale) > 35 {
+		return ""
+	}
+	for i, part := range strings.Split(locale, "-") {
+		if part == "" || len(part) > 8 || (i == 0 && len(part) < 2) {
+			return ""
+		}
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				return ""
+			}
+		}
+	}
+	return locale
+}
+
+// LocaleLanguage returns the language of a normalized locale, e.g. "pt"
+// for "pt-br".
+func LocaleLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
@@ -2,7 +2,6 @@ package shared
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,8 +13,9 @@ type TicketNumber struct {
 	value string
 }
 
-// TicketNumber format: TKT-YYYYMMDD-XXXX
-var ticketNumberRegex = regexp.MustCompile(`^TKT-\d{8}-\d{4}$`)
+// ticketNumberRegex matches the numbers any TicketNumberFormat produces,
+// such as the default TKT-YYYYMMDD-XXXX.
+var ticketNumberRegex = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{3,39}$`)
 
 // ErrInvalidTicketNumber is returned for invalid ticket numbers.
 var ErrInvalidTicketNumber = errors.New("invalid ticket number format")
@@ -29,20 +29,23 @@ func NewTicketNumber(number string) (TicketNumber, error) {
 	return TicketNumber{value: number}, nil
 }
 
-// GenerateTicketNumber generates a new unique ticket number.
-// Format: TKT-YYYYMMDD-XXXX
+// RestoreTicketNumber wraps a previously persisted ticket number without validation.
+func RestoreTicketNumber(number string) TicketNumber {
+	return TicketNumber{value: number}
+}
+
+// GenerateTicketNumber generates a ticket number in the default format from
+// the clock. It is not collision-free; tickets that are persisted get their
+// number from a per-day sequence instead.
 func GenerateTicketNumber() TicketNumber {
 	now := time.Now()
-	seq := now.UnixNano() % 10000
-	value := fmt.Sprintf("TKT-%s-%04d", now.Format("20060102"), seq)
-	return TicketNumber{value: value}
+	return DefaultTicketNumberFormat().Format(now, now.UnixNano()%10000)
 }
 
-// GenerateTicketNumberFromSequence generates a ticket number from a sequence.
+// GenerateTicketNumberFromSequence generates a ticket number in the default
+// format from a per-day sequence value.
 func GenerateTicketNumberFromSequence(seq int64) TicketNumber {
-	now := time.Now()
-	value := fmt.Sprintf("TKT-%s-%04d", now.Format("20060102"), seq%10000)
-	return TicketNumber{value: value}
+	return DefaultTicketNumberFormat().Format(time.Now(), seq)
 }
 
 // Value returns the ticket number string.
@@ -60,28 +63,22 @@ func (n TicketNumber) IsEmpty() bool {
 	return n.value == ""
 }
 
-// Date returns the date portion of the ticket number.
+// Date returns the date portion of a PREFIX-YYYYMMDD-SEQ ticket number.
 func (n TicketNumber) Date() (time.Time, error) {
-	if n.IsEmpty() {
-		return time.Time{}, ErrInvalidTicketNumber
-	}
 	parts := strings.Split(n.value, "-")
-	if len(parts) != 3 {
+	if len(parts) < 3 {
 		return time.Time{}, ErrInvalidTicketNumber
 	}
-	return time.Parse("20060102", parts[1])
+	return time.Parse(ticketNumberDateLayout, parts[len(parts)-2])
 }
 
-// Sequence returns the sequence portion of the ticket number.
+// Sequence returns the sequence portion of a PREFIX-YYYYMMDD-SEQ ticket number.
 func (n TicketNumber) Sequence() int {
-	if n.IsEmpty() {
-		return 0
-	}
 	parts := strings.Split(n.value, "-")
-	if len(parts) != 3 {
+	if len(parts) < 3 {
 		return 0
 	}
-	seq, _ := strconv.Atoi(parts[2])
+	seq, _ := strconv.Atoi(parts[len(parts)-1])
 	return seq
 }
 
@@ -0,0 +1,134 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTicketNumberFormat is returned for unusable ticket number patterns.
+var ErrInvalidTicketNumberFormat = errors.New("invalid ticket number format pattern")
+
+// DefaultTicketNumberPattern produces numbers like TKT-20261016-0042.
+const DefaultTicketNumberPattern = "TKT-{date}-{seq:4}"
+
+const (
+	ticketNumberDateLayout = "20060102"
+	defaultSequenceDigits  = 4
+	maxSequenceDigits      = 10
+	maxPatternLiteral      = 20
+)
+
+var (
+	// ticketNumberTokenRegex matches {date}, {seq} and {seq:N}.
+	ticketNumberTokenRegex = regexp.MustCompile(`\{(date|seq)(?::(\d+))?\}`)
+	// ticketNumberLiteralRegex matches the text between tokens.
+	ticketNumberLiteralRegex = regexp.MustCompile(`^[A-Z0-9_-]*$`)
+)
+
+// TicketNumberFormat describes how ticket numbers are built from the day and
+// a per-day sequence, e.g. "ACME-{date}-{seq:5}". {date} is the day as
+// YYYYMMDD; {seq:N} is the sequence zero-padded to at least N digits, and
+// grows beyond N digits rather than wrapping.
+type TicketNumberFormat struct {
+	pattern   string
+	before    string
+	between   string
+	after     string
+	dateFirst bool
+	digits    int
+}
+
+// ParseTicketNumberFormat parses a pattern with exactly one {date} and one
+// {seq} token. Literal text is upper-cased and may contain letters, digits,
+// "-" and "_".
+func ParseTicketNumberFormat(pattern string) (TicketNumberFormat, error) {
+	pattern = strings.ToUpper(strings.TrimSpace(pattern))
+	pattern = strings.NewReplacer("{DATE}", "{date}", "{SEQ", "{seq").Replace(pattern)
+
+	matches := ticketNumberTokenRegex.FindAllStringSubmatchIndex(pattern, -1)
+	if len(matches) != 2 {
+		return TicketNumberFormat{}, fmt.Errorf("%w: %q needs one {date} and one {seq} token", ErrInvalidTicketNumberFormat, pattern)
+	}
+
+	f := TicketNumberFormat{digits: defaultSequenceDigits}
+	var sawDate, sawSeq bool
+	for i, m := range matches {
+		switch pattern[m[2]:m[3]] {
+		case "date":
+			sawDate = true
+			f.dateFirst = i == 0
+			if m[4] >= 0 {
+				return TicketNumberFormat{}, fmt.Errorf("%w: {date} takes no width", ErrInvalidTicketNumberFormat)
+			}
+		case "seq":
+			sawSeq = true
+			if m[4] >= 0 {
+				digits, _ := strconv.Atoi(pattern[m[4]:m[5]])
+				if digits < 1 || digits > maxSequenceDigits {
+					return TicketNumberFormat{}, fmt.Errorf("%w: {seq} width must be 1 to %d", ErrInvalidTicketNumberFormat, maxSequenceDigits)
+				}
+				f.digits = digits
+			}
+		}
+	}
+	if !sawDate || !sawSeq {
+		return TicketNumberFormat{}, fmt.Errorf("%w: %q needs one {date} and one {seq} token", ErrInvalidTicketNumberFormat, pattern)
+	}
+
+	f.before = pattern[:matches[0][0]]
+	f.between = pattern[matches[0][1]:matches[1][0]]
+	f.after = pattern[matches[1][1]:]
+	for _, literal := range []string{f.before, f.between, f.after} {
+		if !ticketNumberLiteralRegex.MatchString(literal) {
+			return TicketNumberFormat{}, fmt.Errorf("%w: %q may only contain letters, digits, '-' and '_'", ErrInvalidTicketNumberFormat, literal)
+		}
+	}
+	if len(f.before)+len(f.between)+len(f.after) > maxPatternLiteral {
+		return TicketNumberFormat{}, fmt.Errorf("%w: at most %d characters besides the tokens", ErrInvalidTicketNumberFormat, maxPatternLiteral)
+	}
+	if f.before == "" {
+		return TicketNumberFormat{}, fmt.Errorf("%w: %q must start with a prefix", ErrInvalidTicketNumberFormat, pattern)
+	}
+	if f.between == "" {
+		// Without a separator, day 20261016 with sequence 1 and a wider
+		// sequence on another day could read the same.
+		return TicketNumberFormat{}, fmt.Errorf("%w: %q needs a separator between {date} and {seq}", ErrInvalidTicketNumberFormat, pattern)
+	}
+
+	f.pattern = pattern
+	return f, nil
+}
+
+// DefaultTicketNumberFormat returns the TKT-YYYYMMDD-XXXX format.
+func DefaultTicketNumberFormat() TicketNumberFormat {
+	f, _ := ParseTicketNumberFormat(DefaultTicketNumberPattern)
+	return f
+}
+
+// String returns the pattern.
+func (f TicketNumberFormat) String() string {
+	return f.pattern
+}
+
+// Scope identifies the sequence the format draws from. Formats that differ
+// only in the {seq} width could produce the same numbers, so they share one.
+func (f TicketNumberFormat) Scope() string {
+	if f.dateFirst {
+		return f.before + "{date}" + f.between + "{seq}" + f.after
+	}
+	return f.before + "{seq}" + f.between + "{date}" + f.after
+}
+
+// Format builds the ticket number of the sequence value on the given day.
+func (f TicketNumberFormat) Format(day time.Time, seq int64) TicketNumber {
+	date := day.Format(ticketNumberDateLayout)
+	sequence := fmt.Sprintf("%0*d", f.digits, seq)
+	if f.dateFirst {
+		return TicketNumber{value: f.before + date + f.between + sequence + f.after}
+	}
+	return TicketNumber{value: f.before + sequence + f.between + date + f.after}
+}
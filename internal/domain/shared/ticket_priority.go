@@ -25,6 +25,14 @@ const (
 	SLAHoursUrgent = 4
 )
 
+// Priority first response hours
+const (
+	FirstResponseHoursLow    = 24
+	FirstResponseHoursNormal = 8
+	FirstResponseHoursHigh   = 2
+	FirstResponseHoursUrgent = 1
+)
+
 // ErrInvalidTicketPriority is returned for invalid priorities.
 var ErrInvalidTicketPriority = errors.New("invalid ticket priority")
 
@@ -85,6 +93,27 @@ func (p TicketPriority) SLADuration() time.Duration {
 	return time.Duration(p.SLAHours()) * time.Hour
 }
 
+// FirstResponseHours returns the first response hours for this priority.
+func (p TicketPriority) FirstResponseHours() int {
+	switch p {
+	case PriorityLow:
+		return FirstResponseHoursLow
+	case PriorityNormal:
+		return FirstResponseHoursNormal
+	case PriorityHigh:
+		return FirstResponseHoursHigh
+	case PriorityUrgent:
+		return FirstResponseHoursUrgent
+	default:
+		return FirstResponseHoursNormal
+	}
+}
+
+// FirstResponseDuration returns the first response duration for this priority.
+func (p TicketPriority) FirstResponseDuration() time.Duration {
+	return time.Duration(p.FirstResponseHours()) * time.Hour
+}
+
 // CalculateSLADeadline calculates the SLA deadline from the given start time.
 func (p TicketPriority) CalculateSLADeadline(from time.Time) time.Time {
 	return from.Add(p.SLADuration())
@@ -0,0 +1,399 @@
+package sla
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Calendar entity
+var (
+	ErrCalendarNotFound = errors.New("SLA calendar not found")
+	ErrInvalidCalendar  = errors.New("invalid SLA calendar data")
+	ErrCalendarConflict = errors.New("another SLA calendar already covers this scope")
+	ErrHolidayNotFound  = errors.New("holiday not found")
+)
+
+// DateLayout is the layout of holiday dates.
+const DateLayout = "2006-01-02"
+
+// maxSearchDays bounds how far ahead working time is searched for.
+const maxSearchDays = 3 * 366
+
+// WorkingHours is the working window of one weekday, in minutes after
+// midnight local time. End is exclusive.
+type WorkingHours struct {
+	Weekday time.Weekday
+	Start   int
+	End     int
+}
+
+// Holiday is a non-working date.
+type Holiday struct {
+	Date string
+	Name string
+}
+
+// Calendar defines when the SLA clock runs. A calendar without a category is
+// the global calendar; a category calendar overrides it for that category.
+type Calendar struct {
+	id           uuid.UUID
+	name         string
+	timezone     string
+	location     *time.Location
+	categoryID   *uuid.UUID
+	workingHours []WorkingHours
+	holidays     []Holiday
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// CalendarParams contains parameters for creating a Calendar.
+type CalendarParams struct {
+	ID           uuid.UUID
+	Name         string
+	Timezone     string
+	CategoryID   *uuid.UUID
+	WorkingHours []WorkingHours
+	Holidays     []Holiday
+}
+
+// NewCalendar creates a new Calendar entity.
+func NewCalendar(params CalendarParams) (*Calendar, error) {
+	if params.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	location, err := loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := normalizeWorkingHours(params.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	c := &Calendar{
+		id:           id,
+		name:         params.Name,
+		timezone:     location.String(),
+		location:     location,
+		categoryID:   params.CategoryID,
+		workingHours: hours,
+		holidays:     make([]Holiday, 0),
+		createdAt:    now,
+		updatedAt:    now,
+	}
+	for _, h := range params.Holidays {
+		if err := c.AddHoliday(h.Date, h.Name); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// ReconstituteParams contains the persisted state of a Calendar.
+type ReconstituteParams struct {
+	ID           uuid.UUID
+	Name         string
+	Timezone     string
+	CategoryID   *uuid.UUID
+	WorkingHours []WorkingHours
+	Holidays     []Holiday
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Reconstitute rebuilds a Calendar from persisted state. An unknown
+// timezone falls back to UTC.
+func Reconstitute(params ReconstituteParams) *Calendar {
+	location, err := loadLocation(params.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	hours := append([]WorkingHours(nil), params.WorkingHours...)
+	sortWorkingHours(hours)
+	holidays := append([]Holiday(nil), params.Holidays...)
+	sortHolidays(holidays)
+
+	return &Calendar{
+		id:           params.ID,
+		name:         params.Name,
+		timezone:     location.String(),
+		location:     location,
+		categoryID:   params.CategoryID,
+		workingHours: hours,
+		holidays:     holidays,
+		createdAt:    params.CreatedAt,
+		updatedAt:    params.UpdatedAt,
+	}
+}
+
+// Getters
+func (c *Calendar) ID() uuid.UUID                { return c.id }
+func (c *Calendar) Name() string                 { return c.name }
+func (c *Calendar) Timezone() string             { return c.timezone }
+func (c *Calendar) Location() *time.Location     { return c.location }
+func (c *Calendar) CategoryID() *uuid.UUID       { return c.categoryID }
+func (c *Calendar) WorkingHours() []WorkingHours { return c.workingHours }
+func (c *Calendar) Holidays() []Holiday          { return c.holidays }
+func (c *Calendar) CreatedAt() time.Time         { return c.createdAt }
+func (c *Calendar) UpdatedAt() time.Time         { return c.updatedAt }
+
+// IsGlobal checks if the calendar applies to tickets of every category.
+func (c *Calendar) IsGlobal() bool {
+	return c.categoryID == nil
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the calendar name, timezone and working hours.
+func (c *Calendar) Update(name, timezone string, hours []WorkingHours) error {
+	location, err := loadLocation(timezone)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeWorkingHours(hours)
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		c.name = name
+	}
+	c.timezone = location.String()
+	c.location = location
+	c.workingHours = normalized
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// SetCategory attaches the calendar to a category, or makes it global if nil.
+func (c *Calendar) SetCategory(categoryID *uuid.UUID) {
+	c.categoryID = categoryID
+	c.updatedAt = time.Now()
+}
+
+// AddHoliday adds or renames a holiday on the given YYYY-MM-DD date.
+func (c *Calendar) AddHoliday(date, name string) error {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return fmt.Errorf("%w: holiday date must be YYYY-MM-DD", ErrInvalidCalendar)
+	}
+
+	for i, h := range c.holidays {
+		if h.Date == date {
+			c.holidays[i].Name = name
+			c.updatedAt = time.Now()
+			return nil
+		}
+	}
+	c.holidays = append(c.holidays, Holiday{Date: date, Name: name})
+	sortHolidays(c.holidays)
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveHoliday removes the holiday on the given date.
+func (c *Calendar) RemoveHoliday(date string) error {
+	for i, h := range c.holidays {
+		if h.Date == date {
+			c.holidays = append(c.holidays[:i], c.holidays[i+1:]...)
+			c.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrHolidayNotFound
+}
+
+// IsHoliday checks if the given time falls on a holiday in the calendar's timezone.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	date := t.In(c.location).Format(DateLayout)
+	for _, h := range c.holidays {
+		if h.Date == date {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWorkingTime checks if the SLA clock runs at the given time.
+func (c *Calendar) IsWorkingTime(t time.Time) bool {
+	local := t.In(c.location)
+	if c.IsHoliday(local) {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	for _, wh := range c.workingHours {
+		if wh.Weekday == local.Weekday() && minute >= wh.Start && minute < wh.End {
+			return true
+		}
+	}
+	return false
+}
+
+// AddWorkingTime returns the moment at which d of working time has elapsed
+// after from, skipping nights, weekends and holidays.
+func (c *Calendar) AddWorkingTime(from time.Time, d time.Duration) time.Time {
+	if len(c.workingHours) == 0 {
+		return from.Add(d)
+	}
+
+	remaining := d
+	cursor := from.In(c.location)
+	day := startOfDay(cursor)
+	for i := 0; i < maxSearchDays; i++ {
+		for _, window := range c.windows(day) {
+			start, end := window[0], window[1]
+			if !end.After(cursor) {
+				continue
+			}
+			if start.Before(cursor) {
+				start = cursor
+			}
+			available := end.Sub(start)
+			if remaining <= available {
+				return start.Add(remaining).In(from.Location())
+			}
+			remaining -= available
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	// No working time left in the search horizon; fall back to wall-clock.
+	return from.Add(d)
+}
+
+// WorkingTimeBetween returns how much working time elapses between from and to.
+func (c *Calendar) WorkingTimeBetween(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if len(c.workingHours) == 0 {
+		return to.Sub(from)
+	}
+
+	var elapsed time.Duration
+	cursor := from.In(c.location)
+	for day := startOfDay(cursor); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, window := range c.windows(day) {
+			start, end := window[0], window[1]
+			if start.Before(cursor) {
+				start = cursor
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				elapsed += end.Sub(start)
+			}
+		}
+	}
+	return elapsed
+}
+
+// windows returns the working intervals of the given local day.
+func (c *Calendar) windows(day time.Time) [][2]time.Time {
+	if c.IsHoliday(day) {
+		return nil
+	}
+	windows := make([][2]time.Time, 0, 1)
+	for _, wh := range c.workingHours {
+		if wh.Weekday != day.Weekday() {
+			continue
+		}
+		y, m, d := day.Date()
+		start := time.Date(y, m, d, wh.Start/60, wh.Start%60, 0, 0, c.location)
+		end := time.Date(y, m, d, wh.End/60, wh.End%60, 0, 0, c.location)
+		windows = append(windows, [2]time.Time{start, end})
+	}
+	return windows
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidCalendar, timezone)
+	}
+	return location, nil
+}
+
+func normalizeWorkingHours(hours []WorkingHours) ([]WorkingHours, error) {
+	if len(hours) == 0 {
+		return nil, fmt.Errorf("%w: at least one working window is required", ErrInvalidCalendar)
+	}
+
+	normalized := append([]WorkingHours(nil), hours...)
+	sortWorkingHours(normalized)
+	for i, wh := range normalized {
+		if wh.Weekday < time.Sunday || wh.Weekday > time.Saturday {
+			return nil, fmt.Errorf("%w: invalid weekday", ErrInvalidCalendar)
+		}
+		if wh.Start < 0 || wh.End > 24*60 || wh.Start >= wh.End {
+			return nil, fmt.Errorf("%w: working hours on %s must start before they end", ErrInvalidCalendar, wh.Weekday)
+		}
+		if i > 0 && normalized[i-1].Weekday == wh.Weekday && normalized[i-1].End > wh.Start {
+			return nil, fmt.Errorf("%w: working hours on %s overlap", ErrInvalidCalendar, wh.Weekday)
+		}
+	}
+	return normalized, nil
+}
+
+func sortWorkingHours(hours []WorkingHours) {
+	sort.Slice(hours, func(i, j int) bool {
+		if hours[i].Weekday != hours[j].Weekday {
+			return hours[i].Weekday < hours[j].Weekday
+		}
+		return hours[i].Start < hours[j].Start
+	})
+}
+
+func sortHolidays(holidays []Holiday) {
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+}
+
+// ParseWeekday parses an English weekday name such as "monday" or "Mon".
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown weekday %q", ErrInvalidCalendar, s)
+}
+
+// ParseClock parses an HH:MM time of day into minutes after midnight.
+// "24:00" is accepted as the end of the day.
+func ParseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("%w: time of day must be HH:MM", ErrInvalidCalendar)
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock formats minutes after midnight as HH:MM.
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
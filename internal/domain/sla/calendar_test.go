@@ -0,0 +1,103 @@
+package sla
+
+import (
+	"testing"
+	"time"
+)
+
+// at returns a time in October 2026. The 16th is a Friday.
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+}
+
+// officeCalendar works 09:00 to 17:00 on weekdays, with a holiday on
+// Monday the 26th.
+func officeCalendar(t *testing.T) *Calendar {
+	t.Helper()
+	hours := make([]WorkingHours, 0, 5)
+	for d := time.Monday; d <= time.Friday; d++ {
+		hours = append(hours, WorkingHours{Weekday: d, Start: 9 * 60, End: 17 * 60})
+	}
+	c, err := NewCalendar(CalendarParams{
+		Name:         "Office",
+		Timezone:     "UTC",
+		WorkingHours: hours,
+		Holidays:     []Holiday{{Date: "2026-10-26", Name: "Bank holiday"}},
+	})
+	if err != nil {
+		t.Fatalf("NewCalendar: %v", err)
+	}
+	return c
+}
+
+func TestAddWorkingTime(t *testing.T) {
+	office := officeCalendar(t)
+	// One minute of working time a week
+	sparse, err := NewCalendar(CalendarParams{
+		Name:         "Sparse",
+		WorkingHours: []WorkingHours{{Weekday: time.Monday, Start: 9 * 60, End: 9*60 + 1}},
+	})
+	if err != nil {
+		t.Fatalf("NewCalendar: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		calendar *Calendar
+		from     time.Time
+		d        time.Duration
+		want     time.Time
+	}{
+		{name: "within the day", calendar: office, from: at(15, 10, 0), d: 2 * time.Hour, want: at(15, 12, 0)},
+		{name: "up to closing time", calendar: office, from: at(15, 15, 0), d: 2 * time.Hour, want: at(15, 17, 0)},
+		{name: "overnight", calendar: office, from: at(15, 16, 0), d: 2 * time.Hour, want: at(16, 10, 0)},
+		{name: "over the weekend", calendar: office, from: at(16, 16, 0), d: 2 * time.Hour, want: at(19, 10, 0)},
+		{name: "over a weekend and a holiday", calendar: office, from: at(23, 16, 0), d: 2 * time.Hour, want: at(27, 10, 0)},
+		{name: "across several days", calendar: office, from: at(15, 9, 0), d: 20 * time.Hour, want: at(19, 13, 0)},
+		{name: "before opening", calendar: office, from: at(15, 6, 0), d: time.Hour, want: at(15, 10, 0)},
+		{name: "after closing", calendar: office, from: at(15, 20, 0), d: time.Hour, want: at(16, 10, 0)},
+		{name: "on a Saturday", calendar: office, from: at(17, 12, 0), d: time.Hour, want: at(19, 10, 0)},
+		{name: "on a holiday", calendar: office, from: at(26, 12, 0), d: time.Hour, want: at(27, 10, 0)},
+		{name: "beyond the search horizon", calendar: sparse, from: at(15, 10, 0), d: 1000 * time.Hour, want: at(15, 10, 0).Add(1000 * time.Hour)},
+		{name: "without a calendar", from: at(16, 16, 0), d: 2 * time.Hour, want: at(16, 18, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddWorkingTime(tt.calendar, tt.from, tt.d); !got.Equal(tt.want) {
+				t.Fatalf("AddWorkingTime = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingTimeBetween(t *testing.T) {
+	office := officeCalendar(t)
+
+	tests := []struct {
+		name     string
+		calendar *Calendar
+		from, to time.Time
+		want     time.Duration
+	}{
+		{name: "within the day", calendar: office, from: at(15, 10, 0), to: at(15, 12, 0), want: 2 * time.Hour},
+		{name: "overnight", calendar: office, from: at(15, 16, 0), to: at(16, 10, 0), want: 2 * time.Hour},
+		{name: "over the weekend", calendar: office, from: at(16, 16, 0), to: at(19, 10, 0), want: 2 * time.Hour},
+		{name: "over a weekend and a holiday", calendar: office, from: at(23, 16, 0), to: at(27, 10, 0), want: 2 * time.Hour},
+		{name: "a whole week", calendar: office, from: at(19, 0, 0), to: at(26, 0, 0), want: 40 * time.Hour},
+		{name: "outside working hours", calendar: office, from: at(15, 18, 0), to: at(16, 8, 0)},
+		{name: "over a weekend only", calendar: office, from: at(17, 0, 0), to: at(19, 0, 0)},
+		{name: "from before opening", calendar: office, from: at(15, 6, 0), to: at(15, 10, 0), want: time.Hour},
+		{name: "to after closing", calendar: office, from: at(15, 16, 0), to: at(15, 20, 0), want: time.Hour},
+		{name: "backwards", calendar: office, from: at(15, 12, 0), to: at(15, 10, 0)},
+		{name: "without a calendar", from: at(16, 16, 0), to: at(19, 10, 0), want: 66 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorkingTimeBetween(tt.calendar, tt.from, tt.to); got != tt.want {
+				t.Fatalf("WorkingTimeBetween = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
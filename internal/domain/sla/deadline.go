@@ -0,0 +1,24 @@
+package sla
+
+import "time"
+
+// AddWorkingTime adds d of working time to from using the calendar, or
+// wall-clock time when no calendar applies.
+func AddWorkingTime(c *Calendar, from time.Time, d time.Duration) time.Time {
+	if c == nil {
+		return from.Add(d)
+	}
+	return c.AddWorkingTime(from, d)
+}
+
+// WorkingTimeBetween measures working time between from and to using the
+// calendar, or wall-clock time when no calendar applies.
+func WorkingTimeBetween(c *Calendar, from, to time.Time) time.Duration {
+	if c == nil {
+		if to.After(from) {
+			return to.Sub(from)
+		}
+		return 0
+	}
+	return c.WorkingTimeBetween(from, to)
+}
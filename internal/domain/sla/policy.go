@@ -0,0 +1,154 @@
+package sla
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Domain errors for Policy entity
+var (
+	ErrPolicyNotFound = errors.New("SLA policy not found")
+	ErrInvalidPolicy  = errors.New("invalid SLA policy data")
+	ErrPolicyConflict = errors.New("another SLA policy already covers this priority and category")
+)
+
+// Targets are the working time allowed until the first agent response and
+// until resolution.
+type Targets struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+// DefaultTargets returns the built-in targets of a priority, used when no
+// policy applies.
+func DefaultTargets(priority shared.TicketPriority) Targets {
+	return Targets{
+		FirstResponse: priority.FirstResponseDuration(),
+		Resolution:    priority.SLADuration(),
+	}
+}
+
+// Policy sets the SLA targets of one priority. A policy without a category
+// applies to every category that has no policy of its own.
+type Policy struct {
+	id         uuid.UUID
+	name       string
+	priority   shared.TicketPriority
+	categoryID *uuid.UUID
+	targets    Targets
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// PolicyParams contains parameters for creating a Policy.
+type PolicyParams struct {
+	ID         uuid.UUID
+	Name       string
+	Priority   string
+	CategoryID *uuid.UUID
+	Targets    Targets
+}
+
+// NewPolicy creates a new Policy entity.
+func NewPolicy(params PolicyParams) (*Policy, error) {
+	if params.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	priority, err := shared.ParseTicketPriority(params.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTargets(params.Targets); err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Policy{
+		id:         id,
+		name:       params.Name,
+		priority:   priority,
+		categoryID: params.CategoryID,
+		targets:    params.Targets,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// PolicyReconstituteParams contains the persisted state of a Policy.
+type PolicyReconstituteParams struct {
+	ID         uuid.UUID
+	Name       string
+	Priority   string
+	CategoryID *uuid.UUID
+	Targets    Targets
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ReconstitutePolicy rebuilds a Policy from persisted state.
+func ReconstitutePolicy(params PolicyReconstituteParams) *Policy {
+	return &Policy{
+		id:         params.ID,
+		name:       params.Name,
+		priority:   shared.TicketPriority(params.Priority),
+		categoryID: params.CategoryID,
+		targets:    params.Targets,
+		createdAt:  params.CreatedAt,
+		updatedAt:  params.UpdatedAt,
+	}
+}
+
+// Getters
+func (p *Policy) ID() uuid.UUID                   { return p.id }
+func (p *Policy) Name() string                    { return p.name }
+func (p *Policy) Priority() shared.TicketPriority { return p.priority }
+func (p *Policy) CategoryID() *uuid.UUID          { return p.categoryID }
+func (p *Policy) Targets() Targets                { return p.targets }
+func (p *Policy) CreatedAt() time.Time            { return p.createdAt }
+func (p *Policy) UpdatedAt() time.Time            { return p.updatedAt }
+
+// IsGlobal checks if the policy applies to tickets of every category.
+func (p *Policy) IsGlobal() bool {
+	return p.categoryID == nil
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the policy name, scope and targets.
+func (p *Policy) Update(name, priority string, categoryID *uuid.UUID, targets Targets) error {
+	parsed, err := shared.ParseTicketPriority(priority)
+	if err != nil {
+		return err
+	}
+	if err := validateTargets(targets); err != nil {
+		return err
+	}
+
+	if name != "" {
+		p.name = name
+	}
+	p.priority = parsed
+	p.categoryID = categoryID
+	p.targets = targets
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func validateTargets(targets Targets) error {
+	if targets.FirstResponse <= 0 || targets.Resolution <= 0 {
+		return fmt.Errorf("%w: first response and resolution targets must be positive", ErrInvalidPolicy)
+	}
+	if targets.FirstResponse > targets.Resolution {
+		return fmt.Errorf("%w: first response target cannot exceed the resolution target", ErrInvalidPolicy)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package sla
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Repository is the persistence port for SLA calendars.
+type Repository interface {
+	// FindByID loads a calendar. Returns ErrCalendarNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Calendar, error)
+
+	// FindForCategory returns the calendar attached to the category, falling
+	// back to the global calendar. A nil category only matches the global
+	// calendar. Returns ErrCalendarNotFound if neither exists.
+	FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*Calendar, error)
+
+	// List returns all calendars, the global one first.
+	List(ctx context.Context) ([]*Calendar, error)
+
+	// Save creates or updates a calendar with its holidays. Returns
+	// ErrCalendarConflict if another calendar has the same scope.
+	Save(ctx context.Context, calendar *Calendar) error
+
+	// Delete removes a calendar. Returns ErrCalendarNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// PolicyRepository is the persistence port for SLA policies.
+type PolicyRepository interface {
+	// FindByID loads a policy. Returns ErrPolicyNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Policy, error)
+
+	// FindForTicket returns the policy of the priority attached to the
+	// category, falling back to the global policy of the priority. Returns
+	// ErrPolicyNotFound if neither exists.
+	FindForTicket(ctx context.Context, categoryID *uuid.UUID, priority shared.TicketPriority) (*Policy, error)
+
+	// List returns all policies, global ones first.
+	List(ctx context.Context) ([]*Policy, error)
+
+	// Save creates or updates a policy. Returns ErrPolicyConflict if another
+	// policy has the same priority and category.
+	Save(ctx context.Context, policy *Policy) error
+
+	// Delete removes a policy. Returns ErrPolicyNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
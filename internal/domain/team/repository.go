@@ -0,0 +1,32 @@
+package team
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for teams.
+type Repository interface {
+	// FindByID loads a team. Returns ErrTeamNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Team, error)
+
+	// FindByKey loads a team by its key. Returns ErrTeamNotFound if none
+	// exists.
+	FindByKey(ctx context.Context, key string) (*Team, error)
+
+	// FindForCategory returns the team new tickets of the category go to.
+	// Returns ErrTeamNotFound if no team has the category.
+	FindForCategory(ctx context.Context, categoryID uuid.UUID) (*Team, error)
+
+	// List returns all teams ordered by name.
+	List(ctx context.Context) ([]*Team, error)
+
+	// Save creates or updates a team with its categories. Returns
+	// ErrTeamConflict if another team has the key or one of the categories.
+	Save(ctx context.Context, team *Team) error
+
+	// Delete removes a team with its categories. Returns ErrTeamNotFound if
+	// none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,139 @@
+// Package team groups agents into teams that share a ticket queue.
+package team
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Team aggregate
+var (
+	ErrTeamNotFound = errors.New("team not found")
+	ErrInvalidTeam  = errors.New("invalid team data")
+	ErrTeamConflict = errors.New("another team already uses this key or category")
+)
+
+// keyPattern matches team keys, which agents list their teams by.
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// Team is the aggregate root for support teams, e.g. "Billing" or
+// "Logistics". New tickets in one of the team's categories go to its queue.
+// Membership is held by the agents, who list the keys of their teams.
+type Team struct {
+	id          uuid.UUID
+	key         string
+	name        string
+	description string
+	categoryIDs []uuid.UUID
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// TeamParams contains parameters for creating a Team.
+type TeamParams struct {
+	ID          uuid.UUID
+	Key         string
+	Name        string
+	Description string
+	CategoryIDs []uuid.UUID
+}
+
+// NewTeam creates a new Team aggregate. The key cannot be changed later.
+func NewTeam(params TeamParams) (*Team, error) {
+	key := strings.ToLower(strings.TrimSpace(params.Key))
+	if !keyPattern.MatchString(key) {
+		return nil, fmt.Errorf("%w: key %q must be 2-50 lowercase letters, digits, dashes or underscores", ErrInvalidTeam, params.Key)
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, errors.Join(ErrInvalidTeam, errors.New("name is required"))
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Team{
+		id:          id,
+		key:         key,
+		name:        strings.TrimSpace(params.Name),
+		description: params.Description,
+		categoryIDs: uniqueIDs(params.CategoryIDs),
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of a Team.
+type ReconstituteParams struct {
+	ID          uuid.UUID
+	Key         string
+	Name        string
+	Description string
+	CategoryIDs []uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Reconstitute rebuilds a Team from persisted state.
+func Reconstitute(params ReconstituteParams) *Team {
+	return &Team{
+		id:          params.ID,
+		key:         params.Key,
+		name:        params.Name,
+		description: params.Description,
+		categoryIDs: uniqueIDs(params.CategoryIDs),
+		createdAt:   params.CreatedAt,
+		updatedAt:   params.UpdatedAt,
+	}
+}
+
+// Getters
+func (t *Team) ID() uuid.UUID            { return t.id }
+func (t *Team) Key() string              { return t.key }
+func (t *Team) Name() string             { return t.name }
+func (t *Team) Description() string      { return t.description }
+func (t *Team) CategoryIDs() []uuid.UUID { return t.categoryIDs }
+func (t *Team) CreatedAt() time.Time     { return t.createdAt }
+func (t *Team) UpdatedAt() time.Time     { return t.updatedAt }
+
+// --- Behavior Methods ---
+
+// Update updates the team's name and description.
+func (t *Team) Update(name, description string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.Join(ErrInvalidTeam, errors.New("name is required"))
+	}
+	t.name = strings.TrimSpace(name)
+	t.description = description
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// SetCategories sets the categories whose new tickets go to the team.
+func (t *Team) SetCategories(categoryIDs []uuid.UUID) {
+	t.categoryIDs = uniqueIDs(categoryIDs)
+	t.updatedAt = time.Now()
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].String() < unique[j].String()
+	})
+	return unique
+}
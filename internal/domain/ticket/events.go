@@ -44,15 +44,36 @@ func NewTicketCreatedEvent(ticketID uuid.UUID, ticketNumber, subject string) Tic
 type TicketAssignedEvent struct {
 	baseEvent
 	AgentID uuid.UUID
+	Reason  string
+	Auto    bool
 }
 
 func (e TicketAssignedEvent) EventType() string { return "ticket.assigned" }
 
 // NewTicketAssignedEvent creates a new TicketAssignedEvent.
-func NewTicketAssignedEvent(ticketID, agentID uuid.UUID) TicketAssignedEvent {
+func NewTicketAssignedEvent(ticketID, agentID uuid.UUID, reason string, auto bool) TicketAssignedEvent {
 	return TicketAssignedEvent{
 		baseEvent: baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
 		AgentID:   agentID,
+		Reason:    reason,
+		Auto:      auto,
+	}
+}
+
+// TicketTeamChangedEvent is raised when a ticket moves to another team's
+// queue. TeamID is nil when the ticket left all queues.
+type TicketTeamChangedEvent struct {
+	baseEvent
+	TeamID *uuid.UUID
+}
+
+func (e TicketTeamChangedEvent) EventType() string { return "ticket.team_changed" }
+
+// NewTicketTeamChangedEvent creates a new TicketTeamChangedEvent.
+func NewTicketTeamChangedEvent(ticketID uuid.UUID, teamID *uuid.UUID) TicketTeamChangedEvent {
+	return TicketTeamChangedEvent{
+		baseEvent: baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		TeamID:    teamID,
 	}
 }
 
@@ -72,6 +93,26 @@ func NewTicketStatusChangedEvent(ticketID uuid.UUID, newStatus string) TicketSta
 	}
 }
 
+// MessageAddedEvent is raised when a message is added to a ticket.
+type MessageAddedEvent struct {
+	baseEvent
+	MessageID  uuid.UUID
+	SenderType string
+	IsInternal bool
+}
+
+func (e MessageAddedEvent) EventType() string { return "ticket.message_added" }
+
+// NewMessageAddedEvent creates a new MessageAddedEvent.
+func NewMessageAddedEvent(ticketID, messageID uuid.UUID, senderType string, isInternal bool) MessageAddedEvent {
+	return MessageAddedEvent{
+		baseEvent:  baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		MessageID:  messageID,
+		SenderType: senderType,
+		IsInternal: isInternal,
+	}
+}
+
 // TicketEscalatedEvent is raised when a ticket is escalated.
 type TicketEscalatedEvent struct {
 	baseEvent
@@ -120,18 +161,124 @@ func NewTicketClosedEvent(ticketID uuid.UUID) TicketClosedEvent {
 	}
 }
 
+// SLA clocks named by SLA warning and breach events.
+const (
+	SLAClockFirstResponse = "first_response"
+	SLAClockResolution    = "resolution"
+)
+
 // TicketSLABreachedEvent is raised when SLA is breached.
 type TicketSLABreachedEvent struct {
 	baseEvent
+	// Clock is SLAClockFirstResponse or SLAClockResolution.
+	Clock    string
 	Deadline time.Time
 }
 
 func (e TicketSLABreachedEvent) EventType() string { return "ticket.sla_breached" }
 
 // NewTicketSLABreachedEvent creates a new TicketSLABreachedEvent.
-func NewTicketSLABreachedEvent(ticketID uuid.UUID, deadline time.Time) TicketSLABreachedEvent {
+func NewTicketSLABreachedEvent(ticketID uuid.UUID, clock string, deadline time.Time) TicketSLABreachedEvent {
 	return TicketSLABreachedEvent{
 		baseEvent: baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		Clock:     clock,
 		Deadline:  deadline,
 	}
 }
+
+// TicketSLAWarningEvent is raised when the SLA deadline is approaching.
+type TicketSLAWarningEvent struct {
+	baseEvent
+	// Clock is SLAClockFirstResponse or SLAClockResolution.
+	Clock    string
+	Deadline time.Time
+}
+
+func (e TicketSLAWarningEvent) EventType() string { return "ticket.sla_warning" }
+
+// NewTicketSLAWarningEvent creates a new TicketSLAWarningEvent.
+func NewTicketSLAWarningEvent(ticketID uuid.UUID, clock string, deadline time.Time) TicketSLAWarningEvent {
+	return TicketSLAWarningEvent{
+		baseEvent: baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		Clock:     clock,
+		Deadline:  deadline,
+	}
+}
+
+// TicketPendingReminderEvent is raised when the customer of a pending
+// ticket is reminded that it waits on them.
+type TicketPendingReminderEvent struct {
+	baseEvent
+	PendingSince time.Time
+}
+
+func (e TicketPendingReminderEvent) EventType() string { return "ticket.pending_reminder" }
+
+// NewTicketPendingReminderEvent creates a new TicketPendingReminderEvent.
+func NewTicketPendingReminderEvent(ticketID uuid.UUID, pendingSince time.Time) TicketPendingReminderEvent {
+	return TicketPendingReminderEvent{
+		baseEvent:    baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		PendingSince: pendingSince,
+	}
+}
+
+// TicketStaleEvent is raised when an in-progress ticket is flagged because
+// agents have left it idle.
+type TicketStaleEvent struct {
+	baseEvent
+	IdleSince time.Time
+}
+
+func (e TicketStaleEvent) EventType() string { return "ticket.stale" }
+
+// NewTicketStaleEvent creates a new TicketStaleEvent.
+func NewTicketStaleEvent(ticketID uuid.UUID, idleSince time.Time) TicketStaleEvent {
+	return TicketStaleEvent{
+		baseEvent: baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		IdleSince: idleSince,
+	}
+}
+
+// TicketMergedEvent is raised on the target when tickets are merged into it.
+type TicketMergedEvent struct {
+	baseEvent
+	SourceIDs     []uuid.UUID
+	SourceNumbers []string
+	MergedBy      *uuid.UUID
+	Forced        bool
+}
+
+func (e TicketMergedEvent) EventType() string { return "ticket.merged" }
+
+// NewTicketMergedEvent creates a new TicketMergedEvent.
+func NewTicketMergedEvent(ticketID uuid.UUID, sourceIDs []uuid.UUID, sourceNumbers []string, mergedBy *uuid.UUID, forced bool) TicketMergedEvent {
+	return TicketMergedEvent{
+		baseEvent:     baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		SourceIDs:     sourceIDs,
+		SourceNumbers: sourceNumbers,
+		MergedBy:      mergedBy,
+		Forced:        forced,
+	}
+}
+
+// AgentMentionedEvent is raised when an internal note mentions an agent.
+type AgentMentionedEvent struct {
+	baseEvent
+	MessageID  uuid.UUID
+	AgentID    uuid.UUID
+	AgentName  string
+	AgentEmail string
+}
+
+func (e AgentMentionedEvent) EventType() string { return "ticket.agent_mentioned" }
+
+// NewAgentMentionedEvent creates a new AgentMentionedEvent.
+func NewAgentMentionedEvent(ticketID, messageID, agentID uuid.UUID, agentName, agentEmail string) AgentMentionedEvent {
+	return AgentMentionedEvent{
+		baseEvent:  baseEvent{occurredAt: time.Now(), aggregateID: ticketID},
+		MessageID:  messageID,
+		AgentID:    agentID,
+		AgentName:  agentName,
+		AgentEmail: agentEmail,
+	}
+}
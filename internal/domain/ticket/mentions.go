@@ -0,0 +1,22 @@
+package ticket
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MentionAgent records that an internal note of the ticket mentions the
+// agent, so the agent can be told about it.
+func (t *Ticket) MentionAgent(messageID, agentID uuid.UUID, agentName, agentEmail string) error {
+	msg, ok := t.findMessage(messageID)
+	if !ok {
+		return fmt.Errorf("%w: message %s is not on the ticket", ErrInvalidTicket, messageID)
+	}
+	if !msg.IsInternal() {
+		return fmt.Errorf("%w: only internal notes can mention agents", ErrInvalidTicket)
+	}
+
+	t.addEvent(NewAgentMentionedEvent(t.id, messageID, agentID, agentName, agentEmail))
+	return nil
+}
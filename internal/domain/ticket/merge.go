@@ -0,0 +1,108 @@
+package ticket
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Merge combines duplicate tickets into target. The messages of the sources
+// move to target and their tags and watchers are added to it. Their status
+// history stays with them, since it times their own SLA clocks, not the one
+// of target. The sources are then closed, regardless of their workflow,
+// with a note pointing at target, and lookups of them lead to target.
+// Unless force is set, every source must belong to the customer of target.
+// Target raises TicketMergedEvent.
+func Merge(target *Ticket, sources []*Ticket, changedBy *uuid.UUID, changedByName string, force bool) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("%w: no tickets to merge", ErrCannotMerge)
+	}
+	if target.mergedInto != nil || target.isFrozen() {
+		return fmt.Errorf("%w: ticket %s is closed", ErrCannotMerge, target.ticketNumber.Value())
+	}
+	for _, source := range sources {
+		switch {
+		case source.id == target.id:
+			return fmt.Errorf("%w: a ticket cannot be merged into itself", ErrCannotMerge)
+		case source.mergedInto != nil:
+			return fmt.Errorf("%w: ticket %s is already merged", ErrCannotMerge, source.ticketNumber.Value())
+		case source.isFrozen():
+			return fmt.Errorf("%w: ticket %s is closed", ErrCannotMerge, source.ticketNumber.Value())
+		case !force && !sameCustomer(target, source):
+			return fmt.Errorf("%w: %s and %s", ErrCustomerMismatch, target.ticketNumber.Value(), source.ticketNumber.Value())
+		}
+	}
+
+	now := time.Now()
+	numbers := make([]string, 0, len(sources))
+	ids := make([]uuid.UUID, 0, len(sources))
+	for _, source := range sources {
+		for _, msg := range source.messages {
+			msg.ticketID = target.id
+			target.messages = append(target.messages, msg)
+		}
+		for _, tag := range source.tags {
+			target.AddTag(tag)
+		}
+		for _, id := range source.watchers {
+			target.Watch(id)
+		}
+		source.closeMerged(target, changedBy, changedByName, now)
+
+		numbers = append(numbers, source.ticketNumber.Value())
+		ids = append(ids, source.id)
+	}
+
+	sort.SliceStable(target.messages, func(i, j int) bool {
+		return target.messages[i].createdAt.Before(target.messages[j].createdAt)
+	})
+
+	note := NewMessage(MessageParams{
+		TicketID:   target.id,
+		SenderType: string(shared.SenderSystem),
+		SenderName: SystemActor,
+		Content:    fmt.Sprintf("Merged %s into this ticket", strings.Join(numbers, ", ")),
+		IsInternal: true,
+	})
+	if err := target.AddMessage(note); err != nil {
+		return err
+	}
+
+	target.addEvent(NewTicketMergedEvent(target.id, ids, numbers, changedBy, force))
+	return nil
+}
+
+// closeMerged closes a ticket merged into target. Its messages now belong
+// to target; its status history stays and ends with the closing entry.
+func (t *Ticket) closeMerged(target *Ticket, changedBy *uuid.UUID, changedByName string, now time.Time) {
+	history := NewStatusHistory(t.id, t.status, shared.StatusClosed, changedBy,
+		fmt.Sprintf("Merged into %s", target.ticketNumber.Value()))
+	history.SetChangedByName(changedByName)
+
+	t.messages = make([]Message, 0)
+	t.statusHistory = append(t.statusHistory, history)
+	t.status = shared.StatusClosed
+	t.mergedInto = &target.id
+	t.closedAt = &now
+	t.updatedAt = now
+	t.touch(now)
+
+	t.addEvent(NewTicketStatusChangedEvent(t.id, string(shared.StatusClosed)))
+	t.addEvent(NewTicketClosedEvent(t.id))
+}
+
+// sameCustomer checks if two tickets were opened by the same registered
+// customer or from the same email address. A registered customer's address
+// is the one set by UseAccount, so their account tickets match the guest
+// tickets they sent from it.
+func sameCustomer(a, b *Ticket) bool {
+	if a.customerID != nil && b.customerID != nil {
+		return *a.customerID == *b.customerID
+	}
+	email := a.ContactEmail()
+	return email != "" && strings.EqualFold(email, b.ContactEmail())
+}
@@ -0,0 +1,183 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+func mergeTicket(t *testing.T, seq int, customerID *uuid.UUID, guestEmail string) *Ticket {
+	t.Helper()
+	tk, err := NewTicket(TicketParams{
+		TicketNumber: fmt.Sprintf("TKT-20261016-%04d", seq),
+		CustomerID:   customerID,
+		GuestEmail:   guestEmail,
+		Subject:      "Where is my order?",
+	})
+	if err != nil {
+		t.Fatalf("NewTicket: %v", err)
+	}
+	return tk
+}
+
+func TestMergeCustomerCheck(t *testing.T) {
+	account := uuid.New()
+	other := uuid.New()
+
+	tests := []struct {
+		name    string
+		target  func(t *testing.T) *Ticket
+		source  func(t *testing.T) *Ticket
+		force   bool
+		wantErr error
+	}{
+		{
+			name:   "same account",
+			target: func(t *testing.T) *Ticket { return mergeTicket(t, 1, &account, "") },
+			source: func(t *testing.T) *Ticket { return mergeTicket(t, 2, &account, "") },
+		},
+		{
+			name:   "guest tickets from the same address",
+			target: func(t *testing.T) *Ticket { return mergeTicket(t, 1, nil, "jane@example.com") },
+			source: func(t *testing.T) *Ticket { return mergeTicket(t, 2, nil, "Jane@Example.com") },
+		},
+		{
+			name: "account ticket and guest ticket from the account's address",
+			target: func(t *testing.T) *Ticket {
+				tk := mergeTicket(t, 1, &account, "")
+				tk.UseAccount("jane@example.com", "Jane")
+				return tk
+			},
+			source: func(t *testing.T) *Ticket { return mergeTicket(t, 2, nil, "jane@example.com") },
+		},
+		{
+			name:   "guest ticket into an account ticket from its address",
+			target: func(t *testing.T) *Ticket { return mergeTicket(t, 1, nil, "jane@example.com") },
+			source: func(t *testing.T) *Ticket {
+				tk := mergeTicket(t, 2, &account, "")
+				tk.UseAccount("jane@example.com", "Jane")
+				return tk
+			},
+		},
+		{
+			name: "account ticket and guest ticket from another address",
+			target: func(t *testing.T) *Ticket {
+				tk := mergeTicket(t, 1, &account, "")
+				tk.UseAccount("jane@example.com", "Jane")
+				return tk
+			},
+			source:  func(t *testing.T) *Ticket { return mergeTicket(t, 2, nil, "john@example.com") },
+			wantErr: ErrCustomerMismatch,
+		},
+		{
+			name:    "account ticket without a known address",
+			target:  func(t *testing.T) *Ticket { return mergeTicket(t, 1, &account, "") },
+			source:  func(t *testing.T) *Ticket { return mergeTicket(t, 2, nil, "jane@example.com") },
+			wantErr: ErrCustomerMismatch,
+		},
+		{
+			name: "different accounts with the same address",
+			target: func(t *testing.T) *Ticket {
+				tk := mergeTicket(t, 1, &account, "")
+				tk.UseAccount("jane@example.com", "Jane")
+				return tk
+			},
+			source: func(t *testing.T) *Ticket {
+				tk := mergeTicket(t, 2, &other, "")
+				tk.UseAccount("jane@example.com", "Jane")
+				return tk
+			},
+			wantErr: ErrCustomerMismatch,
+		},
+		{
+			name:   "forced across customers",
+			target: func(t *testing.T) *Ticket { return mergeTicket(t, 1, &account, "") },
+			source: func(t *testing.T) *Ticket { return mergeTicket(t, 2, &other, "") },
+			force:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, source := tt.target(t), tt.source(t)
+			err := Merge(target, []*Ticket{source}, nil, "agent@example.com", tt.force)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && source.MergedInto() == nil {
+				t.Fatal("source was not merged")
+			}
+		})
+	}
+}
+
+func TestMergeKeepsTargetResolutionClock(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	account := uuid.New()
+	targets := sla.Targets{FirstResponse: time.Hour, Resolution: 24 * time.Hour}
+
+	target := Reconstitute(ReconstituteParams{
+		ID:                  uuid.New(),
+		TicketNumber:        "TKT-20261016-0001",
+		CustomerID:          &account,
+		Subject:             "Where is my order?",
+		Status:              string(shared.StatusOpen),
+		Priority:            string(shared.PriorityNormal),
+		FirstResponseTarget: targets.FirstResponse,
+		ResolutionTarget:    targets.Resolution,
+		CreatedAt:           now.Add(-2 * time.Hour),
+		UpdatedAt:           now.Add(-2 * time.Hour),
+	})
+	sourceID := uuid.New()
+	history := func(from, to shared.TicketStatus, at time.Time) StatusHistory {
+		return ReconstituteStatusHistory(StatusHistoryParams{
+			ID:         uuid.New(),
+			TicketID:   sourceID,
+			FromStatus: string(from),
+			ToStatus:   string(to),
+			CreatedAt:  at,
+		})
+	}
+	source := Reconstitute(ReconstituteParams{
+		ID:                  sourceID,
+		TicketNumber:        "TKT-20261016-0002",
+		CustomerID:          &account,
+		Subject:             "Order still missing",
+		Status:              string(shared.StatusOpen),
+		Priority:            string(shared.PriorityNormal),
+		FirstResponseTarget: targets.FirstResponse,
+		ResolutionTarget:    targets.Resolution,
+		StatusHistory: []StatusHistory{
+			history(shared.StatusOpen, shared.StatusPending, now.Add(-4*time.Hour)),
+			history(shared.StatusPending, shared.StatusOpen, now.Add(-time.Hour)),
+		},
+		CreatedAt: now.Add(-5 * time.Hour),
+		UpdatedAt: now.Add(-time.Hour),
+	})
+
+	target.RefreshSLA(nil, now)
+	before := *target.SLADeadline()
+
+	if err := Merge(target, []*Ticket{source}, nil, "agent@example.com", false); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	target.RefreshSLA(nil, now)
+
+	if got := target.SLADeadline(); got == nil || !got.Equal(before) {
+		t.Fatalf("resolution deadline = %v, want %v", got, before)
+	}
+	if len(target.StatusHistory()) != 0 {
+		t.Fatalf("target status history = %d entries, want none", len(target.StatusHistory()))
+	}
+	if got := len(source.StatusHistory()); got != 3 {
+		t.Fatalf("source status history = %d entries, want its two entries and the closing one", got)
+	}
+}
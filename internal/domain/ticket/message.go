@@ -16,7 +16,9 @@ type Attachment struct {
 	MimeType string `json:"mime_type"`
 }
 
-// Message represents a message in a ticket conversation.
+// Message represents a message in a ticket conversation. Messages sent or
+// received by email keep their RFC 5322 Message-ID, In-Reply-To and
+// References headers so replies can be threaded.
 type Message struct {
 	id          uuid.UUID
 	ticketID    uuid.UUID
@@ -27,6 +29,9 @@ type Message struct {
 	content     string
 	attachments []Attachment
 	isInternal  bool
+	messageID   string
+	inReplyTo   string
+	references  []string
 	readAt      *time.Time
 	createdAt   time.Time
 }
@@ -42,6 +47,9 @@ type MessageParams struct {
 	Content     string
 	Attachments []Attachment
 	IsInternal  bool
+	MessageID   string
+	InReplyTo   string
+	References  []string
 }
 
 // NewMessage creates a new Message entity.
@@ -69,10 +77,33 @@ func NewMessage(params MessageParams) Message {
 		content:     params.Content,
 		attachments: params.Attachments,
 		isInternal:  params.IsInternal,
+		messageID:   params.MessageID,
+		inReplyTo:   params.InReplyTo,
+		references:  params.References,
 		createdAt:   time.Now(),
 	}
 }
 
+// ReconstituteMessage rebuilds a Message from persisted state.
+func ReconstituteMessage(params MessageParams, readAt *time.Time, createdAt time.Time) Message {
+	return Message{
+		id:          params.ID,
+		ticketID:    params.TicketID,
+		senderType:  shared.SenderType(params.SenderType),
+		senderID:    params.SenderID,
+		senderName:  params.SenderName,
+		senderEmail: params.SenderEmail,
+		content:     params.Content,
+		attachments: params.Attachments,
+		isInternal:  params.IsInternal,
+		messageID:   params.MessageID,
+		inReplyTo:   params.InReplyTo,
+		references:  params.References,
+		readAt:      readAt,
+		createdAt:   createdAt,
+	}
+}
+
 // CreateCustomerMessage creates a message from a customer.
 func CreateCustomerMessage(ticketID uuid.UUID, customerID *uuid.UUID, name, email, content string) Message {
 	return NewMessage(MessageParams{
@@ -118,6 +149,9 @@ func (m Message) SenderEmail() string           { return m.senderEmail }
 func (m Message) Content() string               { return m.content }
 func (m Message) Attachments() []Attachment     { return m.attachments }
 func (m Message) IsInternal() bool              { return m.isInternal }
+func (m Message) MessageID() string             { return m.messageID }
+func (m Message) InReplyTo() string             { return m.inReplyTo }
+func (m Message) References() []string          { return m.references }
 func (m Message) ReadAt() *time.Time            { return m.readAt }
 func (m Message) CreatedAt() time.Time          { return m.createdAt }
 
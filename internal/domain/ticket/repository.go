@@ -0,0 +1,170 @@
+package ticket
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Repository is the persistence port for the Ticket aggregate.
+type Repository interface {
+	// FindByID loads a ticket with its messages and status history.
+	// Returns ErrTicketNotFound if no ticket exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Ticket, error)
+
+	// FindByNumber loads a ticket by its ticket number.
+	// Returns ErrTicketNotFound if no ticket exists.
+	FindByNumber(ctx context.Context, number string) (*Ticket, error)
+
+	// FindByMessageID loads the ticket holding a message with one of the
+	// given RFC 5322 Message-IDs. Returns ErrTicketNotFound if no message
+	// matches.
+	FindByMessageID(ctx context.Context, messageIDs []string) (*Ticket, error)
+
+	// List returns a page of tickets matching the filter, newest first, and
+	// the total number of matches. Messages and status history are not loaded.
+	List(ctx context.Context, filter Filter) ([]*Ticket, int64, error)
+
+	// Save persists the ticket, its new messages and status history entries,
+	// and hands the ticket's pending events to the outbox atomically with
+	// them. The ticket's events are drained. Returns ErrConflict, saving
+	// nothing, if the ticket was saved by someone else since it was loaded;
+	// otherwise the ticket is marked saved.
+	Save(ctx context.Context, ticket *Ticket) error
+
+	// SaveMerged persists a merge atomically: the stored messages, status
+	// history and attachments of the sources move to the target, then the
+	// target and the sources are saved as by Save. Returns ErrConflict, saving nothing, if
+	// any of them is stale.
+	SaveMerged(ctx context.Context, target *Ticket, sources []*Ticket) error
+
+	// SaveSplit persists a split atomically: the split ticket is created
+	// with the stored messages it took from source and their attachments,
+	// then source is saved as by Save. Returns ErrConflict, saving nothing, if source is stale.
+	SaveSplit(ctx context.Context, source, split *Ticket) error
+
+	// ListSLADue returns up to limit active tickets matching the query,
+	// earliest SLA deadline first and by ID among tickets due at the same
+	// time. The first response clock counts only until the first agent
+	// reply. Status history is loaded, as saving recomputes the deadlines
+	// from it; messages are not.
+	ListSLADue(ctx context.Context, query SLADueQuery, limit int) ([]*Ticket, error)
+
+	// ListIdle returns up to limit tickets matching the query, idle longest
+	// first and by ID among tickets idle since the same time. Status history
+	// is loaded, as saving recomputes the SLA deadlines from it; messages
+	// are not.
+	ListIdle(ctx context.Context, query IdleQuery, limit int) ([]*Ticket, error)
+
+	// Stats returns aggregate statistics over all tickets.
+	Stats(ctx context.Context) (*Stats, error)
+
+	// CountActiveByAssignee returns the number of active tickets assigned
+	// to each agent. Agents without active tickets are left out.
+	CountActiveByAssignee(ctx context.Context) (map[uuid.UUID]int, error)
+
+	// LastAssignee returns the agent of the customer's most recently created
+	// assigned ticket, matching registered customers by ID and guests by
+	// email. Returns nil if the customer has no assigned ticket.
+	LastAssignee(ctx context.Context, customerID *uuid.UUID, guestEmail string) (*uuid.UUID, error)
+}
+
+// NumberSequence hands out the per-day sequence values ticket numbers are
+// built from.
+type NumberSequence interface {
+	// Next returns the next value of the scope's counter for the day,
+	// starting at 1. Only the date of day is used. A value is never handed
+	// out twice, also not to concurrent callers; values whose ticket is not
+	// saved leave gaps.
+	Next(ctx context.Context, scope string, day time.Time) (int64, error)
+}
+
+// Filter represents filters for listing tickets. Unassigned keeps tickets
+// without an agent; WatchedBy keeps tickets the agent follows; ActiveOnly
+// keeps tickets that are still being worked on.
+type Filter struct {
+	Status     string
+	Priority   string
+	CategoryID *uuid.UUID
+	CustomerID *uuid.UUID
+	TeamID     *uuid.UUID
+	AssignedTo *uuid.UUID
+	Unassigned bool
+	WatchedBy  *uuid.UUID
+	ActiveOnly bool
+	OrderID    *uuid.UUID
+	Search     string
+	IsOverdue  *bool
+	Page       int
+	PerPage    int
+}
+
+// Normalize applies the default page and page size.
+func (f *Filter) Normalize() {
+	if f.PerPage <= 0 {
+		f.PerPage = 20
+	}
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+}
+
+// Offset returns the number of tickets to skip for the current page.
+func (f Filter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
+
+// IdleQuery selects the tickets in a status that have been idle since
+// before a cutoff, for the time-based automations. CategoryID keeps the
+// tickets of one category; otherwise ExcludeCategoryIDs drops the tickets
+// of the listed categories. NotReminded and NotStale drop the tickets the
+// automation already acted on. After resumes the listing past the last
+// ticket of the previous batch.
+type IdleQuery struct {
+	Status             shared.TicketStatus
+	IdleBefore         time.Time
+	CategoryID         *uuid.UUID
+	ExcludeCategoryIDs []uuid.UUID
+	NotReminded        bool
+	NotStale           bool
+	After              *IdleCursor
+}
+
+// IdleCursor is the position of a ticket in the idle order: by IdleSince,
+// then by ID for tickets that went idle at the same time.
+type IdleCursor struct {
+	IdleSince time.Time
+	ID        uuid.UUID
+}
+
+// SLADueQuery selects the tickets with an unrecorded SLA breach (deadline
+// before Now) or an unsent warning (deadline before WarnBefore) on the first
+// response or the resolution clock. After resumes the listing past the last
+// ticket of the previous batch.
+type SLADueQuery struct {
+	Now        time.Time
+	WarnBefore time.Time
+	After      *SLACursor
+}
+
+// SLACursor is the position of a ticket in the SLA due order: by
+// EarliestSLADeadline, then by ID for tickets due at the same time.
+type SLACursor struct {
+	Deadline time.Time
+	ID       uuid.UUID
+}
+
+// Stats represents ticket statistics.
+type Stats struct {
+	TotalOpen         int64   `json:"total_open"`
+	TotalPending      int64   `json:"total_pending"`
+	TotalInProgress   int64   `json:"total_in_progress"`
+	TotalResolved     int64   `json:"total_resolved"`
+	TotalClosed       int64   `json:"total_closed"`
+	TotalOverdue      int64   `json:"total_overdue"`
+	AvgResponseTime   float64 `json:"avg_response_time_hours"`
+	AvgResolutionTime float64 `json:"avg_resolution_time_hours"`
+	SatisfactionRate  float64 `json:"satisfaction_rate"`
+}
@@ -0,0 +1,209 @@
+package ticket
+
+import (
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// SLATimer is the state of one SLA clock of a ticket.
+type SLATimer struct {
+	Target time.Duration
+	// Deadline is when the target runs out. It is nil while the clock is
+	// paused, because the deadline moves by however long the pause lasts.
+	Deadline  *time.Time
+	Elapsed   time.Duration
+	Remaining time.Duration
+	Paused    bool
+	Stopped   bool
+	// Met is nil while the clock runs within its target.
+	Met *bool
+}
+
+// SLAStatus is the state of a ticket's first response and resolution clocks.
+type SLAStatus struct {
+	FirstResponse SLATimer
+	Resolution    SLATimer
+}
+
+// SLATargets returns the targets the ticket was opened with. Tickets created
+// before targets were recorded fall back to the defaults of their priority.
+func (t *Ticket) SLATargets() sla.Targets {
+	targets := sla.DefaultTargets(t.priority)
+	if t.firstResponseTarget > 0 {
+		targets.FirstResponse = t.firstResponseTarget
+	}
+	if t.resolutionTarget > 0 {
+		targets.Resolution = t.resolutionTarget
+	} else if t.slaDeadline != nil && t.slaDeadline.After(t.createdAt) {
+		targets.Resolution = t.slaDeadline.Sub(t.createdAt)
+	}
+	return targets
+}
+
+// SetSLATargets sets the first response and resolution targets. Deadlines
+// follow on the next RefreshSLA.
+func (t *Ticket) SetSLATargets(targets sla.Targets) {
+	t.firstResponseTarget = targets.FirstResponse
+	t.resolutionTarget = targets.Resolution
+	t.updatedAt = time.Now()
+}
+
+// SLAStatus measures both SLA clocks in the working time of the calendar
+// (wall-clock time when nil). The first response clock runs from creation
+// until the first agent reply. The resolution clock runs while the ticket is
+// open or in progress: it pauses while the ticket is pending on the customer
+// and stops on resolution, as recorded in the status history.
+func (t *Ticket) SLAStatus(calendar *sla.Calendar, now time.Time) SLAStatus {
+	targets := t.SLATargets()
+	return SLAStatus{
+		FirstResponse: t.firstResponseTimer(calendar, targets.FirstResponse, now),
+		Resolution:    t.resolutionTimer(calendar, targets.Resolution, now),
+	}
+}
+
+// EarliestSLADeadline returns the earlier of the stored first response and
+// resolution deadlines, or the zero time when neither is set.
+func (t *Ticket) EarliestSLADeadline() time.Time {
+	var earliest time.Time
+	for _, d := range []*time.Time{t.firstResponseDeadline, t.slaDeadline} {
+		if d != nil && (earliest.IsZero() || d.Before(earliest)) {
+			earliest = *d
+		}
+	}
+	return earliest
+}
+
+// RefreshSLA stores the current first response and resolution deadlines, so
+// the overdue filter and the SLA monitor see them. The resolution deadline
+// is cleared while the ticket is pending.
+func (t *Ticket) RefreshSLA(calendar *sla.Calendar, now time.Time) {
+	if t.firstResponseTarget == 0 || t.resolutionTarget == 0 {
+		// Pin legacy targets before the stored deadline moves
+		targets := t.SLATargets()
+		t.firstResponseTarget = targets.FirstResponse
+		t.resolutionTarget = targets.Resolution
+	}
+
+	status := t.SLAStatus(calendar, now)
+	t.setFirstResponseDeadline(status.FirstResponse.Deadline)
+	t.setResolutionDeadline(status.Resolution.Deadline)
+}
+
+func (t *Ticket) firstResponseTimer(calendar *sla.Calendar, target time.Duration, now time.Time) SLATimer {
+	deadline := sla.AddWorkingTime(calendar, t.createdAt, target)
+	timer := SLATimer{Target: target, Deadline: &deadline}
+
+	end := now
+	switch {
+	case t.firstResponseAt != nil:
+		end = *t.firstResponseAt
+		timer.Stopped = true
+	case t.resolvedAt != nil:
+		end = *t.resolvedAt
+		timer.Stopped = true
+	case t.closedAt != nil:
+		end = *t.closedAt
+		timer.Stopped = true
+	}
+
+	timer.Elapsed = sla.WorkingTimeBetween(calendar, t.createdAt, end)
+	timer.finish()
+	return timer
+}
+
+func (t *Ticket) resolutionTimer(calendar *sla.Calendar, target time.Duration, now time.Time) SLATimer {
+	timer := SLATimer{Target: target}
+
+	// Walk the status history and add up the periods the clock was running.
+	// The deadline is fixed by the period in which the target ran out; until
+	// then it is projected from the start of the latest running period.
+	var deadline *time.Time
+	var elapsedBefore time.Duration
+	runningSince := t.createdAt
+	running := true
+	closeRun := func(end time.Time) {
+		elapsedBefore = timer.Elapsed
+		timer.Elapsed += sla.WorkingTimeBetween(calendar, runningSince, end)
+		if deadline == nil && timer.Elapsed >= target {
+			d := sla.AddWorkingTime(calendar, runningSince, target-elapsedBefore)
+			deadline = &d
+		}
+	}
+
+	for _, h := range t.statusHistory {
+		clockRuns := t.resolutionClockRuns(h.ToStatus())
+		switch {
+		case running && !clockRuns:
+			closeRun(h.CreatedAt())
+			running = false
+		case !running && clockRuns:
+			runningSince = h.CreatedAt()
+			running = true
+		}
+	}
+	if running {
+		closeRun(now)
+	}
+
+	switch {
+	case deadline != nil:
+		timer.Deadline = deadline
+	case t.workflow.PausesSLA(t.status):
+		timer.Paused = true
+	default:
+		d := sla.AddWorkingTime(calendar, runningSince, target-elapsedBefore)
+		timer.Deadline = &d
+	}
+	timer.Stopped = !t.IsActive()
+	timer.finish()
+	return timer
+}
+
+// finish derives the remaining time and whether the target was met.
+func (timer *SLATimer) finish() {
+	timer.Remaining = timer.Target - timer.Elapsed
+	switch {
+	case timer.Remaining < 0:
+		met := false
+		timer.Met = &met
+	case timer.Stopped:
+		met := true
+		timer.Met = &met
+	}
+}
+
+// resolutionClockRuns checks if the workflow counts time spent in the status.
+func (t *Ticket) resolutionClockRuns(status shared.TicketStatus) bool {
+	return t.workflow.IsActive(status) && !t.workflow.PausesSLA(status)
+}
+
+// setFirstResponseDeadline moves the first response deadline. Warnings and
+// breaches recorded against a different deadline no longer apply.
+func (t *Ticket) setFirstResponseDeadline(deadline *time.Time) {
+	if sameTime(t.firstResponseDeadline, deadline) {
+		return
+	}
+	t.firstResponseDeadline = deadline
+	t.firstResponseWarnedAt = nil
+	t.firstResponseBreachedAt = nil
+}
+
+// setResolutionDeadline moves the resolution deadline. Warnings and breaches
+// recorded against a different deadline no longer apply.
+func (t *Ticket) setResolutionDeadline(deadline *time.Time) {
+	if sameTime(t.slaDeadline, deadline) {
+		return
+	}
+	t.slaDeadline = deadline
+	t.slaWarnedAt = nil
+	t.slaBreachedAt = nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
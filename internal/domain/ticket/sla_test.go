@@ -0,0 +1,274 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// slaStart is when the tickets of the SLA tests are opened, a Friday.
+var slaStart = time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+// statusAt records a status change hours after slaStart.
+func statusAt(from, to shared.TicketStatus, hours float64) StatusHistory {
+	return ReconstituteStatusHistory(StatusHistoryParams{
+		ID:         uuid.New(),
+		FromStatus: string(from),
+		ToStatus:   string(to),
+		CreatedAt:  slaStart.Add(time.Duration(hours * float64(time.Hour))),
+	})
+}
+
+// slaTicket returns a ticket opened at slaStart with a one hour first
+// response target and an eight hour resolution target.
+func slaTicket(status shared.TicketStatus, history ...StatusHistory) *Ticket {
+	params := ReconstituteParams{
+		ID:                  uuid.New(),
+		TicketNumber:        "TKT-20261016-0001",
+		GuestEmail:          "jane@example.com",
+		Subject:             "Where is my order?",
+		Status:              string(status),
+		Priority:            string(shared.PriorityNormal),
+		FirstResponseTarget: time.Hour,
+		ResolutionTarget:    8 * time.Hour,
+		StatusHistory:       history,
+		CreatedAt:           slaStart,
+		UpdatedAt:           slaStart,
+	}
+	if status == shared.StatusResolved && len(history) > 0 {
+		resolvedAt := history[len(history)-1].CreatedAt()
+		params.ResolvedAt = &resolvedAt
+	}
+	return Reconstitute(params)
+}
+
+func hoursAfterStart(hours float64) *time.Time {
+	t := slaStart.Add(time.Duration(hours * float64(time.Hour)))
+	return &t
+}
+
+func TestResolutionTimer(t *testing.T) {
+	tests := []struct {
+		name         string
+		ticket       *Ticket
+		now          float64
+		wantElapsed  time.Duration
+		wantDeadline *time.Time
+		wantPaused   bool
+		wantStopped  bool
+		wantMet      *bool
+	}{
+		{
+			name:         "running",
+			ticket:       slaTicket(shared.StatusOpen),
+			now:          3,
+			wantElapsed:  3 * time.Hour,
+			wantDeadline: hoursAfterStart(8),
+		},
+		{
+			name: "paused while pending",
+			ticket: slaTicket(shared.StatusPending,
+				statusAt(shared.StatusOpen, shared.StatusPending, 2)),
+			now:         5,
+			wantElapsed: 2 * time.Hour,
+			wantPaused:  true,
+		},
+		{
+			name: "resumed after a pending period",
+			ticket: slaTicket(shared.StatusOpen,
+				statusAt(shared.StatusOpen, shared.StatusPending, 2),
+				statusAt(shared.StatusPending, shared.StatusOpen, 5)),
+			now:          6,
+			wantElapsed:  3 * time.Hour,
+			wantDeadline: hoursAfterStart(11),
+		},
+		{
+			name: "resumed after several pending periods",
+			ticket: slaTicket(shared.StatusInProgress,
+				statusAt(shared.StatusOpen, shared.StatusPending, 1),
+				statusAt(shared.StatusPending, shared.StatusOpen, 2),
+				statusAt(shared.StatusOpen, shared.StatusPending, 4),
+				statusAt(shared.StatusPending, shared.StatusInProgress, 7)),
+			now:          8,
+			wantElapsed:  4 * time.Hour,
+			wantDeadline: hoursAfterStart(12),
+		},
+		{
+			name: "paused again after several periods",
+			ticket: slaTicket(shared.StatusPending,
+				statusAt(shared.StatusOpen, shared.StatusPending, 1),
+				statusAt(shared.StatusPending, shared.StatusOpen, 2),
+				statusAt(shared.StatusOpen, shared.StatusPending, 4)),
+			now:         9,
+			wantElapsed: 3 * time.Hour,
+			wantPaused:  true,
+		},
+		{
+			name: "breached after resuming",
+			ticket: slaTicket(shared.StatusOpen,
+				statusAt(shared.StatusOpen, shared.StatusPending, 6),
+				statusAt(shared.StatusPending, shared.StatusOpen, 9)),
+			now:          12,
+			wantElapsed:  9 * time.Hour,
+			wantDeadline: hoursAfterStart(11),
+			wantMet:      boolPtr(false),
+		},
+		{
+			name: "breached before pausing keeps its deadline",
+			ticket: slaTicket(shared.StatusPending,
+				statusAt(shared.StatusOpen, shared.StatusPending, 10)),
+			now:          12,
+			wantElapsed:  10 * time.Hour,
+			wantDeadline: hoursAfterStart(8),
+			wantMet:      boolPtr(false),
+		},
+		{
+			name: "resolved within the target",
+			ticket: slaTicket(shared.StatusResolved,
+				statusAt(shared.StatusOpen, shared.StatusPending, 1),
+				statusAt(shared.StatusPending, shared.StatusOpen, 3),
+				statusAt(shared.StatusOpen, shared.StatusResolved, 5)),
+			now:          20,
+			wantElapsed:  3 * time.Hour,
+			wantDeadline: hoursAfterStart(10),
+			wantStopped:  true,
+			wantMet:      boolPtr(true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ticket.SLAStatus(nil, *hoursAfterStart(tt.now)).Resolution
+			if got.Elapsed != tt.wantElapsed {
+				t.Fatalf("elapsed = %s, want %s", got.Elapsed, tt.wantElapsed)
+			}
+			if got.Remaining != 8*time.Hour-tt.wantElapsed {
+				t.Fatalf("remaining = %s, want %s", got.Remaining, 8*time.Hour-tt.wantElapsed)
+			}
+			if !sameTime(got.Deadline, tt.wantDeadline) {
+				t.Fatalf("deadline = %v, want %v", got.Deadline, tt.wantDeadline)
+			}
+			if got.Paused != tt.wantPaused || got.Stopped != tt.wantStopped {
+				t.Fatalf("paused = %v stopped = %v, want %v and %v", got.Paused, got.Stopped, tt.wantPaused, tt.wantStopped)
+			}
+			if (got.Met == nil) != (tt.wantMet == nil) || (got.Met != nil && *got.Met != *tt.wantMet) {
+				t.Fatalf("met = %v, want %v", got.Met, tt.wantMet)
+			}
+		})
+	}
+}
+
+func TestResolutionTimerWorkingHours(t *testing.T) {
+	hours := make([]sla.WorkingHours, 0, 5)
+	for d := time.Monday; d <= time.Friday; d++ {
+		hours = append(hours, sla.WorkingHours{Weekday: d, Start: 9 * 60, End: 17 * 60})
+	}
+	calendar, err := sla.NewCalendar(sla.CalendarParams{Name: "Office", WorkingHours: hours})
+	if err != nil {
+		t.Fatalf("NewCalendar: %v", err)
+	}
+
+	// Pending from Friday 13:00 to Monday 10:00
+	tk := slaTicket(shared.StatusOpen,
+		statusAt(shared.StatusOpen, shared.StatusPending, 4),
+		statusAt(shared.StatusPending, shared.StatusOpen, 73))
+	got := tk.SLAStatus(calendar, *hoursAfterStart(74)).Resolution
+
+	if got.Elapsed != 5*time.Hour {
+		t.Fatalf("elapsed = %s, want 5h", got.Elapsed)
+	}
+	// Four hours left from Monday 10:00
+	if want := hoursAfterStart(77); !sameTime(got.Deadline, want) {
+		t.Fatalf("deadline = %v, want %v", got.Deadline, want)
+	}
+}
+
+func TestRefreshSLAWarningsAndBreaches(t *testing.T) {
+	warned := hoursAfterStart(7)
+	breached := hoursAfterStart(8)
+
+	tests := []struct {
+		name      string
+		deadline  *time.Time
+		status    shared.TicketStatus
+		history   []StatusHistory
+		wantKept  bool
+		wantFirst bool
+	}{
+		{
+			name:      "unchanged deadlines keep them",
+			deadline:  hoursAfterStart(8),
+			status:    shared.StatusOpen,
+			wantKept:  true,
+			wantFirst: true,
+		},
+		{
+			name:     "a deadline moved by a pending period clears them",
+			deadline: hoursAfterStart(8),
+			status:   shared.StatusOpen,
+			history: []StatusHistory{
+				statusAt(shared.StatusOpen, shared.StatusPending, 2),
+				statusAt(shared.StatusPending, shared.StatusOpen, 3),
+			},
+			wantFirst: true,
+		},
+		{
+			name:     "pausing clears them",
+			deadline: hoursAfterStart(8),
+			status:   shared.StatusPending,
+			history: []StatusHistory{
+				statusAt(shared.StatusOpen, shared.StatusPending, 2),
+			},
+			wantFirst: true,
+		},
+		{
+			name:     "a new target clears them",
+			deadline: hoursAfterStart(4),
+			status:   shared.StatusOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			firstDeadline := hoursAfterStart(1)
+			if !tt.wantFirst {
+				firstDeadline = hoursAfterStart(0.5)
+			}
+			tk := Reconstitute(ReconstituteParams{
+				ID:                      uuid.New(),
+				TicketNumber:            "TKT-20261016-0001",
+				GuestEmail:              "jane@example.com",
+				Subject:                 "Where is my order?",
+				Status:                  string(tt.status),
+				Priority:                string(shared.PriorityNormal),
+				FirstResponseTarget:     time.Hour,
+				ResolutionTarget:        8 * time.Hour,
+				SLADeadline:             tt.deadline,
+				SLAWarnedAt:             warned,
+				SLABreachedAt:           breached,
+				FirstResponseDeadline:   firstDeadline,
+				FirstResponseWarnedAt:   hoursAfterStart(0.75),
+				FirstResponseBreachedAt: hoursAfterStart(1),
+				StatusHistory:           tt.history,
+				CreatedAt:               slaStart,
+				UpdatedAt:               slaStart,
+			})
+
+			tk.RefreshSLA(nil, *hoursAfterStart(9))
+
+			if kept := tk.SLAWarnedAt() != nil && tk.SLABreachedAt() != nil; kept != tt.wantKept {
+				t.Fatalf("resolution warning and breach kept = %v, want %v (deadline %v)", kept, tt.wantKept, tk.SLADeadline())
+			}
+			if kept := tk.FirstResponseWarnedAt() != nil && tk.FirstResponseBreachedAt() != nil; kept != tt.wantFirst {
+				t.Fatalf("first response warning and breach kept = %v, want %v", kept, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
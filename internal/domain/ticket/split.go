@@ -0,0 +1,95 @@
+package ticket
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Split moves the messages of source with the given IDs to split, a ticket
+// just opened for them, and leaves a system message in each ticket that
+// points at the other. Source must keep at least one of its messages.
+func Split(source, split *Ticket, messageIDs []uuid.UUID) error {
+	if err := source.CanSplit(messageIDs); err != nil {
+		return err
+	}
+	if split.id == source.id || len(split.messages) > 0 {
+		return fmt.Errorf("%w: messages can only be split into a new ticket", ErrCannotSplit)
+	}
+
+	selected := make(map[uuid.UUID]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		selected[id] = true
+	}
+	kept := make([]Message, 0, len(source.messages))
+	moved := make([]Message, 0, len(selected))
+	for _, msg := range source.messages {
+		if selected[msg.id] {
+			msg.ticketID = split.id
+			moved = append(moved, msg)
+			continue
+		}
+		kept = append(kept, msg)
+	}
+
+	source.messages = kept
+	split.messages = moved
+	for _, msg := range moved {
+		if msg.SenderType().IsAgent() && !msg.IsInternal() {
+			// The customer already had an answer on these messages
+			at := msg.createdAt
+			split.firstResponseAt = &at
+			break
+		}
+	}
+
+	if err := source.AddMessage(crossReference(source, fmt.Sprintf("Some messages moved to ticket %s", split.ticketNumber.Value()))); err != nil {
+		return err
+	}
+	return split.AddMessage(crossReference(split, fmt.Sprintf("Split from ticket %s", source.ticketNumber.Value())))
+}
+
+// CanSplit checks if the messages with the given IDs can be split out of
+// the ticket: they must all be on it and leave at least one behind.
+func (t *Ticket) CanSplit(messageIDs []uuid.UUID) error {
+	if t.mergedInto != nil || t.isFrozen() {
+		return fmt.Errorf("%w: ticket %s is closed", ErrCannotSplit, t.ticketNumber.Value())
+	}
+	if len(messageIDs) == 0 {
+		return fmt.Errorf("%w: no messages selected", ErrCannotSplit)
+	}
+
+	selected := make(map[uuid.UUID]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		if _, ok := t.findMessage(id); !ok {
+			return fmt.Errorf("%w: message %s is not on ticket %s", ErrCannotSplit, id, t.ticketNumber.Value())
+		}
+		selected[id] = true
+	}
+	if len(selected) == len(t.messages) {
+		return fmt.Errorf("%w: ticket %s must keep at least one message", ErrCannotSplit, t.ticketNumber.Value())
+	}
+	return nil
+}
+
+// crossReference creates a system message on t that refers to another
+// ticket.
+func crossReference(t *Ticket, content string) Message {
+	return NewMessage(MessageParams{
+		TicketID:   t.id,
+		SenderType: string(shared.SenderSystem),
+		SenderName: SystemActor,
+		Content:    content,
+	})
+}
+
+// findMessage returns the message of the ticket with the ID.
+func (t *Ticket) findMessage(id uuid.UUID) (Message, bool) {
+	for _, msg := range t.messages {
+		if msg.id == id {
+			return msg, true
+		}
+	}
+	return Message{}, false
+}
@@ -32,6 +32,32 @@ func NewStatusHistory(ticketID uuid.UUID, from, to shared.TicketStatus, changedB
 	}
 }
 
+// StatusHistoryParams contains the persisted state of a StatusHistory entry.
+type StatusHistoryParams struct {
+	ID            uuid.UUID
+	TicketID      uuid.UUID
+	FromStatus    string
+	ToStatus      string
+	ChangedBy     *uuid.UUID
+	ChangedByName string
+	Notes         string
+	CreatedAt     time.Time
+}
+
+// ReconstituteStatusHistory rebuilds a StatusHistory entry from persisted state.
+func ReconstituteStatusHistory(params StatusHistoryParams) StatusHistory {
+	return StatusHistory{
+		id:            params.ID,
+		ticketID:      params.TicketID,
+		fromStatus:    shared.TicketStatus(params.FromStatus),
+		toStatus:      shared.TicketStatus(params.ToStatus),
+		changedBy:     params.ChangedBy,
+		changedByName: params.ChangedByName,
+		notes:         params.Notes,
+		createdAt:     params.CreatedAt,
+	}
+}
+
 // Getters
 func (h StatusHistory) ID() uuid.UUID                   { return h.id }
 func (h StatusHistory) TicketID() uuid.UUID             { return h.ticketID }
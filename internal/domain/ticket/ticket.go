@@ -2,48 +2,105 @@ package ticket
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
 )
 
 // Domain errors for Ticket aggregate
 var (
-	ErrTicketNotFound  = errors.New("ticket not found")
-	ErrInvalidTicket   = errors.New("invalid ticket data")
-	ErrCannotModify    = errors.New("ticket cannot be modified in current state")
-	ErrAlreadyAssigned = errors.New("ticket is already assigned")
-	ErrNotAssigned     = errors.New("ticket is not assigned")
-	ErrSLABreached     = errors.New("SLA deadline has been breached")
+	ErrTicketNotFound   = errors.New("ticket not found")
+	ErrInvalidTicket    = errors.New("invalid ticket data")
+	ErrCannotModify     = errors.New("ticket cannot be modified in current state")
+	ErrAlreadyAssigned  = errors.New("ticket is already assigned")
+	ErrNotAssigned      = errors.New("ticket is not assigned")
+	ErrSLABreached      = errors.New("SLA deadline has been breached")
+	ErrCannotMerge      = errors.New("tickets cannot be merged")
+	ErrCustomerMismatch = errors.New("tickets belong to different customers")
+	ErrCannotSplit      = errors.New("ticket cannot be split")
+	ErrConflict         = errors.New("ticket was changed by someone else")
+)
+
+// SystemActor is the name recorded for changes the service makes on its own.
+const SystemActor = "System"
+
+// Channels tickets arrive through.
+const (
+	ChannelWeb   = "web"
+	ChannelEmail = "email"
 )
 
 // Ticket is the aggregate root for support tickets.
 type Ticket struct {
-	id                  uuid.UUID
-	ticketNumber        shared.TicketNumber
-	customerID          *uuid.UUID
-	guestEmail          string
-	guestName           string
-	guestPhone          string
-	categoryID          *uuid.UUID
-	subject             string
-	status              shared.TicketStatus
-	priority            shared.TicketPriority
-	assignedTo          *uuid.UUID
-	orderID             *uuid.UUID
-	orderNumber         string
-	slaDeadline         *time.Time
-	firstResponseAt     *time.Time
-	resolvedAt          *time.Time
-	closedAt            *time.Time
-	satisfactionRating  *int
-	satisfactionComment string
-	tags                []string
-	messages            []Message
-	statusHistory       []StatusHistory
-	createdAt           time.Time
-	updatedAt           time.Time
+	id                      uuid.UUID
+	ticketNumber            shared.TicketNumber
+	customerID              *uuid.UUID
+	guestEmail              string
+	guestName               string
+	guestPhone              string
+	categoryID              *uuid.UUID
+	subject                 string
+	channel                 string
+	locale                  string
+	brand                   string
+	status                  shared.TicketStatus
+	priority                shared.TicketPriority
+	teamID                  *uuid.UUID
+	assignedTo              *uuid.UUID
+	assignmentReason        string
+	orderID                 *uuid.UUID
+	orderNumber             string
+	slaDeadline             *time.Time
+	slaWarnedAt             *time.Time
+	slaBreachedAt           *time.Time
+	firstResponseTarget     time.Duration
+	resolutionTarget        time.Duration
+	firstResponseDeadline   *time.Time
+	firstResponseWarnedAt   *time.Time
+	firstResponseBreachedAt *time.Time
+	firstResponseAt         *time.Time
+	resolvedAt              *time.Time
+	closedAt                *time.Time
+	satisfactionRating      *int
+	satisfactionComment     string
+	tags                    []string
+	messages                []Message
+	statusHistory           []StatusHistory
+	createdAt               time.Time
+	updatedAt               time.Time
+
+	// idleSince is when the ticket last moved: it changed status, an agent
+	// wrote on it or, while pending, the customer replied. Time-based
+	// automations measure from it.
+	idleSince  time.Time
+	remindedAt *time.Time
+	staleAt    *time.Time
+
+	// mergedInto is the ticket this one was merged into, if any.
+	mergedInto *uuid.UUID
+
+	// watchers are the agents following the ticket; cc are the extra
+	// addresses the customer copies on it.
+	watchers []uuid.UUID
+	cc       []string
+
+	// workflow decides which statuses exist and how the ticket moves
+	// between them. It is not persisted with the ticket.
+	workflow *workflow.Workflow
+
+	// accountEmail and accountName are the current contact details of the
+	// registered customer, kept in the customer directory rather than on
+	// the ticket. They are not persisted with the ticket.
+	accountEmail string
+	accountName  string
+
+	// version counts the saves of the ticket. Repositories refuse to save
+	// a copy whose version is no longer the stored one.
+	version int
 
 	// Domain events
 	events []Event
@@ -59,10 +116,14 @@ type TicketParams struct {
 	GuestPhone   string
 	CategoryID   *uuid.UUID
 	Subject      string
+	Channel      string // defaults to ChannelWeb
+	Locale       string // language customer email is written in, e.g. "en" or "pt-br"
+	Brand        string // storefront the ticket number format was picked for
 	Priority     string
 	OrderID      *uuid.UUID
 	OrderNumber  string
 	Tags         []string
+	CC           []string
 	SLAHours     int
 }
 
@@ -80,12 +141,18 @@ func NewTicket(params TicketParams) (*Ticket, error) {
 		id = uuid.New()
 	}
 
+	channel := params.Channel
+	if channel == "" {
+		channel = ChannelWeb
+	}
+
 	ticketNumber := shared.GenerateTicketNumber()
 	if params.TicketNumber != "" {
 		tn, err := shared.NewTicketNumber(params.TicketNumber)
-		if err == nil {
-			ticketNumber = tn
+		if err != nil {
+			return nil, err
 		}
+		ticketNumber = tn
 	}
 
 	priority := shared.PriorityNormal
@@ -96,32 +163,49 @@ func NewTicket(params TicketParams) (*Ticket, error) {
 		}
 	}
 
-	now := time.Now()
-	slaDeadline := priority.CalculateSLADeadline(now)
+	targets := sla.DefaultTargets(priority)
 	if params.SLAHours > 0 {
-		slaDeadline = now.Add(time.Duration(params.SLAHours) * time.Hour)
+		targets.Resolution = time.Duration(params.SLAHours) * time.Hour
+		if targets.FirstResponse > targets.Resolution {
+			targets.FirstResponse = targets.Resolution
+		}
 	}
 
+	now := time.Now()
+	slaDeadline := now.Add(targets.Resolution)
+	firstResponseDeadline := now.Add(targets.FirstResponse)
+
 	ticket := &Ticket{
-		id:            id,
-		ticketNumber:  ticketNumber,
-		customerID:    params.CustomerID,
-		guestEmail:    params.GuestEmail,
-		guestName:     params.GuestName,
-		guestPhone:    params.GuestPhone,
-		categoryID:    params.CategoryID,
-		subject:       params.Subject,
-		status:        shared.StatusOpen,
-		priority:      priority,
-		orderID:       params.OrderID,
-		orderNumber:   params.OrderNumber,
-		slaDeadline:   &slaDeadline,
-		tags:          params.Tags,
-		messages:      make([]Message, 0),
-		statusHistory: make([]StatusHistory, 0),
-		createdAt:     now,
-		updatedAt:     now,
-		events:        make([]Event, 0),
+		id:                    id,
+		ticketNumber:          ticketNumber,
+		customerID:            params.CustomerID,
+		guestEmail:            params.GuestEmail,
+		guestName:             params.GuestName,
+		guestPhone:            params.GuestPhone,
+		categoryID:            params.CategoryID,
+		subject:               params.Subject,
+		channel:               channel,
+		locale:                shared.NormalizeLocale(params.Locale),
+		brand:                 strings.ToLower(strings.TrimSpace(params.Brand)),
+		status:                shared.StatusOpen,
+		priority:              priority,
+		orderID:               params.OrderID,
+		orderNumber:           params.OrderNumber,
+		slaDeadline:           &slaDeadline,
+		firstResponseTarget:   targets.FirstResponse,
+		resolutionTarget:      targets.Resolution,
+		firstResponseDeadline: &firstResponseDeadline,
+		tags:                  params.Tags,
+		messages:              make([]Message, 0),
+		statusHistory:         make([]StatusHistory, 0),
+		createdAt:             now,
+		updatedAt:             now,
+		idleSince:             now,
+		workflow:              workflow.Default(),
+		events:                make([]Event, 0),
+	}
+	if err := ticket.SetCC(params.CC); err != nil {
+		return nil, err
 	}
 
 	ticket.addEvent(NewTicketCreatedEvent(id, ticketNumber.Value(), params.Subject))
@@ -129,48 +213,214 @@ func NewTicket(params TicketParams) (*Ticket, error) {
 	return ticket, nil
 }
 
+// ReconstituteParams contains the persisted state of a Ticket.
+type ReconstituteParams struct {
+	ID                      uuid.UUID
+	TicketNumber            string
+	CustomerID              *uuid.UUID
+	GuestEmail              string
+	GuestName               string
+	GuestPhone              string
+	CategoryID              *uuid.UUID
+	Subject                 string
+	Channel                 string
+	Locale                  string
+	Brand                   string
+	Status                  string
+	Priority                string
+	TeamID                  *uuid.UUID
+	AssignedTo              *uuid.UUID
+	AssignmentReason        string
+	OrderID                 *uuid.UUID
+	OrderNumber             string
+	SLADeadline             *time.Time
+	SLAWarnedAt             *time.Time
+	SLABreachedAt           *time.Time
+	FirstResponseTarget     time.Duration
+	ResolutionTarget        time.Duration
+	FirstResponseDeadline   *time.Time
+	FirstResponseWarnedAt   *time.Time
+	FirstResponseBreachedAt *time.Time
+	FirstResponseAt         *time.Time
+	ResolvedAt              *time.Time
+	ClosedAt                *time.Time
+	SatisfactionRating      *int
+	SatisfactionComment     string
+	Tags                    []string
+	Messages                []Message
+	StatusHistory           []StatusHistory
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+	// IdleSince defaults to UpdatedAt when zero.
+	IdleSince  time.Time
+	RemindedAt *time.Time
+	StaleAt    *time.Time
+	MergedInto *uuid.UUID
+	Watchers   []uuid.UUID
+	CC         []string
+	// Workflow defaults to the built-in workflow when nil.
+	Workflow *workflow.Workflow
+	// Version is the number of times the ticket was saved.
+	Version int
+}
+
+// Reconstitute rebuilds a Ticket from persisted state without raising events.
+func Reconstitute(params ReconstituteParams) *Ticket {
+	messages := params.Messages
+	if messages == nil {
+		messages = make([]Message, 0)
+	}
+	history := params.StatusHistory
+	if history == nil {
+		history = make([]StatusHistory, 0)
+	}
+	wf := params.Workflow
+	if wf == nil {
+		wf = workflow.Default()
+	}
+	idleSince := params.IdleSince
+	if idleSince.IsZero() {
+		idleSince = params.UpdatedAt
+	}
+
+	return &Ticket{
+		id:                      params.ID,
+		ticketNumber:            shared.RestoreTicketNumber(params.TicketNumber),
+		customerID:              params.CustomerID,
+		guestEmail:              params.GuestEmail,
+		guestName:               params.GuestName,
+		guestPhone:              params.GuestPhone,
+		categoryID:              params.CategoryID,
+		subject:                 params.Subject,
+		channel:                 params.Channel,
+		locale:                  params.Locale,
+		brand:                   params.Brand,
+		status:                  shared.TicketStatus(params.Status),
+		priority:                shared.TicketPriority(params.Priority),
+		teamID:                  params.TeamID,
+		assignedTo:              params.AssignedTo,
+		assignmentReason:        params.AssignmentReason,
+		orderID:                 params.OrderID,
+		orderNumber:             params.OrderNumber,
+		slaDeadline:             params.SLADeadline,
+		slaWarnedAt:             params.SLAWarnedAt,
+		slaBreachedAt:           params.SLABreachedAt,
+		firstResponseTarget:     params.FirstResponseTarget,
+		resolutionTarget:        params.ResolutionTarget,
+		firstResponseDeadline:   params.FirstResponseDeadline,
+		firstResponseWarnedAt:   params.FirstResponseWarnedAt,
+		firstResponseBreachedAt: params.FirstResponseBreachedAt,
+		firstResponseAt:         params.FirstResponseAt,
+		resolvedAt:              params.ResolvedAt,
+		closedAt:                params.ClosedAt,
+		satisfactionRating:      params.SatisfactionRating,
+		satisfactionComment:     params.SatisfactionComment,
+		tags:                    params.Tags,
+		messages:                messages,
+		statusHistory:           history,
+		createdAt:               params.CreatedAt,
+		updatedAt:               params.UpdatedAt,
+		idleSince:               idleSince,
+		remindedAt:              params.RemindedAt,
+		staleAt:                 params.StaleAt,
+		mergedInto:              params.MergedInto,
+		watchers:                params.Watchers,
+		cc:                      params.CC,
+		workflow:                wf,
+		version:                 params.Version,
+		events:                  make([]Event, 0),
+	}
+}
+
 // Getters
-func (t *Ticket) ID() uuid.UUID                     { return t.id }
-func (t *Ticket) TicketNumber() shared.TicketNumber { return t.ticketNumber }
-func (t *Ticket) CustomerID() *uuid.UUID            { return t.customerID }
-func (t *Ticket) GuestEmail() string                { return t.guestEmail }
-func (t *Ticket) GuestName() string                 { return t.guestName }
-func (t *Ticket) GuestPhone() string                { return t.guestPhone }
-func (t *Ticket) CategoryID() *uuid.UUID            { return t.categoryID }
-func (t *Ticket) Subject() string                   { return t.subject }
-func (t *Ticket) Status() shared.TicketStatus       { return t.status }
-func (t *Ticket) Priority() shared.TicketPriority   { return t.priority }
-func (t *Ticket) AssignedTo() *uuid.UUID            { return t.assignedTo }
-func (t *Ticket) OrderID() *uuid.UUID               { return t.orderID }
-func (t *Ticket) OrderNumber() string               { return t.orderNumber }
-func (t *Ticket) SLADeadline() *time.Time           { return t.slaDeadline }
-func (t *Ticket) FirstResponseAt() *time.Time       { return t.firstResponseAt }
-func (t *Ticket) ResolvedAt() *time.Time            { return t.resolvedAt }
-func (t *Ticket) ClosedAt() *time.Time              { return t.closedAt }
-func (t *Ticket) SatisfactionRating() *int          { return t.satisfactionRating }
-func (t *Ticket) SatisfactionComment() string       { return t.satisfactionComment }
-func (t *Ticket) Tags() []string                    { return t.tags }
-func (t *Ticket) Messages() []Message               { return t.messages }
-func (t *Ticket) StatusHistory() []StatusHistory    { return t.statusHistory }
-func (t *Ticket) CreatedAt() time.Time              { return t.createdAt }
-func (t *Ticket) UpdatedAt() time.Time              { return t.updatedAt }
-
-// ContactEmail returns the email of the ticket creator.
+func (t *Ticket) ID() uuid.UUID                       { return t.id }
+func (t *Ticket) TicketNumber() shared.TicketNumber   { return t.ticketNumber }
+func (t *Ticket) CustomerID() *uuid.UUID              { return t.customerID }
+func (t *Ticket) GuestEmail() string                  { return t.guestEmail }
+func (t *Ticket) GuestName() string                   { return t.guestName }
+func (t *Ticket) GuestPhone() string                  { return t.guestPhone }
+func (t *Ticket) CategoryID() *uuid.UUID              { return t.categoryID }
+func (t *Ticket) Subject() string                     { return t.subject }
+func (t *Ticket) Channel() string                     { return t.channel }
+func (t *Ticket) Locale() string                      { return t.locale }
+func (t *Ticket) Brand() string                       { return t.brand }
+func (t *Ticket) Status() shared.TicketStatus         { return t.status }
+func (t *Ticket) Priority() shared.TicketPriority     { return t.priority }
+func (t *Ticket) TeamID() *uuid.UUID                  { return t.teamID }
+func (t *Ticket) AssignedTo() *uuid.UUID              { return t.assignedTo }
+func (t *Ticket) AssignmentReason() string            { return t.assignmentReason }
+func (t *Ticket) OrderID() *uuid.UUID                 { return t.orderID }
+func (t *Ticket) OrderNumber() string                 { return t.orderNumber }
+func (t *Ticket) SLADeadline() *time.Time             { return t.slaDeadline }
+func (t *Ticket) SLAWarnedAt() *time.Time             { return t.slaWarnedAt }
+func (t *Ticket) SLABreachedAt() *time.Time           { return t.slaBreachedAt }
+func (t *Ticket) FirstResponseDeadline() *time.Time   { return t.firstResponseDeadline }
+func (t *Ticket) FirstResponseWarnedAt() *time.Time   { return t.firstResponseWarnedAt }
+func (t *Ticket) FirstResponseBreachedAt() *time.Time { return t.firstResponseBreachedAt }
+func (t *Ticket) FirstResponseAt() *time.Time         { return t.firstResponseAt }
+func (t *Ticket) ResolvedAt() *time.Time              { return t.resolvedAt }
+func (t *Ticket) ClosedAt() *time.Time                { return t.closedAt }
+func (t *Ticket) SatisfactionRating() *int            { return t.satisfactionRating }
+func (t *Ticket) SatisfactionComment() string         { return t.satisfactionComment }
+func (t *Ticket) Tags() []string                      { return t.tags }
+func (t *Ticket) Messages() []Message                 { return t.messages }
+func (t *Ticket) StatusHistory() []StatusHistory      { return t.statusHistory }
+func (t *Ticket) CreatedAt() time.Time                { return t.createdAt }
+func (t *Ticket) UpdatedAt() time.Time                { return t.updatedAt }
+func (t *Ticket) IdleSince() time.Time                { return t.idleSince }
+func (t *Ticket) RemindedAt() *time.Time              { return t.remindedAt }
+func (t *Ticket) StaleAt() *time.Time                 { return t.staleAt }
+func (t *Ticket) MergedInto() *uuid.UUID              { return t.mergedInto }
+func (t *Ticket) Watchers() []uuid.UUID               { return t.watchers }
+func (t *Ticket) CC() []string                        { return t.cc }
+func (t *Ticket) Workflow() *workflow.Workflow        { return t.workflow }
+
+// ContactEmail returns the email of the ticket creator: the registered
+// customer's address set by UseAccount, or the guest email.
 func (t *Ticket) ContactEmail() string {
+	if t.customerID != nil && t.accountEmail != "" {
+		return t.accountEmail
+	}
 	return t.guestEmail
 }
 
 // ContactName returns the name of the ticket creator.
 func (t *Ticket) ContactName() string {
+	if t.customerID != nil && t.accountName != "" {
+		return t.accountName
+	}
 	return t.guestName
 }
 
-// IsOverdue checks if the ticket has exceeded its SLA deadline.
+// UseAccount sets the current email and name of the ticket's registered
+// customer, as held in the customer directory.
+func (t *Ticket) UseAccount(email, name string) {
+	t.accountEmail = strings.TrimSpace(email)
+	t.accountName = strings.TrimSpace(name)
+}
+
+// IsActive checks if the ticket is still being worked on under its workflow.
+func (t *Ticket) IsActive() bool {
+	return t.workflow.IsActive(t.status)
+}
+
+// AcceptsMessages checks if messages may still be added to the ticket.
+func (t *Ticket) AcceptsMessages() bool {
+	return !t.isFrozen()
+}
+
+// isFrozen checks if the ticket sits in a terminal status of its workflow.
+func (t *Ticket) isFrozen() bool {
+	return t.workflow.IsTerminal(t.status)
+}
+
+// IsOverdue checks if the ticket has exceeded its SLA deadline. Deadlines
+// are computed in working time, so this holds outside business hours too.
 func (t *Ticket) IsOverdue() bool {
 	if t.slaDeadline == nil {
 		return false
 	}
-	if !t.status.IsActive() {
+	if !t.IsActive() {
 		return false
 	}
 	return time.Now().After(*t.slaDeadline)
@@ -178,23 +428,66 @@ func (t *Ticket) IsOverdue() bool {
 
 // --- Behavior Methods ---
 
-// Assign assigns the ticket to an agent.
+// AssignmentReasonManual is the assignment reason recorded for tickets
+// assigned by a staff member.
+const AssignmentReasonManual = "manual"
+
+// Assign assigns the ticket to an agent by hand.
 func (t *Ticket) Assign(agentID uuid.UUID, changedBy *uuid.UUID) error {
-	if t.status.IsClosed() {
+	return t.assign(agentID, changedBy, AssignmentReasonManual, false)
+}
+
+// AutoAssign assigns the ticket to the agent an assignment strategy picked,
+// recording the strategy's reason.
+func (t *Ticket) AutoAssign(agentID uuid.UUID, reason string) error {
+	if reason == "" {
+		return errors.New("assignment reason is required")
+	}
+	return t.assign(agentID, nil, reason, true)
+}
+
+func (t *Ticket) assign(agentID uuid.UUID, changedBy *uuid.UUID, reason string, auto bool) error {
+	if t.isFrozen() {
 		return ErrCannotModify
 	}
 
 	t.assignedTo = &agentID
+	t.assignmentReason = reason
 	t.updatedAt = time.Now()
 
 	if t.status.IsOpen() {
 		t.transitionStatus(shared.StatusInProgress, changedBy, "Assigned to agent")
 	}
 
-	t.addEvent(NewTicketAssignedEvent(t.id, agentID))
+	t.addEvent(NewTicketAssignedEvent(t.id, agentID, reason, auto))
+	return nil
+}
+
+// AssignTeam moves the ticket to a team's queue, or out of any queue when
+// teamID is nil. The assigned agent is kept; callers check that the agent
+// belongs to the new team.
+func (t *Ticket) AssignTeam(teamID *uuid.UUID) error {
+	if t.isFrozen() {
+		return ErrCannotModify
+	}
+	if equalTeam(t.teamID, teamID) {
+		return nil
+	}
+
+	t.teamID = teamID
+	t.updatedAt = time.Now()
+
+	t.addEvent(NewTicketTeamChangedEvent(t.id, teamID))
 	return nil
 }
 
+func equalTeam(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // Unassign removes the agent assignment.
 func (t *Ticket) Unassign(changedBy *uuid.UUID) error {
 	if t.assignedTo == nil {
@@ -202,6 +495,7 @@ func (t *Ticket) Unassign(changedBy *uuid.UUID) error {
 	}
 
 	t.assignedTo = nil
+	t.assignmentReason = ""
 	t.updatedAt = time.Now()
 
 	if t.status.IsInProgress() {
@@ -213,7 +507,7 @@ func (t *Ticket) Unassign(changedBy *uuid.UUID) error {
 
 // Escalate escalates the ticket priority.
 func (t *Ticket) Escalate(reason string, changedBy *uuid.UUID) error {
-	if t.status.IsClosed() {
+	if t.isFrozen() {
 		return ErrCannotModify
 	}
 
@@ -230,8 +524,8 @@ func (t *Ticket) Escalate(reason string, changedBy *uuid.UUID) error {
 	}
 
 	t.priority = newPriority
-	t.slaDeadline = new(time.Time)
-	*t.slaDeadline = newPriority.CalculateSLADeadline(time.Now())
+	t.SetSLATargets(sla.DefaultTargets(newPriority))
+	t.SetSLADeadline(newPriority.CalculateSLADeadline(time.Now()))
 	t.updatedAt = time.Now()
 
 	t.addEvent(NewTicketEscalatedEvent(t.id, string(newPriority), reason))
@@ -257,7 +551,14 @@ func (t *Ticket) Resolve(resolution string, changedBy *uuid.UUID) error {
 
 // Close closes the ticket.
 func (t *Ticket) Close(changedBy *uuid.UUID) error {
-	if err := t.transitionStatus(shared.StatusClosed, changedBy, "Ticket closed"); err != nil {
+	return t.close(changedBy, "")
+}
+
+func (t *Ticket) close(changedBy *uuid.UUID, notes string) error {
+	if notes == "" {
+		notes = "Ticket closed"
+	}
+	if err := t.transitionStatus(shared.StatusClosed, changedBy, notes); err != nil {
 		return err
 	}
 
@@ -278,20 +579,180 @@ func (t *Ticket) Reopen(reason string, changedBy *uuid.UUID) error {
 }
 
 // AddMessage adds a message to the ticket.
-func (t *Ticket) AddMessage(msg Message) {
+func (t *Ticket) AddMessage(msg Message) error {
+	if t.isFrozen() {
+		return ErrCannotModify
+	}
+
 	t.messages = append(t.messages, msg)
 	t.updatedAt = time.Now()
 
-	// Track first response
-	if t.firstResponseAt == nil && msg.SenderType().IsAgent() {
-		now := time.Now()
-		t.firstResponseAt = &now
+	// Agents keep the ticket moving; while pending, so does the customer
+	if msg.SenderType().IsAgent() || (t.status.IsPending() && msg.SenderType().IsCustomer() && !msg.IsInternal()) {
+		t.touch(t.updatedAt)
+	}
+
+	if msg.SenderType().IsAgent() && !msg.IsInternal() {
+		// Track first response
+		if t.firstResponseAt == nil {
+			now := time.Now()
+			t.firstResponseAt = &now
+		}
+		if t.status.IsOpen() {
+			t.transitionStatus(shared.StatusInProgress, msg.SenderID(), "Agent replied")
+		}
+	}
+
+	t.addEvent(NewMessageAddedEvent(t.id, msg.ID(), string(msg.SenderType()), msg.IsInternal()))
+	return nil
+}
+
+// ChangeStatus moves the ticket to the target status using the matching behavior method.
+func (t *Ticket) ChangeStatus(target shared.TicketStatus, changedBy *uuid.UUID, changedByName, notes string) error {
+	if !t.workflow.HasState(target) {
+		return shared.ErrInvalidTicketTransition
+	}
+	if target == t.status {
+		return nil
+	}
+
+	var err error
+	switch {
+	case target.IsResolved():
+		err = t.Resolve(notes, changedBy)
+	case target.IsClosed():
+		err = t.close(changedBy, notes)
+	case target.IsPending():
+		err = t.SetPending(notes, changedBy)
+	case target.IsOpen() && t.status.IsResolved():
+		err = t.Reopen(notes, changedBy)
+	default:
+		err = t.transitionStatus(target, changedBy, notes)
+	}
+	if err != nil {
+		return err
+	}
+
+	if changedByName != "" && len(t.statusHistory) > 0 {
+		t.statusHistory[len(t.statusHistory)-1].SetChangedByName(changedByName)
+	}
+	return nil
+}
+
+// ChangePriority sets the ticket priority.
+func (t *Ticket) ChangePriority(priority shared.TicketPriority) error {
+	if t.isFrozen() {
+		return ErrCannotModify
+	}
+	if !priority.IsValid() {
+		return shared.ErrInvalidTicketPriority
+	}
+
+	t.priority = priority
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// RecordSLABreach records that the first response or resolution deadline
+// has passed and raises a TicketSLABreachedEvent for each clock. A breach is
+// recorded once per deadline; it reports whether one was recorded by this
+// call. The first response clock only counts until the first agent reply.
+func (t *Ticket) RecordSLABreach(now time.Time) bool {
+	if !t.IsActive() {
+		return false
+	}
+
+	recorded := false
+	if t.firstResponseAt == nil && t.firstResponseBreachedAt == nil &&
+		t.firstResponseDeadline != nil && now.After(*t.firstResponseDeadline) {
+		t.firstResponseBreachedAt = &now
+		t.addEvent(NewTicketSLABreachedEvent(t.id, SLAClockFirstResponse, *t.firstResponseDeadline))
+		recorded = true
+	}
+	if t.slaBreachedAt == nil && t.slaDeadline != nil && now.After(*t.slaDeadline) {
+		t.slaBreachedAt = &now
+		t.addEvent(NewTicketSLABreachedEvent(t.id, SLAClockResolution, *t.slaDeadline))
+		recorded = true
+	}
+	return recorded
+}
+
+// WarnSLAApproaching raises a TicketSLAWarningEvent once for each of the
+// first response and resolution deadlines that is less than the given window
+// away. It reports whether a warning was raised.
+func (t *Ticket) WarnSLAApproaching(now time.Time, window time.Duration) bool {
+	if !t.IsActive() {
+		return false
+	}
+
+	warned := false
+	if t.firstResponseAt == nil && t.firstResponseWarnedAt == nil && t.firstResponseBreachedAt == nil &&
+		approaching(t.firstResponseDeadline, now, window) {
+		t.firstResponseWarnedAt = &now
+		t.addEvent(NewTicketSLAWarningEvent(t.id, SLAClockFirstResponse, *t.firstResponseDeadline))
+		warned = true
+	}
+	if t.slaWarnedAt == nil && t.slaBreachedAt == nil && approaching(t.slaDeadline, now, window) {
+		t.slaWarnedAt = &now
+		t.addEvent(NewTicketSLAWarningEvent(t.id, SLAClockResolution, *t.slaDeadline))
+		warned = true
+	}
+	return warned
+}
+
+// approaching checks if the deadline has not passed yet but is less than the
+// window away.
+func approaching(deadline *time.Time, now time.Time, window time.Duration) bool {
+	return deadline != nil && !now.After(*deadline) && deadline.Sub(now) <= window
+}
+
+// RemindPending records that the customer of a pending ticket was reminded
+// and raises TicketPendingReminderEvent. A customer is reminded once until
+// the ticket moves again; it reports whether a reminder was recorded.
+func (t *Ticket) RemindPending(now time.Time, notes string) bool {
+	if !t.status.IsPending() || t.remindedAt != nil {
+		return false
+	}
+
+	t.remindedAt = &now
+	t.addSystemNote(notes)
+	t.addEvent(NewTicketPendingReminderEvent(t.id, t.idleSince))
+	return true
+}
+
+// FlagStale flags an in-progress ticket that agents have left idle and
+// raises TicketStaleEvent. A ticket is flagged once until it moves again;
+// it reports whether it was flagged by this call.
+func (t *Ticket) FlagStale(now time.Time, notes string) bool {
+	if !t.status.IsInProgress() || t.staleAt != nil {
+		return false
 	}
+
+	t.staleAt = &now
+	t.addSystemNote(notes)
+	t.addEvent(NewTicketStaleEvent(t.id, t.idleSince))
+	return true
+}
+
+// addSystemNote records a status history entry that leaves the status as
+// it is, made by the service itself.
+func (t *Ticket) addSystemNote(notes string) {
+	history := NewStatusHistory(t.id, t.status, t.status, nil, notes)
+	history.SetChangedByName(SystemActor)
+	t.statusHistory = append(t.statusHistory, history)
+	t.updatedAt = time.Now()
+}
+
+// touch restarts the idle clock and clears the reminder and stale flag.
+func (t *Ticket) touch(now time.Time) {
+	t.idleSince = now
+	t.remindedAt = nil
+	t.staleAt = nil
 }
 
 // RateSatisfaction records customer satisfaction.
 func (t *Ticket) RateSatisfaction(rating int, comment string) error {
-	if !t.status.IsResolved() && !t.status.IsClosed() {
+	if t.IsActive() {
 		return errors.New("can only rate resolved or closed tickets")
 	}
 	if rating < 1 || rating > 5 {
@@ -304,6 +765,16 @@ func (t *Ticket) RateSatisfaction(rating int, comment string) error {
 	return nil
 }
 
+// UseWorkflow sets the workflow the ticket follows. Workflows that do not
+// know the current status are rejected so the ticket cannot get stuck.
+func (t *Ticket) UseWorkflow(wf *workflow.Workflow) error {
+	if wf == nil || !wf.HasState(t.status) {
+		return workflow.ErrInvalidWorkflow
+	}
+	t.workflow = wf
+	return nil
+}
+
 // SetCategory sets the ticket category.
 func (t *Ticket) SetCategory(categoryID uuid.UUID) {
 	t.categoryID = &categoryID
@@ -321,6 +792,15 @@ func (t *Ticket) AddTag(tag string) {
 	t.updatedAt = time.Now()
 }
 
+// SetTags replaces the ticket tags.
+func (t *Ticket) SetTags(tags []string) {
+	t.tags = make([]string, 0, len(tags))
+	for _, tag := range tags {
+		t.AddTag(tag)
+	}
+	t.updatedAt = time.Now()
+}
+
 // RemoveTag removes a tag from the ticket.
 func (t *Ticket) RemoveTag(tag string) {
 	for i, existingTag := range t.tags {
@@ -334,7 +814,7 @@ func (t *Ticket) RemoveTag(tag string) {
 
 // transitionStatus transitions the ticket to a new status.
 func (t *Ticket) transitionStatus(target shared.TicketStatus, changedBy *uuid.UUID, notes string) error {
-	if !t.status.CanTransitionTo(target) {
+	if !t.workflow.CanTransition(t.status, target) {
 		return ErrCannotModify
 	}
 
@@ -342,11 +822,36 @@ func (t *Ticket) transitionStatus(target shared.TicketStatus, changedBy *uuid.UU
 	t.statusHistory = append(t.statusHistory, history)
 	t.status = target
 	t.updatedAt = time.Now()
+	t.touch(t.updatedAt)
 
 	t.addEvent(NewTicketStatusChangedEvent(t.id, string(target)))
 	return nil
 }
 
+// SetSLADeadline moves the SLA deadline. Warnings and breaches recorded
+// against the previous deadline no longer apply.
+func (t *Ticket) SetSLADeadline(deadline time.Time) {
+	t.slaDeadline = &deadline
+	t.slaWarnedAt = nil
+	t.slaBreachedAt = nil
+}
+
+// Version returns the number of times the ticket was saved; zero for a
+// ticket never saved.
+func (t *Ticket) Version() int {
+	return t.version
+}
+
+// MarkSaved advances the version once a repository stored the ticket.
+func (t *Ticket) MarkSaved() {
+	t.version++
+}
+
+// PendingEvents returns the collected domain events without clearing them.
+func (t *Ticket) PendingEvents() []Event {
+	return append([]Event(nil), t.events...)
+}
+
 // Events returns and clears the collected domain events.
 func (t *Ticket) Events() []Event {
 	events := t.events
@@ -0,0 +1,78 @@
+package ticket
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCC is how many addresses a customer may copy on a ticket.
+const MaxCC = 10
+
+// IsWatchedBy checks if the agent follows the ticket.
+func (t *Ticket) IsWatchedBy(agentID uuid.UUID) bool {
+	for _, id := range t.watchers {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch makes the agent follow the ticket. It reports whether the agent
+// was not following it yet.
+func (t *Ticket) Watch(agentID uuid.UUID) bool {
+	if t.IsWatchedBy(agentID) {
+		return false
+	}
+	t.watchers = append(t.watchers, agentID)
+	t.updatedAt = time.Now()
+	return true
+}
+
+// Unwatch stops the agent following the ticket. It reports whether the
+// agent was following it.
+func (t *Ticket) Unwatch(agentID uuid.UUID) bool {
+	for i, id := range t.watchers {
+		if id == agentID {
+			t.watchers = append(t.watchers[:i:i], t.watchers[i+1:]...)
+			t.updatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
+// SetCC replaces the addresses copied on the ticket. Addresses are
+// lower-cased and deduplicated, and the ticket's own contact address is
+// left out.
+func (t *Ticket) SetCC(emails []string) error {
+	if t.isFrozen() {
+		return ErrCannotModify
+	}
+
+	cc := make([]string, 0, len(emails))
+	seen := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		addr, err := mail.ParseAddress(strings.TrimSpace(email))
+		if err != nil {
+			return fmt.Errorf("%w: invalid CC address %q", ErrInvalidTicket, email)
+		}
+		address := strings.ToLower(addr.Address)
+		if seen[address] || strings.EqualFold(address, t.guestEmail) {
+			continue
+		}
+		seen[address] = true
+		cc = append(cc, address)
+	}
+	if len(cc) > MaxCC {
+		return fmt.Errorf("%w: at most %d CC addresses are allowed", ErrInvalidTicket, MaxCC)
+	}
+
+	t.cc = cc
+	t.updatedAt = time.Now()
+	return nil
+}
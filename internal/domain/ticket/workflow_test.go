@@ -0,0 +1,155 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+const (
+	triage        shared.TicketStatus = "triage"
+	awaitingParts shared.TicketStatus = "awaiting_parts"
+)
+
+// returnsWorkflow triages tickets before they are resolved and pauses the
+// SLA while spare parts are awaited.
+func returnsWorkflow(t *testing.T) *workflow.Workflow {
+	t.Helper()
+	wf, err := workflow.NewWorkflow(workflow.WorkflowParams{
+		Name: "Returns",
+		States: []workflow.State{
+			{Key: shared.StatusOpen, Label: "Open", Active: true},
+			{Key: triage, Label: "Triage", Active: true},
+			{Key: awaitingParts, Label: "Awaiting parts", Active: true, PausesSLA: true},
+			{Key: shared.StatusResolved, Label: "Resolved"},
+			{Key: shared.StatusClosed, Label: "Closed", Terminal: true},
+		},
+		Transitions: []workflow.Transition{
+			{From: shared.StatusOpen, To: triage},
+			{From: triage, To: awaitingParts},
+			{From: awaitingParts, To: triage},
+			{From: triage, To: shared.StatusResolved},
+			{From: shared.StatusResolved, To: shared.StatusOpen},
+			{From: shared.StatusResolved, To: shared.StatusClosed},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewWorkflow: %v", err)
+	}
+	return wf
+}
+
+// ticketIn returns a ticket in the status under the workflow.
+func ticketIn(t *testing.T, status shared.TicketStatus, wf *workflow.Workflow) *Ticket {
+	t.Helper()
+	tk := slaTicket(status)
+	if err := tk.UseWorkflow(wf); err != nil {
+		t.Fatalf("UseWorkflow: %v", err)
+	}
+	return tk
+}
+
+func TestChangeStatusFollowsWorkflow(t *testing.T) {
+	returns := returnsWorkflow(t)
+
+	tests := []struct {
+		name     string
+		workflow *workflow.Workflow
+		from, to shared.TicketStatus
+		wantErr  error
+	}{
+		{name: "default: open to pending", workflow: workflow.Default(), from: shared.StatusOpen, to: shared.StatusPending},
+		{name: "default: in progress to resolved", workflow: workflow.Default(), from: shared.StatusInProgress, to: shared.StatusResolved},
+		{name: "default: resolved reopened", workflow: workflow.Default(), from: shared.StatusResolved, to: shared.StatusOpen},
+		{name: "default: resolved to closed", workflow: workflow.Default(), from: shared.StatusResolved, to: shared.StatusClosed},
+		{name: "default: in progress back to open", workflow: workflow.Default(), from: shared.StatusInProgress, to: shared.StatusOpen, wantErr: ErrCannotModify},
+		{name: "default: open straight to closed", workflow: workflow.Default(), from: shared.StatusOpen, to: shared.StatusClosed, wantErr: ErrCannotModify},
+		{name: "default: out of closed", workflow: workflow.Default(), from: shared.StatusClosed, to: shared.StatusOpen, wantErr: ErrCannotModify},
+		{name: "default: status of another workflow", workflow: workflow.Default(), from: shared.StatusOpen, to: triage, wantErr: shared.ErrInvalidTicketTransition},
+		{name: "custom: open to triage", workflow: returns, from: shared.StatusOpen, to: triage},
+		{name: "custom: triage to awaiting parts", workflow: returns, from: triage, to: awaitingParts},
+		{name: "custom: back to triage", workflow: returns, from: awaitingParts, to: triage},
+		{name: "custom: triage to resolved", workflow: returns, from: triage, to: shared.StatusResolved},
+		{name: "custom: open skips triage", workflow: returns, from: shared.StatusOpen, to: shared.StatusResolved, wantErr: ErrCannotModify},
+		{name: "custom: awaiting parts skips triage", workflow: returns, from: awaitingParts, to: shared.StatusResolved, wantErr: ErrCannotModify},
+		{name: "custom: default status it does not define", workflow: returns, from: shared.StatusOpen, to: shared.StatusPending, wantErr: shared.ErrInvalidTicketTransition},
+		{name: "custom: same status", workflow: returns, from: triage, to: triage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := ticketIn(t, tt.from, tt.workflow)
+			history := len(tk.StatusHistory())
+
+			err := tk.ChangeStatus(tt.to, nil, "Agent", "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ChangeStatus(%s -> %s) error = %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			}
+			if err != nil {
+				if tk.Status() != tt.from || len(tk.StatusHistory()) != history {
+					t.Fatalf("blocked move changed the ticket to %s", tk.Status())
+				}
+				return
+			}
+			if tk.Status() != tt.to {
+				t.Fatalf("status = %s, want %s", tk.Status(), tt.to)
+			}
+			wantHistory := history + 1
+			if tt.from == tt.to {
+				wantHistory = history
+			}
+			if len(tk.StatusHistory()) != wantHistory {
+				t.Fatalf("history = %d entries, want %d", len(tk.StatusHistory()), wantHistory)
+			}
+		})
+	}
+}
+
+func TestWorkflowStateBehaviour(t *testing.T) {
+	returns := returnsWorkflow(t)
+
+	t.Run("terminal state freezes the ticket", func(t *testing.T) {
+		tk := ticketIn(t, shared.StatusClosed, returns)
+		msg := CreateAgentMessage(tk.ID(), uuid.New(), "Agent", "agent@shop.test", "Any news?", false)
+		if err := tk.AddMessage(msg); !errors.Is(err, ErrCannotModify) {
+			t.Fatalf("AddMessage error = %v, want ErrCannotModify", err)
+		}
+	})
+
+	t.Run("agent reply leaves a custom open state alone", func(t *testing.T) {
+		tk := ticketIn(t, shared.StatusOpen, returns)
+		msg := CreateAgentMessage(tk.ID(), uuid.New(), "Agent", "agent@shop.test", "Looking into it", false)
+		if err := tk.AddMessage(msg); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		// The workflow has no in_progress to move to
+		if tk.Status() != shared.StatusOpen {
+			t.Fatalf("status = %s, want open", tk.Status())
+		}
+	})
+
+	t.Run("workflow without the ticket's status is refused", func(t *testing.T) {
+		tk := slaTicket(shared.StatusInProgress)
+		if err := tk.UseWorkflow(returns); !errors.Is(err, workflow.ErrInvalidWorkflow) {
+			t.Fatalf("UseWorkflow error = %v, want ErrInvalidWorkflow", err)
+		}
+	})
+
+	t.Run("pausing state stops the resolution clock", func(t *testing.T) {
+		tk := slaTicket(triage,
+			statusAt(shared.StatusOpen, triage, 1),
+			statusAt(triage, awaitingParts, 2),
+			statusAt(awaitingParts, triage, 6))
+		if err := tk.UseWorkflow(returns); err != nil {
+			t.Fatalf("UseWorkflow: %v", err)
+		}
+		got := tk.SLAStatus(nil, *hoursAfterStart(7)).Resolution
+		if got.Elapsed != 3*time.Hour {
+			t.Fatalf("elapsed = %s, want 3h without the four hours awaiting parts", got.Elapsed)
+		}
+	})
+}
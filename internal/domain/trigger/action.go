@@ -0,0 +1,71 @@
+package trigger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// ActionType is a change a rule makes to the tickets it matches.
+type ActionType string
+
+// Action types
+const (
+	ActionSetPriority        ActionType = "set_priority"         // value: priority
+	ActionSetStatus          ActionType = "set_status"           // value: status
+	ActionSetCategory        ActionType = "set_category"         // value: category ID
+	ActionAddTag             ActionType = "add_tag"              // value: tag
+	ActionRemoveTag          ActionType = "remove_tag"           // value: tag
+	ActionAssignAgent        ActionType = "assign_agent"         // value: agent ID
+	ActionAssignTeam         ActionType = "assign_team"          // value: team ID
+	ActionAddNote            ActionType = "add_note"             // value: internal note text
+	ActionSendCannedResponse ActionType = "send_canned_response" // value: canned response ID
+)
+
+// Action is one change of a rule.
+type Action struct {
+	Type  ActionType
+	Value string
+}
+
+// TargetID returns the ID an action refers to: the category, agent, team
+// or canned response. It is uuid.Nil for other actions.
+func (a Action) TargetID() uuid.UUID {
+	switch a.Type {
+	case ActionSetCategory, ActionAssignAgent, ActionAssignTeam, ActionSendCannedResponse:
+		id, _ := uuid.Parse(a.Value)
+		return id
+	}
+	return uuid.Nil
+}
+
+// validate checks the action and normalizes its value.
+func (a Action) validate() (Action, error) {
+	value := strings.TrimSpace(a.Value)
+	if value == "" {
+		return Action{}, fmt.Errorf("action %q needs a value", a.Type)
+	}
+
+	switch a.Type {
+	case ActionSetPriority:
+		p, err := shared.ParseTicketPriority(strings.ToLower(value))
+		if err != nil {
+			return Action{}, fmt.Errorf("action %q: %w", a.Type, err)
+		}
+		value = string(p)
+	case ActionSetStatus:
+		value = strings.ToLower(value)
+	case ActionSetCategory, ActionAssignAgent, ActionAssignTeam, ActionSendCannedResponse:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return Action{}, fmt.Errorf("action %q: %q is not a valid ID", a.Type, value)
+		}
+		value = id.String()
+	case ActionAddTag, ActionRemoveTag, ActionAddNote:
+	default:
+		return Action{}, fmt.Errorf("unknown action %q", a.Type)
+	}
+	return Action{Type: a.Type, Value: value}, nil
+}
@@ -0,0 +1,285 @@
+// Package trigger runs admin-defined rules when tickets are created, the
+// customer replies or the status changes.
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// Field is a ticket or message detail a condition tests.
+type Field string
+
+// Condition fields
+const (
+	FieldSubject      Field = "subject"
+	FieldContent      Field = "content" // the message that fired the rule
+	FieldCategory     Field = "category"
+	FieldPriority     Field = "priority"
+	FieldStatus       Field = "status"
+	FieldChannel      Field = "channel"
+	FieldCustomerType Field = "customer_type"
+	FieldTags         Field = "tags"
+)
+
+// Operator compares a field with a condition's value. On tags, equals and
+// contains test for a tag and their negations for its absence.
+type Operator string
+
+// Condition operators
+const (
+	OperatorEquals      Operator = "equals"
+	OperatorNotEquals   Operator = "not_equals"
+	OperatorContains    Operator = "contains"
+	OperatorNotContains Operator = "not_contains"
+)
+
+// Customer types
+const (
+	CustomerRegistered = "registered"
+	CustomerGuest      = "guest"
+)
+
+// maxConditionDepth bounds how deeply groups nest.
+const maxConditionDepth = 5
+
+// Facts are the ticket and message details conditions are tested against.
+type Facts struct {
+	Subject      string
+	Content      string
+	CategoryID   *uuid.UUID
+	Priority     string
+	Status       string
+	Channel      string
+	CustomerType string
+	Tags         []string
+}
+
+// FactsOf collects the facts of a ticket. The content is the message that
+// fired the rule, or the customer's latest message when msg is nil.
+func FactsOf(t *ticket.Ticket, msg *ticket.Message) Facts {
+	content := ""
+	if msg != nil {
+		content = msg.Content()
+	} else {
+		messages := t.Messages()
+		for i := len(messages) - 1; i >= 0; i-- {
+			if messages[i].IsFromCustomer() {
+				content = messages[i].Content()
+				break
+			}
+		}
+	}
+
+	customerType := CustomerGuest
+	if t.CustomerID() != nil {
+		customerType = CustomerRegistered
+	}
+
+	return Facts{
+		Subject:      t.Subject(),
+		Content:      content,
+		CategoryID:   t.CategoryID(),
+		Priority:     string(t.Priority()),
+		Status:       string(t.Status()),
+		Channel:      t.Channel(),
+		CustomerType: customerType,
+		Tags:         t.Tags(),
+	}
+}
+
+// Condition is a test on a ticket. A leaf compares Field with Value using
+// Operator, ignoring case; a group holds when all of All, or any of Any,
+// hold. The zero Condition always holds.
+type Condition struct {
+	All      []Condition
+	Any      []Condition
+	Field    Field
+	Operator Operator
+	Value    string
+}
+
+// IsZero checks if the condition is empty and so always holds.
+func (c Condition) IsZero() bool {
+	return len(c.All) == 0 && len(c.Any) == 0 && c.Field == ""
+}
+
+// Evaluate checks if the condition holds for the facts.
+func (c Condition) Evaluate(f Facts) bool {
+	switch {
+	case len(c.All) > 0:
+		for _, sub := range c.All {
+			if !sub.Evaluate(f) {
+				return false
+			}
+		}
+		return true
+	case len(c.Any) > 0:
+		for _, sub := range c.Any {
+			if sub.Evaluate(f) {
+				return true
+			}
+		}
+		return false
+	case c.Field == "":
+		return true
+	}
+
+	if c.Field == FieldTags {
+		has := false
+		for _, tag := range f.Tags {
+			if strings.EqualFold(tag, c.Value) {
+				has = true
+				break
+			}
+		}
+		return has == (c.Operator == OperatorEquals || c.Operator == OperatorContains)
+	}
+
+	value := c.fieldValue(f)
+	switch c.Operator {
+	case OperatorEquals:
+		return strings.EqualFold(value, c.Value)
+	case OperatorNotEquals:
+		return !strings.EqualFold(value, c.Value)
+	case OperatorContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case OperatorNotContains:
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	}
+	return false
+}
+
+func (c Condition) fieldValue(f Facts) string {
+	switch c.Field {
+	case FieldSubject:
+		return f.Subject
+	case FieldContent:
+		return f.Content
+	case FieldCategory:
+		if f.CategoryID == nil {
+			return ""
+		}
+		return f.CategoryID.String()
+	case FieldPriority:
+		return f.Priority
+	case FieldStatus:
+		return f.Status
+	case FieldChannel:
+		return f.Channel
+	case FieldCustomerType:
+		return f.CustomerType
+	}
+	return ""
+}
+
+// CategoryIDs returns the categories the condition refers to.
+func (c Condition) CategoryIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, sub := range append(append([]Condition{}, c.All...), c.Any...) {
+		ids = append(ids, sub.CategoryIDs()...)
+	}
+	if c.Field == FieldCategory {
+		if id, err := uuid.Parse(c.Value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// validate checks the condition and normalizes its values.
+func (c Condition) validate(depth int) (Condition, error) {
+	if depth > maxConditionDepth {
+		return Condition{}, fmt.Errorf("conditions nest deeper than %d levels", maxConditionDepth)
+	}
+
+	set := 0
+	if len(c.All) > 0 {
+		set++
+	}
+	if len(c.Any) > 0 {
+		set++
+	}
+	if c.Field != "" {
+		set++
+	}
+	if set > 1 {
+		return Condition{}, errors.New("a condition is either a field test, an all group or an any group")
+	}
+
+	if len(c.All) > 0 || len(c.Any) > 0 {
+		all, err := validateConditions(c.All, depth)
+		if err != nil {
+			return Condition{}, err
+		}
+		anyOf, err := validateConditions(c.Any, depth)
+		if err != nil {
+			return Condition{}, err
+		}
+		return Condition{All: all, Any: anyOf}, nil
+	}
+	if c.Field == "" {
+		if c.Operator != "" || c.Value != "" {
+			return Condition{}, errors.New("condition field is required")
+		}
+		return Condition{}, nil
+	}
+
+	switch c.Operator {
+	case OperatorEquals, OperatorNotEquals:
+	case OperatorContains, OperatorNotContains:
+		if c.Field != FieldSubject && c.Field != FieldContent && c.Field != FieldTags {
+			return Condition{}, fmt.Errorf("operator %q does not apply to field %q", c.Operator, c.Field)
+		}
+	default:
+		return Condition{}, fmt.Errorf("unknown operator %q", c.Operator)
+	}
+
+	value := strings.TrimSpace(c.Value)
+	switch c.Field {
+	case FieldSubject, FieldContent, FieldTags:
+		if value == "" {
+			return Condition{}, fmt.Errorf("field %q needs a value", c.Field)
+		}
+	case FieldCategory:
+		// An empty value tests for tickets without a category
+		if value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return Condition{}, fmt.Errorf("category %q is not a valid ID", value)
+			}
+			value = id.String()
+		}
+	case FieldPriority, FieldStatus, FieldChannel, FieldCustomerType:
+		value = strings.ToLower(value)
+		if value == "" {
+			return Condition{}, fmt.Errorf("field %q needs a value", c.Field)
+		}
+	default:
+		return Condition{}, fmt.Errorf("unknown field %q", c.Field)
+	}
+
+	return Condition{Field: c.Field, Operator: c.Operator, Value: value}, nil
+}
+
+func validateConditions(conditions []Condition, depth int) ([]Condition, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	validated := make([]Condition, 0, len(conditions))
+	for _, c := range conditions {
+		v, err := c.validate(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if v.IsZero() {
+			return nil, errors.New("a group cannot hold an empty condition")
+		}
+		validated = append(validated, v)
+	}
+	return validated, nil
+}
@@ -0,0 +1,119 @@
+package trigger
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestConditionEvaluate(t *testing.T) {
+	billing, shipping := uuid.New(), uuid.New()
+	facts := Facts{
+		Subject:      "Refund for order 12345",
+		Content:      "The parcel arrived DAMAGED.",
+		CategoryID:   &billing,
+		Priority:     "high",
+		Status:       "open",
+		Channel:      "email",
+		CustomerType: CustomerGuest,
+		Tags:         []string{"VIP", "returns"},
+	}
+	leaf := func(field Field, op Operator, value string) Condition {
+		return Condition{Field: field, Operator: op, Value: value}
+	}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		facts     *Facts // the shared facts when nil
+		want      bool
+	}{
+		{name: "no condition", condition: Condition{}, want: true},
+		{name: "subject contains, any case", condition: leaf(FieldSubject, OperatorContains, "REFUND"), want: true},
+		{name: "subject does not contain", condition: leaf(FieldSubject, OperatorContains, "invoice")},
+		{name: "subject equals the whole subject only", condition: leaf(FieldSubject, OperatorEquals, "refund")},
+		{name: "content contains", condition: leaf(FieldContent, OperatorContains, "damaged"), want: true},
+		{name: "content not contains", condition: leaf(FieldContent, OperatorNotContains, "damaged")},
+		{name: "category equals", condition: leaf(FieldCategory, OperatorEquals, billing.String()), want: true},
+		{name: "category not equals", condition: leaf(FieldCategory, OperatorNotEquals, shipping.String()), want: true},
+		{name: "no category", condition: leaf(FieldCategory, OperatorEquals, ""), facts: &Facts{}, want: true},
+		{name: "priority equals", condition: leaf(FieldPriority, OperatorEquals, "high"), want: true},
+		{name: "priority not equals", condition: leaf(FieldPriority, OperatorNotEquals, "high")},
+		{name: "status equals", condition: leaf(FieldStatus, OperatorEquals, "pending")},
+		{name: "channel equals, any case", condition: leaf(FieldChannel, OperatorEquals, "EMAIL"), want: true},
+		{name: "customer type", condition: leaf(FieldCustomerType, OperatorEquals, CustomerRegistered)},
+		{name: "has the tag, any case", condition: leaf(FieldTags, OperatorEquals, "vip"), want: true},
+		{name: "tags contain a whole tag only", condition: leaf(FieldTags, OperatorContains, "return")},
+		{name: "lacks the tag", condition: leaf(FieldTags, OperatorNotEquals, "urgent"), want: true},
+		{name: "lacks the tag it has", condition: leaf(FieldTags, OperatorNotContains, "returns")},
+		{
+			name: "all hold",
+			condition: Condition{All: []Condition{
+				leaf(FieldPriority, OperatorEquals, "high"),
+				leaf(FieldTags, OperatorEquals, "vip"),
+			}},
+			want: true,
+		},
+		{
+			name: "one of all fails",
+			condition: Condition{All: []Condition{
+				leaf(FieldPriority, OperatorEquals, "high"),
+				leaf(FieldChannel, OperatorEquals, "chat"),
+			}},
+		},
+		{
+			name: "one of any holds",
+			condition: Condition{Any: []Condition{
+				leaf(FieldChannel, OperatorEquals, "chat"),
+				leaf(FieldContent, OperatorContains, "damaged"),
+			}},
+			want: true,
+		},
+		{
+			name: "none of any holds",
+			condition: Condition{Any: []Condition{
+				leaf(FieldChannel, OperatorEquals, "chat"),
+				leaf(FieldStatus, OperatorEquals, "closed"),
+			}},
+		},
+		{
+			name: "nested groups",
+			condition: Condition{All: []Condition{
+				leaf(FieldCustomerType, OperatorEquals, CustomerGuest),
+				{Any: []Condition{
+					leaf(FieldPriority, OperatorEquals, "urgent"),
+					leaf(FieldTags, OperatorEquals, "vip"),
+				}},
+			}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := facts
+			if tt.facts != nil {
+				f = *tt.facts
+			}
+			if got := tt.condition.Evaluate(f); got != tt.want {
+				t.Fatalf("Evaluate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionCategoryIDs(t *testing.T) {
+	billing, shipping := uuid.New(), uuid.New()
+	c := Condition{Any: []Condition{
+		{Field: FieldCategory, Operator: OperatorEquals, Value: billing.String()},
+		{All: []Condition{
+			{Field: FieldCategory, Operator: OperatorNotEquals, Value: shipping.String()},
+			{Field: FieldCategory, Operator: OperatorEquals, Value: ""},
+		}},
+	}}
+
+	ids := c.CategoryIDs()
+	if len(ids) != 2 || ids[0] != billing || ids[1] != shipping {
+		t.Fatalf("CategoryIDs = %v, want %v and %v", ids, billing, shipping)
+	}
+}
@@ -0,0 +1,83 @@
+package trigger
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActionResult is the outcome of one action of a firing. Error is empty
+// when the action was applied.
+type ActionResult struct {
+	Type  ActionType
+	Value string
+	Error string
+}
+
+// Firing records that a rule matched a ticket and which of its actions
+// were applied.
+type Firing struct {
+	id       uuid.UUID
+	ruleID   uuid.UUID
+	ruleName string
+	ticketID uuid.UUID
+	event    Event
+	actions  []ActionResult
+	firedAt  time.Time
+}
+
+// NewFiring records a firing of the rule on the ticket.
+func NewFiring(rule *Rule, ticketID uuid.UUID, event Event, actions []ActionResult) Firing {
+	return Firing{
+		id:       uuid.New(),
+		ruleID:   rule.ID(),
+		ruleName: rule.Name(),
+		ticketID: ticketID,
+		event:    event,
+		actions:  actions,
+		firedAt:  time.Now(),
+	}
+}
+
+// FiringParams contains the persisted state of a Firing.
+type FiringParams struct {
+	ID       uuid.UUID
+	RuleID   uuid.UUID
+	RuleName string
+	TicketID uuid.UUID
+	Event    Event
+	Actions  []ActionResult
+	FiredAt  time.Time
+}
+
+// ReconstituteFiring rebuilds a Firing from persisted state.
+func ReconstituteFiring(params FiringParams) Firing {
+	return Firing{
+		id:       params.ID,
+		ruleID:   params.RuleID,
+		ruleName: params.RuleName,
+		ticketID: params.TicketID,
+		event:    params.Event,
+		actions:  params.Actions,
+		firedAt:  params.FiredAt,
+	}
+}
+
+// Getters
+func (f Firing) ID() uuid.UUID           { return f.id }
+func (f Firing) RuleID() uuid.UUID       { return f.ruleID }
+func (f Firing) RuleName() string        { return f.ruleName }
+func (f Firing) TicketID() uuid.UUID     { return f.ticketID }
+func (f Firing) Event() Event            { return f.event }
+func (f Firing) Actions() []ActionResult { return f.actions }
+func (f Firing) FiredAt() time.Time      { return f.firedAt }
+
+// Failed checks if any action of the firing failed.
+func (f Firing) Failed() bool {
+	for _, a := range f.actions {
+		if a.Error != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for trigger rules.
+type Repository interface {
+	// FindByID loads a rule. Returns ErrRuleNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+
+	// List returns all rules in run order: by position, then name.
+	List(ctx context.Context) ([]*Rule, error)
+
+	// ListForEvent returns the enabled rules that run on the event, in run
+	// order.
+	ListForEvent(ctx context.Context, event Event) ([]*Rule, error)
+
+	// Save creates or updates a rule.
+	Save(ctx context.Context, rule *Rule) error
+
+	// Delete removes a rule. Its firings are kept. Returns ErrRuleNotFound
+	// if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// FiringLog is the persistence port for the log of rule firings.
+type FiringLog interface {
+	// Record appends firings to the log.
+	Record(ctx context.Context, firings []Firing) error
+
+	// List returns a page of firings matching the filter, newest first,
+	// and the total number of matches.
+	List(ctx context.Context, filter FiringFilter) ([]Firing, int64, error)
+}
+
+// FiringFilter represents filters for listing firings.
+type FiringFilter struct {
+	RuleID   *uuid.UUID
+	TicketID *uuid.UUID
+	Page     int
+	PerPage  int
+}
+
+// Normalize applies the default page and page size.
+func (f *FiringFilter) Normalize() {
+	if f.PerPage <= 0 {
+		f.PerPage = 20
+	}
+	if f.Page <= 0 {
+		f.Page = 1
+	}
+}
+
+// Offset returns the number of firings to skip for the current page.
+func (f FiringFilter) Offset() int {
+	return (f.Page - 1) * f.PerPage
+}
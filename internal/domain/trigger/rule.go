@@ -0,0 +1,190 @@
+package trigger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain errors for Rule aggregate
+var (
+	ErrRuleNotFound = errors.New("trigger rule not found")
+	ErrInvalidRule  = errors.New("invalid trigger rule")
+)
+
+// Event is a ticket change rules run on.
+type Event string
+
+// Events rules run on
+const (
+	EventTicketCreated   Event = "ticket_created"
+	EventCustomerReplied Event = "customer_replied"
+	EventStatusChanged   Event = "status_changed"
+)
+
+// ParseEvent parses an event name.
+func ParseEvent(s string) (Event, error) {
+	switch e := Event(strings.ToLower(strings.TrimSpace(s))); e {
+	case EventTicketCreated, EventCustomerReplied, EventStatusChanged:
+		return e, nil
+	}
+	return "", fmt.Errorf("%w: unknown event %q", ErrInvalidRule, s)
+}
+
+// Rule is the aggregate root for trigger rules. When one of its events
+// happens to a ticket the rule's condition is tested and, if it holds, its
+// actions are applied in order. Rules run by position, each seeing the
+// changes of the rules before it.
+type Rule struct {
+	id          uuid.UUID
+	name        string
+	description string
+	position    int
+	enabled     bool
+	events      []Event
+	condition   Condition
+	actions     []Action
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// RuleParams contains parameters for creating or updating a Rule.
+type RuleParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Position    int
+	Enabled     bool
+	Events      []Event
+	Condition   Condition
+	Actions     []Action
+}
+
+// NewRule creates a new Rule aggregate.
+func NewRule(params RuleParams) (*Rule, error) {
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	r := &Rule{id: id, createdAt: now}
+	if err := r.Update(params); err != nil {
+		return nil, err
+	}
+	r.updatedAt = now
+	return r, nil
+}
+
+// ReconstituteParams contains the persisted state of a Rule.
+type ReconstituteParams struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Position    int
+	Enabled     bool
+	Events      []Event
+	Condition   Condition
+	Actions     []Action
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Reconstitute rebuilds a Rule from persisted state.
+func Reconstitute(params ReconstituteParams) *Rule {
+	return &Rule{
+		id:          params.ID,
+		name:        params.Name,
+		description: params.Description,
+		position:    params.Position,
+		enabled:     params.Enabled,
+		events:      params.Events,
+		condition:   params.Condition,
+		actions:     params.Actions,
+		createdAt:   params.CreatedAt,
+		updatedAt:   params.UpdatedAt,
+	}
+}
+
+// Getters
+func (r *Rule) ID() uuid.UUID        { return r.id }
+func (r *Rule) Name() string         { return r.name }
+func (r *Rule) Description() string  { return r.description }
+func (r *Rule) Position() int        { return r.position }
+func (r *Rule) IsEnabled() bool      { return r.enabled }
+func (r *Rule) Events() []Event      { return r.events }
+func (r *Rule) Condition() Condition { return r.condition }
+func (r *Rule) Actions() []Action    { return r.actions }
+func (r *Rule) CreatedAt() time.Time { return r.createdAt }
+func (r *Rule) UpdatedAt() time.Time { return r.updatedAt }
+
+// RunsOn checks if the rule runs on the event.
+func (r *Rule) RunsOn(e Event) bool {
+	for _, event := range r.events {
+		if event == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if the rule's condition holds for the facts. Whether the
+// rule is enabled is not considered, so disabled rules can be tried out.
+func (r *Rule) Matches(f Facts) bool {
+	return r.condition.Evaluate(f)
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the rule's definition.
+func (r *Rule) Update(params RuleParams) error {
+	if strings.TrimSpace(params.Name) == "" {
+		return errors.Join(ErrInvalidRule, errors.New("name is required"))
+	}
+	if len(params.Events) == 0 {
+		return errors.Join(ErrInvalidRule, errors.New("at least one event is required"))
+	}
+	if len(params.Actions) == 0 {
+		return errors.Join(ErrInvalidRule, errors.New("at least one action is required"))
+	}
+
+	events := make([]Event, 0, len(params.Events))
+	seen := make(map[Event]bool, len(params.Events))
+	for _, e := range params.Events {
+		event, err := ParseEvent(string(e))
+		if err != nil {
+			return err
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	condition, err := params.Condition.validate(1)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	actions := make([]Action, 0, len(params.Actions))
+	for _, a := range params.Actions {
+		action, err := a.validate()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+		actions = append(actions, action)
+	}
+
+	r.name = strings.TrimSpace(params.Name)
+	r.description = strings.TrimSpace(params.Description)
+	r.position = params.Position
+	r.enabled = params.Enabled
+	r.events = events
+	r.condition = condition
+	r.actions = actions
+	r.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,113 @@
+package trigger
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRuleValidation(t *testing.T) {
+	category := uuid.New()
+	addTag := []Action{{Type: ActionAddTag, Value: "vip"}}
+	leaf := func(field Field, op Operator, value string) Condition {
+		return Condition{Field: field, Operator: op, Value: value}
+	}
+	nested := func(depth int) Condition {
+		c := leaf(FieldChannel, OperatorEquals, "email")
+		for i := 1; i < depth; i++ {
+			c = Condition{All: []Condition{c}}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name          string
+		params        RuleParams
+		wantErr       bool
+		wantEvents    []Event
+		wantCondition Condition
+		wantActions   []Action
+	}{
+		{
+			name:       "events deduplicated and normalized",
+			params:     RuleParams{Name: " VIP ", Events: []Event{"Ticket_Created", EventTicketCreated, EventCustomerReplied}, Actions: addTag},
+			wantEvents: []Event{EventTicketCreated, EventCustomerReplied},
+		},
+		{
+			name:          "condition values normalized",
+			params:        RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: Condition{All: []Condition{leaf(FieldPriority, OperatorEquals, " HIGH "), leaf(FieldCategory, OperatorEquals, strings.ToUpper(category.String()))}}, Actions: addTag},
+			wantCondition: Condition{All: []Condition{leaf(FieldPriority, OperatorEquals, "high"), leaf(FieldCategory, OperatorEquals, category.String())}},
+		},
+		{
+			name:        "action values normalized",
+			params:      RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Actions: []Action{{Type: ActionSetPriority, Value: "Urgent"}, {Type: ActionSetStatus, Value: " Pending"}, {Type: ActionAssignTeam, Value: strings.ToUpper(category.String())}}},
+			wantActions: []Action{{Type: ActionSetPriority, Value: "urgent"}, {Type: ActionSetStatus, Value: "pending"}, {Type: ActionAssignTeam, Value: category.String()}},
+		},
+		{name: "conditions nested to the limit", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: nested(maxConditionDepth), Actions: addTag}},
+		{name: "no name", params: RuleParams{Name: " ", Events: []Event{EventTicketCreated}, Actions: addTag}, wantErr: true},
+		{name: "no events", params: RuleParams{Name: "VIP", Actions: addTag}, wantErr: true},
+		{name: "unknown event", params: RuleParams{Name: "VIP", Events: []Event{"ticket_deleted"}, Actions: addTag}, wantErr: true},
+		{name: "no actions", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}}, wantErr: true},
+		{name: "conditions nested too deep", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: nested(maxConditionDepth + 1), Actions: addTag}, wantErr: true},
+		{name: "field test and group at once", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: Condition{Field: FieldChannel, Operator: OperatorEquals, Value: "email", Any: []Condition{leaf(FieldTags, OperatorEquals, "vip")}}, Actions: addTag}, wantErr: true},
+		{name: "empty condition in a group", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: Condition{All: []Condition{{}}}, Actions: addTag}, wantErr: true},
+		{name: "operator without field", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: Condition{Operator: OperatorEquals, Value: "email"}, Actions: addTag}, wantErr: true},
+		{name: "unknown field", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: leaf("order_total", OperatorEquals, "100"), Actions: addTag}, wantErr: true},
+		{name: "unknown operator", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: leaf(FieldSubject, "starts_with", "refund"), Actions: addTag}, wantErr: true},
+		{name: "contains on priority", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: leaf(FieldPriority, OperatorContains, "high"), Actions: addTag}, wantErr: true},
+		{name: "subject without value", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: leaf(FieldSubject, OperatorContains, " "), Actions: addTag}, wantErr: true},
+		{name: "invalid category", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Condition: leaf(FieldCategory, OperatorEquals, "billing"), Actions: addTag}, wantErr: true},
+		{name: "action without value", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Actions: []Action{{Type: ActionAddNote, Value: " "}}}, wantErr: true},
+		{name: "unknown priority", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Actions: []Action{{Type: ActionSetPriority, Value: "critical"}}}, wantErr: true},
+		{name: "invalid agent ID", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Actions: []Action{{Type: ActionAssignAgent, Value: "alice"}}}, wantErr: true},
+		{name: "unknown action", params: RuleParams{Name: "VIP", Events: []Event{EventTicketCreated}, Actions: []Action{{Type: "send_sms", Value: "hi"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewRule(tt.params)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("NewRule error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidRule) {
+					t.Fatalf("NewRule error = %v, want ErrInvalidRule", err)
+				}
+				return
+			}
+			if tt.wantEvents != nil && !reflect.DeepEqual(rule.Events(), tt.wantEvents) {
+				t.Fatalf("events = %v, want %v", rule.Events(), tt.wantEvents)
+			}
+			if !tt.wantCondition.IsZero() && !reflect.DeepEqual(rule.Condition(), tt.wantCondition) {
+				t.Fatalf("condition = %+v, want %+v", rule.Condition(), tt.wantCondition)
+			}
+			if tt.wantActions != nil && !reflect.DeepEqual(rule.Actions(), tt.wantActions) {
+				t.Fatalf("actions = %+v, want %+v", rule.Actions(), tt.wantActions)
+			}
+		})
+	}
+}
+
+func TestRuleRunsOn(t *testing.T) {
+	rule, err := NewRule(RuleParams{
+		Name:    "Reopened",
+		Events:  []Event{EventCustomerReplied, EventStatusChanged},
+		Actions: []Action{{Type: ActionAddTag, Value: "reopened"}},
+	})
+	if err != nil {
+		t.Fatalf("NewRule: %v", err)
+	}
+
+	for event, want := range map[Event]bool{
+		EventTicketCreated:   false,
+		EventCustomerReplied: true,
+		EventStatusChanged:   true,
+	} {
+		if got := rule.RunsOn(event); got != want {
+			t.Errorf("RunsOn(%s) = %v, want %v", event, got, want)
+		}
+	}
+}
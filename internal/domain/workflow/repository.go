@@ -0,0 +1,29 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository is the persistence port for workflows.
+type Repository interface {
+	// FindByID loads a workflow. Returns ErrWorkflowNotFound if none exists.
+	FindByID(ctx context.Context, id uuid.UUID) (*Workflow, error)
+
+	// FindForCategory returns the workflow assigned to the category, falling
+	// back to the stored default workflow. A nil category only matches the
+	// default. Returns ErrWorkflowNotFound if neither exists.
+	FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*Workflow, error)
+
+	// List returns all stored workflows, the default one first.
+	List(ctx context.Context) ([]*Workflow, error)
+
+	// Save creates or updates a workflow with its category assignments.
+	// Returns ErrWorkflowConflict if another workflow is the default or is
+	// assigned one of the categories.
+	Save(ctx context.Context, workflow *Workflow) error
+
+	// Delete removes a workflow. Returns ErrWorkflowNotFound if none exists.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,335 @@
+// Package workflow defines the ticket statuses and transitions a category uses.
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// Domain errors for Workflow aggregate
+var (
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrInvalidWorkflow  = errors.New("invalid workflow definition")
+	ErrWorkflowConflict = errors.New("another workflow already covers this scope")
+)
+
+// statusKeyPattern matches status keys that fit the tickets.status column.
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// requiredStatuses are the statuses the Ticket aggregate's behaviour relies
+// on: tickets open as "open", Resolve and Close target "resolved" and
+// "closed".
+var requiredStatuses = []shared.TicketStatus{shared.StatusOpen, shared.StatusResolved, shared.StatusClosed}
+
+// State is a status of a workflow.
+type State struct {
+	Key   shared.TicketStatus
+	Label string
+	// Active states count towards workload and the SLA; inactive states
+	// are finished, such as resolved and closed.
+	Active bool
+	// Terminal states cannot be left and freeze the ticket.
+	Terminal bool
+	// PausesSLA stops the resolution clock while the ticket waits on
+	// someone other than the support team.
+	PausesSLA bool
+}
+
+// Transition is an allowed move between two states.
+type Transition struct {
+	From shared.TicketStatus
+	To   shared.TicketStatus
+}
+
+// Workflow is the aggregate root for ticket workflows. The default workflow
+// applies to categories without a workflow of their own.
+type Workflow struct {
+	id          uuid.UUID
+	name        string
+	isDefault   bool
+	categoryIDs []uuid.UUID
+	states      []State
+	transitions []Transition
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// WorkflowParams contains parameters for creating a Workflow.
+type WorkflowParams struct {
+	ID          uuid.UUID
+	Name        string
+	IsDefault   bool
+	CategoryIDs []uuid.UUID
+	States      []State
+	Transitions []Transition
+}
+
+// NewWorkflow creates a new Workflow aggregate.
+func NewWorkflow(params WorkflowParams) (*Workflow, error) {
+	if params.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := validate(params.States, params.Transitions); err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	now := time.Now()
+	return &Workflow{
+		id:          id,
+		name:        params.Name,
+		isDefault:   params.IsDefault,
+		categoryIDs: uniqueIDs(params.CategoryIDs),
+		states:      append([]State(nil), params.States...),
+		transitions: append([]Transition(nil), params.Transitions...),
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstituteParams contains the persisted state of a Workflow.
+type ReconstituteParams struct {
+	ID          uuid.UUID
+	Name        string
+	IsDefault   bool
+	CategoryIDs []uuid.UUID
+	States      []State
+	Transitions []Transition
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Reconstitute rebuilds a Workflow from persisted state. The definition is
+// validated again, so a corrupt row is reported instead of enforced.
+func Reconstitute(params ReconstituteParams) (*Workflow, error) {
+	if err := validate(params.States, params.Transitions); err != nil {
+		return nil, fmt.Errorf("workflow %s: %w", params.ID, err)
+	}
+
+	return &Workflow{
+		id:          params.ID,
+		name:        params.Name,
+		isDefault:   params.IsDefault,
+		categoryIDs: uniqueIDs(params.CategoryIDs),
+		states:      append([]State(nil), params.States...),
+		transitions: append([]Transition(nil), params.Transitions...),
+		createdAt:   params.CreatedAt,
+		updatedAt:   params.UpdatedAt,
+	}, nil
+}
+
+// Default returns the built-in workflow of the five standard statuses. It
+// applies when no default workflow is stored.
+func Default() *Workflow {
+	statuses := shared.AllTicketStatuses()
+	states := make([]State, 0, len(statuses))
+	transitions := make([]Transition, 0)
+	for _, s := range statuses {
+		states = append(states, State{
+			Key:       s,
+			Label:     s.Label(),
+			Active:    s.IsActive(),
+			Terminal:  s.IsTerminal(),
+			PausesSLA: s.IsPending(),
+		})
+		for _, to := range s.ValidTransitions() {
+			transitions = append(transitions, Transition{From: s, To: to})
+		}
+	}
+
+	return &Workflow{
+		name:        "Default",
+		isDefault:   true,
+		categoryIDs: make([]uuid.UUID, 0),
+		states:      states,
+		transitions: transitions,
+	}
+}
+
+// Getters
+func (w *Workflow) ID() uuid.UUID             { return w.id }
+func (w *Workflow) Name() string              { return w.name }
+func (w *Workflow) IsDefault() bool           { return w.isDefault }
+func (w *Workflow) CategoryIDs() []uuid.UUID  { return w.categoryIDs }
+func (w *Workflow) States() []State           { return w.states }
+func (w *Workflow) Transitions() []Transition { return w.transitions }
+func (w *Workflow) CreatedAt() time.Time      { return w.createdAt }
+func (w *Workflow) UpdatedAt() time.Time      { return w.updatedAt }
+
+// IsBuiltIn checks if this is the built-in default workflow.
+func (w *Workflow) IsBuiltIn() bool {
+	return w.id == uuid.Nil
+}
+
+// State returns the state with the given key.
+func (w *Workflow) State(key shared.TicketStatus) (State, bool) {
+	for _, s := range w.states {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return State{}, false
+}
+
+// HasState checks if the workflow defines the status.
+func (w *Workflow) HasState(key shared.TicketStatus) bool {
+	_, ok := w.State(key)
+	return ok
+}
+
+// CanTransition checks if the workflow allows moving from one status to another.
+func (w *Workflow) CanTransition(from, to shared.TicketStatus) bool {
+	for _, t := range w.transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStates returns the statuses reachable from the given one.
+func (w *Workflow) NextStates(from shared.TicketStatus) []shared.TicketStatus {
+	next := make([]shared.TicketStatus, 0)
+	for _, t := range w.transitions {
+		if t.From == from {
+			next = append(next, t.To)
+		}
+	}
+	return next
+}
+
+// IsActive checks if tickets in the status are still being worked on.
+// Statuses unknown to the workflow count as active.
+func (w *Workflow) IsActive(key shared.TicketStatus) bool {
+	s, ok := w.State(key)
+	return !ok || s.Active
+}
+
+// IsTerminal checks if tickets in the status are frozen.
+func (w *Workflow) IsTerminal(key shared.TicketStatus) bool {
+	s, ok := w.State(key)
+	return ok && s.Terminal
+}
+
+// PausesSLA checks if the resolution clock stops in the status.
+func (w *Workflow) PausesSLA(key shared.TicketStatus) bool {
+	s, ok := w.State(key)
+	return ok && s.PausesSLA
+}
+
+// --- Behavior Methods ---
+
+// Update replaces the workflow name, states and transitions.
+func (w *Workflow) Update(name string, states []State, transitions []Transition) error {
+	if err := validate(states, transitions); err != nil {
+		return err
+	}
+
+	if name != "" {
+		w.name = name
+	}
+	w.states = append([]State(nil), states...)
+	w.transitions = append([]Transition(nil), transitions...)
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// Assign sets whether the workflow is the default and which categories use it.
+func (w *Workflow) Assign(isDefault bool, categoryIDs []uuid.UUID) {
+	w.isDefault = isDefault
+	w.categoryIDs = uniqueIDs(categoryIDs)
+	w.updatedAt = time.Now()
+}
+
+// validate checks that a definition can drive the Ticket aggregate.
+func validate(states []State, transitions []Transition) error {
+	if len(states) == 0 {
+		return fmt.Errorf("%w: at least one state is required", ErrInvalidWorkflow)
+	}
+
+	index := make(map[shared.TicketStatus]State, len(states))
+	for _, s := range states {
+		if !statusKeyPattern.MatchString(string(s.Key)) {
+			return fmt.Errorf("%w: state key %q must be lower_snake_case and at most 20 characters", ErrInvalidWorkflow, s.Key)
+		}
+		if _, dup := index[s.Key]; dup {
+			return fmt.Errorf("%w: duplicate state %q", ErrInvalidWorkflow, s.Key)
+		}
+		if s.Terminal && s.Active {
+			return fmt.Errorf("%w: terminal state %q cannot be active", ErrInvalidWorkflow, s.Key)
+		}
+		if s.PausesSLA && !s.Active {
+			return fmt.Errorf("%w: only active states can pause the SLA, not %q", ErrInvalidWorkflow, s.Key)
+		}
+		index[s.Key] = s
+	}
+
+	for _, key := range requiredStatuses {
+		if _, ok := index[key]; !ok {
+			return fmt.Errorf("%w: state %q is required", ErrInvalidWorkflow, key)
+		}
+	}
+	if open := index[shared.StatusOpen]; !open.Active || open.PausesSLA {
+		return fmt.Errorf("%w: state %q must be active and run the SLA", ErrInvalidWorkflow, shared.StatusOpen)
+	}
+	if index[shared.StatusResolved].Active {
+		return fmt.Errorf("%w: state %q cannot be active", ErrInvalidWorkflow, shared.StatusResolved)
+	}
+	if !index[shared.StatusClosed].Terminal {
+		return fmt.Errorf("%w: state %q must be terminal", ErrInvalidWorkflow, shared.StatusClosed)
+	}
+
+	outgoing := make(map[shared.TicketStatus]int, len(states))
+	seen := make(map[Transition]bool, len(transitions))
+	for _, t := range transitions {
+		from, ok := index[t.From]
+		if !ok {
+			return fmt.Errorf("%w: transition from unknown state %q", ErrInvalidWorkflow, t.From)
+		}
+		if _, ok := index[t.To]; !ok {
+			return fmt.Errorf("%w: transition to unknown state %q", ErrInvalidWorkflow, t.To)
+		}
+		if t.From == t.To {
+			return fmt.Errorf("%w: state %q cannot transition to itself", ErrInvalidWorkflow, t.From)
+		}
+		if from.Terminal {
+			return fmt.Errorf("%w: terminal state %q cannot have transitions", ErrInvalidWorkflow, t.From)
+		}
+		if seen[t] {
+			return fmt.Errorf("%w: duplicate transition %s -> %s", ErrInvalidWorkflow, t.From, t.To)
+		}
+		seen[t] = true
+		outgoing[t.From]++
+	}
+	for _, s := range states {
+		if !s.Terminal && outgoing[s.Key] == 0 {
+			return fmt.Errorf("%w: state %q needs a transition or must be terminal", ErrInvalidWorkflow, s.Key)
+		}
+	}
+	return nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].String() < unique[j].String()
+	})
+	return unique
+}
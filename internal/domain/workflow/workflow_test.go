@@ -0,0 +1,215 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+const (
+	triage        shared.TicketStatus = "triage"
+	awaitingParts shared.TicketStatus = "awaiting_parts"
+)
+
+// returnsStates are the states of a returns desk: tickets are triaged, may
+// wait on spare parts with the SLA paused, and are resolved from triage.
+func returnsStates() []State {
+	return []State{
+		{Key: shared.StatusOpen, Label: "Open", Active: true},
+		{Key: triage, Label: "Triage", Active: true},
+		{Key: awaitingParts, Label: "Awaiting parts", Active: true, PausesSLA: true},
+		{Key: shared.StatusResolved, Label: "Resolved"},
+		{Key: shared.StatusClosed, Label: "Closed", Terminal: true},
+	}
+}
+
+func returnsTransitions() []Transition {
+	return []Transition{
+		{From: shared.StatusOpen, To: triage},
+		{From: triage, To: awaitingParts},
+		{From: awaitingParts, To: triage},
+		{From: triage, To: shared.StatusResolved},
+		{From: shared.StatusResolved, To: shared.StatusOpen},
+		{From: shared.StatusResolved, To: shared.StatusClosed},
+	}
+}
+
+func TestNewWorkflowValidation(t *testing.T) {
+	replace := func(key shared.TicketStatus, change func(s *State)) []State {
+		states := returnsStates()
+		for i := range states {
+			if states[i].Key == key {
+				change(&states[i])
+			}
+		}
+		return states
+	}
+	without := func(key shared.TicketStatus) []State {
+		states := make([]State, 0)
+		for _, s := range returnsStates() {
+			if s.Key != key {
+				states = append(states, s)
+			}
+		}
+		return states
+	}
+	with := func(extra ...Transition) []Transition {
+		return append(returnsTransitions(), extra...)
+	}
+
+	tests := []struct {
+		name        string
+		states      []State
+		transitions []Transition
+		wantErr     bool
+	}{
+		{name: "valid", states: returnsStates(), transitions: returnsTransitions()},
+		{name: "no states", wantErr: true},
+		{name: "open missing", states: without(shared.StatusOpen), transitions: returnsTransitions(), wantErr: true},
+		{name: "resolved missing", states: without(shared.StatusResolved), transitions: returnsTransitions(), wantErr: true},
+		{
+			name:        "key not lower snake case",
+			states:      append(returnsStates(), State{Key: "On-Hold", Active: true}),
+			transitions: with(Transition{From: "On-Hold", To: shared.StatusOpen}),
+			wantErr:     true,
+		},
+		{
+			name:        "duplicate state",
+			states:      append(returnsStates(), State{Key: triage, Active: true}),
+			transitions: returnsTransitions(),
+			wantErr:     true,
+		},
+		{
+			name:        "open pauses the SLA",
+			states:      replace(shared.StatusOpen, func(s *State) { s.PausesSLA = true }),
+			transitions: returnsTransitions(),
+			wantErr:     true,
+		},
+		{
+			name:        "resolved active",
+			states:      replace(shared.StatusResolved, func(s *State) { s.Active = true }),
+			transitions: returnsTransitions(),
+			wantErr:     true,
+		},
+		{
+			name:        "closed not terminal",
+			states:      replace(shared.StatusClosed, func(s *State) { s.Terminal = false }),
+			transitions: with(Transition{From: shared.StatusClosed, To: shared.StatusOpen}),
+			wantErr:     true,
+		},
+		{
+			name:        "terminal and active",
+			states:      replace(shared.StatusClosed, func(s *State) { s.Active = true }),
+			transitions: returnsTransitions(),
+			wantErr:     true,
+		},
+		{
+			name:        "inactive state pauses the SLA",
+			states:      replace(shared.StatusResolved, func(s *State) { s.PausesSLA = true }),
+			transitions: returnsTransitions(),
+			wantErr:     true,
+		},
+		{
+			name:        "transition to an unknown state",
+			states:      returnsStates(),
+			transitions: with(Transition{From: triage, To: shared.StatusInProgress}),
+			wantErr:     true,
+		},
+		{
+			name:        "transition to itself",
+			states:      returnsStates(),
+			transitions: with(Transition{From: triage, To: triage}),
+			wantErr:     true,
+		},
+		{
+			name:        "transition out of a terminal state",
+			states:      returnsStates(),
+			transitions: with(Transition{From: shared.StatusClosed, To: shared.StatusOpen}),
+			wantErr:     true,
+		},
+		{
+			name:        "duplicate transition",
+			states:      returnsStates(),
+			transitions: with(Transition{From: shared.StatusOpen, To: triage}),
+			wantErr:     true,
+		},
+		{
+			name:        "dead end",
+			states:      append(returnsStates(), State{Key: "escalated", Active: true}),
+			transitions: with(Transition{From: triage, To: "escalated"}),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWorkflow(WorkflowParams{Name: "Returns", States: tt.states, Transitions: tt.transitions})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("NewWorkflow error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidWorkflow) {
+				t.Fatalf("NewWorkflow error = %v, want ErrInvalidWorkflow", err)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	returns, err := NewWorkflow(WorkflowParams{Name: "Returns", States: returnsStates(), Transitions: returnsTransitions()})
+	if err != nil {
+		t.Fatalf("NewWorkflow: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		from, to shared.TicketStatus
+		want     bool
+	}{
+		{name: "default: open to in progress", workflow: Default(), from: shared.StatusOpen, to: shared.StatusInProgress, want: true},
+		{name: "default: pending back to open", workflow: Default(), from: shared.StatusPending, to: shared.StatusOpen, want: true},
+		{name: "default: resolved reopened", workflow: Default(), from: shared.StatusResolved, to: shared.StatusOpen, want: true},
+		{name: "default: in progress back to open", workflow: Default(), from: shared.StatusInProgress, to: shared.StatusOpen},
+		{name: "default: open straight to closed", workflow: Default(), from: shared.StatusOpen, to: shared.StatusClosed},
+		{name: "default: out of closed", workflow: Default(), from: shared.StatusClosed, to: shared.StatusOpen},
+		{name: "custom: open to triage", workflow: returns, from: shared.StatusOpen, to: triage, want: true},
+		{name: "custom: triage to awaiting parts", workflow: returns, from: triage, to: awaitingParts, want: true},
+		{name: "custom: open skips triage", workflow: returns, from: shared.StatusOpen, to: shared.StatusResolved},
+		{name: "custom: awaiting parts skips triage", workflow: returns, from: awaitingParts, to: shared.StatusResolved},
+		{name: "custom: status it does not define", workflow: returns, from: shared.StatusOpen, to: shared.StatusInProgress},
+		{name: "custom: backwards", workflow: returns, from: triage, to: shared.StatusOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.workflow.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Fatalf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateFlags(t *testing.T) {
+	returns, err := NewWorkflow(WorkflowParams{Name: "Returns", States: returnsStates(), Transitions: returnsTransitions()})
+	if err != nil {
+		t.Fatalf("NewWorkflow: %v", err)
+	}
+
+	if !returns.IsActive(awaitingParts) || !returns.PausesSLA(awaitingParts) {
+		t.Fatal("awaiting parts should be active with the SLA paused")
+	}
+	if returns.IsActive(shared.StatusResolved) || returns.IsTerminal(shared.StatusResolved) {
+		t.Fatal("resolved should be inactive but not terminal")
+	}
+	if !returns.IsTerminal(shared.StatusClosed) {
+		t.Fatal("closed should be terminal")
+	}
+	// Statuses the workflow does not know stay active, so tickets are not lost
+	if !returns.IsActive(shared.StatusInProgress) || returns.PausesSLA(shared.StatusInProgress) {
+		t.Fatal("unknown statuses should count as active and running")
+	}
+	if next := returns.NextStates(triage); len(next) != 2 {
+		t.Fatalf("next states of triage = %v, want awaiting parts and resolved", next)
+	}
+}
@@ -0,0 +1,50 @@
+package email
+
+import (
+	"mime"
+	"strings"
+)
+
+// bounceSenders are the local parts mail systems send delivery reports
+// from.
+var bounceSenders = []string{"mailer-daemon", "postmaster"}
+
+// Automated reports whether the message was sent by a machine rather than
+// a person, and why: a bounce or delivery report, an auto-reply such as an
+// out-of-office notice, or bulk and list mail. Such messages must not open
+// tickets or be taken for customer replies.
+func (m *Message) Automated() (string, bool) {
+	h := m.Header
+
+	if strings.TrimSpace(h.Get("Return-Path")) == "<>" {
+		return "bounce: null return path", true
+	}
+	if mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil &&
+		mediaType == "multipart/report" && strings.EqualFold(params["report-type"], "delivery-status") {
+		return "bounce: delivery status report", true
+	}
+	if local, _, ok := strings.Cut(m.FromAddress(), "@"); ok {
+		for _, sender := range bounceSenders {
+			if local == sender {
+				return "bounce: sent by " + sender, true
+			}
+		}
+	}
+
+	if v := strings.ToLower(strings.TrimSpace(h.Get("Auto-Submitted"))); v != "" && v != "no" {
+		return "auto-reply: Auto-Submitted " + v, true
+	}
+	switch precedence := strings.ToLower(strings.TrimSpace(h.Get("Precedence"))); precedence {
+	case "bulk", "junk", "list", "auto_reply":
+		return "auto-reply: Precedence " + precedence, true
+	}
+	for _, key := range []string{"X-Autoreply", "X-Autorespond"} {
+		if h.Get(key) != "" {
+			return "auto-reply: " + key, true
+		}
+	}
+	if h.Get("List-Id") != "" || h.Get("List-Unsubscribe") != "" {
+		return "mailing list message", true
+	}
+	return "", false
+}
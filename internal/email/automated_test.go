@@ -0,0 +1,82 @@
+package email
+
+import (
+	"net/mail"
+	"net/textproto"
+	"testing"
+)
+
+func TestAutomated(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       string
+		header     map[string]string
+		wantReason string
+	}{
+		{
+			name: "mail from a person",
+			from: "jane@example.com",
+		},
+		{
+			name:       "null return path",
+			from:       "jane@example.com",
+			header:     map[string]string{"Return-Path": "<>"},
+			wantReason: "bounce: null return path",
+		},
+		{
+			name:       "delivery status report",
+			from:       "jane@example.com",
+			header:     map[string]string{"Content-Type": "multipart/report; report-type=delivery-status; boundary=b"},
+			wantReason: "bounce: delivery status report",
+		},
+		{
+			name:       "sent by the mailer daemon",
+			from:       "MAILER-DAEMON@mail.example.com",
+			wantReason: "bounce: sent by mailer-daemon",
+		},
+		{
+			name:       "auto-replied",
+			from:       "jane@example.com",
+			header:     map[string]string{"Auto-Submitted": "auto-replied"},
+			wantReason: "auto-reply: Auto-Submitted auto-replied",
+		},
+		{
+			name:   "Auto-Submitted no",
+			from:   "jane@example.com",
+			header: map[string]string{"Auto-Submitted": "no"},
+		},
+		{
+			name:       "bulk precedence",
+			from:       "jane@example.com",
+			header:     map[string]string{"Precedence": "Bulk"},
+			wantReason: "auto-reply: Precedence bulk",
+		},
+		{
+			name:       "out-of-office header",
+			from:       "jane@example.com",
+			header:     map[string]string{"X-Autoreply": "yes"},
+			wantReason: "auto-reply: X-Autoreply",
+		},
+		{
+			name:       "mailing list",
+			from:       "news@example.com",
+			header:     map[string]string{"List-Unsubscribe": "<mailto:leave@example.com>"},
+			wantReason: "mailing list message",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := mail.Header{}
+			for k, v := range tt.header {
+				header[textproto.CanonicalMIMEHeaderKey(k)] = []string{v}
+			}
+			msg := &Message{Header: header, From: &mail.Address{Address: tt.from}}
+
+			reason, ok := msg.Automated()
+			if ok != (tt.wantReason != "") || reason != tt.wantReason {
+				t.Fatalf("Automated = %q %v, want %q", reason, ok, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Envelope is a raw message waiting in a mailbox.
+type Envelope struct {
+	ID   string
+	Data []byte
+}
+
+// Mailbox is a store of delivered mail that is polled for new messages,
+// such as a maildir or an IMAP folder.
+type Mailbox interface {
+	// Fetch returns up to limit messages that have not been acknowledged,
+	// oldest first.
+	Fetch(ctx context.Context, limit int) ([]Envelope, error)
+
+	// Ack marks a message as processed so it is not fetched again.
+	Ack(ctx context.Context, id string) error
+}
+
+// Maildir is a Mailbox over a maildir directory: new mail is read from
+// new/ and moved to cur/, flagged as seen, once acknowledged. It doubles
+// as a local stand-in for a remote mailbox.
+type Maildir struct {
+	dir string
+}
+
+var _ Mailbox = (*Maildir)(nil)
+
+// NewMaildir opens the maildir at dir, creating its subdirectories when
+// missing.
+func NewMaildir(dir string) (*Maildir, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o750); err != nil {
+			return nil, err
+		}
+	}
+	return &Maildir{dir: dir}, nil
+}
+
+// Fetch reads the oldest messages in new/.
+func (m *Maildir) Fetch(ctx context.Context, limit int) ([]Envelope, error) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, "new"))
+	if err != nil {
+		return nil, err
+	}
+	// Maildir names start with the delivery time
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	envelopes := make([]Envelope, 0, limit)
+	for _, entry := range entries {
+		if len(envelopes) == limit {
+			break
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(m.dir, "new", entry.Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, Envelope{ID: entry.Name(), Data: data})
+	}
+	return envelopes, nil
+}
+
+// Ack moves the message to cur/ and flags it as seen.
+func (m *Maildir) Ack(ctx context.Context, id string) error {
+	if id != filepath.Base(id) {
+		return errors.New("invalid maildir message name")
+	}
+	return os.Rename(filepath.Join(m.dir, "new", id), filepath.Join(m.dir, "cur", id+":2,S"))
+}
+
+// PollerConfig tunes the mailbox poller.
+type PollerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Poller fetches new mail from a mailbox and hands it to the handler.
+// Messages that cannot be parsed or are rejected are acknowledged and
+// dropped; messages the handler fails on are left for the next poll.
+type Poller struct {
+	mailbox Mailbox
+	handler Handler
+	config  PollerConfig
+	logger  *zap.Logger
+}
+
+// NewPoller creates a new mailbox poller
+func NewPoller(mailbox Mailbox, handler Handler, config PollerConfig, logger *zap.Logger) *Poller {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	return &Poller{
+		mailbox: mailbox,
+		handler: handler,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// Run polls the mailbox until the context is cancelled.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("Failed to poll mailbox", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll handles one batch of new mail and returns how many messages were
+// acknowledged.
+func (p *Poller) Poll(ctx context.Context) (int, error) {
+	envelopes, err := p.mailbox.Fetch(ctx, p.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	acked := 0
+	for _, env := range envelopes {
+		if ctx.Err() != nil {
+			return acked, ctx.Err()
+		}
+		if !p.handle(ctx, env) {
+			continue
+		}
+		if err := p.mailbox.Ack(ctx, env.ID); err != nil {
+			return acked, err
+		}
+		acked++
+	}
+	return acked, nil
+}
+
+// handle parses and handles one message and reports whether it is done
+// with.
+func (p *Poller) handle(ctx context.Context, env Envelope) bool {
+	msg, err := Parse(bytes.NewReader(env.Data))
+	if err != nil {
+		p.logger.Warn("Dropping unparseable email", zap.String("id", env.ID), zap.Error(err))
+		return true
+	}
+
+	handleCtx, cancel := context.WithTimeout(ctx, handleTimeout)
+	defer cancel()
+	err = p.handler.HandleEmail(handleCtx, msg)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, ErrRejected):
+		p.logger.Info("Rejected inbound email", zap.String("id", env.ID), zap.Error(err))
+		return true
+	default:
+		p.logger.Error("Failed to handle inbound email", zap.String("id", env.ID), zap.Error(err))
+		return false
+	}
+}
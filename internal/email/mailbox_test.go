@@ -0,0 +1,134 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// handlerFunc adapts a function to a Handler.
+type handlerFunc func(ctx context.Context, msg *Message) error
+
+func (f handlerFunc) HandleEmail(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+// deliver puts a message in new/ of the maildir.
+func deliver(t *testing.T, dir, name, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "new", name), []byte(data), 0o640); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func TestMaildir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	m, err := NewMaildir(dir)
+	if err != nil {
+		t.Fatalf("NewMaildir: %v", err)
+	}
+	deliver(t, dir, "1760608800.M2.host", "second")
+	deliver(t, dir, "1760608800.M1.host", "first")
+	deliver(t, dir, "1760608800.M3.host", "third")
+	deliver(t, dir, ".hidden", "skipped")
+
+	envelopes, err := m.Fetch(ctx, 2)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(envelopes) != 2 || string(envelopes[0].Data) != "first" || string(envelopes[1].Data) != "second" {
+		t.Fatalf("fetched %d messages, want the two oldest in order", len(envelopes))
+	}
+
+	if err := m.Ack(ctx, envelopes[0].ID); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cur", "1760608800.M1.host:2,S")); err != nil {
+		t.Fatalf("acknowledged message not in cur/ flagged as seen: %v", err)
+	}
+	if err := m.Ack(ctx, "../cur/1760608800.M1.host:2,S"); err == nil {
+		t.Fatal("Ack accepted a path outside new/")
+	}
+
+	envelopes, err = m.Fetch(ctx, 10)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(envelopes) != 2 || string(envelopes[0].Data) != "second" {
+		t.Fatalf("fetched %d messages, want the two left", len(envelopes))
+	}
+}
+
+func TestPollerPoll(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	m, err := NewMaildir(dir)
+	if err != nil {
+		t.Fatalf("NewMaildir: %v", err)
+	}
+	deliver(t, dir, "1.good", "From: jane@example.com\r\nSubject: ok\r\n\r\nHello\r\n")
+	deliver(t, dir, "2.garbage", "no header here\r\n")
+	deliver(t, dir, "3.rejected", "From: jane@example.com\r\nSubject: reject\r\n\r\nHello\r\n")
+	deliver(t, dir, "4.failing", "From: jane@example.com\r\nSubject: fail\r\n\r\nHello\r\n")
+
+	var handled []string
+	handler := handlerFunc(func(ctx context.Context, msg *Message) error {
+		handled = append(handled, msg.Subject)
+		switch msg.Subject {
+		case "reject":
+			return ErrRejected
+		case "fail":
+			return errors.New("database down")
+		}
+		return nil
+	})
+	poller := NewPoller(m, handler, PollerConfig{}, zap.NewNop())
+
+	acked, err := poller.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	// The unparseable and rejected messages are dropped, the failed one kept
+	if acked != 3 {
+		t.Fatalf("acknowledged %d messages, want 3", acked)
+	}
+	if len(handled) != 3 {
+		t.Fatalf("handled %v, want the three parseable messages", handled)
+	}
+
+	left, err := m.Fetch(ctx, 10)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(left) != 1 || left[0].ID != "4.failing" {
+		t.Fatalf("left %d messages, want only the failed one for the next poll", len(left))
+	}
+}
+
+func TestPollerStopsWhenCancelled(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewMaildir(dir)
+	if err != nil {
+		t.Fatalf("NewMaildir: %v", err)
+	}
+	deliver(t, dir, "1.good", "From: jane@example.com\r\n\r\nHello\r\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	poller := NewPoller(m, &recorder{}, PollerConfig{}, zap.NewNop())
+	if _, err := poller.Poll(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Poll error = %v, want context.Canceled", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		poller.Run(ctx)
+		close(done)
+	}()
+	<-done
+}
@@ -0,0 +1,193 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outgoing is an email to send. Headers holds extra headers such as
+// Auto-Submitted.
+type Outgoing struct {
+	From       mail.Address
+	To         []string
+	Cc         []string
+	ReplyTo    string
+	Subject    string
+	Text       string
+	MessageID  string
+	InReplyTo  string
+	References []string
+	Headers    map[string]string
+}
+
+// Recipients returns the To and Cc addresses.
+func (m *Outgoing) Recipients() []string {
+	return append(append([]string(nil), m.To...), m.Cc...)
+}
+
+// Bytes formats the email as RFC 5322 text with a quoted-printable UTF-8
+// body. Line breaks in header values are dropped so values cannot add
+// headers of their own.
+func (m *Outgoing) Bytes() []byte {
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, headerValue(value))
+		}
+	}
+
+	header("From", m.From.String())
+	header("To", strings.Join(m.To, ", "))
+	header("Cc", strings.Join(m.Cc, ", "))
+	header("Reply-To", m.ReplyTo)
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", m.MessageID)
+	header("In-Reply-To", m.InReplyTo)
+	header("References", strings.Join(m.References, " "))
+	keys := make([]string, 0, len(m.Headers))
+	for k := range m.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		header(textproto.CanonicalMIMEHeaderKey(k), m.Headers[k])
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	qp.Write([]byte(strings.ReplaceAll(normalizeNewlines(m.Text), "\n", "\r\n")))
+	qp.Close()
+	return buf.Bytes()
+}
+
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
+
+// NewMessageID returns a new unique Message-ID under the domain.
+func NewMessageID(domain string) string {
+	return "<" + uuid.New().String() + "@" + domain + ">"
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, msg *Outgoing) error
+}
+
+// IsPermanent checks if a send failed for good, such as when the server
+// refused a recipient, so that retrying is pointless.
+func IsPermanent(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
+
+// SMTPMailerConfig holds the mail server email is sent through. STARTTLS
+// is used when the server offers it; Username turns on PLAIN
+// authentication, which needs TLS unless the server is local.
+type SMTPMailerConfig struct {
+	Addr      string
+	Username  string
+	Password  string
+	HelloName string
+	Timeout   time.Duration
+}
+
+// SMTPMailer sends email through an SMTP server, such as a relay or a
+// local mail sink.
+type SMTPMailer struct {
+	config SMTPMailerConfig
+}
+
+var _ Mailer = (*SMTPMailer)(nil)
+
+// NewSMTPMailer creates a new SMTP mailer
+func NewSMTPMailer(config SMTPMailerConfig) *SMTPMailer {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &SMTPMailer{config: config}
+}
+
+// Send delivers the email to the server in one SMTP transaction.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Outgoing) error {
+	recipients := msg.Recipients()
+	if len(recipients) == 0 {
+		return errors.New("email has no recipients")
+	}
+	host, _, err := net.SplitHostPort(m.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: m.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.config.Addr)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(m.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if m.config.HelloName != "" {
+		if err := client.Hello(m.config.HelloName); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(msg.From.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,281 @@
+// Package email receives support mail: it parses RFC 5322/MIME messages
+// delivered to the built-in SMTP listener or found in a polled mailbox and
+// hands them to a Handler.
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Errors returned while receiving mail
+var (
+	// ErrMalformed is returned for messages that cannot be parsed.
+	ErrMalformed = errors.New("malformed email message")
+	// ErrRejected is wrapped by handlers for messages that will never be
+	// accepted, so they are not retried.
+	ErrRejected = errors.New("email message rejected")
+)
+
+// maxPartDepth bounds how deeply nested multipart bodies are read.
+const maxPartDepth = 10
+
+// Handler processes received messages. Errors wrapping ErrRejected are
+// permanent; other errors are retried.
+type Handler interface {
+	HandleEmail(ctx context.Context, msg *Message) error
+}
+
+// Attachment is a file carried by a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a parsed email message. Recipients are the addresses it was
+// delivered to, which may differ from the To and Cc headers.
+type Message struct {
+	Header      mail.Header
+	From        *mail.Address
+	To          []*mail.Address
+	Cc          []*mail.Address
+	Recipients  []string
+	Subject     string
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	Date        time.Time
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Parse reads an RFC 5322 message. The text body is taken from the first
+// text/plain part, or from the first text/html part with the markup
+// removed; other parts with a filename, or marked as attachments, become
+// attachments.
+func Parse(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	decoder := new(mime.WordDecoder)
+	msg := &Message{
+		Header:     raw.Header,
+		Subject:    decodeHeader(decoder, raw.Header.Get("Subject")),
+		MessageID:  messageID(raw.Header.Get("Message-Id")),
+		InReplyTo:  messageID(raw.Header.Get("In-Reply-To")),
+		References: messageIDs(raw.Header.Get("References")),
+	}
+	if from, err := raw.Header.AddressList("From"); err == nil && len(from) > 0 {
+		msg.From = from[0]
+	}
+	msg.To, _ = raw.Header.AddressList("To")
+	msg.Cc, _ = raw.Header.AddressList("Cc")
+	if date, err := raw.Header.Date(); err == nil {
+		msg.Date = date
+	}
+	for _, key := range []string{"Delivered-To", "X-Original-To"} {
+		for _, value := range raw.Header[key] {
+			if addr, err := mail.ParseAddress(value); err == nil {
+				msg.Recipients = append(msg.Recipients, strings.ToLower(addr.Address))
+			}
+		}
+	}
+
+	if err := msg.readPart(raw.Header, raw.Body, 0); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if msg.Text == "" && msg.HTML != "" {
+		msg.Text = htmlToText(msg.HTML)
+	}
+	return msg, nil
+}
+
+// partHeader is the part of a MIME header the parser looks at.
+type partHeader interface {
+	Get(key string) string
+}
+
+// readPart reads one MIME part, descending into multipart bodies.
+func (m *Message) readPart(header partHeader, body io.Reader, depth int) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxPartDepth {
+			return errors.New("multipart nesting too deep")
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := m.readPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(transferDecoder(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if filename != "" {
+		filename = decodeHeader(new(mime.WordDecoder), filename)
+	}
+
+	switch {
+	case disposition == "attachment" || filename != "":
+		if filename == "" {
+			filename = "attachment"
+		}
+		m.Attachments = append(m.Attachments, Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Data:        data,
+		})
+	case mediaType == "text/plain" && m.Text == "":
+		m.Text = normalizeNewlines(decodeCharset(data, params["charset"]))
+	case mediaType == "text/html" && m.HTML == "":
+		m.HTML = decodeCharset(data, params["charset"])
+	}
+	return nil
+}
+
+// FromAddress returns the sender's address, lower-cased.
+func (m *Message) FromAddress() string {
+	if m.From == nil {
+		return ""
+	}
+	return strings.ToLower(m.From.Address)
+}
+
+// FromName returns the sender's display name, or the address without one.
+func (m *Message) FromName() string {
+	if m.From == nil {
+		return ""
+	}
+	if m.From.Name != "" {
+		return m.From.Name
+	}
+	return m.From.Address
+}
+
+func transferDecoder(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// base64Cleaner drops the line breaks and spaces base64 bodies are
+// wrapped with.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	kept := 0
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+			p[kept] = b
+			kept++
+		}
+	}
+	return kept, err
+}
+
+func decodeHeader(decoder *mime.WordDecoder, value string) string {
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// decodeCharset converts a body to UTF-8. Latin-1 bodies are converted;
+// other charsets are assumed to be UTF-8 compatible.
+func decodeCharset(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		runes := make([]rune, 0, len(data))
+		for _, b := range data {
+			runes = append(runes, rune(b))
+		}
+		return string(runes)
+	default:
+		return string(bytes.ToValidUTF8(data, []byte("�")))
+	}
+}
+
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+var messageIDPattern = regexp.MustCompile(`<[^<>\s]+>`)
+
+// messageID returns the first message ID of a header, with its brackets.
+func messageID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// messageIDs returns the message IDs of a header, with their brackets.
+func messageIDs(value string) []string {
+	return messageIDPattern.FindAllString(value, -1)
+}
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/tr|/h[1-6])\s*/?>`)
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces an HTML body to its text.
+func htmlToText(body string) string {
+	text := htmlDropPattern.ReplaceAllString(body, "")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(normalizeNewlines(text))
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankRunPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
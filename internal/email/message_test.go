@@ -0,0 +1,211 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// crlf joins lines into a raw message with CRLF line endings.
+func crlf(lines ...string) string {
+	return strings.Join(lines, "\r\n")
+}
+
+// nestedMessage returns a message of multipart bodies nested levels deep
+// around a text part.
+func nestedMessage(levels int) string {
+	body := crlf("Content-Type: text/plain", "", "deep")
+	for i := levels - 1; i >= 0; i-- {
+		boundary := fmt.Sprintf("b%d", i)
+		body = crlf(`Content-Type: multipart/mixed; boundary="`+boundary+`"`, "", "--"+boundary, body, "--"+boundary+"--")
+	}
+	return crlf("From: jane@example.com", body)
+}
+
+func mustParse(t *testing.T, raw string) *Message {
+	t.Helper()
+	msg, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return msg
+}
+
+func TestParseHeaders(t *testing.T) {
+	msg := mustParse(t, crlf(
+		"From: Jane Doe <Jane@Example.com>",
+		"To: support@shop.test, Partner <partner@example.com>",
+		"Cc: boss@example.com",
+		"Delivered-To: support+tag@shop.test",
+		"Subject: =?UTF-8?B?V2hlcmUgaXMgbXkgb3JkZXI/?=",
+		"Date: Fri, 16 Oct 2026 09:00:00 +0000",
+		"Message-ID: <reply@mail.example.com>",
+		"In-Reply-To: <notice@shop.test> (the notification)",
+		"References: <question@mail.example.com>\r\n <notice@shop.test>",
+		"",
+		"Hello",
+	))
+
+	if msg.FromAddress() != "jane@example.com" || msg.FromName() != "Jane Doe" {
+		t.Fatalf("from = %q %q, want Jane Doe <jane@example.com>", msg.FromName(), msg.FromAddress())
+	}
+	if len(msg.To) != 2 || len(msg.Cc) != 1 || msg.Cc[0].Address != "boss@example.com" {
+		t.Fatalf("to = %v cc = %v, want two and one addresses", msg.To, msg.Cc)
+	}
+	if len(msg.Recipients) != 1 || msg.Recipients[0] != "support+tag@shop.test" {
+		t.Fatalf("recipients = %v, want the Delivered-To address", msg.Recipients)
+	}
+	if msg.Subject != "Where is my order?" {
+		t.Fatalf("subject = %q, want the decoded subject", msg.Subject)
+	}
+	if msg.Date.IsZero() || msg.Date.Day() != 16 {
+		t.Fatalf("date = %v, want 16 October", msg.Date)
+	}
+	if msg.MessageID != "<reply@mail.example.com>" || msg.InReplyTo != "<notice@shop.test>" {
+		t.Fatalf("message id = %q in reply to %q", msg.MessageID, msg.InReplyTo)
+	}
+	if len(msg.References) != 2 || msg.References[1] != "<notice@shop.test>" {
+		t.Fatalf("references = %v, want both IDs", msg.References)
+	}
+	if msg.Text != "Hello" {
+		t.Fatalf("text = %q, want Hello", msg.Text)
+	}
+}
+
+func TestParseBodies(t *testing.T) {
+	tests := []struct {
+		name            string
+		raw             string
+		wantText        string
+		wantAttachments []Attachment
+	}{
+		{
+			name: "plain text",
+			raw: crlf(
+				"From: jane@example.com",
+				"",
+				"Line one",
+				"Line two",
+			),
+			wantText: "Line one\nLine two",
+		},
+		{
+			name: "text and HTML alternatives",
+			raw: crlf(
+				"From: jane@example.com",
+				`Content-Type: multipart/alternative; boundary="b1"`,
+				"",
+				"--b1",
+				"Content-Type: text/plain; charset=utf-8",
+				"",
+				"Plain text",
+				"--b1",
+				"Content-Type: text/html; charset=utf-8",
+				"",
+				"<p>HTML text</p>",
+				"--b1--",
+			),
+			wantText: "Plain text",
+		},
+		{
+			name: "HTML only",
+			raw: crlf(
+				"From: jane@example.com",
+				"Content-Type: text/html; charset=utf-8",
+				"",
+				"<html><head><style>p { color: red; }</style></head>",
+				"<body><p>Hello&nbsp;there</p><div>Fish &amp; chips<br>please</div>",
+				"<script>alert(1)</script></body></html>",
+			),
+			wantText: "Hello there\nFish & chips\nplease",
+		},
+		{
+			name:     "nested as deeply as allowed",
+			raw:      nestedMessage(maxPartDepth),
+			wantText: "deep",
+		},
+		{
+			name: "quoted-printable Latin-1",
+			raw: crlf(
+				"From: jane@example.com",
+				"Content-Type: text/plain; charset=iso-8859-1",
+				"Content-Transfer-Encoding: quoted-printable",
+				"",
+				"Gr=FC=DFe aus M=FCnchen",
+			),
+			wantText: "Grüße aus München",
+		},
+		{
+			name: "attachments",
+			raw: crlf(
+				"From: jane@example.com",
+				`Content-Type: multipart/mixed; boundary="b1"`,
+				"",
+				"--b1",
+				"Content-Type: text/plain",
+				"",
+				"See attached",
+				"--b1",
+				"Content-Type: application/pdf",
+				`Content-Disposition: attachment; filename="=?UTF-8?Q?Rechnung_M=C3=A4rz.pdf?="`,
+				"Content-Transfer-Encoding: base64",
+				"",
+				"JVBERi0x",
+				"LjQK",
+				"--b1",
+				`Content-Type: image/png; name="photo.png"`,
+				"",
+				"PNG",
+				"--b1",
+				"Content-Type: application/octet-stream",
+				"Content-Disposition: attachment",
+				"",
+				"data",
+				"--b1--",
+			),
+			wantText: "See attached",
+			wantAttachments: []Attachment{
+				{Filename: "Rechnung März.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4\n")},
+				{Filename: "photo.png", ContentType: "image/png", Data: []byte("PNG")},
+				{Filename: "attachment", ContentType: "application/octet-stream", Data: []byte("data")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := mustParse(t, tt.raw)
+			if msg.Text != tt.wantText {
+				t.Fatalf("text = %q, want %q", msg.Text, tt.wantText)
+			}
+			if len(msg.Attachments) != len(tt.wantAttachments) {
+				t.Fatalf("attachments = %d, want %d", len(msg.Attachments), len(tt.wantAttachments))
+			}
+			for i, want := range tt.wantAttachments {
+				got := msg.Attachments[i]
+				if got.Filename != want.Filename || got.ContentType != want.ContentType || string(got.Data) != string(want.Data) {
+					t.Fatalf("attachment %d = %q %s %q, want %q %s %q", i, got.Filename, got.ContentType, got.Data, want.Filename, want.ContentType, want.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "no header", raw: "just some text without headers\r\n"},
+		{name: "nested too deeply", raw: nestedMessage(maxPartDepth + 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tt.raw)); !errors.Is(err, ErrMalformed) {
+				t.Fatalf("Parse error = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package email
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Lines that start the quoted message in a reply
+	quoteHeaderPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^on\b.+\bwrote:$`),
+		regexp.MustCompile(`(?i)^-{2,}\s*original message\s*-{2,}$`),
+		regexp.MustCompile(`^_{10,}$`),
+	}
+	// Lines that start a signature or a mail client footer
+	signaturePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^--\s?$`),
+		regexp.MustCompile(`(?i)^sent from my \w+`),
+		regexp.MustCompile(`(?i)^get outlook for \w+`),
+	}
+	outlookHeaderPattern = regexp.MustCompile(`(?i)^(sent|date|to|subject):\s`)
+)
+
+// StripQuoted returns the new text of a reply: the quoted message, lines
+// quoted with ">" and the signature are removed. Text that would be left
+// empty is returned as it was.
+func StripQuoted(text string) string {
+	return strip(text, true)
+}
+
+// StripSignature removes the signature and mail client footer of a
+// message, keeping any quoted or forwarded text.
+func StripSignature(text string) string {
+	return strip(text, false)
+}
+
+func strip(text string, quotes bool) string {
+	lines := strings.Split(normalizeNewlines(text), "\n")
+
+	kept := make([]string, 0, len(lines))
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if matchesAny(signaturePatterns, line) || (quotes && startsQuote(lines, i)) {
+			break
+		}
+		if quotes && strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	stripped := strings.TrimSpace(strings.Join(kept, "\n"))
+	if stripped == "" {
+		return strings.TrimSpace(text)
+	}
+	return stripped
+}
+
+// startsQuote checks if line i starts the quoted message: an "On ...
+// wrote:" line, which clients may wrap onto the next line, a separator, or
+// an Outlook "From:" header block.
+func startsQuote(lines []string, i int) bool {
+	line := strings.TrimSpace(lines[i])
+	if matchesAny(quoteHeaderPatterns, line) {
+		return true
+	}
+	if i+1 < len(lines) {
+		next := strings.TrimSpace(lines[i+1])
+		if strings.HasPrefix(strings.ToLower(line), "on ") && strings.HasSuffix(strings.ToLower(next), "wrote:") {
+			return true
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(line), "from:") {
+		for j := i + 1; j < len(lines) && j <= i+3; j++ {
+			if outlookHeaderPattern.MatchString(strings.TrimSpace(lines[j])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []*regexp.Regexp, line string) bool {
+	for _, p := range patterns {
+		if p.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"strings"
+)
+
+// signatureBytes is how much of the HMAC a reply address carries: 80 bits,
+// 16 characters once encoded.
+const signatureBytes = 10
+
+var signatureEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ReplyAddresses builds and verifies the per-ticket addresses put in the
+// Reply-To of outgoing mail, such as
+// support+TKT-20261016-0042.k5q3xj2m4pnwdr7a@shop.test. The tag carries the
+// ticket number and an HMAC of it, so replies can be matched to their
+// ticket whatever the subject says, and no one who has not been sent the
+// address can make one up for another customer's ticket.
+type ReplyAddresses struct {
+	local  string
+	domain string
+	secret []byte
+}
+
+// NewReplyAddresses creates reply addresses under the support address,
+// signed with the secret.
+func NewReplyAddresses(address string, secret []byte) (*ReplyAddresses, error) {
+	local, domain, ok := strings.Cut(BaseAddress(strings.ToLower(strings.TrimSpace(address))), "@")
+	if !ok || local == "" || domain == "" {
+		return nil, errors.New("reply address: invalid support address")
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("reply address: secret is required")
+	}
+	return &ReplyAddresses{local: local, domain: domain, secret: secret}, nil
+}
+
+// Address returns the reply address of the ticket.
+func (r *ReplyAddresses) Address(ticketNumber string) string {
+	return r.local + "+" + ticketNumber + "." + r.sign(ticketNumber) + "@" + r.domain
+}
+
+// TicketNumber returns the ticket number of a reply address. It reports
+// false if the address is not a reply address or its signature does not
+// match.
+func (r *ReplyAddresses) TicketNumber(address string) (string, bool) {
+	local, domain, ok := strings.Cut(strings.TrimSpace(address), "@")
+	if !ok || !strings.EqualFold(domain, r.domain) {
+		return "", false
+	}
+	base, tag, ok := strings.Cut(local, "+")
+	if !ok || !strings.EqualFold(base, r.local) {
+		return "", false
+	}
+	i := strings.LastIndex(tag, ".")
+	if i <= 0 {
+		return "", false
+	}
+	number, signature := tag[:i], tag[i+1:]
+	// Mail systems may change the case of the local part
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(r.sign(number))) {
+		return "", false
+	}
+	return number, true
+}
+
+// sign returns the encoded HMAC of the ticket number, ignoring case.
+func (r *ReplyAddresses) sign(ticketNumber string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(strings.ToLower(ticketNumber)))
+	return strings.ToLower(signatureEncoding.EncodeToString(mac.Sum(nil)[:signatureBytes]))
+}
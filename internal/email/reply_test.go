@@ -0,0 +1,107 @@
+package email
+
+import "testing"
+
+func TestStripQuoted(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "plain reply",
+			text: "Thanks, that worked.\n",
+			want: "Thanks, that worked.",
+		},
+		{
+			name: "quote after an On ... wrote: line",
+			text: "Thanks!\n\nOn Fri, 16 Oct 2026 at 09:00, Support <support@shop.test> wrote:\n> Your order shipped.\n",
+			want: "Thanks!",
+		},
+		{
+			name: "On ... wrote: line wrapped by the client",
+			text: "Thanks!\n\nOn Fri, 16 Oct 2026 at 09:00, Support\n<support@shop.test> wrote:\n> Your order shipped.\n",
+			want: "Thanks!",
+		},
+		{
+			name: "inline quoted lines",
+			text: "> Which order?\nOrder 1001.\n> And the address?\nThe usual one.",
+			want: "Order 1001.\nThe usual one.",
+		},
+		{
+			name: "original message separator",
+			text: "See below.\r\n\r\n-----Original Message-----\r\nFrom: Support\r\n",
+			want: "See below.",
+		},
+		{
+			name: "Outlook header block",
+			text: "Got it.\n\nFrom: Support <support@shop.test>\nSent: Friday, 16 October 2026 09:00\nTo: Jane\nSubject: Your order\n",
+			want: "Got it.",
+		},
+		{
+			name: "signature",
+			text: "Any news?\n\n-- \nJane Doe\nAcme Ltd",
+			want: "Any news?",
+		},
+		{
+			name: "mobile footer",
+			text: "Any news?\n\nSent from my iPhone",
+			want: "Any news?",
+		},
+		{
+			name: "a From: line that is not a header block",
+			text: "From: the warehouse, it never left.\nPlease check.",
+			want: "From: the warehouse, it never left.\nPlease check.",
+		},
+		{
+			name: "nothing but quoted text",
+			text: "> Your order shipped.\n",
+			want: "> Your order shipped.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuoted(tt.text); got != tt.want {
+				t.Fatalf("StripQuoted = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripSignature(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "signature",
+			text: "Where is my order?\n\n--\nJane Doe",
+			want: "Where is my order?",
+		},
+		{
+			name: "Outlook footer",
+			text: "Where is my order?\n\nGet Outlook for Android",
+			want: "Where is my order?",
+		},
+		{
+			name: "forwarded text is kept",
+			text: "See the mail below.\n\n-----Original Message-----\nFrom: Courier\n> Parcel lost.\n\n-- \nJane",
+			want: "See the mail below.\n\n-----Original Message-----\nFrom: Courier\n> Parcel lost.",
+		},
+		{
+			name: "nothing but a signature",
+			text: "-- \nJane Doe",
+			want: "-- \nJane Doe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripSignature(tt.text); got != tt.want {
+				t.Fatalf("StripSignature = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// handleTimeout bounds how long one message may take to handle.
+const handleTimeout = time.Minute
+
+// maxLineLength is the longest command line taken, CRLF included; RFC 5321
+// allows no text line to be longer.
+const maxLineLength = 1000
+
+// errLineTooLong reports a command line over maxLineLength.
+var errLineTooLong = errors.New("smtp: line too long")
+
+// SMTPConfig tunes the SMTP listener. Recipients are the addresses mail is
+// accepted for; plus-addressed variants such as support+tag@ are accepted
+// too, and an empty list accepts any address.
+type SMTPConfig struct {
+	Addr          string
+	Domain        string
+	Recipients    []string
+	MaxSize       int64
+	MaxRecipients int
+	Timeout       time.Duration
+}
+
+// DefaultSMTPConfig returns the listener settings used when none are
+// configured.
+func DefaultSMTPConfig() SMTPConfig {
+	return SMTPConfig{
+		Addr:          ":2525",
+		Domain:        "localhost",
+		MaxSize:       25 << 20,
+		MaxRecipients: 100,
+		Timeout:       5 * time.Minute,
+	}
+}
+
+// SMTPServer is a minimal SMTP listener that receives mail for the support
+// addresses. It has no TLS or authentication and is meant to sit behind
+// the mail relay that delivers support mail to it.
+type SMTPServer struct {
+	config  SMTPConfig
+	handler Handler
+	logger  *zap.Logger
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    sync.WaitGroup
+}
+
+// NewSMTPServer creates a new SMTP listener
+func NewSMTPServer(config SMTPConfig, handler Handler, logger *zap.Logger) *SMTPServer {
+	defaults := DefaultSMTPConfig()
+	if config.Addr == "" {
+		config.Addr = defaults.Addr
+	}
+	if config.Domain == "" {
+		config.Domain = defaults.Domain
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaults.MaxSize
+	}
+	if config.MaxRecipients <= 0 {
+		config.MaxRecipients = defaults.MaxRecipients
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	return &SMTPServer{
+		config:  config,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// ListenAndServe listens on the configured address and serves until the
+// context is cancelled.
+func (s *SMTPServer) ListenAndServe(ctx context.Context) error {
+	l, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, l)
+}
+
+// Serve accepts connections on the listener until the context is
+// cancelled, then waits for open sessions to finish.
+func (s *SMTPServer) Serve(ctx context.Context, l net.Listener) error {
+	s.mu.Lock()
+	s.listener = l
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.conns.Wait()
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		s.conns.Add(1)
+		go func() {
+			defer s.conns.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// Addr returns the address the server listens on, or nil before Serve.
+func (s *SMTPServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// smtpSession is the state of one SMTP connection. Everything read from
+// the connection goes through reader, which buffers no more than a command
+// line, so a client cannot make the session hold more than a line or a
+// message of the configured size.
+type smtpSession struct {
+	server     *SMTPServer
+	conn       net.Conn
+	reader     *bufio.Reader
+	writer     *textproto.Writer
+	greeted    bool
+	from       string
+	hasFrom    bool
+	recipients []string
+}
+
+func (s *SMTPServer) serveConn(ctx context.Context, conn net.Conn) {
+	done := make(chan struct{})
+	defer close(done)
+	defer conn.Close()
+	// Drop idle sessions on shutdown
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	session := &smtpSession{
+		server: s,
+		conn:   conn,
+		reader: bufio.NewReaderSize(conn, maxLineLength),
+		writer: textproto.NewWriter(bufio.NewWriter(conn)),
+	}
+	session.reply(220, s.config.Domain+" ESMTP support mail ready")
+
+	for {
+		conn.SetDeadline(time.Now().Add(s.config.Timeout))
+		line, err := session.readLine()
+		if errors.Is(err, errLineTooLong) {
+			session.reply(500, "Line too long")
+			return
+		}
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if !session.handle(ctx, strings.ToUpper(verb), strings.TrimSpace(arg)) {
+			return
+		}
+	}
+}
+
+// handle runs one command and reports whether the session goes on.
+func (c *smtpSession) handle(ctx context.Context, verb, arg string) bool {
+	config := c.server.config
+	switch verb {
+	case "HELO":
+		c.greeted = true
+		c.reset()
+		c.reply(250, config.Domain)
+	case "EHLO":
+		c.greeted = true
+		c.reset()
+		c.reply(250, config.Domain, "SIZE "+strconv.FormatInt(config.MaxSize, 10), "8BITMIME")
+	case "MAIL":
+		if !c.greeted {
+			c.reply(503, "Send HELO or EHLO first")
+			break
+		}
+		from, params, ok := parsePath(arg, "FROM:")
+		if !ok {
+			c.reply(501, "Syntax: MAIL FROM:<address>")
+			break
+		}
+		if size, err := strconv.ParseInt(params["SIZE"], 10, 64); err == nil && size > config.MaxSize {
+			c.reply(552, "Message size exceeds the limit")
+			break
+		}
+		c.reset()
+		c.from, c.hasFrom = from, true
+		c.reply(250, "OK")
+	case "RCPT":
+		if !c.hasFrom {
+			c.reply(503, "Send MAIL first")
+			break
+		}
+		to, _, ok := parsePath(arg, "TO:")
+		if !ok || to == "" {
+			c.reply(501, "Syntax: RCPT TO:<address>")
+			break
+		}
+		if len(c.recipients) >= config.MaxRecipients {
+			c.reply(452, "Too many recipients")
+			break
+		}
+		if !c.server.accepts(to) {
+			c.reply(550, "No such mailbox")
+			break
+		}
+		c.recipients = append(c.recipients, strings.ToLower(to))
+		c.reply(250, "OK")
+	case "DATA":
+		if len(c.recipients) == 0 {
+			c.reply(503, "Send RCPT first")
+			break
+		}
+		c.reply(354, "End data with <CR><LF>.<CR><LF>")
+		if !c.receive(ctx) {
+			return false
+		}
+		c.reset()
+	case "RSET":
+		c.reset()
+		c.reply(250, "OK")
+	case "NOOP":
+		c.reply(250, "OK")
+	case "VRFY":
+		c.reply(252, "Cannot verify addresses")
+	case "QUIT":
+		c.reply(221, "Bye")
+		return false
+	default:
+		c.reply(502, "Command not implemented")
+	}
+	return true
+}
+
+// readLine reads a command line without its CRLF.
+func (c *smtpSession) readLine() (string, error) {
+	line, err := c.reader.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", errLineTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+// receive reads the message data and hands the message to the handler. It
+// reports whether the session goes on: the rest of a message over the size
+// limit is not read, so the session ends.
+func (c *smtpSession) receive(ctx context.Context) bool {
+	maxSize := c.server.config.MaxSize
+	c.conn.SetDeadline(time.Now().Add(c.server.config.Timeout))
+	body := textproto.NewReader(c.reader).DotReader()
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		c.reply(451, "Error reading message")
+		return false
+	}
+	if int64(len(data)) > maxSize {
+		c.reply(552, "Message size exceeds the limit")
+		return false
+	}
+
+	// Record the envelope sender as a final delivery would
+	raw := append([]byte(fmt.Sprintf("Return-Path: <%s>\r\n", c.from)), data...)
+	msg, err := Parse(bytes.NewReader(raw))
+	if err != nil {
+		c.reply(554, "Message could not be parsed")
+		return true
+	}
+	msg.Recipients = append([]string(nil), c.recipients...)
+
+	handleCtx, cancel := context.WithTimeout(ctx, handleTimeout)
+	defer cancel()
+	if err := c.server.handler.HandleEmail(handleCtx, msg); err != nil {
+		if errors.Is(err, ErrRejected) {
+			c.server.logger.Info("Rejected inbound email", zap.String("message_id", msg.MessageID), zap.Error(err))
+			c.reply(550, "Message rejected")
+			return true
+		}
+		c.server.logger.Error("Failed to handle inbound email", zap.String("message_id", msg.MessageID), zap.Error(err))
+		c.reply(451, "Temporary failure, try again later")
+		return true
+	}
+	c.reply(250, "OK: queued")
+	return true
+}
+
+func (c *smtpSession) reset() {
+	c.from, c.hasFrom = "", false
+	c.recipients = nil
+}
+
+// reply writes a reply, on several lines when given several texts.
+func (c *smtpSession) reply(code int, lines ...string) {
+	for i, line := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		c.writer.PrintfLine("%d%s%s", code, sep, line)
+	}
+}
+
+// accepts checks if mail for the address is taken.
+func (s *SMTPServer) accepts(address string) bool {
+	if len(s.config.Recipients) == 0 {
+		return true
+	}
+	address = BaseAddress(address)
+	for _, r := range s.config.Recipients {
+		if strings.EqualFold(r, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// BaseAddress removes the +tag of a plus-addressed address.
+func BaseAddress(address string) string {
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok {
+		return address
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + domain
+}
+
+// parsePath parses the argument of MAIL or RCPT, e.g.
+// "FROM:<a@example.com> SIZE=1000", into the address and its parameters.
+func parsePath(arg, prefix string) (string, map[string]string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	rest := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", nil, false
+	}
+	end := strings.Index(rest, ">")
+	if end < 0 {
+		return "", nil, false
+	}
+	address := rest[1:end]
+	// Drop a source route, e.g. <@relay:a@example.com>
+	if i := strings.LastIndex(address, ":"); strings.HasPrefix(address, "@") && i >= 0 {
+		address = address[i+1:]
+	}
+
+	params := make(map[string]string)
+	for _, p := range strings.Fields(rest[end+1:]) {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = v
+	}
+	return address, params, true
+}
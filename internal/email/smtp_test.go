@@ -0,0 +1,307 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recorder keeps the messages handled, failing them with err.
+type recorder struct {
+	mu       sync.Mutex
+	messages []*Message
+	err      error
+}
+
+func (r *recorder) HandleEmail(ctx context.Context, msg *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return r.err
+}
+
+func (r *recorder) received() []*Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Message(nil), r.messages...)
+}
+
+// startSMTP serves the handler on a local port until the test ends.
+func startSMTP(t *testing.T, config SMTPConfig, handler Handler) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	server := NewSMTPServer(config, handler, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, l) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return l.Addr().String()
+}
+
+// smtpClient connects to the server and reads its greeting.
+func smtpClient(t *testing.T, addr string) *textproto.Conn {
+	t.Helper()
+	c, err := textproto.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	expect(t, c, 220)
+	return c
+}
+
+// send writes a command and checks the reply code.
+func send(t *testing.T, c *textproto.Conn, code int, format string, args ...any) string {
+	t.Helper()
+	if err := c.PrintfLine(format, args...); err != nil {
+		t.Fatalf("send %q: %v", format, err)
+	}
+	return expect(t, c, code)
+}
+
+func expect(t *testing.T, c *textproto.Conn, code int) string {
+	t.Helper()
+	got, msg, err := c.ReadResponse(0)
+	if got != code {
+		t.Fatalf("reply = %d %s (%v), want %d", got, msg, err, code)
+	}
+	return msg
+}
+
+// sendMessage runs the envelope and DATA for one message and returns the
+// reply code to the data.
+func sendMessage(t *testing.T, c *textproto.Conn, rcpt, data string) (int, string) {
+	t.Helper()
+	send(t, c, 250, "MAIL FROM:<jane@example.com>")
+	send(t, c, 250, "RCPT TO:<%s>", rcpt)
+	send(t, c, 354, "DATA")
+	w := c.DotWriter()
+	if _, err := w.Write([]byte(data)); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close data: %v", err)
+	}
+	code, msg, _ := c.ReadResponse(0)
+	return code, msg
+}
+
+func TestSMTPServerReceivesMail(t *testing.T) {
+	handler := &recorder{}
+	addr := startSMTP(t, SMTPConfig{Domain: "shop.test", Recipients: []string{"support@shop.test"}}, handler)
+	c := smtpClient(t, addr)
+
+	ehlo := send(t, c, 250, "EHLO client.example.com")
+	if !strings.Contains(ehlo, "SIZE 26214400") {
+		t.Fatalf("EHLO = %q, want the size limit advertised", ehlo)
+	}
+	code, _ := sendMessage(t, c, "Support+TKT-1.sig@shop.test", crlf(
+		"From: Jane <jane@example.com>",
+		"Subject: Where is my order?",
+		"",
+		"Hello",
+		".leading dot",
+		"",
+	))
+	if code != 250 {
+		t.Fatalf("DATA reply = %d, want 250", code)
+	}
+	send(t, c, 221, "QUIT")
+
+	received := handler.received()
+	if len(received) != 1 {
+		t.Fatalf("handled %d messages, want 1", len(received))
+	}
+	msg := received[0]
+	if msg.Subject != "Where is my order?" || msg.Text != "Hello\n.leading dot\n" {
+		t.Fatalf("message %q %q, want the sent subject and text", msg.Subject, msg.Text)
+	}
+	if len(msg.Recipients) != 1 || msg.Recipients[0] != "support+tkt-1.sig@shop.test" {
+		t.Fatalf("recipients = %v, want the envelope recipient", msg.Recipients)
+	}
+	if got := msg.Header.Get("Return-Path"); got != "<jane@example.com>" {
+		t.Fatalf("Return-Path = %q, want the envelope sender", got)
+	}
+}
+
+func TestSMTPServerReplies(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  error
+		config   SMTPConfig
+		commands func(t *testing.T, c *textproto.Conn)
+	}{
+		{
+			name: "commands out of order",
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 503, "MAIL FROM:<jane@example.com>")
+				send(t, c, 250, "HELO client.example.com")
+				send(t, c, 503, "RCPT TO:<support@shop.test>")
+				send(t, c, 250, "MAIL FROM:<jane@example.com>")
+				send(t, c, 503, "DATA")
+				send(t, c, 502, "EXPN staff")
+			},
+		},
+		{
+			name: "bad syntax",
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				send(t, c, 501, "MAIL FROM:jane@example.com")
+				send(t, c, 250, "MAIL FROM:<>")
+				send(t, c, 501, "RCPT TO:<>")
+			},
+		},
+		{
+			name: "unknown mailbox",
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				send(t, c, 250, "MAIL FROM:<jane@example.com>")
+				send(t, c, 550, "RCPT TO:<sales@shop.test>")
+			},
+		},
+		{
+			name:   "too many recipients",
+			config: SMTPConfig{MaxRecipients: 1},
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				send(t, c, 250, "MAIL FROM:<jane@example.com>")
+				send(t, c, 250, "RCPT TO:<support@shop.test>")
+				send(t, c, 452, "RCPT TO:<support+other@shop.test>")
+			},
+		},
+		{
+			name:   "declared size over the limit",
+			config: SMTPConfig{MaxSize: 100},
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "EHLO client.example.com")
+				send(t, c, 552, "MAIL FROM:<jane@example.com> SIZE=101")
+				send(t, c, 250, "MAIL FROM:<jane@example.com> SIZE=100")
+			},
+		},
+		{
+			name:    "rejected by the handler",
+			handler: ErrRejected,
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				if code, msg := sendMessage(t, c, "support@shop.test", "From: jane@example.com\r\n\r\nHi\r\n"); code != 550 {
+					t.Fatalf("DATA reply = %d %s, want 550", code, msg)
+				}
+			},
+		},
+		{
+			name:    "handler failure",
+			handler: errors.New("database down"),
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				if code, msg := sendMessage(t, c, "support@shop.test", "From: jane@example.com\r\n\r\nHi\r\n"); code != 451 {
+					t.Fatalf("DATA reply = %d %s, want 451", code, msg)
+				}
+				// The session goes on
+				send(t, c, 250, "NOOP")
+			},
+		},
+		{
+			name: "unparseable message",
+			commands: func(t *testing.T, c *textproto.Conn) {
+				send(t, c, 250, "HELO client.example.com")
+				if code, msg := sendMessage(t, c, "support@shop.test", "no header here\r\n"); code != 554 {
+					t.Fatalf("DATA reply = %d %s, want 554", code, msg)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.Recipients = []string{"support@shop.test"}
+			addr := startSMTP(t, config, &recorder{err: tt.handler})
+			c := smtpClient(t, addr)
+			tt.commands(t, c)
+			send(t, c, 221, "QUIT")
+		})
+	}
+}
+
+func TestSMTPServerLimits(t *testing.T) {
+	t.Run("message over the size limit ends the session", func(t *testing.T) {
+		handler := &recorder{}
+		addr := startSMTP(t, SMTPConfig{MaxSize: 1024}, handler)
+		c := smtpClient(t, addr)
+		send(t, c, 250, "HELO client.example.com")
+
+		data := "From: jane@example.com\r\n\r\n" + strings.Repeat("x", 2048) + "\r\n"
+		if code, msg := sendMessage(t, c, "support@shop.test", data); code != 552 {
+			t.Fatalf("DATA reply = %d %s, want 552", code, msg)
+		}
+		if _, _, err := c.ReadResponse(0); err == nil {
+			t.Fatal("session still open after an oversized message")
+		}
+		if len(handler.received()) != 0 {
+			t.Fatal("oversized message was handled")
+		}
+	})
+
+	t.Run("command line over the length limit ends the session", func(t *testing.T) {
+		addr := startSMTP(t, SMTPConfig{}, &recorder{})
+		c := smtpClient(t, addr)
+
+		// Sent in the background, as the server stops reading mid-line
+		go c.PrintfLine("HELO %s", strings.Repeat("a", 1<<20))
+		expect(t, c, 500)
+		if _, _, err := c.ReadResponse(0); err == nil {
+			t.Fatal("session still open after an overlong line")
+		}
+	})
+
+	t.Run("command line at the length limit", func(t *testing.T) {
+		addr := startSMTP(t, SMTPConfig{}, &recorder{})
+		c := smtpClient(t, addr)
+		// 1000 bytes with the CRLF
+		send(t, c, 250, "HELO %s", strings.Repeat("a", maxLineLength-len("HELO ")-2))
+	})
+
+	t.Run("idle session times out", func(t *testing.T) {
+		addr := startSMTP(t, SMTPConfig{Timeout: 50 * time.Millisecond}, &recorder{})
+		c := smtpClient(t, addr)
+		time.Sleep(100 * time.Millisecond)
+		if err := c.PrintfLine("NOOP"); err == nil {
+			if _, _, err := c.ReadResponse(0); err == nil {
+				t.Fatal("idle session still open")
+			}
+		}
+	})
+}
+
+func TestBaseAddress(t *testing.T) {
+	tests := map[string]string{
+		"support@shop.test":             "support@shop.test",
+		"support+TKT-1.sig@shop.test":   "support@shop.test",
+		"support+a+b@shop.test":         "support@shop.test",
+		"not an address":                "not an address",
+		"support+tag@sub.shop.test":     "support@sub.shop.test",
+		"Support+Tag@Shop.Test":         "Support@Shop.Test",
+		"+only-a-tag@shop.test":         "@shop.test",
+		"support@shop.test+not-the-tag": "support@shop.test+not-the-tag",
+	}
+	for address, want := range tests {
+		if got := BaseAddress(address); got != want {
+			t.Errorf("BaseAddress(%q) = %q, want %q", address, got, want)
+		}
+	}
+}
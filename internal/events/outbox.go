@@ -0,0 +1,332 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// OutboxMessage is an encoded domain event waiting in the outbox to be
+// relayed to NATS.
+type OutboxMessage struct {
+	ID          uuid.UUID
+	AggregateID uuid.UUID
+	EventType   string
+	Subject     string
+	Payload     []byte
+	Attempts    int
+	CreatedAt   time.Time
+}
+
+// OutboxStore is the relay's view of the outbox. Messages of one aggregate
+// are handed out strictly in the order they were written.
+type OutboxStore interface {
+	// ClaimPending leases up to limit due messages for the given duration.
+	// Only the oldest pending message of each aggregate is returned, so a
+	// ticket's events are never published out of order, even when several
+	// relays share the table.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+
+	// MarkPublished records a successful publish.
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+
+	// MarkFailed records a failed attempt and schedules the next one.
+	MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt time.Time) error
+
+	// MarkDead moves a message to the dead-letter state after its last
+	// failed attempt. Dead messages no longer block their aggregate.
+	MarkDead(ctx context.Context, id uuid.UUID, lastErr string) error
+}
+
+// Encode turns the domain events raised by a ticket into outbox messages.
+// Events without a subscriber-facing subject, internal notes and the opening
+// message and team of a new ticket (covered by the created event) are dropped.
+func Encode(t *ticket.Ticket, events []ticket.Event) ([]OutboxMessage, error) {
+	created := false
+	for _, event := range events {
+		if _, ok := event.(ticket.TicketCreatedEvent); ok {
+			created = true
+		}
+	}
+
+	messages := make([]OutboxMessage, 0, len(events))
+	for _, event := range events {
+		var subject string
+		var payload interface{}
+		switch e := event.(type) {
+		case ticket.TicketCreatedEvent:
+			subject, payload = EventTicketCreated, newTicketCreatedEvent(t)
+		case ticket.MessageAddedEvent:
+			if e.IsInternal || created {
+				continue
+			}
+			for _, msg := range t.Messages() {
+				if msg.ID() == e.MessageID {
+					subject, payload = EventTicketReplied, newTicketReplyEvent(t, msg)
+					break
+				}
+			}
+		case ticket.TicketResolvedEvent:
+			subject, payload = EventTicketResolved, newTicketSummaryEvent(t)
+		case ticket.TicketClosedEvent:
+			subject, payload = EventTicketClosed, newTicketSummaryEvent(t)
+		case ticket.TicketAssignedEvent:
+			subject, payload = EventTicketAssigned, newTicketAssignedEvent(t, e)
+		case ticket.TicketTeamChangedEvent:
+			if created {
+				continue
+			}
+			subject, payload = EventTicketUpdated, newTicketUpdatedEvent(t, event.EventType())
+		case ticket.TicketStatusChangedEvent, ticket.TicketEscalatedEvent:
+			subject, payload = EventTicketUpdated, newTicketUpdatedEvent(t, event.EventType())
+		case ticket.TicketSLAWarningEvent:
+			subject, payload = EventTicketSLAWarning, newTicketSLAEvent(t, e.Clock, e.Deadline, e.OccurredAt())
+		case ticket.TicketSLABreachedEvent:
+			subject, payload = EventTicketSLABreached, newTicketSLAEvent(t, e.Clock, e.Deadline, e.OccurredAt())
+		case ticket.TicketPendingReminderEvent:
+			subject, payload = EventTicketPendingReminder, newTicketIdleEvent(t, e.PendingSince, e.OccurredAt())
+		case ticket.TicketStaleEvent:
+			subject, payload = EventTicketStale, newTicketIdleEvent(t, e.IdleSince, e.OccurredAt())
+		case ticket.TicketMergedEvent:
+			subject, payload = EventTicketMerged, newTicketMergedEvent(t, e)
+		case ticket.AgentMentionedEvent:
+			for _, msg := range t.Messages() {
+				if msg.ID() == e.MessageID {
+					subject, payload = EventAgentMentioned, newAgentMentionedEvent(t, msg, e)
+					break
+				}
+			}
+		}
+		if subject == "" {
+			continue
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, OutboxMessage{
+			ID:          uuid.New(),
+			AggregateID: t.ID(),
+			EventType:   event.EventType(),
+			Subject:     subject,
+			Payload:     data,
+			CreatedAt:   event.OccurredAt(),
+		})
+	}
+	return messages, nil
+}
+
+func newTicketCreatedEvent(t *ticket.Ticket) TicketCreatedEvent {
+	event := TicketCreatedEvent{
+		TicketID:     t.ID().String(),
+		TicketNumber: t.TicketNumber().Value(),
+		Subject:      t.Subject(),
+		GuestEmail:   t.GuestEmail(),
+		GuestName:    t.GuestName(),
+		Priority:     string(t.Priority()),
+	}
+
+	if t.CustomerID() != nil {
+		event.CustomerID = t.CustomerID().String()
+	}
+	if t.CategoryID() != nil {
+		event.CategoryID = t.CategoryID().String()
+	}
+	if t.TeamID() != nil {
+		event.TeamID = t.TeamID().String()
+	}
+	return event
+}
+
+func newTicketReplyEvent(t *ticket.Ticket, message ticket.Message) TicketReplyEvent {
+	event := TicketReplyEvent{
+		TicketID:       t.ID().String(),
+		TicketNumber:   t.TicketNumber().Value(),
+		Subject:        t.Subject(),
+		MessageID:      message.ID().String(),
+		MessageContent: message.Content(),
+		SenderType:     string(message.SenderType()),
+		GuestEmail:     t.GuestEmail(),
+		IsAgentReply:   message.IsFromAgent(),
+		Recipients:     replyRecipients(t, message),
+		WatcherIDs:     watcherIDs(t, message.SenderID()),
+	}
+
+	if t.CustomerID() != nil {
+		event.CustomerID = t.CustomerID().String()
+	}
+	return event
+}
+
+// replyRecipients returns the addresses a reply is emailed to: the
+// customer and the CC addresses, less whoever sent it.
+func replyRecipients(t *ticket.Ticket, message ticket.Message) []string {
+	recipients := make([]string, 0, len(t.CC())+1)
+	for _, email := range append([]string{t.GuestEmail()}, t.CC()...) {
+		if email == "" || strings.EqualFold(email, message.SenderEmail()) {
+			continue
+		}
+		if message.IsFromCustomer() && email == t.GuestEmail() {
+			continue
+		}
+		recipients = append(recipients, email)
+	}
+	return recipients
+}
+
+// watcherIDs returns the agents following the ticket, less the one who
+// made the change.
+func watcherIDs(t *ticket.Ticket, changedBy *uuid.UUID) []string {
+	var ids []string
+	for _, id := range t.Watchers() {
+		if changedBy != nil && id == *changedBy {
+			continue
+		}
+		ids = append(ids, id.String())
+	}
+	return ids
+}
+
+func newTicketAssignedEvent(t *ticket.Ticket, e ticket.TicketAssignedEvent) TicketAssignedEvent {
+	return TicketAssignedEvent{
+		TicketID:     t.ID().String(),
+		TicketNumber: t.TicketNumber().Value(),
+		Subject:      t.Subject(),
+		AgentID:      e.AgentID.String(),
+		Reason:       e.Reason,
+		Auto:         e.Auto,
+		Status:       string(t.Status()),
+		Priority:     string(t.Priority()),
+	}
+}
+
+func newTicketUpdatedEvent(t *ticket.Ticket, change string) TicketUpdatedEvent {
+	event := TicketUpdatedEvent{
+		TicketID:     t.ID().String(),
+		TicketNumber: t.TicketNumber().Value(),
+		Change:       change,
+		Status:       string(t.Status()),
+		Priority:     string(t.Priority()),
+		WatcherIDs:   watcherIDs(t, nil),
+	}
+
+	if t.TeamID() != nil {
+		event.TeamID = t.TeamID().String()
+	}
+	if t.AssignedTo() != nil {
+		event.AssignedTo = t.AssignedTo().String()
+	}
+	return event
+}
+
+func newTicketSLAEvent(t *ticket.Ticket, clock string, deadline, detectedAt time.Time) TicketSLAEvent {
+	event := TicketSLAEvent{
+		TicketID:     t.ID().String(),
+		TicketNumber: t.TicketNumber().Value(),
+		Subject:      t.Subject(),
+		Status:       string(t.Status()),
+		Priority:     string(t.Priority()),
+		Clock:        clock,
+		SLADeadline:  deadline,
+		DetectedAt:   detectedAt,
+	}
+
+	if t.AssignedTo() != nil {
+		event.AssignedTo = t.AssignedTo().String()
+	}
+	if t.CategoryID() != nil {
+		event.CategoryID = t.CategoryID().String()
+	}
+	return event
+}
+
+func newTicketIdleEvent(t *ticket.Ticket, idleSince, detectedAt time.Time) TicketIdleEvent {
+	event := TicketIdleEvent{
+		TicketID:     t.ID().String(),
+		TicketNumber: t.TicketNumber().Value(),
+		Subject:      t.Subject(),
+		Status:       string(t.Status()),
+		Priority:     string(t.Priority()),
+		GuestEmail:   t.GuestEmail(),
+		GuestName:    t.GuestName(),
+		IdleSince:    idleSince,
+		DetectedAt:   detectedAt,
+	}
+
+	if t.CustomerID() != nil {
+		event.CustomerID = t.CustomerID().String()
+	}
+	if t.AssignedTo() != nil {
+		event.AssignedTo = t.AssignedTo().String()
+	}
+	if t.TeamID() != nil {
+		event.TeamID = t.TeamID().String()
+	}
+	if t.CategoryID() != nil {
+		event.CategoryID = t.CategoryID().String()
+	}
+	return event
+}
+
+func newTicketSummaryEvent(t *ticket.Ticket) map[string]interface{} {
+	event := map[string]interface{}{
+		"ticket_id":     t.ID().String(),
+		"ticket_number": t.TicketNumber().Value(),
+		"subject":       t.Subject(),
+		"customer_id":   "",
+		"guest_email":   t.GuestEmail(),
+	}
+
+	if t.CustomerID() != nil {
+		event["customer_id"] = t.CustomerID().String()
+	}
+	return event
+}
+
+func newTicketMergedEvent(t *ticket.Ticket, e ticket.TicketMergedEvent) TicketMergedEvent {
+	event := TicketMergedEvent{
+		TicketID:            t.ID().String(),
+		TicketNumber:        t.TicketNumber().Value(),
+		Subject:             t.Subject(),
+		GuestEmail:          t.GuestEmail(),
+		MergedTicketIDs:     make([]string, 0, len(e.SourceIDs)),
+		MergedTicketNumbers: e.SourceNumbers,
+		Forced:              e.Forced,
+		MergedAt:            e.OccurredAt(),
+	}
+
+	for _, id := range e.SourceIDs {
+		event.MergedTicketIDs = append(event.MergedTicketIDs, id.String())
+	}
+	if t.CustomerID() != nil {
+		event.CustomerID = t.CustomerID().String()
+	}
+	if e.MergedBy != nil {
+		event.MergedBy = e.MergedBy.String()
+	}
+	return event
+}
+
+func newAgentMentionedEvent(t *ticket.Ticket, message ticket.Message, e ticket.AgentMentionedEvent) AgentMentionedEvent {
+	event := AgentMentionedEvent{
+		TicketID:        t.ID().String(),
+		TicketNumber:    t.TicketNumber().Value(),
+		Subject:         t.Subject(),
+		MessageID:       message.ID().String(),
+		MessageContent:  message.Content(),
+		AgentID:         e.AgentID.String(),
+		AgentName:       e.AgentName,
+		AgentEmail:      e.AgentEmail,
+		MentionedByName: message.SenderName(),
+	}
+
+	if message.SenderID() != nil {
+		event.MentionedBy = message.SenderID().String()
+	}
+	return event
+}
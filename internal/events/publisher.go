@@ -1,21 +1,38 @@
 package events
 
 import (
-	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/nats-io/nats.go"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
 )
 
 // Event types
 const (
 	EventTicketCreated  = "support.ticket.created"
 	EventTicketUpdated  = "support.ticket.updated"
+	EventTicketAssigned = "support.ticket.assigned"
 	EventTicketReplied  = "support.ticket.replied"
 	EventTicketResolved = "support.ticket.resolved"
 	EventTicketClosed   = "support.ticket.closed"
+
+	EventTicketSLAWarning  = "support.ticket.sla_warning"
+	EventTicketSLABreached = "support.ticket.sla_breached"
+
+	EventTicketPendingReminder = "support.ticket.pending_reminder"
+	EventTicketStale           = "support.ticket.stale"
+
+	EventTicketMerged = "support.ticket.merged"
+
+	EventAgentMentioned = "support.agent.mentioned"
 )
 
+// ErrNotConnected is returned when publishing without a NATS connection.
+var ErrNotConnected = errors.New("nats not connected")
+
+// flushTimeout bounds how long Publish waits for the server round trip.
+const flushTimeout = 5 * time.Second
+
 // Publisher handles NATS event publishing
 type Publisher struct {
 	nc *nats.Conn
@@ -35,103 +52,130 @@ type TicketCreatedEvent struct {
 	GuestEmail   string `json:"guest_email,omitempty"`
 	GuestName    string `json:"guest_name,omitempty"`
 	CategoryID   string `json:"category_id,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
 	Priority     string `json:"priority"`
 }
 
-// TicketReplyEvent represents ticket reply event
+// TicketReplyEvent represents ticket reply event. Recipients are the
+// addresses to email the reply to; WatcherIDs are the agents following the
+// ticket, other than the one replying.
 type TicketReplyEvent struct {
-	TicketID       string `json:"ticket_id"`
-	TicketNumber   string `json:"ticket_number"`
-	Subject        string `json:"subject"`
-	MessageID      string `json:"message_id"`
-	MessageContent string `json:"message_content"`
-	SenderType     string `json:"sender_type"`
-	CustomerID     string `json:"customer_id,omitempty"`
-	GuestEmail     string `json:"guest_email,omitempty"`
-	IsAgentReply   bool   `json:"is_agent_reply"`
+	TicketID       string   `json:"ticket_id"`
+	TicketNumber   string   `json:"ticket_number"`
+	Subject        string   `json:"subject"`
+	MessageID      string   `json:"message_id"`
+	MessageContent string   `json:"message_content"`
+	SenderType     string   `json:"sender_type"`
+	CustomerID     string   `json:"customer_id,omitempty"`
+	GuestEmail     string   `json:"guest_email,omitempty"`
+	IsAgentReply   bool     `json:"is_agent_reply"`
+	Recipients     []string `json:"recipients"`
+	WatcherIDs     []string `json:"watcher_ids,omitempty"`
 }
 
-// PublishTicketCreated publishes a ticket created event
-func (p *Publisher) PublishTicketCreated(ticket *domain.Ticket) error {
-	if p.nc == nil {
-		return nil
-	}
-
-	event := TicketCreatedEvent{
-		TicketID:     ticket.ID.String(),
-		TicketNumber: ticket.TicketNumber,
-		Subject:      ticket.Subject,
-		GuestEmail:   ticket.GuestEmail,
-		GuestName:    ticket.GuestName,
-		Priority:     string(ticket.Priority),
-	}
-
-	if ticket.CustomerID != nil {
-		event.CustomerID = ticket.CustomerID.String()
-	}
-	if ticket.CategoryID != nil {
-		event.CategoryID = ticket.CategoryID.String()
-	}
-
-	data, err := json.Marshal(event)
-	if err != nil {
-		return err
-	}
-
-	return p.nc.Publish(EventTicketCreated, data)
+// TicketAssignedEvent represents a ticket being assigned to an agent
+type TicketAssignedEvent struct {
+	TicketID     string `json:"ticket_id"`
+	TicketNumber string `json:"ticket_number"`
+	Subject      string `json:"subject"`
+	AgentID      string `json:"agent_id"`
+	Reason       string `json:"reason"`
+	Auto         bool   `json:"auto"`
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
 }
 
-// PublishTicketReply publishes a ticket reply event
-func (p *Publisher) PublishTicketReply(ticket *domain.Ticket, message *domain.Message, isAgentReply bool) error {
-	if p.nc == nil {
-		return nil
-	}
-
-	event := TicketReplyEvent{
-		TicketID:       ticket.ID.String(),
-		TicketNumber:   ticket.TicketNumber,
-		Subject:        ticket.Subject,
-		MessageID:      message.ID.String(),
-		MessageContent: message.Content,
-		SenderType:     string(message.SenderType),
-		GuestEmail:     ticket.GuestEmail,
-		IsAgentReply:   isAgentReply,
-	}
+// TicketUpdatedEvent represents a ticket status, priority or team change
+type TicketUpdatedEvent struct {
+	TicketID     string   `json:"ticket_id"`
+	TicketNumber string   `json:"ticket_number"`
+	Change       string   `json:"change"`
+	Status       string   `json:"status"`
+	Priority     string   `json:"priority"`
+	TeamID       string   `json:"team_id,omitempty"`
+	AssignedTo   string   `json:"assigned_to,omitempty"`
+	WatcherIDs   []string `json:"watcher_ids,omitempty"`
+}
 
-	if ticket.CustomerID != nil {
-		event.CustomerID = ticket.CustomerID.String()
-	}
+// TicketSLAEvent represents an approaching or breached first response or
+// resolution deadline, named by Clock
+type TicketSLAEvent struct {
+	TicketID     string    `json:"ticket_id"`
+	TicketNumber string    `json:"ticket_number"`
+	Subject      string    `json:"subject"`
+	Status       string    `json:"status"`
+	Priority     string    `json:"priority"`
+	AssignedTo   string    `json:"assigned_to,omitempty"`
+	CategoryID   string    `json:"category_id,omitempty"`
+	Clock        string    `json:"clock"`
+	SLADeadline  time.Time `json:"sla_deadline"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
 
-	data, err := json.Marshal(event)
-	if err != nil {
-		return err
-	}
+// TicketIdleEvent represents a pending reminder or a stale ticket flag
+type TicketIdleEvent struct {
+	TicketID     string    `json:"ticket_id"`
+	TicketNumber string    `json:"ticket_number"`
+	Subject      string    `json:"subject"`
+	Status       string    `json:"status"`
+	Priority     string    `json:"priority"`
+	CustomerID   string    `json:"customer_id,omitempty"`
+	GuestEmail   string    `json:"guest_email,omitempty"`
+	GuestName    string    `json:"guest_name,omitempty"`
+	AssignedTo   string    `json:"assigned_to,omitempty"`
+	TeamID       string    `json:"team_id,omitempty"`
+	CategoryID   string    `json:"category_id,omitempty"`
+	IdleSince    time.Time `json:"idle_since"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
 
-	return p.nc.Publish(EventTicketReplied, data)
+// AgentMentionedEvent represents an agent mentioned in an internal note
+type AgentMentionedEvent struct {
+	TicketID        string `json:"ticket_id"`
+	TicketNumber    string `json:"ticket_number"`
+	Subject         string `json:"subject"`
+	MessageID       string `json:"message_id"`
+	MessageContent  string `json:"message_content"`
+	AgentID         string `json:"agent_id"`
+	AgentName       string `json:"agent_name"`
+	AgentEmail      string `json:"agent_email"`
+	MentionedBy     string `json:"mentioned_by,omitempty"`
+	MentionedByName string `json:"mentioned_by_name"`
 }
 
-// PublishTicketResolved publishes a ticket resolved event
-func (p *Publisher) PublishTicketResolved(ticket *domain.Ticket) error {
-	if p.nc == nil {
-		return nil
-	}
+// TicketMergedEvent represents tickets merged into another ticket
+type TicketMergedEvent struct {
+	TicketID            string    `json:"ticket_id"`
+	TicketNumber        string    `json:"ticket_number"`
+	Subject             string    `json:"subject"`
+	CustomerID          string    `json:"customer_id,omitempty"`
+	GuestEmail          string    `json:"guest_email,omitempty"`
+	MergedTicketIDs     []string  `json:"merged_ticket_ids"`
+	MergedTicketNumbers []string  `json:"merged_ticket_numbers"`
+	MergedBy            string    `json:"merged_by,omitempty"`
+	Forced              bool      `json:"forced"`
+	MergedAt            time.Time `json:"merged_at"`
+}
 
-	event := map[string]interface{}{
-		"ticket_id":     ticket.ID.String(),
-		"ticket_number": ticket.TicketNumber,
-		"subject":       ticket.Subject,
-		"customer_id":   "",
-		"guest_email":   ticket.GuestEmail,
-	}
+// Connected reports whether the NATS connection is currently up.
+func (p *Publisher) Connected() bool {
+	return p.nc != nil && p.nc.IsConnected()
+}
 
-	if ticket.CustomerID != nil {
-		event["customer_id"] = ticket.CustomerID.String()
+// Publish sends a message to NATS and waits for the server to acknowledge
+// it, so a failed publish can be retried. The message ID is set as the
+// Nats-Msg-Id header to let consumers drop duplicates. While the client is
+// reconnecting it returns ErrNotConnected rather than buffering the message.
+func (p *Publisher) Publish(subject, msgID string, data []byte) error {
+	if !p.Connected() {
+		return ErrNotConnected
 	}
 
-	data, err := json.Marshal(event)
-	if err != nil {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, msgID)
+	if err := p.nc.PublishMsg(msg); err != nil {
 		return err
 	}
-
-	return p.nc.Publish(EventTicketResolved, data)
+	return p.nc.FlushTimeout(flushTimeout)
 }
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MessagePublisher sends a single encoded event to the broker.
+type MessagePublisher interface {
+	Publish(subject, msgID string, data []byte) error
+}
+
+// connectionReporter is implemented by publishers that know whether the
+// broker is reachable. The relay leaves the outbox alone while it is not,
+// so an outage does not use up the messages' attempts.
+type connectionReporter interface {
+	Connected() bool
+}
+
+// RelayConfig tunes the outbox relay.
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+}
+
+// DefaultRelayConfig returns the relay settings used when none are configured.
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxAttempts:  10,
+		RetryBackoff: 2 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		Lease:        30 * time.Second,
+	}
+}
+
+// Relay drains the outbox to NATS. Failed publishes are retried with
+// exponential backoff; after MaxAttempts a message is dead-lettered.
+type Relay struct {
+	store     OutboxStore
+	publisher MessagePublisher
+	config    RelayConfig
+	logger    *zap.Logger
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(store OutboxStore, publisher MessagePublisher, config RelayConfig, logger *zap.Logger) *Relay {
+	defaults := DefaultRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaults.RetryBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// Run relays outbox messages until the context is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep draining while messages are being published; publishing the
+		// head of an aggregate makes its next message claimable.
+		for {
+			published, err := r.RelayPending(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.logger.Error("Failed to relay outbox", zap.Error(err))
+			}
+			if published == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of due messages and returns how many were
+// published.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	if c, ok := r.publisher.(connectionReporter); ok && !c.Connected() {
+		return 0, nil
+	}
+	messages, err := r.store.ClaimPending(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, msg := range messages {
+		if err := r.publisher.Publish(msg.Subject, msg.ID.String(), msg.Payload); err != nil {
+			// The connection dropped mid-batch: leave the rest to be claimed
+			// again once their lease runs out, without counting an attempt
+			if errors.Is(err, ErrNotConnected) {
+				break
+			}
+			r.fail(ctx, msg, err)
+			continue
+		}
+		if err := r.store.MarkPublished(ctx, msg.ID); err != nil {
+			// The lease expires and the message is sent again; consumers
+			// drop the duplicate by its message ID.
+			r.logger.Error("Failed to mark outbox message published",
+				zap.String("outbox_id", msg.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+func (r *Relay) fail(ctx context.Context, msg OutboxMessage, publishErr error) {
+	attempts := msg.Attempts + 1
+	fields := []zap.Field{
+		zap.String("outbox_id", msg.ID.String()),
+		zap.String("ticket_id", msg.AggregateID.String()),
+		zap.String("subject", msg.Subject),
+		zap.Int("attempts", attempts),
+		zap.Error(publishErr),
+	}
+
+	var err error
+	if attempts >= r.config.MaxAttempts {
+		r.logger.Error("Outbox message dead-lettered", fields...)
+		err = r.store.MarkDead(ctx, msg.ID, publishErr.Error())
+	} else {
+		r.logger.Warn("Failed to publish outbox message", fields...)
+		err = r.store.MarkFailed(ctx, msg.ID, publishErr.Error(), time.Now().Add(r.backoff(attempts)))
+	}
+	if err != nil {
+		r.logger.Error("Failed to record outbox failure",
+			zap.String("outbox_id", msg.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// backoff returns the delay before the given attempt is retried.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.RetryBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.config.MaxBackoff {
+			return r.config.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// User event types, taken from the last token of the subject, e.g.
+// "user.updated".
+const (
+	UserCreated     = "created"
+	UserUpdated     = "updated"
+	UserRoleChanged = "role_changed"
+	UserDeactivated = "deactivated"
+	UserDeleted     = "deleted"
+)
+
+// handleTimeout bounds how long one user event may take to apply.
+const handleTimeout = 10 * time.Second
+
+// UserEvent is a change to a user published by the identity service.
+type UserEvent struct {
+	Type      string `json:"-"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+	IsActive  *bool  `json:"is_active"`
+}
+
+// DisplayName returns the user's name, built from the first and last name
+// when the event carries no full name.
+func (e UserEvent) DisplayName() string {
+	if name := strings.TrimSpace(e.Name); name != "" {
+		return name
+	}
+	return strings.TrimSpace(e.FirstName + " " + e.LastName)
+}
+
+// UserEventHandler applies user events.
+type UserEventHandler interface {
+	HandleUserEvent(ctx context.Context, event UserEvent) error
+}
+
+// UserEventHandlers applies each user event with every handler in turn,
+// stopping at the first that fails.
+type UserEventHandlers []UserEventHandler
+
+// HandleUserEvent passes the event to each handler.
+func (hs UserEventHandlers) HandleUserEvent(ctx context.Context, event UserEvent) error {
+	for _, h := range hs {
+		if err := h.HandleUserEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UserConsumer subscribes to the identity service's user events. Replicas
+// share a queue group so each event is handled once.
+type UserConsumer struct {
+	nc      *nats.Conn
+	subject string
+	queue   string
+	handler UserEventHandler
+	logger  *zap.Logger
+	sub     *nats.Subscription
+}
+
+// NewUserConsumer creates a consumer for the subject, e.g. "user.>".
+func NewUserConsumer(nc *nats.Conn, subject, queue string, handler UserEventHandler, logger *zap.Logger) *UserConsumer {
+	return &UserConsumer{
+		nc:      nc,
+		subject: subject,
+		queue:   queue,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start subscribes to the user events.
+func (c *UserConsumer) Start() error {
+	if c.nc == nil {
+		return ErrNotConnected
+	}
+
+	sub, err := c.nc.QueueSubscribe(c.subject, c.queue, func(msg *nats.Msg) {
+		c.handle(msg.Subject, msg.Data)
+	})
+	if err != nil {
+		return err
+	}
+	c.sub = sub
+	return nil
+}
+
+// Stop drains the subscription, letting in-flight events finish.
+func (c *UserConsumer) Stop() error {
+	if c.sub == nil {
+		return nil
+	}
+	return c.sub.Drain()
+}
+
+func (c *UserConsumer) handle(subject string, data []byte) {
+	var event UserEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		c.logger.Warn("Dropping malformed user event", zap.String("subject", subject), zap.Error(err))
+		return
+	}
+	event.Type = subject[strings.LastIndex(subject, ".")+1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), handleTimeout)
+	defer cancel()
+
+	if err := c.handler.HandleUserEvent(ctx, event); err != nil {
+		c.logger.Error("Failed to apply user event",
+			zap.String("subject", subject),
+			zap.String("user_id", event.UserID),
+			zap.Error(err))
+	}
+}
@@ -1,55 +1,58 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/Ecom-micro-template/service-support/internal/events"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
-	"github.com/Ecom-micro-template/service-support/internal/infrastructure/persistence"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
 	"go.uber.org/zap"
 )
 
 // AdminHandler handles admin support management requests
 type AdminHandler struct {
-	ticketRepo        *persistence.TicketRepository
-	messageRepo       *persistence.MessageRepository
-	categoryRepo      *persistence.CategoryRepository
-	cannedResponseRepo *persistence.CannedResponseRepository
-	publisher         *events.Publisher
-	logger            *zap.Logger
+	tickets            *application.TicketService
+	links              *application.LinkService
+	ticketRepo         ticket.Repository
+	categoryRepo       category.Repository
+	agentRepo          agent.Repository
+	cannedResponseRepo response.Repository
+	logger             *zap.Logger
 }
 
 // NewAdminHandler creates a new admin handler
 func NewAdminHandler(
-	ticketRepo *persistence.TicketRepository,
-	messageRepo *persistence.MessageRepository,
-	categoryRepo *persistence.CategoryRepository,
-	cannedResponseRepo *persistence.CannedResponseRepository,
+	tickets *application.TicketService,
+	links *application.LinkService,
+	ticketRepo ticket.Repository,
+	categoryRepo category.Repository,
+	agentRepo agent.Repository,
+	cannedResponseRepo response.Repository,
 	logger *zap.Logger,
 ) *AdminHandler {
 	return &AdminHandler{
-		ticketRepo:        ticketRepo,
-		messageRepo:       messageRepo,
-		categoryRepo:      categoryRepo,
+		tickets:            tickets,
+		links:              links,
+		ticketRepo:         ticketRepo,
+		categoryRepo:       categoryRepo,
+		agentRepo:          agentRepo,
 		cannedResponseRepo: cannedResponseRepo,
-		logger:            logger,
+		logger:             logger,
 	}
 }
 
-// SetEventPublisher sets the event publisher
-func (h *AdminHandler) SetEventPublisher(publisher *events.Publisher) {
-	h.publisher = publisher
-}
-
 // ListTickets lists all tickets for admin
 // GET /api/v1/admin/support/tickets
 func (h *AdminHandler) ListTickets(c *gin.Context) {
 	// Parse filters
-	filter := persistence.TicketFilter{
+	filter := ticket.Filter{
 		Status:   c.Query("status"),
 		Priority: c.Query("priority"),
 		Search:   c.Query("search"),
@@ -62,12 +65,30 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 		}
 	}
 
+	if teamID := c.Query("team_id"); teamID != "" {
+		id, err := uuid.Parse(teamID)
+		if err == nil {
+			filter.TeamID = &id
+		}
+	}
+
 	if assignedTo := c.Query("assigned_to"); assignedTo != "" {
 		id, err := uuid.Parse(assignedTo)
 		if err == nil {
 			filter.AssignedTo = &id
 		}
 	}
+	filter.Unassigned = c.Query("unassigned") == "true"
+
+	if watchedBy := c.Query("watched_by"); watchedBy == "me" {
+		id, _ := adminIdentity(c)
+		filter.WatchedBy = &id
+	} else if watchedBy != "" {
+		id, err := uuid.Parse(watchedBy)
+		if err == nil {
+			filter.WatchedBy = &id
+		}
+	}
 
 	if overdue := c.Query("overdue"); overdue == "true" {
 		t := true
@@ -76,6 +97,7 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 
 	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
 	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	filter.Normalize()
 
 	tickets, total, err := h.ticketRepo.List(c.Request.Context(), filter)
 	if err != nil {
@@ -89,7 +111,7 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    tickets,
+		"data":    newTicketViews(c.Request.Context(), h.categoryRepo, h.agentRepo, h.tickets.SLAStatuses(c.Request.Context(), tickets), tickets),
 		"meta": gin.H{
 			"page":     filter.Page,
 			"per_page": filter.PerPage,
@@ -101,32 +123,25 @@ func (h *AdminHandler) ListTickets(c *gin.Context) {
 // GetTicket retrieves a specific ticket for admin
 // GET /api/v1/admin/support/tickets/:id
 func (h *AdminHandler) GetTicket(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	t, requested, err := h.tickets.LookupTicket(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Invalid ticket ID"},
-		})
+		respondTicketError(c, h.logger, err, "Failed to retrieve ticket")
 		return
 	}
 
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
+	linked, err := h.links.TicketLinks(c.Request.Context(), t.ID())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
-		})
+		respondLinkError(c, h.logger, err, "Failed to retrieve ticket links")
 		return
 	}
 
 	// Include internal notes for admin
-	messages, _ := h.messageRepo.GetByTicketID(c.Request.Context(), ticket.ID, true)
-	ticket.Messages = messages
-
+	view := newTicketDetailView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t), true)
+	view.Links = newTicketLinkViews(linked)
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    ticket,
+		"success":         true,
+		"data":            view,
+		"redirected_from": redirectedFrom(requested),
 	})
 }
 
@@ -161,15 +176,6 @@ func (h *AdminHandler) UpdateTicket(c *gin.Context) {
 		return
 	}
 
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
-		})
-		return
-	}
-
 	// Get admin info
 	adminIDStr, _ := c.Get("user_id")
 	var adminID uuid.UUID
@@ -180,63 +186,36 @@ func (h *AdminHandler) UpdateTicket(c *gin.Context) {
 		adminID = v
 	}
 	adminName, _ := c.Get("email")
-
-	// Update status if changed
-	if req.Status != "" && req.Status != string(ticket.Status) {
-		err := h.ticketRepo.UpdateStatus(
-			c.Request.Context(),
-			id,
-			domain.TicketStatus(req.Status),
-			&adminID,
-			adminName.(string),
-			"",
-		)
-		if err != nil {
-			h.logger.Error("Failed to update ticket status", zap.Error(err))
-		}
-		ticket.Status = domain.TicketStatus(req.Status)
-	}
-
-	// Update other fields
-	if req.Priority != "" {
-		ticket.Priority = domain.TicketPriority(req.Priority)
-	}
-	if req.CategoryID != nil {
-		ticket.CategoryID = req.CategoryID
-	}
-	if req.AssignedTo != nil {
-		ticket.AssignedTo = req.AssignedTo
-	}
-	if req.Tags != nil {
-		ticket.Tags = req.Tags
-	}
-
-	if err := h.ticketRepo.Update(c.Request.Context(), ticket); err != nil {
-		h.logger.Error("Failed to update ticket", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Failed to update ticket"},
-		})
+	adminNameStr, _ := adminName.(string)
+
+	t, err := h.tickets.UpdateTicket(c.Request.Context(), application.UpdateTicketCommand{
+		TicketID:      id,
+		Status:        req.Status,
+		Priority:      req.Priority,
+		CategoryID:    req.CategoryID,
+		AssignedTo:    req.AssignedTo,
+		Tags:          req.Tags,
+		ChangedBy:     &adminID,
+		ChangedByName: adminNameStr,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to update ticket")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    ticket,
+		"data":    newTicketView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t)),
 		"message": "Ticket updated successfully",
 	})
 }
 
-// AdminReplyRequest represents admin reply to ticket
+// AdminReplyRequest represents admin reply to ticket.
+// AttachmentIDs are files uploaded to the ticket to post with it.
 type AdminReplyRequest struct {
-	Content    string `json:"content" binding:"required"`
-	IsInternal bool   `json:"is_internal"`
-	Attachments []struct {
-		Name     string `json:"name"`
-		URL      string `json:"url"`
-		Size     int64  `json:"size"`
-		MimeType string `json:"mime_type"`
-	} `json:"attachments"`
+	Content       string      `json:"content" binding:"required"`
+	IsInternal    bool        `json:"is_internal"`
+	AttachmentIDs []uuid.UUID `json:"attachment_ids"`
 }
 
 // ReplyToTicket sends admin reply to ticket
@@ -261,11 +240,63 @@ func (h *AdminHandler) ReplyToTicket(c *gin.Context) {
 		return
 	}
 
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
+	// Get admin info
+	adminIDStr, _ := c.Get("user_id")
+	var adminID uuid.UUID
+	switch v := adminIDStr.(type) {
+	case string:
+		adminID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		adminID = v
+	}
+	adminEmail, _ := c.Get("email")
+	adminEmailStr, _ := adminEmail.(string)
+
+	_, msg, err := h.tickets.ReplyToTicket(c.Request.Context(), application.ReplyToTicketCommand{
+		TicketID:      id,
+		SenderID:      adminID,
+		SenderEmail:   adminEmailStr,
+		Content:       req.Content,
+		AttachmentIDs: req.AttachmentIDs,
+		IsInternal:    req.IsInternal,
+		IsStaff:       true,
+	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+		respondTicketError(c, h.logger, err, "Failed to send reply")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newMessageView(msg),
+		"message": "Reply sent successfully",
+	})
+}
+
+// MergeTicketsRequest represents the tickets to merge into a ticket
+type MergeTicketsRequest struct {
+	TicketIDs []uuid.UUID `json:"ticket_ids" binding:"required,min=1"`
+	Force     bool        `json:"force"`
+}
+
+// MergeTickets merges duplicate tickets into a ticket
+// POST /api/v1/admin/support/tickets/:id/merge
+func (h *AdminHandler) MergeTickets(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return
+	}
+
+	var req MergeTicketsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
+			"error":   gin.H{"message": err.Error()},
 		})
 		return
 	}
@@ -279,39 +310,74 @@ func (h *AdminHandler) ReplyToTicket(c *gin.Context) {
 	case uuid.UUID:
 		adminID = v
 	}
-	adminEmail, _ := c.Get("email")
+	adminName, _ := c.Get("email")
+	adminNameStr, _ := adminName.(string)
+
+	t, err := h.tickets.MergeTickets(c.Request.Context(), application.MergeTicketsCommand{
+		TargetID:     id,
+		SourceIDs:    req.TicketIDs,
+		Force:        req.Force,
+		MergedBy:     &adminID,
+		MergedByName: adminNameStr,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to merge tickets")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newTicketDetailView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t), true),
+		"message": "Tickets merged successfully",
+	})
+}
 
-	// Convert attachments to JSON
-	attachmentsJSON, _ := json.Marshal(req.Attachments)
+// SplitTicketRequest represents the messages to split into a new ticket
+type SplitTicketRequest struct {
+	MessageIDs []uuid.UUID `json:"message_ids" binding:"required,min=1"`
+	Subject    string      `json:"subject" binding:"required"`
+	CategoryID *uuid.UUID  `json:"category_id"`
+	Priority   string      `json:"priority"`
+}
 
-	message := &domain.Message{
-		TicketID:    id,
-		SenderType:  domain.SenderTypeAgent,
-		SenderID:    &adminID,
-		SenderEmail: adminEmail.(string),
-		Content:     req.Content,
-		Attachments: attachmentsJSON,
-		IsInternal:  req.IsInternal,
+// SplitTicket moves messages of a ticket into a new ticket
+// POST /api/v1/admin/support/tickets/:id/split
+func (h *AdminHandler) SplitTicket(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return
 	}
 
-	if err := h.messageRepo.Create(c.Request.Context(), message); err != nil {
-		h.logger.Error("Failed to create reply", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+	var req SplitTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   gin.H{"message": "Failed to send reply"},
+			"error":   gin.H{"message": err.Error()},
 		})
 		return
 	}
 
-	// Publish event for notification (only for external replies)
-	if h.publisher != nil && !req.IsInternal {
-		h.publisher.PublishTicketReply(ticket, message, true)
+	t, err := h.tickets.SplitTicket(c.Request.Context(), application.SplitTicketCommand{
+		TicketID:   id,
+		MessageIDs: req.MessageIDs,
+		Subject:    req.Subject,
+		CategoryID: req.CategoryID,
+		Priority:   req.Priority,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to split ticket")
+		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    message,
-		"message": "Reply sent successfully",
+		"data":    newTicketDetailView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t), true),
+		"message": "Ticket split successfully",
 	})
 }
 
@@ -339,25 +405,209 @@ func (h *AdminHandler) AssignTicket(c *gin.Context) {
 		return
 	}
 
-	if err := h.ticketRepo.Assign(c.Request.Context(), id, req.AgentID); err != nil {
-		h.logger.Error("Failed to assign ticket", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+	adminIDStr, _ := c.Get("user_id")
+	var adminID uuid.UUID
+	switch v := adminIDStr.(type) {
+	case string:
+		adminID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		adminID = v
+	}
+
+	_, err = h.tickets.Assign(c.Request.Context(), application.AssignCommand{
+		TicketID:  id,
+		AgentID:   req.AgentID,
+		ChangedBy: &adminID,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to assign ticket")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Ticket assigned successfully",
+	})
+}
+
+// UnassignTicket takes a ticket off its agent. With automatic assignment
+// enabled the ticket is routed to another agent.
+// DELETE /api/v1/admin/support/tickets/:id/assign
+func (h *AdminHandler) UnassignTicket(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   gin.H{"message": "Failed to assign ticket"},
+			"error":   gin.H{"message": "Invalid ticket ID"},
 		})
 		return
 	}
 
+	adminIDStr, _ := c.Get("user_id")
+	var adminID uuid.UUID
+	switch v := adminIDStr.(type) {
+	case string:
+		adminID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		adminID = v
+	}
+
+	t, err := h.tickets.Unassign(c.Request.Context(), application.UnassignCommand{
+		TicketID:  id,
+		ChangedBy: &adminID,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to unassign ticket")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Ticket assigned successfully",
+		"data": gin.H{
+			"assigned_to":       t.AssignedTo(),
+			"assignment_reason": t.AssignmentReason(),
+		},
+		"message": "Ticket unassigned successfully",
+	})
+}
+
+// AssignTicketTeam moves a ticket to a team's queue, optionally assigning
+// it to a member of the team. A null team_id takes it out of all queues.
+// PUT /api/v1/admin/support/tickets/:id/team
+func (h *AdminHandler) AssignTicketTeam(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return
+	}
+
+	var req struct {
+		TeamID  *uuid.UUID `json:"team_id"`
+		AgentID *uuid.UUID `json:"agent_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	adminIDStr, _ := c.Get("user_id")
+	var adminID uuid.UUID
+	switch v := adminIDStr.(type) {
+	case string:
+		adminID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		adminID = v
+	}
+
+	t, err := h.tickets.AssignTeam(c.Request.Context(), application.AssignTeamCommand{
+		TicketID:  id,
+		TeamID:    req.TeamID,
+		AgentID:   req.AgentID,
+		ChangedBy: &adminID,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to assign ticket to team")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"team_id":           t.TeamID(),
+			"assigned_to":       t.AssignedTo(),
+			"assignment_reason": t.AssignmentReason(),
+		},
+		"message": "Ticket team updated successfully",
+	})
+}
+
+// WatchTicket makes an agent follow a ticket. Without an agent_id the
+// signed-in admin follows it.
+// POST /api/v1/admin/support/tickets/:id/watchers
+func (h *AdminHandler) WatchTicket(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		AgentID *uuid.UUID `json:"agent_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	adminID, _ := adminIdentity(c)
+	agentID := adminID
+	if req.AgentID != nil {
+		agentID = *req.AgentID
+	}
+
+	t, err := h.tickets.WatchTicket(c.Request.Context(), application.WatchTicketCommand{
+		TicketID: id,
+		AgentID:  agentID,
+		AddedBy:  adminID,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to watch ticket")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"watcher_ids": t.Watchers()},
+		"message": "Ticket watched successfully",
+	})
+}
+
+// UnwatchTicket stops an agent following a ticket. An agent_id of "me"
+// unsubscribes the signed-in admin.
+// DELETE /api/v1/admin/support/tickets/:id/watchers/:agent_id
+func (h *AdminHandler) UnwatchTicket(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	agentID, _ := adminIdentity(c)
+	if param := c.Param("agent_id"); param != "me" {
+		var err error
+		if agentID, err = uuid.Parse(param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Invalid agent ID"},
+			})
+			return
+		}
+	}
+
+	t, err := h.tickets.UnwatchTicket(c.Request.Context(), id, agentID)
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to unwatch ticket")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"watcher_ids": t.Watchers()},
+		"message": "Ticket unwatched successfully",
 	})
 }
 
 // GetStats retrieves support statistics
 // GET /api/v1/admin/support/stats
 func (h *AdminHandler) GetStats(c *gin.Context) {
-	stats, err := h.ticketRepo.GetStats(c.Request.Context())
+	stats, err := h.ticketRepo.Stats(c.Request.Context())
 	if err != nil {
 		h.logger.Error("Failed to get stats", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -390,7 +640,7 @@ func (h *AdminHandler) ListCategories(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    categories,
+		"data":    newCategoryViews(categories),
 	})
 }
 
@@ -422,22 +672,24 @@ func (h *AdminHandler) CreateCategory(c *gin.Context) {
 		isActive = *req.IsActive
 	}
 
-	slaHours := 24
-	if req.SLAHours > 0 {
-		slaHours = req.SLAHours
-	}
-
-	category := &domain.Category{
+	cat, err := category.NewCategory(category.CategoryParams{
 		Name:        req.Name,
 		NameMS:      req.NameMS,
 		Description: req.Description,
 		Icon:        req.Icon,
-		SLAHours:    slaHours,
+		SLAHours:    req.SLAHours,
 		Priority:    req.Priority,
 		IsActive:    isActive,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
 	}
 
-	if err := h.categoryRepo.Create(c.Request.Context(), category); err != nil {
+	if err := h.categoryRepo.Save(c.Request.Context(), cat); err != nil {
 		h.logger.Error("Failed to create category", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -448,7 +700,7 @@ func (h *AdminHandler) CreateCategory(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    category,
+		"data":    newCategoryView(cat),
 		"message": "Category created successfully",
 	})
 }
@@ -466,7 +718,7 @@ func (h *AdminHandler) UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := h.categoryRepo.GetByID(c.Request.Context(), id)
+	cat, err := h.categoryRepo.FindByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
@@ -484,29 +736,32 @@ func (h *AdminHandler) UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	if req.Name != "" {
-		category.Name = req.Name
-	}
+	nameMS := cat.NameMS()
 	if req.NameMS != "" {
-		category.NameMS = req.NameMS
+		nameMS = req.NameMS
 	}
+	description := cat.Description()
 	if req.Description != "" {
-		category.Description = req.Description
+		description = req.Description
 	}
+	icon := cat.Icon()
 	if req.Icon != "" {
-		category.Icon = req.Icon
-	}
-	if req.SLAHours > 0 {
-		category.SLAHours = req.SLAHours
+		icon = req.Icon
 	}
+	cat.Update(req.Name, nameMS, description, icon)
+	cat.SetSLAHours(req.SLAHours)
 	if req.Priority > 0 {
-		category.Priority = req.Priority
+		cat.SetPriority(req.Priority)
 	}
 	if req.IsActive != nil {
-		category.IsActive = *req.IsActive
+		if *req.IsActive {
+			cat.Activate()
+		} else {
+			cat.Deactivate()
+		}
 	}
 
-	if err := h.categoryRepo.Update(c.Request.Context(), category); err != nil {
+	if err := h.categoryRepo.Save(c.Request.Context(), cat); err != nil {
 		h.logger.Error("Failed to update category", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -517,7 +772,7 @@ func (h *AdminHandler) UpdateCategory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    category,
+		"data":    newCategoryView(cat),
 		"message": "Category updated successfully",
 	})
 }
@@ -536,7 +791,7 @@ func (h *AdminHandler) DeleteCategory(c *gin.Context) {
 	}
 
 	// Check if category has tickets
-	count, _ := h.categoryRepo.GetTicketCount(c.Request.Context(), id)
+	_, count, _ := h.ticketRepo.List(c.Request.Context(), ticket.Filter{CategoryID: &id, PerPage: 1})
 	if count > 0 {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
@@ -546,6 +801,13 @@ func (h *AdminHandler) DeleteCategory(c *gin.Context) {
 	}
 
 	if err := h.categoryRepo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, category.ErrCategoryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Category not found"},
+			})
+			return
+		}
 		h.logger.Error("Failed to delete category", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -585,7 +847,7 @@ func (h *AdminHandler) ListCannedResponses(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    responses,
+		"data":    newCannedResponseViews(responses),
 	})
 }
 
@@ -622,16 +884,23 @@ func (h *AdminHandler) CreateCannedResponse(c *gin.Context) {
 		isActive = *req.IsActive
 	}
 
-	response := &domain.CannedResponse{
+	cr, err := response.NewCannedResponse(response.CannedResponseParams{
 		Title:      req.Title,
 		Content:    req.Content,
 		CategoryID: req.CategoryID,
 		Shortcut:   req.Shortcut,
 		IsActive:   isActive,
 		CreatedBy:  &creatorID,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
 	}
 
-	if err := h.cannedResponseRepo.Create(c.Request.Context(), response); err != nil {
+	if err := h.cannedResponseRepo.Save(c.Request.Context(), cr); err != nil {
 		h.logger.Error("Failed to create canned response", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -642,7 +911,7 @@ func (h *AdminHandler) CreateCannedResponse(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    response,
+		"data":    newCannedResponseView(cr),
 		"message": "Canned response created successfully",
 	})
 }
@@ -660,7 +929,7 @@ func (h *AdminHandler) UpdateCannedResponse(c *gin.Context) {
 		return
 	}
 
-	response, err := h.cannedResponseRepo.GetByID(c.Request.Context(), id)
+	cr, err := h.cannedResponseRepo.FindByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
@@ -684,23 +953,23 @@ func (h *AdminHandler) UpdateCannedResponse(c *gin.Context) {
 		return
 	}
 
-	if req.Title != "" {
-		response.Title = req.Title
-	}
-	if req.Content != "" {
-		response.Content = req.Content
+	shortcut := cr.Shortcut()
+	if req.Shortcut != "" {
+		shortcut = req.Shortcut
 	}
+	cr.Update(req.Title, req.Content, shortcut)
 	if req.CategoryID != nil {
-		response.CategoryID = req.CategoryID
-	}
-	if req.Shortcut != "" {
-		response.Shortcut = req.Shortcut
+		cr.SetCategory(req.CategoryID)
 	}
 	if req.IsActive != nil {
-		response.IsActive = *req.IsActive
+		if *req.IsActive {
+			cr.Activate()
+		} else {
+			cr.Deactivate()
+		}
 	}
 
-	if err := h.cannedResponseRepo.Update(c.Request.Context(), response); err != nil {
+	if err := h.cannedResponseRepo.Save(c.Request.Context(), cr); err != nil {
 		h.logger.Error("Failed to update canned response", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -711,7 +980,7 @@ func (h *AdminHandler) UpdateCannedResponse(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    response,
+		"data":    newCannedResponseView(cr),
 		"message": "Canned response updated successfully",
 	})
 }
@@ -730,6 +999,13 @@ func (h *AdminHandler) DeleteCannedResponse(c *gin.Context) {
 	}
 
 	if err := h.cannedResponseRepo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, response.ErrResponseNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Canned response not found"},
+			})
+			return
+		}
 		h.logger.Error("Failed to delete canned response", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// AgentHandler handles the agent directory
+type AgentHandler struct {
+	agents     agent.Repository
+	ticketRepo ticket.Repository
+	logger     *zap.Logger
+}
+
+// NewAgentHandler creates a new agent handler
+func NewAgentHandler(agents agent.Repository, ticketRepo ticket.Repository, logger *zap.Logger) *AgentHandler {
+	return &AgentHandler{
+		agents:     agents,
+		ticketRepo: ticketRepo,
+		logger:     logger,
+	}
+}
+
+// AgentSkillInput is a skill with its proficiency from 1 (novice) to 5
+// (expert), e.g. {"name": "returns", "level": 4}
+type AgentSkillInput struct {
+	Name  string `json:"name" binding:"required"`
+	Level int    `json:"level" binding:"required"`
+}
+
+// AgentRequest represents the request to register or update an agent.
+// A max_concurrent_tickets of 0 means no limit on open tickets.
+type AgentRequest struct {
+	Name                 string            `json:"name" binding:"required"`
+	Email                string            `json:"email"`
+	Role                 string            `json:"role"`
+	Teams                []string          `json:"teams"`
+	Skills               []AgentSkillInput `json:"skills"`
+	Status               string            `json:"status"`
+	MaxConcurrentTickets *int              `json:"max_concurrent_tickets"`
+	Timezone             string            `json:"timezone"`
+	Signature            string            `json:"signature"`
+}
+
+func (r AgentRequest) skills() []agent.Skill {
+	skills := make([]agent.Skill, 0, len(r.Skills))
+	for _, s := range r.Skills {
+		skills = append(skills, agent.Skill{Name: s.Name, Level: s.Level})
+	}
+	return skills
+}
+
+// CreateAgentRequest registers a user as an agent
+type CreateAgentRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	AgentRequest
+}
+
+// AgentStatusRequest represents the request to change an agent's status
+type AgentStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=online away offline"`
+}
+
+// ListAgents lists agents with their open ticket counts, optionally by
+// status and team
+// GET /api/v1/admin/support/agents
+func (h *AgentHandler) ListAgents(c *gin.Context) {
+	filter := agent.Filter{Team: c.Query("team")}
+	if s := c.Query("status"); s != "" {
+		status, err := agent.ParseStatus(s)
+		if err != nil {
+			respondAgentError(c, h.logger, err, "Failed to retrieve agents")
+			return
+		}
+		filter.Status = status
+	}
+
+	agents, err := h.agents.List(c.Request.Context(), filter)
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to retrieve agents")
+		return
+	}
+
+	load, err := h.ticketRepo.CountActiveByAssignee(c.Request.Context())
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to retrieve agents")
+		return
+	}
+
+	views := make([]agentView, 0, len(agents))
+	for _, a := range agents {
+		views = append(views, newAgentView(a, load[a.ID()]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetAgent gets an agent by ID
+// GET /api/v1/admin/support/agents/:id
+func (h *AgentHandler) GetAgent(c *gin.Context) {
+	id, ok := parseAgentID(c)
+	if !ok {
+		return
+	}
+
+	a, err := h.agents.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to retrieve agent")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.view(c, a),
+	})
+}
+
+// CreateAgent registers a user as an agent
+// POST /api/v1/admin/support/agents
+func (h *AgentHandler) CreateAgent(c *gin.Context) {
+	var req CreateAgentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.agents.FindByID(ctx, req.UserID); err == nil {
+		respondAgentError(c, h.logger, agent.ErrAgentAlreadyExists, "Failed to create agent")
+		return
+	} else if !errors.Is(err, agent.ErrAgentNotFound) {
+		respondAgentError(c, h.logger, err, "Failed to create agent")
+		return
+	}
+
+	params := agent.AgentParams{
+		ID:        req.UserID,
+		Name:      req.Name,
+		Email:     req.Email,
+		Role:      req.Role,
+		Teams:     req.Teams,
+		Skills:    req.skills(),
+		Status:    agent.Status(req.Status),
+		Timezone:  req.Timezone,
+		Signature: req.Signature,
+	}
+	if req.MaxConcurrentTickets != nil {
+		params.MaxConcurrentTickets = *req.MaxConcurrentTickets
+	}
+	a, err := agent.NewAgent(params)
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to create agent")
+		return
+	}
+
+	if err := h.agents.Save(ctx, a); err != nil {
+		respondAgentError(c, h.logger, err, "Failed to create agent")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newAgentView(a, 0),
+		"message": "Agent created successfully",
+	})
+}
+
+// UpdateAgent replaces an agent's profile. Skills, status and the ticket
+// limit are only changed when given.
+// PUT /api/v1/admin/support/agents/:id
+func (h *AgentHandler) UpdateAgent(c *gin.Context) {
+	id, ok := parseAgentID(c)
+	if !ok {
+		return
+	}
+
+	var req AgentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	a, err := h.agents.FindByID(ctx, id)
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to retrieve agent")
+		return
+	}
+
+	err = a.UpdateProfile(agent.Profile{
+		Name:      req.Name,
+		Email:     req.Email,
+		Role:      req.Role,
+		Teams:     req.Teams,
+		Timezone:  req.Timezone,
+		Signature: req.Signature,
+	})
+	if err == nil && req.Skills != nil {
+		err = a.SetSkills(req.skills())
+	}
+	if err == nil && req.Status != "" {
+		err = a.SetStatus(agent.Status(req.Status))
+	}
+	if err == nil && req.MaxConcurrentTickets != nil {
+		err = a.SetMaxConcurrentTickets(*req.MaxConcurrentTickets)
+	}
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to update agent")
+		return
+	}
+
+	if err := h.agents.Save(ctx, a); err != nil {
+		respondAgentError(c, h.logger, err, "Failed to update agent")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.view(c, a),
+		"message": "Agent updated successfully",
+	})
+}
+
+// DeleteAgent removes an agent from the directory. Their tickets stay
+// assigned.
+// DELETE /api/v1/admin/support/agents/:id
+func (h *AgentHandler) DeleteAgent(c *gin.Context) {
+	id, ok := parseAgentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.agents.Delete(c.Request.Context(), id); err != nil {
+		respondAgentError(c, h.logger, err, "Failed to delete agent")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Agent deleted successfully",
+	})
+}
+
+// SetAgentStatus sets an agent's status
+// PUT /api/v1/admin/support/agents/:id/status
+func (h *AgentHandler) SetAgentStatus(c *gin.Context) {
+	id, ok := parseAgentID(c)
+	if !ok {
+		return
+	}
+	h.setStatus(c, id)
+}
+
+// SetMyStatus sets the calling agent's own status
+// PUT /api/v1/admin/support/agents/me/status
+func (h *AgentHandler) SetMyStatus(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	var id uuid.UUID
+	switch v := userID.(type) {
+	case string:
+		id, _ = uuid.Parse(v)
+	case uuid.UUID:
+		id = v
+	}
+	if id == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "User not authenticated"},
+		})
+		return
+	}
+	h.setStatus(c, id)
+}
+
+func (h *AgentHandler) setStatus(c *gin.Context, id uuid.UUID) {
+	var req AgentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	a, err := h.agents.FindByID(ctx, id)
+	if err != nil {
+		respondAgentError(c, h.logger, err, "Failed to retrieve agent")
+		return
+	}
+
+	if err := a.SetStatus(agent.Status(req.Status)); err != nil {
+		respondAgentError(c, h.logger, err, "Failed to update agent status")
+		return
+	}
+
+	if err := h.agents.Save(ctx, a); err != nil {
+		respondAgentError(c, h.logger, err, "Failed to update agent status")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.view(c, a),
+		"message": "Agent status updated successfully",
+	})
+}
+
+// view renders the agent with its open ticket count.
+func (h *AgentHandler) view(c *gin.Context, a *agent.Agent) agentView {
+	load, err := h.ticketRepo.CountActiveByAssignee(c.Request.Context())
+	if err != nil {
+		h.logger.Warn("Failed to count agent tickets", zap.Error(err))
+	}
+	return newAgentView(a, load[a.ID()])
+}
+
+func parseAgentID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid agent ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
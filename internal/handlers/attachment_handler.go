@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"go.uber.org/zap"
+)
+
+// maxUploadFiles is the most files accepted in one upload request.
+const maxUploadFiles = 10
+
+// AttachmentHandler handles file uploads to tickets and downloads through
+// signed links. Uploaded files are posted in a message by listing their
+// IDs in attachment_ids.
+type AttachmentHandler struct {
+	attachments *application.AttachmentService
+	downloadURL string
+	logger      *zap.Logger
+}
+
+// NewAttachmentHandler creates a new attachment handler. Download links
+// are made under downloadURL, the public address of
+// /api/v1/support/attachments.
+func NewAttachmentHandler(attachments *application.AttachmentService, downloadURL string, logger *zap.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachments: attachments,
+		downloadURL: downloadURL,
+		logger:      logger,
+	}
+}
+
+// Upload stores the files uploaded to one of the customer's tickets
+// POST /api/v1/support/tickets/:id/attachments
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	role, _ := c.Get("role")
+	h.upload(c, id, userID, role == "admin" || role == "super_admin" || role == "support")
+}
+
+// AdminUpload stores the files uploaded to a ticket by an agent
+// POST /api/v1/admin/support/tickets/:id/attachments
+func (h *AttachmentHandler) AdminUpload(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+	adminID, _ := adminIdentity(c)
+	h.upload(c, id, adminID, true)
+}
+
+// upload reads the "file" parts of a multipart form and stores each. If
+// one is refused, those stored before it are removed again.
+func (h *AttachmentHandler) upload(c *gin.Context, ticketID, uploadedBy uuid.UUID, isStaff bool) {
+	limits := h.attachments.Limits()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadFiles*limits.MaxFileSize+1<<20)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		status := http.StatusBadRequest
+		message := "Invalid multipart form"
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+			message = "Upload is too large"
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   gin.H{"message": message},
+		})
+		return
+	}
+	defer form.RemoveAll()
+
+	files := form.File["file"]
+	if len(files) == 0 || len(files) > maxUploadFiles {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": fmt.Sprintf("Upload between 1 and %d files in the file field", maxUploadFiles)},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	stored := make([]*attachment.Attachment, 0, len(files))
+	for _, fh := range files {
+		a, err := h.uploadFile(ctx, ticketID, uploadedBy, isStaff, fh)
+		if err != nil {
+			for _, s := range stored {
+				if err := h.attachments.Delete(context.WithoutCancel(ctx), ticketID, s.ID(), uploadedBy, true); err != nil {
+					h.logger.Warn("Failed to remove upload", zap.String("attachment_id", s.ID().String()), zap.Error(err))
+				}
+			}
+			respondAttachmentError(c, h.logger, err, "Failed to upload attachment")
+			return
+		}
+		stored = append(stored, a)
+	}
+
+	views := make([]attachmentView, 0, len(stored))
+	for _, a := range stored {
+		views = append(views, newAttachmentView(a))
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    views,
+		"message": "Attachments uploaded successfully",
+	})
+}
+
+func (h *AttachmentHandler) uploadFile(ctx context.Context, ticketID, uploadedBy uuid.UUID, isStaff bool, fh *multipart.FileHeader) (*attachment.Attachment, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return h.attachments.Upload(ctx, application.UploadAttachmentCommand{
+		TicketID:   ticketID,
+		UploadedBy: uploadedBy,
+		Name:       fh.Filename,
+		Content:    f,
+		Size:       fh.Size,
+		IsStaff:    isStaff,
+	})
+}
+
+// List lists the files stored for a ticket, oldest first, posted or not
+// GET /api/v1/admin/support/tickets/:id/attachments
+func (h *AttachmentHandler) List(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachments.List(c.Request.Context(), id)
+	if err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to retrieve attachments")
+		return
+	}
+
+	views := make([]attachmentView, 0, len(attachments))
+	for _, a := range attachments {
+		views = append(views, newAttachmentView(a))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// Delete removes one of the customer's uploads not yet posted in a message
+// DELETE /api/v1/support/tickets/:id/attachments/:attachment_id
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	h.delete(c, userID, false)
+}
+
+// AdminDelete removes an upload not yet posted in a message
+// DELETE /api/v1/admin/support/tickets/:id/attachments/:attachment_id
+func (h *AttachmentHandler) AdminDelete(c *gin.Context) {
+	adminID, _ := adminIdentity(c)
+	h.delete(c, adminID, true)
+}
+
+func (h *AttachmentHandler) delete(c *gin.Context, actorID uuid.UUID, isStaff bool) {
+	ticketID, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseAttachmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.attachments.Delete(c.Request.Context(), ticketID, id, actorID, isStaff); err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to delete attachment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// Link issues a link to download an attachment of one of the customer's
+// tickets
+// GET /api/v1/support/tickets/:id/attachments/:attachment_id/link
+func (h *AttachmentHandler) Link(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	role, _ := c.Get("role")
+	h.link(c, userID, role == "admin" || role == "super_admin" || role == "support")
+}
+
+// AdminLink issues a link to download an attachment of a ticket
+// GET /api/v1/admin/support/tickets/:id/attachments/:attachment_id/link
+func (h *AttachmentHandler) AdminLink(c *gin.Context) {
+	adminID, _ := adminIdentity(c)
+	h.link(c, adminID, true)
+}
+
+func (h *AttachmentHandler) link(c *gin.Context, userID uuid.UUID, isStaff bool) {
+	ticketID, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseAttachmentID(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.attachments.IssueLink(c.Request.Context(), application.IssueLinkCommand{
+		TicketID:     ticketID,
+		AttachmentID: id,
+		UserID:       userID,
+		IsStaff:      isStaff,
+	})
+	if err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to issue download link")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"url":        h.downloadURL + "/" + id.String() + "/download?" + link.Query().Encode(),
+			"expires_at": link.ExpiresAt,
+		},
+	})
+}
+
+// Download streams an attachment to whoever holds a valid link to it. The
+// link is the credential, so no session is needed; each download is
+// logged.
+// GET /api/v1/support/attachments/:attachment_id/download
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	id, ok := parseAttachmentID(c)
+	if !ok {
+		return
+	}
+	link, err := attachment.ParseLink(id, c.Request.URL.Query())
+	if err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to download attachment")
+		return
+	}
+
+	a, content, err := h.attachments.Download(c.Request.Context(), application.DownloadCommand{
+		Link:      link,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+
+	// Serve as a download that browsers neither sniff nor render in our origin
+	c.DataFromReader(http.StatusOK, a.Size(), a.ContentType(), content, map[string]string{
+		"Content-Disposition":     mime.FormatMediaType("attachment", map[string]string{"filename": a.Name()}),
+		"X-Content-Type-Options":  "nosniff",
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"Cache-Control":           "private, no-store",
+	})
+}
+
+// ListDownloads lists the download log of an attachment, newest first
+// GET /api/v1/admin/support/tickets/:id/attachments/:attachment_id/downloads
+func (h *AttachmentHandler) ListDownloads(c *gin.Context) {
+	ticketID, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseAttachmentID(c)
+	if !ok {
+		return
+	}
+
+	filter := attachment.DownloadFilter{AttachmentID: &id}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	filter.Normalize()
+
+	downloads, total, err := h.attachments.Downloads(c.Request.Context(), ticketID, filter)
+	if err != nil {
+		respondAttachmentError(c, h.logger, err, "Failed to retrieve attachment downloads")
+		return
+	}
+
+	views := make([]attachmentDownloadView, 0, len(downloads))
+	for _, d := range downloads {
+		views = append(views, newAttachmentDownloadView(d))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+		"meta": gin.H{
+			"page":     filter.Page,
+			"per_page": filter.PerPage,
+			"total":    total,
+		},
+	})
+}
+
+func parseAttachmentID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid attachment ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// requestUserID returns the ID of the signed-in user, responding 401 if
+// there is none.
+func requestUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Not authenticated"},
+		})
+		return uuid.Nil, false
+	}
+
+	var id uuid.UUID
+	switch v := userID.(type) {
+	case string:
+		id, _ = uuid.Parse(v)
+	case uuid.UUID:
+		id = v
+	}
+	return id, true
+}
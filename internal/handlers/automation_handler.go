@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"go.uber.org/zap"
+)
+
+// AutomationHandler handles automation policy management requests
+type AutomationHandler struct {
+	policies     automation.Repository
+	categoryRepo category.Repository
+	logger       *zap.Logger
+}
+
+// NewAutomationHandler creates a new automation handler
+func NewAutomationHandler(policies automation.Repository, categoryRepo category.Repository, logger *zap.Logger) *AutomationHandler {
+	return &AutomationHandler{
+		policies:     policies,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+	}
+}
+
+// AutomationPolicyRequest represents the request to create or update an
+// automation policy. A policy without category_id applies to every category
+// without its own; zero or omitted durations turn an automation off.
+type AutomationPolicyRequest struct {
+	Name                 string     `json:"name" binding:"required"`
+	CategoryID           *uuid.UUID `json:"category_id"`
+	AutoCloseDays        int        `json:"auto_close_days"`
+	PendingReminderHours int        `json:"pending_reminder_hours"`
+	PendingResolveHours  int        `json:"pending_resolve_hours"`
+	StaleHours           int        `json:"stale_hours"`
+}
+
+func (r AutomationPolicyRequest) schedule() automation.Schedule {
+	return automation.Schedule{
+		AutoCloseAfter:       time.Duration(r.AutoCloseDays) * 24 * time.Hour,
+		PendingReminderAfter: time.Duration(r.PendingReminderHours) * time.Hour,
+		PendingResolveAfter:  time.Duration(r.PendingResolveHours) * time.Hour,
+		StaleAfter:           time.Duration(r.StaleHours) * time.Hour,
+	}
+}
+
+// ListPolicies lists all automation policies
+// GET /api/v1/admin/support/automation/policies
+func (h *AutomationHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.policies.List(c.Request.Context())
+	if err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to retrieve automation policies")
+		return
+	}
+
+	views := make([]automationPolicyView, 0, len(policies))
+	for _, p := range policies {
+		views = append(views, newAutomationPolicyView(p))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// CreatePolicy creates an automation policy
+// POST /api/v1/admin/support/automation/policies
+func (h *AutomationHandler) CreatePolicy(c *gin.Context) {
+	var req AutomationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	policy, err := automation.NewPolicy(automation.PolicyParams{
+		Name:       req.Name,
+		CategoryID: req.CategoryID,
+		Schedule:   req.schedule(),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.policies.Save(c.Request.Context(), policy); err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to create automation policy")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newAutomationPolicyView(policy),
+		"message": "Automation policy created successfully",
+	})
+}
+
+// UpdatePolicy updates an automation policy. The new schedule applies from
+// the next scheduler pass, also to tickets that are already idle.
+// PUT /api/v1/admin/support/automation/policies/:id
+func (h *AutomationHandler) UpdatePolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid policy ID"},
+		})
+		return
+	}
+
+	var req AutomationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	policy, err := h.policies.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to retrieve automation policy")
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	if err := policy.Update(req.Name, req.CategoryID, req.schedule()); err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to update automation policy")
+		return
+	}
+
+	if err := h.policies.Save(c.Request.Context(), policy); err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to update automation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newAutomationPolicyView(policy),
+		"message": "Automation policy updated successfully",
+	})
+}
+
+// DeletePolicy deletes an automation policy
+// DELETE /api/v1/admin/support/automation/policies/:id
+func (h *AutomationHandler) DeletePolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid policy ID"},
+		})
+		return
+	}
+
+	if err := h.policies.Delete(c.Request.Context(), id); err != nil {
+		respondAutomationError(c, h.logger, err, "Failed to delete automation policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Automation policy deleted successfully",
+	})
+}
+
+// checkCategory rejects policies attached to an unknown category.
+func (h *AutomationHandler) checkCategory(c *gin.Context, categoryID *uuid.UUID) bool {
+	if categoryID == nil {
+		return true
+	}
+
+	if _, err := h.categoryRepo.FindByID(c.Request.Context(), *categoryID); err != nil {
+		if errors.Is(err, category.ErrCategoryNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Category not found"},
+			})
+			return false
+		}
+		h.logger.Error("Failed to retrieve category", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Failed to retrieve category"},
+		})
+		return false
+	}
+	return true
+}
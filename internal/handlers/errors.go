@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+	"go.uber.org/zap"
+)
+
+// respondTicketError maps ticket use case errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondTicketError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, application.ErrAccessDenied):
+		status = http.StatusForbidden
+		message = "Access denied"
+	case errors.Is(err, category.ErrCategoryNotFound):
+		status = http.StatusBadRequest
+		message = "Category not found"
+	case errors.Is(err, category.ErrCategoryInactive):
+		status = http.StatusBadRequest
+		message = "Category is not active"
+	case errors.Is(err, team.ErrTeamNotFound):
+		status = http.StatusBadRequest
+		message = "Team not found"
+	case errors.Is(err, agent.ErrAgentNotFound):
+		status = http.StatusBadRequest
+		message = "Agent not found"
+	case errors.Is(err, application.ErrAgentNotInTeam):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, ticket.ErrCustomerMismatch):
+		status = http.StatusConflict
+		message = err.Error() + "; set force to merge them anyway"
+	case errors.Is(err, ticket.ErrCannotMerge):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, ticket.ErrCannotSplit):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, ticket.ErrConflict):
+		status = http.StatusConflict
+		message = err.Error() + "; reload it and try again"
+	case errors.Is(err, mention.ErrMentionNotFound):
+		status = http.StatusNotFound
+		message = "Mention not found"
+	case errors.Is(err, mention.ErrUnknownAgent),
+		errors.Is(err, mention.ErrAmbiguousMention):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, attachment.ErrAttachmentNotFound):
+		status = http.StatusBadRequest
+		message = "Attachment not found"
+	case errors.Is(err, attachment.ErrAlreadyPosted):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, ticket.ErrInvalidTicket),
+		errors.Is(err, ticket.ErrCannotModify),
+		errors.Is(err, ticket.ErrNotAssigned),
+		errors.Is(err, shared.ErrInvalidTicketTransition),
+		errors.Is(err, shared.ErrInvalidTicketPriority):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondLinkError maps ticket link errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondLinkError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, link.ErrLinkNotFound):
+		status = http.StatusNotFound
+		message = "Ticket link not found"
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, link.ErrLinkExists),
+		errors.Is(err, link.ErrLinkConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, link.ErrInvalidLink):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondAutomationError maps automation policy errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondAutomationError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, automation.ErrPolicyNotFound):
+		status = http.StatusNotFound
+		message = "Automation policy not found"
+	case errors.Is(err, automation.ErrPolicyConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, automation.ErrInvalidPolicy):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondSLAError maps SLA calendar and policy errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondSLAError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, sla.ErrCalendarNotFound):
+		status = http.StatusNotFound
+		message = "SLA calendar not found"
+	case errors.Is(err, sla.ErrHolidayNotFound):
+		status = http.StatusNotFound
+		message = "Holiday not found"
+	case errors.Is(err, sla.ErrPolicyNotFound):
+		status = http.StatusNotFound
+		message = "SLA policy not found"
+	case errors.Is(err, sla.ErrCalendarConflict),
+		errors.Is(err, sla.ErrPolicyConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, sla.ErrInvalidCalendar),
+		errors.Is(err, sla.ErrInvalidPolicy),
+		errors.Is(err, shared.ErrInvalidTicketPriority):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondWorkflowError maps workflow errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondWorkflowError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, workflow.ErrWorkflowNotFound):
+		status = http.StatusNotFound
+		message = "Workflow not found"
+	case errors.Is(err, workflow.ErrWorkflowConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, workflow.ErrInvalidWorkflow):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondAgentError maps agent errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondAgentError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, agent.ErrAgentNotFound):
+		status = http.StatusNotFound
+		message = "Agent not found"
+	case errors.Is(err, agent.ErrAgentAlreadyExists):
+		status = http.StatusConflict
+		message = "Agent already exists"
+	case errors.Is(err, agent.ErrInvalidAgent):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondTeamError maps team errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondTeamError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, team.ErrTeamNotFound):
+		status = http.StatusNotFound
+		message = "Team not found"
+	case errors.Is(err, agent.ErrAgentNotFound):
+		status = http.StatusNotFound
+		message = "Agent not found"
+	case errors.Is(err, team.ErrTeamConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, team.ErrInvalidTeam):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondRoutingError maps routing rule errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondRoutingError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, routing.ErrRuleNotFound):
+		status = http.StatusNotFound
+		message = "Routing rule not found"
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, category.ErrCategoryNotFound):
+		status = http.StatusBadRequest
+		message = "Category not found"
+	case errors.Is(err, team.ErrTeamNotFound):
+		status = http.StatusBadRequest
+		message = "Team not found"
+	case errors.Is(err, routing.ErrInvalidRule):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondTriggerError maps trigger rule errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondTriggerError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, trigger.ErrRuleNotFound):
+		status = http.StatusNotFound
+		message = "Trigger rule not found"
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, category.ErrCategoryNotFound):
+		status = http.StatusBadRequest
+		message = "Category not found"
+	case errors.Is(err, team.ErrTeamNotFound):
+		status = http.StatusBadRequest
+		message = "Team not found"
+	case errors.Is(err, agent.ErrAgentNotFound):
+		status = http.StatusBadRequest
+		message = "Agent not found"
+	case errors.Is(err, response.ErrResponseNotFound):
+		status = http.StatusBadRequest
+		message = "Canned response not found"
+	case errors.Is(err, trigger.ErrInvalidRule):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondNotificationError maps notification template errors to an HTTP
+// response. Unexpected errors are logged and reported with the fallback
+// message.
+func respondNotificationError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, notification.ErrTemplateNotFound):
+		status = http.StatusNotFound
+		message = "Notification template not found"
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, notification.ErrTemplateConflict):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, notification.ErrInvalidTemplate):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
+
+// respondAttachmentError maps attachment errors to an HTTP response.
+// Unexpected errors are logged and reported with the fallback message.
+func respondAttachmentError(c *gin.Context, logger *zap.Logger, err error, fallback string) {
+	status := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, attachment.ErrAttachmentNotFound),
+		errors.Is(err, attachment.ErrBlobNotFound):
+		status = http.StatusNotFound
+		message = "Attachment not found"
+	case errors.Is(err, ticket.ErrTicketNotFound):
+		status = http.StatusNotFound
+		message = "Ticket not found"
+	case errors.Is(err, application.ErrAccessDenied):
+		status = http.StatusForbidden
+		message = "Access denied"
+	case errors.Is(err, attachment.ErrInvalidLink):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, application.ErrLinksDisabled):
+		status = http.StatusServiceUnavailable
+		message = err.Error()
+	case errors.Is(err, attachment.ErrAlreadyPosted):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, attachment.ErrFileTooLarge),
+		errors.Is(err, attachment.ErrTicketQuotaExceeded):
+		status = http.StatusRequestEntityTooLarge
+		message = err.Error()
+	case errors.Is(err, attachment.ErrTypeNotAllowed):
+		status = http.StatusUnsupportedMediaType
+		message = err.Error()
+	case errors.Is(err, attachment.ErrInvalidAttachment),
+		errors.Is(err, ticket.ErrCannotModify):
+		status = http.StatusBadRequest
+		message = err.Error()
+	default:
+		logger.Error(fallback, zap.Error(err))
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"error":   gin.H{"message": message},
+	})
+}
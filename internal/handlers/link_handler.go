@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"go.uber.org/zap"
+)
+
+// LinkHandler handles links between tickets and the resolution of
+// incidents with child tickets
+type LinkHandler struct {
+	links        *application.LinkService
+	tickets      *application.TicketService
+	categoryRepo category.Repository
+	agentRepo    agent.Repository
+	logger       *zap.Logger
+}
+
+// NewLinkHandler creates a new link handler
+func NewLinkHandler(
+	links *application.LinkService,
+	tickets *application.TicketService,
+	categoryRepo category.Repository,
+	agentRepo agent.Repository,
+	logger *zap.Logger,
+) *LinkHandler {
+	return &LinkHandler{
+		links:        links,
+		tickets:      tickets,
+		categoryRepo: categoryRepo,
+		agentRepo:    agentRepo,
+		logger:       logger,
+	}
+}
+
+// TicketLinkRequest represents the request to link a ticket to another.
+// Relation is what the ticket is to the other one: parent, child, related,
+// duplicate_of, duplicated_by, blocks or blocked_by.
+type TicketLinkRequest struct {
+	TicketID uuid.UUID `json:"ticket_id" binding:"required"`
+	Relation string    `json:"relation" binding:"required"`
+}
+
+// ResolveIncidentRequest represents the request to resolve a ticket and,
+// optionally, its child tickets. Message is sent to every customer.
+type ResolveIncidentRequest struct {
+	Message         string `json:"message"`
+	Notes           string `json:"notes"`
+	ResolveChildren bool   `json:"resolve_children"`
+}
+
+// ListLinks lists the links of a ticket
+// GET /api/v1/admin/support/tickets/:id/links
+func (h *LinkHandler) ListLinks(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	linked, err := h.links.TicketLinks(c.Request.Context(), id)
+	if err != nil {
+		respondLinkError(c, h.logger, err, "Failed to retrieve ticket links")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newTicketLinkViews(linked),
+	})
+}
+
+// CreateLink links a ticket to another
+// POST /api/v1/admin/support/tickets/:id/links
+func (h *LinkHandler) CreateLink(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	var req TicketLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	adminID, adminEmail := adminIdentity(c)
+	linked, err := h.links.LinkTickets(c.Request.Context(), application.LinkTicketsCommand{
+		TicketID:      id,
+		OtherID:       req.TicketID,
+		Relation:      req.Relation,
+		CreatedBy:     &adminID,
+		CreatedByName: adminEmail,
+	})
+	if err != nil {
+		respondLinkError(c, h.logger, err, "Failed to link tickets")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newTicketLinkView(*linked),
+		"message": "Tickets linked successfully",
+	})
+}
+
+// DeleteLink removes a link of a ticket
+// DELETE /api/v1/admin/support/tickets/:id/links/:link_id
+func (h *LinkHandler) DeleteLink(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("link_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid link ID"},
+		})
+		return
+	}
+
+	if err := h.links.UnlinkTickets(c.Request.Context(), id, linkID); err != nil {
+		respondLinkError(c, h.logger, err, "Failed to remove ticket link")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Ticket link removed successfully",
+	})
+}
+
+// ResolveIncident resolves a ticket and, optionally, its child tickets
+// POST /api/v1/admin/support/tickets/:id/resolve
+func (h *LinkHandler) ResolveIncident(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	var req ResolveIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	adminID, adminEmail := adminIdentity(c)
+	result, err := h.links.ResolveIncident(c.Request.Context(), application.ResolveIncidentCommand{
+		TicketID:        id,
+		Message:         req.Message,
+		Notes:           req.Notes,
+		ResolveChildren: req.ResolveChildren,
+		ResolvedBy:      adminID,
+		ResolvedByName:  adminEmail,
+		ResolvedByEmail: adminEmail,
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to resolve ticket")
+		return
+	}
+
+	ctx := c.Request.Context()
+	t := result.Ticket
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"ticket":   newTicketView(t, findCategory(ctx, h.categoryRepo, t), findAssignee(ctx, h.agentRepo, t), h.tickets.SLAStatus(ctx, t)),
+			"children": newIncidentChildViews(result),
+		},
+		"message": "Ticket resolved successfully",
+	})
+}
+
+func parseTicketID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// adminIdentity returns the ID and email of the signed-in admin.
+func adminIdentity(c *gin.Context) (uuid.UUID, string) {
+	adminIDStr, _ := c.Get("user_id")
+	var adminID uuid.UUID
+	switch v := adminIDStr.(type) {
+	case string:
+		adminID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		adminID = v
+	}
+	adminEmail, _ := c.Get("email")
+	adminEmailStr, _ := adminEmail.(string)
+	return adminID, adminEmailStr
+}
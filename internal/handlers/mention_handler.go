@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"go.uber.org/zap"
+)
+
+// MentionHandler handles the mentions of the signed-in agent in internal
+// notes
+type MentionHandler struct {
+	tickets *application.TicketService
+	logger  *zap.Logger
+}
+
+// NewMentionHandler creates a new mention handler
+func NewMentionHandler(tickets *application.TicketService, logger *zap.Logger) *MentionHandler {
+	return &MentionHandler{
+		tickets: tickets,
+		logger:  logger,
+	}
+}
+
+// ListMine lists the mentions of the signed-in agent, newest first
+// GET /api/v1/admin/support/mentions
+func (h *MentionHandler) ListMine(c *gin.Context) {
+	agentID, _ := adminIdentity(c)
+	filter := mention.Filter{
+		AgentID:    agentID,
+		UnreadOnly: c.Query("unread") == "true",
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	filter.Normalize()
+
+	mentions, total, err := h.tickets.Mentions(c.Request.Context(), filter)
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to retrieve mentions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newMentionViews(mentions),
+		"meta": gin.H{
+			"page":     filter.Page,
+			"per_page": filter.PerPage,
+			"total":    total,
+		},
+	})
+}
+
+// MarkRead marks a mention of the signed-in agent as seen
+// POST /api/v1/admin/support/mentions/:id/read
+func (h *MentionHandler) MarkRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid mention ID"},
+		})
+		return
+	}
+
+	agentID, _ := adminIdentity(c)
+	m, err := h.tickets.MarkMentionRead(c.Request.Context(), id, agentID)
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to update mention")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newMentionView(m),
+	})
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// NotificationHandler handles customer notification template and send log
+// requests
+type NotificationHandler struct {
+	templates  notification.TemplateRepository
+	notifier   *application.Notifier
+	ticketRepo ticket.Repository
+	logger     *zap.Logger
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(templates notification.TemplateRepository, notifier *application.Notifier, ticketRepo ticket.Repository, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		templates:  templates,
+		notifier:   notifier,
+		ticketRepo: ticketRepo,
+		logger:     logger,
+	}
+}
+
+// NotificationTemplateRequest represents the request to create a
+// notification template. Subject and body are Go text/templates over the
+// ticket, e.g. "[{{.TicketNumber}}] {{.Subject}}".
+type NotificationTemplateRequest struct {
+	Event   string `json:"event" binding:"required"`
+	Locale  string `json:"locale" binding:"required"`
+	Subject string `json:"subject" binding:"required"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// UpdateNotificationTemplateRequest represents the request to update a
+// notification template
+type UpdateNotificationTemplateRequest struct {
+	Subject string `json:"subject" binding:"required"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// PreviewNotificationRequest represents the request to preview a
+// notification. Subject and body default to the template used for the
+// event and locale; ticket_id renders with a real ticket instead of
+// sample data.
+type PreviewNotificationRequest struct {
+	Event    string     `json:"event" binding:"required"`
+	Locale   string     `json:"locale"`
+	Subject  string     `json:"subject"`
+	Body     string     `json:"body"`
+	TicketID *uuid.UUID `json:"ticket_id"`
+}
+
+// ListTemplates lists notification templates, filtered by the event and
+// locale query parameters. Built-in templates are listed for events
+// without a stored template in their locale.
+// GET /api/v1/admin/support/notifications/templates
+func (h *NotificationHandler) ListTemplates(c *gin.Context) {
+	var filter notification.TemplateFilter
+	if event := c.Query("event"); event != "" {
+		parsed, err := notification.ParseEvent(event)
+		if err != nil {
+			respondNotificationError(c, h.logger, err, "Failed to retrieve notification templates")
+			return
+		}
+		filter.Event = parsed
+	}
+	if locale := c.Query("locale"); locale != "" {
+		filter.Locale = shared.NormalizeLocale(locale)
+	}
+
+	templates, err := h.templates.List(c.Request.Context(), filter)
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to retrieve notification templates")
+		return
+	}
+
+	views := make([]notificationTemplateView, 0, len(templates))
+	if filter.Locale == "" || filter.Locale == notification.DefaultLocale {
+		for _, event := range notification.AllEvents() {
+			if (filter.Event == "" || filter.Event == event) && !hasTemplate(templates, event, notification.DefaultLocale) {
+				views = append(views, newNotificationTemplateView(notification.DefaultTemplate(event)))
+			}
+		}
+	}
+	for _, t := range templates {
+		views = append(views, newNotificationTemplateView(t))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetTemplate gets a notification template by ID
+// GET /api/v1/admin/support/notifications/templates/:id
+func (h *NotificationHandler) GetTemplate(c *gin.Context) {
+	id, ok := parseNotificationTemplateID(c)
+	if !ok {
+		return
+	}
+
+	t, err := h.templates.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to retrieve notification template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newNotificationTemplateView(t),
+	})
+}
+
+// CreateTemplate creates a notification template for an event and locale
+// POST /api/v1/admin/support/notifications/templates
+func (h *NotificationHandler) CreateTemplate(c *gin.Context) {
+	var req NotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	t, err := notification.NewTemplate(notification.TemplateParams{
+		Event:   req.Event,
+		Locale:  req.Locale,
+		Subject: req.Subject,
+		Body:    req.Body,
+	})
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to create notification template")
+		return
+	}
+
+	if err := h.templates.Save(c.Request.Context(), t); err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to create notification template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newNotificationTemplateView(t),
+		"message": "Notification template created successfully",
+	})
+}
+
+// UpdateTemplate replaces a notification template's subject and body
+// PUT /api/v1/admin/support/notifications/templates/:id
+func (h *NotificationHandler) UpdateTemplate(c *gin.Context) {
+	id, ok := parseNotificationTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	t, err := h.templates.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to retrieve notification template")
+		return
+	}
+
+	if err := t.Update(req.Subject, req.Body); err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to update notification template")
+		return
+	}
+
+	if err := h.templates.Save(c.Request.Context(), t); err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to update notification template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newNotificationTemplateView(t),
+		"message": "Notification template updated successfully",
+	})
+}
+
+// DeleteTemplate deletes a notification template. Its event falls back to
+// the template of the default locale or the built-in one.
+// DELETE /api/v1/admin/support/notifications/templates/:id
+func (h *NotificationHandler) DeleteTemplate(c *gin.Context) {
+	id, ok := parseNotificationTemplateID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.Delete(c.Request.Context(), id); err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to delete notification template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Notification template deleted successfully",
+	})
+}
+
+// PreviewTemplate renders a notification without sending it
+// POST /api/v1/admin/support/notifications/templates/preview
+func (h *NotificationHandler) PreviewTemplate(c *gin.Context) {
+	var req PreviewNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	rendered, err := h.notifier.Preview(c.Request.Context(), application.PreviewCommand{
+		Event:    req.Event,
+		Locale:   req.Locale,
+		Subject:  req.Subject,
+		Body:     req.Body,
+		TicketID: req.TicketID,
+	})
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to preview notification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"subject": rendered.Subject,
+			"body":    rendered.Body,
+		},
+	})
+}
+
+// ListDeliveries lists the customer notifications queued for a ticket,
+// oldest first, with their send status
+// GET /api/v1/admin/support/tickets/:id/notifications
+func (h *NotificationHandler) ListDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return
+	}
+
+	if _, err := h.ticketRepo.FindByID(c.Request.Context(), id); err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to retrieve ticket")
+		return
+	}
+
+	deliveries, err := h.notifier.Deliveries(c.Request.Context(), id)
+	if err != nil {
+		respondNotificationError(c, h.logger, err, "Failed to retrieve notifications")
+		return
+	}
+
+	views := make([]notificationDeliveryView, 0, len(deliveries))
+	for _, d := range deliveries {
+		views = append(views, newNotificationDeliveryView(d))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+func hasTemplate(templates []*notification.Template, event notification.Event, locale string) bool {
+	for _, t := range templates {
+		if t.Event() == event && t.Locale() == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNotificationTemplateID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid notification template ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// RoutingHandler handles skills-based routing rules
+type RoutingHandler struct {
+	rules        routing.Repository
+	assigner     *application.Assigner
+	ticketRepo   ticket.Repository
+	categoryRepo category.Repository
+	teamRepo     team.Repository
+	logger       *zap.Logger
+}
+
+// NewRoutingHandler creates a new routing handler. A nil assigner means
+// tickets are assigned by hand and routing cannot be explained.
+func NewRoutingHandler(
+	rules routing.Repository,
+	assigner *application.Assigner,
+	ticketRepo ticket.Repository,
+	categoryRepo category.Repository,
+	teamRepo team.Repository,
+	logger *zap.Logger,
+) *RoutingHandler {
+	return &RoutingHandler{
+		rules:        rules,
+		assigner:     assigner,
+		ticketRepo:   ticketRepo,
+		categoryRepo: categoryRepo,
+		teamRepo:     teamRepo,
+		logger:       logger,
+	}
+}
+
+// RoutingConditionInput selects the tickets a rule applies to. Every field
+// that is set must hold; a list holds when the ticket has any of its values.
+type RoutingConditionInput struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+	Tags        []string    `json:"tags"`
+	Languages   []string    `json:"languages"` // "en", "ms" or "zh"
+	HasOrder    *bool       `json:"has_order"`
+}
+
+// RoutingSkillInput is a skill agents need at least min_level (1-5) of
+type RoutingSkillInput struct {
+	Name     string `json:"name" binding:"required"`
+	MinLevel int    `json:"min_level" binding:"required"`
+}
+
+// RoutingRuleRequest represents the request to create or update a routing
+// rule. Rules are evaluated by position; enabled defaults to true.
+type RoutingRuleRequest struct {
+	Name           string                `json:"name" binding:"required"`
+	Position       int                   `json:"position"`
+	Enabled        *bool                 `json:"enabled"`
+	Conditions     RoutingConditionInput `json:"conditions"`
+	RequiredSkills []RoutingSkillInput   `json:"required_skills"`
+	FallbackTeamID *uuid.UUID            `json:"fallback_team_id"`
+}
+
+func (r RoutingRuleRequest) params() routing.RuleParams {
+	enabled := r.Enabled == nil || *r.Enabled
+	skills := make([]routing.SkillRequirement, 0, len(r.RequiredSkills))
+	for _, s := range r.RequiredSkills {
+		skills = append(skills, routing.SkillRequirement{Name: s.Name, MinLevel: s.MinLevel})
+	}
+
+	return routing.RuleParams{
+		Name:     r.Name,
+		Position: r.Position,
+		Enabled:  enabled,
+		Condition: routing.Condition{
+			CategoryIDs: r.Conditions.CategoryIDs,
+			Tags:        r.Conditions.Tags,
+			Languages:   r.Conditions.Languages,
+			HasOrder:    r.Conditions.HasOrder,
+		},
+		RequiredSkills: skills,
+		FallbackTeamID: r.FallbackTeamID,
+	}
+}
+
+// ListRoutingRules lists all routing rules in evaluation order
+// GET /api/v1/admin/support/routing-rules
+func (h *RoutingHandler) ListRoutingRules(c *gin.Context) {
+	rules, err := h.rules.List(c.Request.Context())
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to retrieve routing rules")
+		return
+	}
+
+	views := make([]routingRuleView, 0, len(rules))
+	for _, r := range rules {
+		views = append(views, newRoutingRuleView(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetRoutingRule gets a routing rule by ID
+// GET /api/v1/admin/support/routing-rules/:id
+func (h *RoutingHandler) GetRoutingRule(c *gin.Context) {
+	id, ok := parseRoutingRuleID(c)
+	if !ok {
+		return
+	}
+
+	r, err := h.rules.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to retrieve routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newRoutingRuleView(r),
+	})
+}
+
+// CreateRoutingRule creates a routing rule
+// POST /api/v1/admin/support/routing-rules
+func (h *RoutingHandler) CreateRoutingRule(c *gin.Context) {
+	var req RoutingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.checkReferences(c.Request.Context(), req); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to create routing rule")
+		return
+	}
+
+	r, err := routing.NewRule(req.params())
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to create routing rule")
+		return
+	}
+
+	if err := h.rules.Save(c.Request.Context(), r); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to create routing rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newRoutingRuleView(r),
+		"message": "Routing rule created successfully",
+	})
+}
+
+// UpdateRoutingRule replaces a routing rule's definition
+// PUT /api/v1/admin/support/routing-rules/:id
+func (h *RoutingHandler) UpdateRoutingRule(c *gin.Context) {
+	id, ok := parseRoutingRuleID(c)
+	if !ok {
+		return
+	}
+
+	var req RoutingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	r, err := h.rules.FindByID(ctx, id)
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to retrieve routing rule")
+		return
+	}
+
+	if err := h.checkReferences(ctx, req); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to update routing rule")
+		return
+	}
+
+	if err := r.Update(req.params()); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to update routing rule")
+		return
+	}
+
+	if err := h.rules.Save(ctx, r); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to update routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newRoutingRuleView(r),
+		"message": "Routing rule updated successfully",
+	})
+}
+
+// DeleteRoutingRule deletes a routing rule
+// DELETE /api/v1/admin/support/routing-rules/:id
+func (h *RoutingHandler) DeleteRoutingRule(c *gin.Context) {
+	id, ok := parseRoutingRuleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.rules.Delete(c.Request.Context(), id); err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to delete routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Routing rule deleted successfully",
+	})
+}
+
+// ExplainRouting shows how a ticket would be routed now: its detected
+// attributes, which rule matched and why the others did not, how each
+// agent scored and where the ticket would go
+// GET /api/v1/admin/support/tickets/:id/routing
+func (h *RoutingHandler) ExplainRouting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid ticket ID"},
+		})
+		return
+	}
+
+	if h.assigner == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Automatic assignment is disabled"},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	t, err := h.ticketRepo.FindByID(ctx, id)
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to retrieve ticket")
+		return
+	}
+
+	explanation, err := h.assigner.Explain(ctx, t)
+	if err != nil {
+		respondRoutingError(c, h.logger, err, "Failed to explain routing")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newRoutingExplanationView(t, explanation),
+	})
+}
+
+// checkReferences rejects rules naming an unknown category or fallback team.
+func (h *RoutingHandler) checkReferences(ctx context.Context, req RoutingRuleRequest) error {
+	for _, id := range req.Conditions.CategoryIDs {
+		if _, err := h.categoryRepo.FindByID(ctx, id); err != nil {
+			return err
+		}
+	}
+	if req.FallbackTeamID != nil {
+		if _, err := h.teamRepo.FindByID(ctx, *req.FallbackTeamID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRoutingRuleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid routing rule ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,511 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"go.uber.org/zap"
+)
+
+// SLAHandler handles SLA calendar and policy management requests
+type SLAHandler struct {
+	calendars    sla.Repository
+	policies     sla.PolicyRepository
+	categoryRepo category.Repository
+	logger       *zap.Logger
+}
+
+// NewSLAHandler creates a new SLA handler
+func NewSLAHandler(calendars sla.Repository, policies sla.PolicyRepository, categoryRepo category.Repository, logger *zap.Logger) *SLAHandler {
+	return &SLAHandler{
+		calendars:    calendars,
+		policies:     policies,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+	}
+}
+
+// WorkingHoursInput is one working window of a calendar, e.g.
+// {"weekday": "monday", "start": "09:00", "end": "17:30"}
+type WorkingHoursInput struct {
+	Weekday string `json:"weekday" binding:"required"`
+	Start   string `json:"start" binding:"required"`
+	End     string `json:"end" binding:"required"`
+}
+
+// HolidayInput is a non-working date of a calendar
+type HolidayInput struct {
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name"`
+}
+
+// SLACalendarRequest represents the request to create or update a calendar.
+// A calendar without category_id is the global calendar.
+type SLACalendarRequest struct {
+	Name         string              `json:"name" binding:"required"`
+	Timezone     string              `json:"timezone"`
+	CategoryID   *uuid.UUID          `json:"category_id"`
+	WorkingHours []WorkingHoursInput `json:"working_hours" binding:"required"`
+	Holidays     []HolidayInput      `json:"holidays"`
+}
+
+// ListCalendars lists all SLA calendars
+// GET /api/v1/admin/support/sla/calendars
+func (h *SLAHandler) ListCalendars(c *gin.Context) {
+	calendars, err := h.calendars.List(c.Request.Context())
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA calendars")
+		return
+	}
+
+	views := make([]slaCalendarView, 0, len(calendars))
+	for _, cal := range calendars {
+		views = append(views, newSLACalendarView(cal))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetCalendar retrieves an SLA calendar
+// GET /api/v1/admin/support/sla/calendars/:id
+func (h *SLAHandler) GetCalendar(c *gin.Context) {
+	id, ok := parseCalendarID(c)
+	if !ok {
+		return
+	}
+
+	cal, err := h.calendars.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSLACalendarView(cal),
+	})
+}
+
+// CreateCalendar creates an SLA calendar
+// POST /api/v1/admin/support/sla/calendars
+func (h *SLAHandler) CreateCalendar(c *gin.Context) {
+	var req SLACalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	hours, err := parseWorkingHours(req.WorkingHours)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to create SLA calendar")
+		return
+	}
+
+	holidays := make([]sla.Holiday, 0, len(req.Holidays))
+	for _, holiday := range req.Holidays {
+		holidays = append(holidays, sla.Holiday{Date: holiday.Date, Name: holiday.Name})
+	}
+
+	cal, err := sla.NewCalendar(sla.CalendarParams{
+		Name:         req.Name,
+		Timezone:     req.Timezone,
+		CategoryID:   req.CategoryID,
+		WorkingHours: hours,
+		Holidays:     holidays,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.calendars.Save(c.Request.Context(), cal); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to create SLA calendar")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newSLACalendarView(cal),
+		"message": "SLA calendar created successfully",
+	})
+}
+
+// UpdateCalendar replaces the settings of an SLA calendar. Holidays are
+// managed through their own endpoints and are kept unless the request lists
+// them.
+// PUT /api/v1/admin/support/sla/calendars/:id
+func (h *SLAHandler) UpdateCalendar(c *gin.Context) {
+	id, ok := parseCalendarID(c)
+	if !ok {
+		return
+	}
+
+	var req SLACalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	cal, err := h.calendars.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA calendar")
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	hours, err := parseWorkingHours(req.WorkingHours)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to update SLA calendar")
+		return
+	}
+	if err := cal.Update(req.Name, req.Timezone, hours); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to update SLA calendar")
+		return
+	}
+	cal.SetCategory(req.CategoryID)
+
+	if req.Holidays != nil {
+		for _, existing := range append([]sla.Holiday(nil), cal.Holidays()...) {
+			_ = cal.RemoveHoliday(existing.Date)
+		}
+		for _, holiday := range req.Holidays {
+			if err := cal.AddHoliday(holiday.Date, holiday.Name); err != nil {
+				respondSLAError(c, h.logger, err, "Failed to update SLA calendar")
+				return
+			}
+		}
+	}
+
+	if err := h.calendars.Save(c.Request.Context(), cal); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to update SLA calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSLACalendarView(cal),
+		"message": "SLA calendar updated successfully",
+	})
+}
+
+// DeleteCalendar deletes an SLA calendar
+// DELETE /api/v1/admin/support/sla/calendars/:id
+func (h *SLAHandler) DeleteCalendar(c *gin.Context) {
+	id, ok := parseCalendarID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendars.Delete(c.Request.Context(), id); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to delete SLA calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "SLA calendar deleted successfully",
+	})
+}
+
+// AddHoliday adds a holiday to an SLA calendar, renaming it if the date is
+// already a holiday
+// POST /api/v1/admin/support/sla/calendars/:id/holidays
+func (h *SLAHandler) AddHoliday(c *gin.Context) {
+	id, ok := parseCalendarID(c)
+	if !ok {
+		return
+	}
+
+	var req HolidayInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	cal, err := h.calendars.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA calendar")
+		return
+	}
+
+	if err := cal.AddHoliday(req.Date, req.Name); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to add holiday")
+		return
+	}
+
+	if err := h.calendars.Save(c.Request.Context(), cal); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to add holiday")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSLACalendarView(cal),
+		"message": "Holiday added successfully",
+	})
+}
+
+// RemoveHoliday removes a holiday from an SLA calendar
+// DELETE /api/v1/admin/support/sla/calendars/:id/holidays/:date
+func (h *SLAHandler) RemoveHoliday(c *gin.Context) {
+	id, ok := parseCalendarID(c)
+	if !ok {
+		return
+	}
+
+	cal, err := h.calendars.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA calendar")
+		return
+	}
+
+	if err := cal.RemoveHoliday(c.Param("date")); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to remove holiday")
+		return
+	}
+
+	if err := h.calendars.Save(c.Request.Context(), cal); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to remove holiday")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSLACalendarView(cal),
+		"message": "Holiday removed successfully",
+	})
+}
+
+// SLAPolicyRequest represents the request to create or update a policy.
+// A policy without category_id applies to every category without its own.
+type SLAPolicyRequest struct {
+	Name                 string     `json:"name" binding:"required"`
+	Priority             string     `json:"priority" binding:"required"`
+	CategoryID           *uuid.UUID `json:"category_id"`
+	FirstResponseMinutes int        `json:"first_response_minutes" binding:"required"`
+	ResolutionMinutes    int        `json:"resolution_minutes" binding:"required"`
+}
+
+func (r SLAPolicyRequest) targets() sla.Targets {
+	return sla.Targets{
+		FirstResponse: time.Duration(r.FirstResponseMinutes) * time.Minute,
+		Resolution:    time.Duration(r.ResolutionMinutes) * time.Minute,
+	}
+}
+
+// ListPolicies lists all SLA policies
+// GET /api/v1/admin/support/sla/policies
+func (h *SLAHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.policies.List(c.Request.Context())
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA policies")
+		return
+	}
+
+	views := make([]slaPolicyView, 0, len(policies))
+	for _, p := range policies {
+		views = append(views, newSLAPolicyView(p))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// CreatePolicy creates an SLA policy
+// POST /api/v1/admin/support/sla/policies
+func (h *SLAHandler) CreatePolicy(c *gin.Context) {
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	policy, err := sla.NewPolicy(sla.PolicyParams{
+		Name:       req.Name,
+		Priority:   req.Priority,
+		CategoryID: req.CategoryID,
+		Targets:    req.targets(),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.policies.Save(c.Request.Context(), policy); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to create SLA policy")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newSLAPolicyView(policy),
+		"message": "SLA policy created successfully",
+	})
+}
+
+// UpdatePolicy updates an SLA policy. Existing tickets keep the targets they
+// were opened with.
+// PUT /api/v1/admin/support/sla/policies/:id
+func (h *SLAHandler) UpdatePolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid policy ID"},
+		})
+		return
+	}
+
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	policy, err := h.policies.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondSLAError(c, h.logger, err, "Failed to retrieve SLA policy")
+		return
+	}
+
+	if !h.checkCategory(c, req.CategoryID) {
+		return
+	}
+
+	if err := policy.Update(req.Name, req.Priority, req.CategoryID, req.targets()); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to update SLA policy")
+		return
+	}
+
+	if err := h.policies.Save(c.Request.Context(), policy); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to update SLA policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSLAPolicyView(policy),
+		"message": "SLA policy updated successfully",
+	})
+}
+
+// DeletePolicy deletes an SLA policy
+// DELETE /api/v1/admin/support/sla/policies/:id
+func (h *SLAHandler) DeletePolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid policy ID"},
+		})
+		return
+	}
+
+	if err := h.policies.Delete(c.Request.Context(), id); err != nil {
+		respondSLAError(c, h.logger, err, "Failed to delete SLA policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "SLA policy deleted successfully",
+	})
+}
+
+// checkCategory rejects calendars attached to an unknown category.
+func (h *SLAHandler) checkCategory(c *gin.Context, categoryID *uuid.UUID) bool {
+	if categoryID == nil {
+		return true
+	}
+
+	if _, err := h.categoryRepo.FindByID(c.Request.Context(), *categoryID); err != nil {
+		if errors.Is(err, category.ErrCategoryNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Category not found"},
+			})
+			return false
+		}
+		h.logger.Error("Failed to retrieve category", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Failed to retrieve category"},
+		})
+		return false
+	}
+	return true
+}
+
+func parseCalendarID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid calendar ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func parseWorkingHours(inputs []WorkingHoursInput) ([]sla.WorkingHours, error) {
+	hours := make([]sla.WorkingHours, 0, len(inputs))
+	for _, input := range inputs {
+		weekday, err := sla.ParseWeekday(input.Weekday)
+		if err != nil {
+			return nil, err
+		}
+		start, err := sla.ParseClock(input.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := sla.ParseClock(input.End)
+		if err != nil {
+			return nil, err
+		}
+		hours = append(hours, sla.WorkingHours{Weekday: weekday, Start: start, End: end})
+	}
+	return hours, nil
+}
@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"go.uber.org/zap"
+)
+
+// TeamHandler handles support teams and their queues
+type TeamHandler struct {
+	teams        team.Repository
+	tickets      *application.TicketService
+	ticketRepo   ticket.Repository
+	categoryRepo category.Repository
+	agentRepo    agent.Repository
+	logger       *zap.Logger
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(
+	teams team.Repository,
+	tickets *application.TicketService,
+	ticketRepo ticket.Repository,
+	categoryRepo category.Repository,
+	agentRepo agent.Repository,
+	logger *zap.Logger,
+) *TeamHandler {
+	return &TeamHandler{
+		teams:        teams,
+		tickets:      tickets,
+		ticketRepo:   ticketRepo,
+		categoryRepo: categoryRepo,
+		agentRepo:    agentRepo,
+		logger:       logger,
+	}
+}
+
+// TeamRequest represents the request to create or update a team. New
+// tickets in the team's categories join its queue.
+type TeamRequest struct {
+	Key         string      `json:"key"`
+	Name        string      `json:"name" binding:"required"`
+	Description string      `json:"description"`
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+}
+
+// TeamMemberRequest represents the request to add an agent to a team
+type TeamMemberRequest struct {
+	AgentID uuid.UUID `json:"agent_id" binding:"required"`
+}
+
+// ListTeams lists all teams with their members
+// GET /api/v1/admin/support/teams
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	teams, err := h.teams.List(c.Request.Context())
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve teams")
+		return
+	}
+
+	views := make([]teamView, 0, len(teams))
+	for _, t := range teams {
+		view, err := h.view(c.Request.Context(), t)
+		if err != nil {
+			respondTeamError(c, h.logger, err, "Failed to retrieve teams")
+			return
+		}
+		views = append(views, view)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetTeam gets a team by ID with its members
+// GET /api/v1/admin/support/teams/:id
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	id, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	t, err := h.teams.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team")
+		return
+	}
+	h.respond(c, http.StatusOK, t, "")
+}
+
+// CreateTeam creates a team
+// POST /api/v1/admin/support/teams
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if !h.checkCategories(c, req.CategoryIDs) {
+		return
+	}
+
+	t, err := team.NewTeam(team.TeamParams{
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+		CategoryIDs: req.CategoryIDs,
+	})
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to create team")
+		return
+	}
+
+	if err := h.teams.Save(c.Request.Context(), t); err != nil {
+		respondTeamError(c, h.logger, err, "Failed to create team")
+		return
+	}
+	h.respond(c, http.StatusCreated, t, "Team created successfully")
+}
+
+// UpdateTeam updates a team's name, description and categories. The key
+// cannot be changed.
+// PUT /api/v1/admin/support/teams/:id
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	id, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	t, err := h.teams.FindByID(ctx, id)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team")
+		return
+	}
+
+	if !h.checkCategories(c, req.CategoryIDs) {
+		return
+	}
+
+	if err := t.Update(req.Name, req.Description); err != nil {
+		respondTeamError(c, h.logger, err, "Failed to update team")
+		return
+	}
+	t.SetCategories(req.CategoryIDs)
+
+	if err := h.teams.Save(ctx, t); err != nil {
+		respondTeamError(c, h.logger, err, "Failed to update team")
+		return
+	}
+	h.respond(c, http.StatusOK, t, "Team updated successfully")
+}
+
+// DeleteTeam deletes a team. Its members leave the team and its tickets
+// leave the team's queue, keeping their agents.
+// DELETE /api/v1/admin/support/teams/:id
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	id, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	t, err := h.teams.FindByID(ctx, id)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team")
+		return
+	}
+
+	members, err := h.agentRepo.List(ctx, agent.Filter{Team: t.Key()})
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to delete team")
+		return
+	}
+	for _, a := range members {
+		a.LeaveTeam(t.Key())
+		if err := h.agentRepo.Save(ctx, a); err != nil {
+			respondTeamError(c, h.logger, err, "Failed to delete team")
+			return
+		}
+	}
+
+	if err := h.teams.Delete(ctx, id); err != nil {
+		respondTeamError(c, h.logger, err, "Failed to delete team")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team deleted successfully",
+	})
+}
+
+// AddTeamMember adds an agent to a team
+// POST /api/v1/admin/support/teams/:id/members
+func (h *TeamHandler) AddTeamMember(c *gin.Context) {
+	id, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	var req TeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	h.changeMembership(c, id, req.AgentID, true)
+}
+
+// RemoveTeamMember removes an agent from a team. Tickets they hold stay
+// assigned to them.
+// DELETE /api/v1/admin/support/teams/:id/members/:agent_id
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	id, ok := parseTeamID(c)
+	if !ok {
+		return
+	}
+
+	agentID, err := uuid.Parse(c.Param("agent_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid agent ID"},
+		})
+		return
+	}
+
+	h.changeMembership(c, id, agentID, false)
+}
+
+func (h *TeamHandler) changeMembership(c *gin.Context, teamID, agentID uuid.UUID, join bool) {
+	ctx := c.Request.Context()
+	t, err := h.teams.FindByID(ctx, teamID)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team")
+		return
+	}
+	a, err := h.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve agent")
+		return
+	}
+
+	message := "Team member removed successfully"
+	if join {
+		a.JoinTeam(t.Key())
+		message = "Team member added successfully"
+	} else {
+		a.LeaveTeam(t.Key())
+	}
+
+	if err := h.agentRepo.Save(ctx, a); err != nil {
+		respondTeamError(c, h.logger, err, "Failed to update team members")
+		return
+	}
+	h.respond(c, http.StatusOK, t, message)
+}
+
+// GetQueue lists a team's unassigned active tickets and the tickets its
+// agents are working on. The team is given by ID or key.
+// GET /api/v1/admin/support/queues/:team
+func (h *TeamHandler) GetQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var t *team.Team
+	var err error
+	if id, parseErr := uuid.Parse(c.Param("team")); parseErr == nil {
+		t, err = h.teams.FindByID(ctx, id)
+	} else {
+		t, err = h.teams.FindByKey(ctx, c.Param("team"))
+	}
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team")
+		return
+	}
+
+	teamID := t.ID()
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "50"))
+	unassigned, unassignedTotal, err := h.ticketRepo.List(ctx, ticket.Filter{
+		TeamID:     &teamID,
+		Unassigned: true,
+		ActiveOnly: true,
+		PerPage:    perPage,
+	})
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve queue")
+		return
+	}
+	inProgress, inProgressTotal, err := h.ticketRepo.List(ctx, ticket.Filter{
+		TeamID:  &teamID,
+		Status:  string(shared.StatusInProgress),
+		PerPage: perPage,
+	})
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve queue")
+		return
+	}
+
+	view, err := h.view(ctx, t)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve queue")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": queueView{
+			Team:       view,
+			Unassigned: newTicketViews(ctx, h.categoryRepo, h.agentRepo, h.tickets.SLAStatuses(ctx, unassigned), unassigned),
+			InProgress: newTicketViews(ctx, h.categoryRepo, h.agentRepo, h.tickets.SLAStatuses(ctx, inProgress), inProgress),
+		},
+		"meta": gin.H{
+			"unassigned_total":  unassignedTotal,
+			"in_progress_total": inProgressTotal,
+		},
+	})
+}
+
+// respond renders the team with its members.
+func (h *TeamHandler) respond(c *gin.Context, status int, t *team.Team, message string) {
+	view, err := h.view(c.Request.Context(), t)
+	if err != nil {
+		respondTeamError(c, h.logger, err, "Failed to retrieve team members")
+		return
+	}
+
+	body := gin.H{
+		"success": true,
+		"data":    view,
+	}
+	if message != "" {
+		body["message"] = message
+	}
+	c.JSON(status, body)
+}
+
+// view renders the team with its members and their open ticket counts.
+func (h *TeamHandler) view(ctx context.Context, t *team.Team) (teamView, error) {
+	members, err := h.agentRepo.List(ctx, agent.Filter{Team: t.Key()})
+	if err != nil {
+		return teamView{}, err
+	}
+	load, err := h.ticketRepo.CountActiveByAssignee(ctx)
+	if err != nil {
+		h.logger.Warn("Failed to count agent tickets", zap.Error(err))
+	}
+
+	views := make([]agentView, 0, len(members))
+	for _, a := range members {
+		views = append(views, newAgentView(a, load[a.ID()]))
+	}
+	return newTeamView(t, views), nil
+}
+
+// checkCategories rejects teams mapped to an unknown category.
+func (h *TeamHandler) checkCategories(c *gin.Context, categoryIDs []uuid.UUID) bool {
+	for _, id := range categoryIDs {
+		if _, err := h.categoryRepo.FindByID(c.Request.Context(), id); err != nil {
+			if errors.Is(err, category.ErrCategoryNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   gin.H{"message": "Category not found"},
+				})
+				return false
+			}
+			h.logger.Error("Failed to retrieve category", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Failed to retrieve category"},
+			})
+			return false
+		}
+	}
+	return true
+}
+
+func parseTeamID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid team ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
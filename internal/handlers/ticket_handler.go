@@ -1,44 +1,45 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/Ecom-micro-template/service-support/internal/events"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
-	"github.com/Ecom-micro-template/service-support/internal/infrastructure/persistence"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
 	"go.uber.org/zap"
 )
 
 // TicketHandler handles ticket-related requests
 type TicketHandler struct {
-	ticketRepo  *persistence.TicketRepository
-	messageRepo *persistence.MessageRepository
-	publisher   *events.Publisher
-	logger      *zap.Logger
+	tickets      *application.TicketService
+	ticketRepo   ticket.Repository
+	categoryRepo category.Repository
+	agentRepo    agent.Repository
+	logger       *zap.Logger
 }
 
 // NewTicketHandler creates a new ticket handler
 func NewTicketHandler(
-	ticketRepo *persistence.TicketRepository,
-	messageRepo *persistence.MessageRepository,
+	tickets *application.TicketService,
+	ticketRepo ticket.Repository,
+	categoryRepo category.Repository,
+	agentRepo agent.Repository,
 	logger *zap.Logger,
 ) *TicketHandler {
 	return &TicketHandler{
-		ticketRepo:  ticketRepo,
-		messageRepo: messageRepo,
-		logger:      logger,
+		tickets:      tickets,
+		ticketRepo:   ticketRepo,
+		categoryRepo: categoryRepo,
+		agentRepo:    agentRepo,
+		logger:       logger,
 	}
 }
 
-// SetEventPublisher sets the event publisher for notifications
-func (h *TicketHandler) SetEventPublisher(publisher *events.Publisher) {
-	h.publisher = publisher
-}
-
 // CreateTicketRequest represents the request to create a ticket
 type CreateTicketRequest struct {
 	Subject     string     `json:"subject" binding:"required"`
@@ -47,10 +48,17 @@ type CreateTicketRequest struct {
 	Priority    string     `json:"priority"`
 	OrderID     *uuid.UUID `json:"order_id"`
 	OrderNumber string     `json:"order_number"`
+	// CC are extra addresses to copy on replies
+	CC []string `json:"cc"`
+	// Brand selects the ticket number format of the storefront
+	Brand string `json:"brand"`
+	// Locale is the language customer email is written in; defaults to
+	// the first language of the Accept-Language header
+	Locale string `json:"locale"`
 	// For guest contact form
-	GuestEmail  string `json:"guest_email"`
-	GuestName   string `json:"guest_name"`
-	GuestPhone  string `json:"guest_phone"`
+	GuestEmail string `json:"guest_email"`
+	GuestName  string `json:"guest_name"`
+	GuestPhone string `json:"guest_phone"`
 }
 
 // Create creates a new support ticket (authenticated user)
@@ -91,52 +99,26 @@ func (h *TicketHandler) Create(c *gin.Context) {
 		customerID = v
 	}
 
-	// Set priority
-	priority := domain.TicketPriorityNormal
-	if req.Priority != "" {
-		priority = domain.TicketPriority(req.Priority)
-	}
-
-	// Create ticket
-	ticket := &domain.Ticket{
+	t, err := h.tickets.CreateTicket(c.Request.Context(), application.CreateTicketCommand{
 		CustomerID:  &customerID,
 		Subject:     req.Subject,
+		Message:     req.Message,
 		CategoryID:  req.CategoryID,
-		Priority:    priority,
-		Status:      domain.TicketStatusOpen,
+		Priority:    req.Priority,
 		OrderID:     req.OrderID,
 		OrderNumber: req.OrderNumber,
-	}
-
-	if err := h.ticketRepo.Create(c.Request.Context(), ticket); err != nil {
-		h.logger.Error("Failed to create ticket", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Failed to create ticket"},
-		})
+		CC:          req.CC,
+		Brand:       req.Brand,
+		Locale:      requestLocale(c, req.Locale),
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to create ticket")
 		return
 	}
 
-	// Create initial message
-	message := &domain.Message{
-		TicketID:   ticket.ID,
-		SenderType: domain.SenderTypeCustomer,
-		SenderID:   &customerID,
-		Content:    req.Message,
-	}
-
-	if err := h.messageRepo.Create(c.Request.Context(), message); err != nil {
-		h.logger.Error("Failed to create initial message", zap.Error(err))
-	}
-
-	// Publish event for notification
-	if h.publisher != nil {
-		h.publisher.PublishTicketCreated(ticket)
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    ticket,
+		"data":    newTicketView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t)),
 		"message": "Ticket created successfully",
 	})
 }
@@ -162,48 +144,26 @@ func (h *TicketHandler) SubmitContactForm(c *gin.Context) {
 		return
 	}
 
-	// Create ticket for guest
-	ticket := &domain.Ticket{
+	t, err := h.tickets.CreateTicket(c.Request.Context(), application.CreateTicketCommand{
 		GuestEmail: req.GuestEmail,
 		GuestName:  req.GuestName,
 		GuestPhone: req.GuestPhone,
 		Subject:    req.Subject,
+		Message:    req.Message,
 		CategoryID: req.CategoryID,
-		Priority:   domain.TicketPriorityNormal,
-		Status:     domain.TicketStatusOpen,
-	}
-
-	if err := h.ticketRepo.Create(c.Request.Context(), ticket); err != nil {
-		h.logger.Error("Failed to create contact form ticket", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Failed to submit contact form"},
-		})
+		CC:         req.CC,
+		Brand:      req.Brand,
+		Locale:     requestLocale(c, req.Locale),
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to submit contact form")
 		return
 	}
 
-	// Create initial message
-	message := &domain.Message{
-		TicketID:    ticket.ID,
-		SenderType:  domain.SenderTypeCustomer,
-		SenderName:  req.GuestName,
-		SenderEmail: req.GuestEmail,
-		Content:     req.Message,
-	}
-
-	if err := h.messageRepo.Create(c.Request.Context(), message); err != nil {
-		h.logger.Error("Failed to create initial message", zap.Error(err))
-	}
-
-	// Publish event for notification
-	if h.publisher != nil {
-		h.publisher.PublishTicketCreated(ticket)
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
 		"data": gin.H{
-			"ticket_number": ticket.TicketNumber,
+			"ticket_number": t.TicketNumber().Value(),
 		},
 		"message": "Thank you for contacting us. We will respond to your inquiry soon.",
 	})
@@ -242,7 +202,11 @@ func (h *TicketHandler) List(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", "20"))
 
-	tickets, total, err := h.ticketRepo.ListByCustomer(c.Request.Context(), customerID, page, perPage)
+	tickets, total, err := h.ticketRepo.List(c.Request.Context(), ticket.Filter{
+		CustomerID: &customerID,
+		Page:       page,
+		PerPage:    perPage,
+	})
 	if err != nil {
 		h.logger.Error("Failed to list tickets", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -254,7 +218,7 @@ func (h *TicketHandler) List(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    tickets,
+		"data":    newTicketViews(c.Request.Context(), h.categoryRepo, h.agentRepo, h.tickets.SLAStatuses(c.Request.Context(), tickets), tickets),
 		"meta": gin.H{
 			"page":     page,
 			"per_page": perPage,
@@ -266,22 +230,9 @@ func (h *TicketHandler) List(c *gin.Context) {
 // GetByID retrieves a specific ticket
 // GET /api/v1/support/tickets/:id
 func (h *TicketHandler) GetByID(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Invalid ticket ID"},
-		})
-		return
-	}
-
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
+	t, requested, err := h.tickets.LookupTicket(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
-		})
+		respondTicketError(c, h.logger, err, "Failed to retrieve ticket")
 		return
 	}
 
@@ -296,7 +247,7 @@ func (h *TicketHandler) GetByID(c *gin.Context) {
 			customerID = v
 		}
 
-		if ticket.CustomerID != nil && *ticket.CustomerID != customerID {
+		if t.CustomerID() != nil && *t.CustomerID() != customerID {
 			// Check if user is admin
 			role, _ := c.Get("role")
 			if role != "admin" && role != "super_admin" && role != "support" {
@@ -309,31 +260,25 @@ func (h *TicketHandler) GetByID(c *gin.Context) {
 		}
 	}
 
-	// Get messages (exclude internal notes for customers)
+	// Exclude internal notes for customers
 	includeInternal := false
 	role, _ := c.Get("role")
 	if role == "admin" || role == "super_admin" || role == "support" {
 		includeInternal = true
 	}
 
-	messages, _ := h.messageRepo.GetByTicketID(c.Request.Context(), ticket.ID, includeInternal)
-	ticket.Messages = messages
-
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    ticket,
+		"success":         true,
+		"data":            newTicketDetailView(t, findCategory(c.Request.Context(), h.categoryRepo, t), findAssignee(c.Request.Context(), h.agentRepo, t), h.tickets.SLAStatus(c.Request.Context(), t), includeInternal),
+		"redirected_from": redirectedFrom(requested),
 	})
 }
 
-// AddMessageRequest represents the request to add a message
+// AddMessageRequest represents the request to add a message.
+// AttachmentIDs are files uploaded to the ticket to post with it.
 type AddMessageRequest struct {
-	Content     string `json:"content" binding:"required"`
-	Attachments []struct {
-		Name     string `json:"name"`
-		URL      string `json:"url"`
-		Size     int64  `json:"size"`
-		MimeType string `json:"mime_type"`
-	} `json:"attachments"`
+	Content       string      `json:"content" binding:"required"`
+	AttachmentIDs []uuid.UUID `json:"attachment_ids"`
 }
 
 // AddMessage adds a message to a ticket
@@ -358,16 +303,6 @@ func (h *TicketHandler) AddMessage(c *gin.Context) {
 		return
 	}
 
-	// Verify ticket exists and user has access
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
-		})
-		return
-	}
-
 	// Get user info
 	customerIDStr, exists := c.Get("user_id")
 	if !exists {
@@ -386,49 +321,22 @@ func (h *TicketHandler) AddMessage(c *gin.Context) {
 		senderID = v
 	}
 
-	// Determine sender type
-	senderType := domain.SenderTypeCustomer
-	if ticket.CustomerID != nil && *ticket.CustomerID != senderID {
-		role, _ := c.Get("role")
-		if role == "admin" || role == "super_admin" || role == "support" {
-			senderType = domain.SenderTypeAgent
-		} else {
-			c.JSON(http.StatusForbidden, gin.H{
-				"success": false,
-				"error":   gin.H{"message": "Access denied"},
-			})
-			return
-		}
-	}
-
-	// Convert attachments to JSON
-	attachmentsJSON, _ := json.Marshal(req.Attachments)
-
-	message := &domain.Message{
-		TicketID:    id,
-		SenderType:  senderType,
-		SenderID:    &senderID,
-		Content:     req.Content,
-		Attachments: attachmentsJSON,
-	}
-
-	if err := h.messageRepo.Create(c.Request.Context(), message); err != nil {
-		h.logger.Error("Failed to create message", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Failed to send message"},
-		})
+	role, _ := c.Get("role")
+	_, msg, err := h.tickets.ReplyToTicket(c.Request.Context(), application.ReplyToTicketCommand{
+		TicketID:      id,
+		SenderID:      senderID,
+		Content:       req.Content,
+		AttachmentIDs: req.AttachmentIDs,
+		IsStaff:       role == "admin" || role == "super_admin" || role == "support",
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to send message")
 		return
 	}
 
-	// Publish event for notification
-	if h.publisher != nil {
-		h.publisher.PublishTicketReply(ticket, message, senderType == domain.SenderTypeAgent)
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    message,
+		"data":    newMessageView(msg),
 		"message": "Message sent successfully",
 	})
 }
@@ -458,38 +366,108 @@ func (h *TicketHandler) RateTicket(c *gin.Context) {
 		return
 	}
 
-	ticket, err := h.ticketRepo.GetByID(c.Request.Context(), id)
+	customerIDStr, _ := c.Get("user_id")
+	var customerID uuid.UUID
+	switch v := customerIDStr.(type) {
+	case string:
+		customerID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		customerID = v
+	}
+
+	_, err = h.tickets.Rate(c.Request.Context(), application.RateCommand{
+		TicketID:   id,
+		CustomerID: customerID,
+		Rating:     req.Rating,
+		Comment:    req.Comment,
+	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   gin.H{"message": "Ticket not found"},
-		})
+		respondTicketError(c, h.logger, err, "Failed to submit rating")
 		return
 	}
 
-	// Only allow rating for resolved/closed tickets
-	if ticket.Status != domain.TicketStatusResolved && ticket.Status != domain.TicketStatusClosed {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Thank you for your feedback!",
+	})
+}
+
+// SetCC replaces the addresses the customer copies on a ticket
+// PUT /api/v1/support/tickets/:id/cc
+func (h *TicketHandler) SetCC(c *gin.Context) {
+	id, ok := parseTicketID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Emails []string `json:"emails"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   gin.H{"message": "Can only rate resolved or closed tickets"},
+			"error":   gin.H{"message": err.Error()},
 		})
 		return
 	}
 
-	ticket.SatisfactionRating = &req.Rating
-	ticket.SatisfactionComment = req.Comment
+	customerIDStr, _ := c.Get("user_id")
+	var customerID uuid.UUID
+	switch v := customerIDStr.(type) {
+	case string:
+		customerID, _ = uuid.Parse(v)
+	case uuid.UUID:
+		customerID = v
+	}
+
+	role, _ := c.Get("role")
+	t, err := h.tickets.SetTicketCC(c.Request.Context(), application.SetTicketCCCommand{
+		TicketID:   id,
+		CustomerID: customerID,
+		Emails:     req.Emails,
+		IsStaff:    role == "admin" || role == "super_admin" || role == "support",
+	})
+	if err != nil {
+		respondTicketError(c, h.logger, err, "Failed to update CC addresses")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"cc": t.CC()},
+		"message": "CC addresses updated successfully",
+	})
+}
 
-	if err := h.ticketRepo.Update(c.Request.Context(), ticket); err != nil {
-		h.logger.Error("Failed to rate ticket", zap.Error(err))
+// ListCategories lists active support categories
+// GET /api/v1/support/categories
+func (h *TicketHandler) ListCategories(c *gin.Context) {
+	categories, err := h.categoryRepo.List(c.Request.Context(), true)
+	if err != nil {
+		h.logger.Error("Failed to list categories", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   gin.H{"message": "Failed to submit rating"},
+			"error":   gin.H{"message": "Failed to retrieve categories"},
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Thank you for your feedback!",
+		"data":    newCategoryViews(categories),
 	})
 }
+
+// requestLocale returns the locale asked for in the request body, falling
+// back to the first language of the Accept-Language header.
+func requestLocale(c *gin.Context, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	first, _, _ := strings.Cut(c.GetHeader("Accept-Language"), ",")
+	first, _, _ = strings.Cut(first, ";")
+	if first = strings.TrimSpace(first); first == "*" {
+		return ""
+	}
+	return first
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"go.uber.org/zap"
+)
+
+// TriggerHandler handles trigger rules, their dry runs and firing log
+type TriggerHandler struct {
+	rules        trigger.Repository
+	tickets      *application.TicketService
+	categoryRepo category.Repository
+	teamRepo     team.Repository
+	agentRepo    agent.Repository
+	responseRepo response.Repository
+	logger       *zap.Logger
+}
+
+// NewTriggerHandler creates a new trigger handler
+func NewTriggerHandler(
+	rules trigger.Repository,
+	tickets *application.TicketService,
+	categoryRepo category.Repository,
+	teamRepo team.Repository,
+	agentRepo agent.Repository,
+	responseRepo response.Repository,
+	logger *zap.Logger,
+) *TriggerHandler {
+	return &TriggerHandler{
+		rules:        rules,
+		tickets:      tickets,
+		categoryRepo: categoryRepo,
+		teamRepo:     teamRepo,
+		agentRepo:    agentRepo,
+		responseRepo: responseRepo,
+		logger:       logger,
+	}
+}
+
+// TriggerConditionInput is a test on a ticket: either a field test, or a
+// group that holds when all or any of its conditions hold. Fields are
+// subject, content, category, priority, status, channel, customer_type and
+// tags; operators are equals, not_equals, contains and not_contains.
+type TriggerConditionInput struct {
+	All      []TriggerConditionInput `json:"all"`
+	Any      []TriggerConditionInput `json:"any"`
+	Field    string                  `json:"field"`
+	Operator string                  `json:"operator"`
+	Value    string                  `json:"value"`
+}
+
+func (in TriggerConditionInput) condition() trigger.Condition {
+	c := trigger.Condition{
+		Field:    trigger.Field(in.Field),
+		Operator: trigger.Operator(in.Operator),
+		Value:    in.Value,
+	}
+	for _, sub := range in.All {
+		c.All = append(c.All, sub.condition())
+	}
+	for _, sub := range in.Any {
+		c.Any = append(c.Any, sub.condition())
+	}
+	return c
+}
+
+// TriggerActionInput is a change a rule makes, such as
+// {"type": "set_priority", "value": "high"}
+type TriggerActionInput struct {
+	Type  string `json:"type" binding:"required"`
+	Value string `json:"value"`
+}
+
+// TriggerRuleRequest represents the request to create or update a trigger
+// rule. Events are ticket_created, customer_replied and status_changed;
+// an empty condition matches every ticket. Enabled defaults to true.
+type TriggerRuleRequest struct {
+	Name        string                `json:"name" binding:"required"`
+	Description string                `json:"description"`
+	Position    int                   `json:"position"`
+	Enabled     *bool                 `json:"enabled"`
+	Events      []string              `json:"events"`
+	Conditions  TriggerConditionInput `json:"conditions"`
+	Actions     []TriggerActionInput  `json:"actions"`
+}
+
+func (r TriggerRuleRequest) params() trigger.RuleParams {
+	events := make([]trigger.Event, 0, len(r.Events))
+	for _, e := range r.Events {
+		events = append(events, trigger.Event(e))
+	}
+	actions := make([]trigger.Action, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		actions = append(actions, trigger.Action{Type: trigger.ActionType(a.Type), Value: a.Value})
+	}
+
+	return trigger.RuleParams{
+		Name:        r.Name,
+		Description: r.Description,
+		Position:    r.Position,
+		Enabled:     r.Enabled == nil || *r.Enabled,
+		Events:      events,
+		Condition:   r.Conditions.condition(),
+		Actions:     actions,
+	}
+}
+
+// ListTriggerRules lists all trigger rules in run order
+// GET /api/v1/admin/support/triggers
+func (h *TriggerHandler) ListTriggerRules(c *gin.Context) {
+	rules, err := h.rules.List(c.Request.Context())
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to retrieve trigger rules")
+		return
+	}
+
+	views := make([]triggerRuleView, 0, len(rules))
+	for _, r := range rules {
+		views = append(views, newTriggerRuleView(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetTriggerRule gets a trigger rule by ID
+// GET /api/v1/admin/support/triggers/:id
+func (h *TriggerHandler) GetTriggerRule(c *gin.Context) {
+	id, ok := parseTriggerRuleID(c)
+	if !ok {
+		return
+	}
+
+	r, err := h.rules.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to retrieve trigger rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newTriggerRuleView(r),
+	})
+}
+
+// CreateTriggerRule creates a trigger rule
+// POST /api/v1/admin/support/triggers
+func (h *TriggerHandler) CreateTriggerRule(c *gin.Context) {
+	var req TriggerRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	r, err := trigger.NewRule(req.params())
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to create trigger rule")
+		return
+	}
+	if err := h.checkReferences(ctx, r); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to create trigger rule")
+		return
+	}
+
+	if err := h.rules.Save(ctx, r); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to create trigger rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newTriggerRuleView(r),
+		"message": "Trigger rule created successfully",
+	})
+}
+
+// UpdateTriggerRule replaces a trigger rule's definition
+// PUT /api/v1/admin/support/triggers/:id
+func (h *TriggerHandler) UpdateTriggerRule(c *gin.Context) {
+	id, ok := parseTriggerRuleID(c)
+	if !ok {
+		return
+	}
+
+	var req TriggerRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	r, err := h.rules.FindByID(ctx, id)
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to retrieve trigger rule")
+		return
+	}
+
+	if err := r.Update(req.params()); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to update trigger rule")
+		return
+	}
+	if err := h.checkReferences(ctx, r); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to update trigger rule")
+		return
+	}
+
+	if err := h.rules.Save(ctx, r); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to update trigger rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newTriggerRuleView(r),
+		"message": "Trigger rule updated successfully",
+	})
+}
+
+// DeleteTriggerRule deletes a trigger rule. Its firings stay in the log.
+// DELETE /api/v1/admin/support/triggers/:id
+func (h *TriggerHandler) DeleteTriggerRule(c *gin.Context) {
+	id, ok := parseTriggerRuleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.rules.Delete(c.Request.Context(), id); err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to delete trigger rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Trigger rule deleted successfully",
+	})
+}
+
+// DryRunTriggersRequest represents the request to try trigger rules on a
+// ticket. Event defaults to ticket_created. A rule_id tries that stored
+// rule and a rule tries an unsaved definition; without either the enabled
+// rules of the event are tried.
+type DryRunTriggersRequest struct {
+	TicketID uuid.UUID           `json:"ticket_id" binding:"required"`
+	Event    string              `json:"event"`
+	RuleID   *uuid.UUID          `json:"rule_id"`
+	Rule     *TriggerRuleRequest `json:"rule"`
+}
+
+// DryRunTriggers shows which rules would fire on a ticket and what their
+// actions would change, without saving anything
+// POST /api/v1/admin/support/triggers/dry-run
+func (h *TriggerHandler) DryRunTriggers(c *gin.Context) {
+	var req DryRunTriggersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	event := trigger.EventTicketCreated
+	if req.Event != "" {
+		e, err := trigger.ParseEvent(req.Event)
+		if err != nil {
+			respondTriggerError(c, h.logger, err, "Failed to dry-run trigger rules")
+			return
+		}
+		event = e
+	}
+
+	ctx := c.Request.Context()
+	cmd := application.DryRunTriggersCommand{TicketID: req.TicketID, Event: event}
+	switch {
+	case req.Rule != nil:
+		r, err := trigger.NewRule(req.Rule.params())
+		if err != nil {
+			respondTriggerError(c, h.logger, err, "Failed to dry-run trigger rules")
+			return
+		}
+		cmd.Rule = r
+	case req.RuleID != nil:
+		r, err := h.rules.FindByID(ctx, *req.RuleID)
+		if err != nil {
+			respondTriggerError(c, h.logger, err, "Failed to retrieve trigger rule")
+			return
+		}
+		cmd.Rule = r
+	}
+
+	run, err := h.tickets.DryRunTriggers(ctx, cmd)
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to dry-run trigger rules")
+		return
+	}
+
+	t := run.Ticket
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": newTriggerRunView(run, newTicketDetailView(t, findCategory(ctx, h.categoryRepo, t),
+			findAssignee(ctx, h.agentRepo, t), h.tickets.SLAStatus(ctx, t), true)),
+	})
+}
+
+// ListTriggerFirings lists the firing log, newest first, optionally for
+// one rule (?rule_id) or ticket (?ticket_id)
+// GET /api/v1/admin/support/triggers/firings
+func (h *TriggerHandler) ListTriggerFirings(c *gin.Context) {
+	var filter trigger.FiringFilter
+	if ruleID := c.Query("rule_id"); ruleID != "" {
+		id, err := uuid.Parse(ruleID)
+		if err == nil {
+			filter.RuleID = &id
+		}
+	}
+	if ticketID := c.Query("ticket_id"); ticketID != "" {
+		id, err := uuid.Parse(ticketID)
+		if err == nil {
+			filter.TicketID = &id
+		}
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	filter.Normalize()
+
+	firings, total, err := h.tickets.ListTriggerFirings(c.Request.Context(), filter)
+	if err != nil {
+		respondTriggerError(c, h.logger, err, "Failed to retrieve trigger firings")
+		return
+	}
+
+	views := make([]triggerFiringView, 0, len(firings))
+	for _, f := range firings {
+		views = append(views, newTriggerFiringView(f))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+		"meta": gin.H{
+			"page":     filter.Page,
+			"per_page": filter.PerPage,
+			"total":    total,
+		},
+	})
+}
+
+// checkReferences rejects rules naming an unknown category, agent, team or
+// canned response.
+func (h *TriggerHandler) checkReferences(ctx context.Context, r *trigger.Rule) error {
+	for _, id := range r.Condition().CategoryIDs() {
+		if _, err := h.categoryRepo.FindByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range r.Actions() {
+		var err error
+		switch a.Type {
+		case trigger.ActionSetCategory:
+			_, err = h.categoryRepo.FindByID(ctx, a.TargetID())
+		case trigger.ActionAssignAgent:
+			_, err = h.agentRepo.FindByID(ctx, a.TargetID())
+		case trigger.ActionAssignTeam:
+			_, err = h.teamRepo.FindByID(ctx, a.TargetID())
+		case trigger.ActionSendCannedResponse:
+			_, err = h.responseRepo.FindByID(ctx, a.TargetID())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseTriggerRuleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid trigger rule ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
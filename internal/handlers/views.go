@@ -0,0 +1,1115 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/application"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+// ticketView is the JSON representation of a ticket
+type ticketView struct {
+	ID                    uuid.UUID        `json:"id"`
+	TicketNumber          string           `json:"ticket_number"`
+	CustomerID            *uuid.UUID       `json:"customer_id"`
+	GuestEmail            string           `json:"guest_email"`
+	GuestName             string           `json:"guest_name"`
+	GuestPhone            string           `json:"guest_phone"`
+	CategoryID            *uuid.UUID       `json:"category_id"`
+	Category              *categoryView    `json:"category,omitempty"`
+	Subject               string           `json:"subject"`
+	Channel               string           `json:"channel"`
+	Locale                string           `json:"locale,omitempty"`
+	Brand                 string           `json:"brand,omitempty"`
+	Status                string           `json:"status"`
+	NextStatuses          []string         `json:"next_statuses"`
+	Priority              string           `json:"priority"`
+	TeamID                *uuid.UUID       `json:"team_id"`
+	AssignedTo            *uuid.UUID       `json:"assigned_to"`
+	AssignedToName        string           `json:"assigned_to_name"`
+	AssignmentReason      string           `json:"assignment_reason,omitempty"`
+	OrderID               *uuid.UUID       `json:"order_id"`
+	OrderNumber           string           `json:"order_number"`
+	SLADeadline           *time.Time       `json:"sla_deadline"`
+	SLABreachedAt         *time.Time       `json:"sla_breached_at"`
+	FirstResponseDeadline *time.Time       `json:"first_response_deadline"`
+	SLA                   slaView          `json:"sla"`
+	FirstResponseAt       *time.Time       `json:"first_response_at"`
+	ResolvedAt            *time.Time       `json:"resolved_at"`
+	ClosedAt              *time.Time       `json:"closed_at"`
+	SatisfactionRating    *int             `json:"satisfaction_rating"`
+	SatisfactionComment   string           `json:"satisfaction_comment"`
+	Tags                  []string         `json:"tags"`
+	IsOverdue             bool             `json:"is_overdue"`
+	IdleSince             time.Time        `json:"idle_since"`
+	RemindedAt            *time.Time       `json:"reminded_at"`
+	StaleAt               *time.Time       `json:"stale_at"`
+	MergedIntoID          *uuid.UUID       `json:"merged_into_id"`
+	WatcherIDs            []uuid.UUID      `json:"watcher_ids"`
+	CC                    []string         `json:"cc"`
+	Links                 []ticketLinkView `json:"links,omitempty"`
+	Messages              []messageView    `json:"messages,omitempty"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+}
+
+// ticketRefView is the JSON representation of a reference to a ticket
+type ticketRefView struct {
+	ID           uuid.UUID `json:"id"`
+	TicketNumber string    `json:"ticket_number"`
+}
+
+// ticketLinkView is the JSON representation of a link read from one of its
+// tickets; relation is what that ticket is to the linked one
+type ticketLinkView struct {
+	ID            uuid.UUID        `json:"id"`
+	Relation      string           `json:"relation"`
+	Ticket        linkedTicketView `json:"ticket"`
+	CreatedByName string           `json:"created_by_name,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// linkedTicketView is the JSON representation of the ticket at the other
+// end of a link
+type linkedTicketView struct {
+	ID           uuid.UUID `json:"id"`
+	TicketNumber string    `json:"ticket_number"`
+	Subject      string    `json:"subject"`
+	Status       string    `json:"status"`
+	Priority     string    `json:"priority"`
+}
+
+// incidentChildView is the JSON representation of a child ticket of a
+// resolved incident; reason says why a skipped child was left alone
+type incidentChildView struct {
+	linkedTicketView
+	Resolved bool   `json:"resolved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// slaView is the JSON representation of a ticket's SLA clocks
+type slaView struct {
+	FirstResponse slaTimerView `json:"first_response"`
+	Resolution    slaTimerView `json:"resolution"`
+}
+
+// slaTimerView is the JSON representation of one SLA clock. Met is null
+// while the clock runs within its target; deadline is null while paused.
+type slaTimerView struct {
+	TargetMinutes    int        `json:"target_minutes"`
+	Deadline         *time.Time `json:"deadline"`
+	ElapsedSeconds   int64      `json:"elapsed_seconds"`
+	RemainingSeconds int64      `json:"remaining_seconds"`
+	Paused           bool       `json:"paused"`
+	Stopped          bool       `json:"stopped"`
+	Met              *bool      `json:"met"`
+}
+
+// mentionView is the JSON representation of an agent mentioned in an
+// internal note
+type mentionView struct {
+	ID              uuid.UUID  `json:"id"`
+	TicketID        uuid.UUID  `json:"ticket_id"`
+	MessageID       uuid.UUID  `json:"message_id"`
+	MentionedBy     *uuid.UUID `json:"mentioned_by"`
+	MentionedByName string     `json:"mentioned_by_name"`
+	ReadAt          *time.Time `json:"read_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func newMentionView(m *mention.Mention) mentionView {
+	return mentionView{
+		ID:              m.ID(),
+		TicketID:        m.TicketID(),
+		MessageID:       m.MessageID(),
+		MentionedBy:     m.MentionedBy(),
+		MentionedByName: m.MentionedByName(),
+		ReadAt:          m.ReadAt(),
+		CreatedAt:       m.CreatedAt(),
+	}
+}
+
+func newMentionViews(mentions []*mention.Mention) []mentionView {
+	views := make([]mentionView, 0, len(mentions))
+	for _, m := range mentions {
+		views = append(views, newMentionView(m))
+	}
+	return views
+}
+
+// messageView is the JSON representation of a ticket message
+type messageView struct {
+	ID          uuid.UUID           `json:"id"`
+	TicketID    uuid.UUID           `json:"ticket_id"`
+	SenderType  string              `json:"sender_type"`
+	SenderID    *uuid.UUID          `json:"sender_id"`
+	SenderName  string              `json:"sender_name"`
+	SenderEmail string              `json:"sender_email"`
+	Content     string              `json:"content"`
+	Attachments []ticket.Attachment `json:"attachments"`
+	IsInternal  bool                `json:"is_internal"`
+	ReadAt      *time.Time          `json:"read_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// categoryView is the JSON representation of a support category
+type categoryView struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	NameMS      string    `json:"name_ms"`
+	Description string    `json:"description"`
+	Icon        string    `json:"icon"`
+	SLAHours    int       `json:"sla_hours"`
+	Priority    int       `json:"priority"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// cannedResponseView is the JSON representation of a canned response
+type cannedResponseView struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Content    string     `json:"content"`
+	CategoryID *uuid.UUID `json:"category_id"`
+	Shortcut   string     `json:"shortcut"`
+	IsActive   bool       `json:"is_active"`
+	UsageCount int        `json:"usage_count"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// slaCalendarView is the JSON representation of an SLA calendar
+type slaCalendarView struct {
+	ID           uuid.UUID          `json:"id"`
+	Name         string             `json:"name"`
+	Timezone     string             `json:"timezone"`
+	CategoryID   *uuid.UUID         `json:"category_id"`
+	IsGlobal     bool               `json:"is_global"`
+	WorkingHours []workingHoursView `json:"working_hours"`
+	Holidays     []holidayView      `json:"holidays"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// slaPolicyView is the JSON representation of an SLA policy
+type slaPolicyView struct {
+	ID                   uuid.UUID  `json:"id"`
+	Name                 string     `json:"name"`
+	Priority             string     `json:"priority"`
+	CategoryID           *uuid.UUID `json:"category_id"`
+	IsGlobal             bool       `json:"is_global"`
+	FirstResponseMinutes int        `json:"first_response_minutes"`
+	ResolutionMinutes    int        `json:"resolution_minutes"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// automationPolicyView is the JSON representation of an automation policy.
+// Zero turns an automation off.
+type automationPolicyView struct {
+	ID                   uuid.UUID  `json:"id"`
+	Name                 string     `json:"name"`
+	CategoryID           *uuid.UUID `json:"category_id"`
+	IsGlobal             bool       `json:"is_global"`
+	AutoCloseDays        int        `json:"auto_close_days"`
+	PendingReminderHours int        `json:"pending_reminder_hours"`
+	PendingResolveHours  int        `json:"pending_resolve_hours"`
+	StaleHours           int        `json:"stale_hours"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// workflowView is the JSON representation of a workflow. The built-in
+// workflow has no ID.
+type workflowView struct {
+	ID          *uuid.UUID               `json:"id"`
+	Name        string                   `json:"name"`
+	IsDefault   bool                     `json:"is_default"`
+	IsBuiltIn   bool                     `json:"is_built_in"`
+	CategoryIDs []uuid.UUID              `json:"category_ids"`
+	States      []workflowStateView      `json:"states"`
+	Transitions []workflowTransitionView `json:"transitions"`
+	CreatedAt   *time.Time               `json:"created_at"`
+	UpdatedAt   *time.Time               `json:"updated_at"`
+}
+
+// workflowStateView is the JSON representation of a workflow state
+type workflowStateView struct {
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Active    bool   `json:"active"`
+	Terminal  bool   `json:"terminal"`
+	PausesSLA bool   `json:"pauses_sla"`
+}
+
+// workflowTransitionView is the JSON representation of a workflow transition
+type workflowTransitionView struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// agentView is the JSON representation of a support agent
+type agentView struct {
+	ID                   uuid.UUID        `json:"id"`
+	Name                 string           `json:"name"`
+	Email                string           `json:"email"`
+	Role                 string           `json:"role"`
+	Teams                []string         `json:"teams"`
+	Skills               []agentSkillView `json:"skills"`
+	Status               string           `json:"status"`
+	MaxConcurrentTickets int              `json:"max_concurrent_tickets"`
+	OpenTickets          int              `json:"open_tickets"`
+	Timezone             string           `json:"timezone"`
+	Signature            string           `json:"signature"`
+	LastAssignedAt       *time.Time       `json:"last_assigned_at"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+}
+
+// teamView is the JSON representation of a team with its members
+type teamView struct {
+	ID          uuid.UUID   `json:"id"`
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+	Members     []agentView `json:"members"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// queueView is the JSON representation of a team's queue
+type queueView struct {
+	Team       teamView     `json:"team"`
+	Unassigned []ticketView `json:"unassigned"`
+	InProgress []ticketView `json:"in_progress"`
+}
+
+// agentSkillView is the JSON representation of an agent skill
+type agentSkillView struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// routingRuleView is the JSON representation of a routing rule
+type routingRuleView struct {
+	ID             uuid.UUID            `json:"id"`
+	Name           string               `json:"name"`
+	Position       int                  `json:"position"`
+	Enabled        bool                 `json:"enabled"`
+	Conditions     routingConditionView `json:"conditions"`
+	RequiredSkills []routingSkillView   `json:"required_skills"`
+	FallbackTeamID *uuid.UUID           `json:"fallback_team_id"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// routingConditionView is the JSON representation of a routing condition
+type routingConditionView struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+	Tags        []string    `json:"tags"`
+	Languages   []string    `json:"languages"`
+	HasOrder    *bool       `json:"has_order"`
+}
+
+// routingSkillView is the JSON representation of a required skill
+type routingSkillView struct {
+	Name     string `json:"name"`
+	MinLevel int    `json:"min_level"`
+}
+
+// routingExplanationView is the JSON representation of a routing decision
+type routingExplanationView struct {
+	TicketID       uuid.UUID                 `json:"ticket_id"`
+	Strategy       string                    `json:"strategy"`
+	Attributes     routingAttributesView     `json:"attributes"`
+	Rules          []ruleEvaluationView      `json:"rules"`
+	MatchedRuleID  *uuid.UUID                `json:"matched_rule_id"`
+	Candidates     []candidateEvaluationView `json:"candidates"`
+	AgentID        *uuid.UUID                `json:"agent_id"`
+	FallbackTeamID *uuid.UUID                `json:"fallback_team_id"`
+	Reason         string                    `json:"reason"`
+}
+
+// routingAttributesView is the JSON representation of the ticket details
+// routing matches on
+type routingAttributesView struct {
+	CategoryID *uuid.UUID `json:"category_id"`
+	Tags       []string   `json:"tags"`
+	Language   string     `json:"language"`
+	HasOrder   bool       `json:"has_order"`
+}
+
+// ruleEvaluationView is the JSON representation of one rule's outcome
+type ruleEvaluationView struct {
+	RuleID     uuid.UUID `json:"rule_id"`
+	Name       string    `json:"name"`
+	Matched    bool      `json:"matched"`
+	Mismatches []string  `json:"mismatches"`
+}
+
+// candidateEvaluationView is the JSON representation of an agent considered
+// for a ticket
+type candidateEvaluationView struct {
+	AgentID     uuid.UUID `json:"agent_id"`
+	Name        string    `json:"name"`
+	OpenTickets int       `json:"open_tickets"`
+	Eligible    bool      `json:"eligible"`
+	Qualified   bool      `json:"qualified"`
+	Score       int       `json:"score"`
+}
+
+// triggerRuleView is the JSON representation of a trigger rule
+type triggerRuleView struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Position    int                  `json:"position"`
+	Enabled     bool                 `json:"enabled"`
+	Events      []string             `json:"events"`
+	Conditions  triggerConditionView `json:"conditions"`
+	Actions     []triggerActionView  `json:"actions"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// triggerConditionView is the JSON representation of a trigger condition:
+// a field test or an all/any group
+type triggerConditionView struct {
+	All      []triggerConditionView `json:"all,omitempty"`
+	Any      []triggerConditionView `json:"any,omitempty"`
+	Field    string                 `json:"field,omitempty"`
+	Operator string                 `json:"operator,omitempty"`
+	Value    string                 `json:"value,omitempty"`
+}
+
+// triggerActionView is the JSON representation of a trigger action and,
+// in firings and dry runs, of why it failed
+type triggerActionView struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Error string `json:"error,omitempty"`
+}
+
+// triggerFiringView is the JSON representation of a logged rule firing
+type triggerFiringView struct {
+	ID       uuid.UUID           `json:"id"`
+	RuleID   uuid.UUID           `json:"rule_id"`
+	RuleName string              `json:"rule_name"`
+	TicketID uuid.UUID           `json:"ticket_id"`
+	Event    string              `json:"event"`
+	Actions  []triggerActionView `json:"actions"`
+	Failed   bool                `json:"failed"`
+	FiredAt  time.Time           `json:"fired_at"`
+}
+
+// triggerRunView is the JSON representation of a dry run: each rule's
+// outcome and the ticket as the matched rules would leave it
+type triggerRunView struct {
+	Event  string                  `json:"event"`
+	Rules  []triggerEvaluationView `json:"rules"`
+	Ticket ticketView              `json:"ticket"`
+}
+
+// triggerEvaluationView is the JSON representation of one rule in a dry run
+type triggerEvaluationView struct {
+	RuleID   uuid.UUID           `json:"rule_id"`
+	RuleName string              `json:"rule_name"`
+	Matched  bool                `json:"matched"`
+	Actions  []triggerActionView `json:"actions"`
+}
+
+// workingHoursView is the JSON representation of a working window
+type workingHoursView struct {
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// holidayView is the JSON representation of a calendar holiday
+type holidayView struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// newTicketView renders a ticket without its messages. The assignee is nil
+// for unassigned tickets and agents missing from the directory.
+func newTicketView(t *ticket.Ticket, cat *category.Category, assignee *agent.Agent, status ticket.SLAStatus) ticketView {
+	view := ticketView{
+		ID:                    t.ID(),
+		TicketNumber:          t.TicketNumber().Value(),
+		CustomerID:            t.CustomerID(),
+		GuestEmail:            t.GuestEmail(),
+		GuestName:             t.GuestName(),
+		GuestPhone:            t.GuestPhone(),
+		CategoryID:            t.CategoryID(),
+		Subject:               t.Subject(),
+		Channel:               t.Channel(),
+		Locale:                t.Locale(),
+		Brand:                 t.Brand(),
+		Status:                string(t.Status()),
+		NextStatuses:          make([]string, 0),
+		Priority:              string(t.Priority()),
+		TeamID:                t.TeamID(),
+		AssignedTo:            t.AssignedTo(),
+		AssignmentReason:      t.AssignmentReason(),
+		OrderID:               t.OrderID(),
+		OrderNumber:           t.OrderNumber(),
+		SLADeadline:           t.SLADeadline(),
+		SLABreachedAt:         t.SLABreachedAt(),
+		FirstResponseDeadline: t.FirstResponseDeadline(),
+		SLA: slaView{
+			FirstResponse: newSLATimerView(status.FirstResponse),
+			Resolution:    newSLATimerView(status.Resolution),
+		},
+		FirstResponseAt:     t.FirstResponseAt(),
+		ResolvedAt:          t.ResolvedAt(),
+		ClosedAt:            t.ClosedAt(),
+		SatisfactionRating:  t.SatisfactionRating(),
+		SatisfactionComment: t.SatisfactionComment(),
+		Tags:                t.Tags(),
+		IsOverdue:           t.IsOverdue(),
+		IdleSince:           t.IdleSince(),
+		RemindedAt:          t.RemindedAt(),
+		StaleAt:             t.StaleAt(),
+		MergedIntoID:        t.MergedInto(),
+		WatcherIDs:          t.Watchers(),
+		CC:                  t.CC(),
+		CreatedAt:           t.CreatedAt(),
+		UpdatedAt:           t.UpdatedAt(),
+	}
+	for _, next := range t.Workflow().NextStates(t.Status()) {
+		view.NextStatuses = append(view.NextStatuses, string(next))
+	}
+	if cat != nil {
+		cv := newCategoryView(cat)
+		view.Category = &cv
+	}
+	if assignee != nil {
+		view.AssignedToName = assignee.Name()
+	}
+	return view
+}
+
+// newTicketDetailView renders a ticket with its messages. Internal notes are
+// only included for staff.
+func newTicketDetailView(t *ticket.Ticket, cat *category.Category, assignee *agent.Agent, status ticket.SLAStatus, includeInternal bool) ticketView {
+	view := newTicketView(t, cat, assignee, status)
+	view.Messages = make([]messageView, 0, len(t.Messages()))
+	for _, msg := range t.Messages() {
+		if msg.IsInternal() && !includeInternal {
+			continue
+		}
+		view.Messages = append(view.Messages, newMessageView(msg))
+	}
+	return view
+}
+
+// newTicketViews renders a page of tickets with their categories, assignees
+// and SLA clocks.
+func newTicketViews(ctx context.Context, categories category.Repository, agents agent.Repository, statuses map[uuid.UUID]ticket.SLAStatus, tickets []*ticket.Ticket) []ticketView {
+	index := make(map[uuid.UUID]*category.Category)
+	if all, err := categories.List(ctx, false); err == nil {
+		for _, c := range all {
+			index[c.ID()] = c
+		}
+	}
+
+	assigneeIDs := make([]uuid.UUID, 0, len(tickets))
+	for _, t := range tickets {
+		if t.AssignedTo() != nil {
+			assigneeIDs = append(assigneeIDs, *t.AssignedTo())
+		}
+	}
+	assignees := make(map[uuid.UUID]*agent.Agent)
+	if found, err := agents.FindByIDs(ctx, assigneeIDs); err == nil {
+		for _, a := range found {
+			assignees[a.ID()] = a
+		}
+	}
+
+	views := make([]ticketView, 0, len(tickets))
+	for _, t := range tickets {
+		var cat *category.Category
+		if t.CategoryID() != nil {
+			cat = index[*t.CategoryID()]
+		}
+		var assignee *agent.Agent
+		if t.AssignedTo() != nil {
+			assignee = assignees[*t.AssignedTo()]
+		}
+		views = append(views, newTicketView(t, cat, assignee, statuses[t.ID()]))
+	}
+	return views
+}
+
+// findCategory returns the ticket's category, or nil if it has none.
+func findCategory(ctx context.Context, categories category.Repository, t *ticket.Ticket) *category.Category {
+	if t.CategoryID() == nil {
+		return nil
+	}
+	cat, _ := categories.FindByID(ctx, *t.CategoryID())
+	return cat
+}
+
+func newLinkedTicketView(t *ticket.Ticket) linkedTicketView {
+	return linkedTicketView{
+		ID:           t.ID(),
+		TicketNumber: t.TicketNumber().Value(),
+		Subject:      t.Subject(),
+		Status:       string(t.Status()),
+		Priority:     string(t.Priority()),
+	}
+}
+
+func newTicketLinkView(l application.LinkedTicket) ticketLinkView {
+	return ticketLinkView{
+		ID:            l.Link.ID(),
+		Relation:      string(l.Relation),
+		Ticket:        newLinkedTicketView(l.Ticket),
+		CreatedByName: l.Link.CreatedByName(),
+		CreatedAt:     l.Link.CreatedAt(),
+	}
+}
+
+func newTicketLinkViews(linked []application.LinkedTicket) []ticketLinkView {
+	views := make([]ticketLinkView, 0, len(linked))
+	for _, l := range linked {
+		views = append(views, newTicketLinkView(l))
+	}
+	return views
+}
+
+// newIncidentChildViews renders the resolved children of an incident
+// followed by those that were skipped.
+func newIncidentChildViews(r *application.IncidentResolution) []incidentChildView {
+	views := make([]incidentChildView, 0, len(r.Children)+len(r.Skipped))
+	for _, t := range r.Children {
+		views = append(views, incidentChildView{linkedTicketView: newLinkedTicketView(t), Resolved: true})
+	}
+	for _, s := range r.Skipped {
+		views = append(views, incidentChildView{linkedTicketView: newLinkedTicketView(s.Ticket), Reason: s.Reason})
+	}
+	return views
+}
+
+// redirectedFrom renders the merged ticket a lookup was redirected from,
+// or nil if it was not redirected.
+func redirectedFrom(t *ticket.Ticket) *ticketRefView {
+	if t == nil {
+		return nil
+	}
+	return &ticketRefView{ID: t.ID(), TicketNumber: t.TicketNumber().Value()}
+}
+
+// findAssignee returns the ticket's agent, or nil if it is unassigned or
+// the agent is not in the directory.
+func findAssignee(ctx context.Context, agents agent.Repository, t *ticket.Ticket) *agent.Agent {
+	if t.AssignedTo() == nil {
+		return nil
+	}
+	a, _ := agents.FindByID(ctx, *t.AssignedTo())
+	return a
+}
+
+func newSLATimerView(timer ticket.SLATimer) slaTimerView {
+	return slaTimerView{
+		TargetMinutes:    int(timer.Target / time.Minute),
+		Deadline:         timer.Deadline,
+		ElapsedSeconds:   int64(timer.Elapsed / time.Second),
+		RemainingSeconds: int64(timer.Remaining / time.Second),
+		Paused:           timer.Paused,
+		Stopped:          timer.Stopped,
+		Met:              timer.Met,
+	}
+}
+
+func newMessageView(m ticket.Message) messageView {
+	attachments := m.Attachments()
+	if attachments == nil {
+		attachments = []ticket.Attachment{}
+	}
+	return messageView{
+		ID:          m.ID(),
+		TicketID:    m.TicketID(),
+		SenderType:  string(m.SenderType()),
+		SenderID:    m.SenderID(),
+		SenderName:  m.SenderName(),
+		SenderEmail: m.SenderEmail(),
+		Content:     m.Content(),
+		Attachments: attachments,
+		IsInternal:  m.IsInternal(),
+		ReadAt:      m.ReadAt(),
+		CreatedAt:   m.CreatedAt(),
+	}
+}
+
+func newCategoryView(c *category.Category) categoryView {
+	return categoryView{
+		ID:          c.ID(),
+		Name:        c.Name(),
+		NameMS:      c.NameMS(),
+		Description: c.Description(),
+		Icon:        c.Icon(),
+		SLAHours:    c.SLAHours(),
+		Priority:    c.Priority(),
+		IsActive:    c.IsActive(),
+		CreatedAt:   c.CreatedAt(),
+		UpdatedAt:   c.UpdatedAt(),
+	}
+}
+
+func newCategoryViews(categories []*category.Category) []categoryView {
+	views := make([]categoryView, 0, len(categories))
+	for _, c := range categories {
+		views = append(views, newCategoryView(c))
+	}
+	return views
+}
+
+func newCannedResponseView(r *response.CannedResponse) cannedResponseView {
+	return cannedResponseView{
+		ID:         r.ID(),
+		Title:      r.Title(),
+		Content:    r.Content(),
+		CategoryID: r.CategoryID(),
+		Shortcut:   r.Shortcut(),
+		IsActive:   r.IsActive(),
+		UsageCount: r.UsageCount(),
+		CreatedBy:  r.CreatedBy(),
+		CreatedAt:  r.CreatedAt(),
+		UpdatedAt:  r.UpdatedAt(),
+	}
+}
+
+func newCannedResponseViews(responses []*response.CannedResponse) []cannedResponseView {
+	views := make([]cannedResponseView, 0, len(responses))
+	for _, r := range responses {
+		views = append(views, newCannedResponseView(r))
+	}
+	return views
+}
+
+func newSLACalendarView(c *sla.Calendar) slaCalendarView {
+	view := slaCalendarView{
+		ID:           c.ID(),
+		Name:         c.Name(),
+		Timezone:     c.Timezone(),
+		CategoryID:   c.CategoryID(),
+		IsGlobal:     c.IsGlobal(),
+		WorkingHours: make([]workingHoursView, 0, len(c.WorkingHours())),
+		Holidays:     make([]holidayView, 0, len(c.Holidays())),
+		CreatedAt:    c.CreatedAt(),
+		UpdatedAt:    c.UpdatedAt(),
+	}
+	for _, wh := range c.WorkingHours() {
+		view.WorkingHours = append(view.WorkingHours, workingHoursView{
+			Weekday: strings.ToLower(wh.Weekday.String()),
+			Start:   sla.FormatClock(wh.Start),
+			End:     sla.FormatClock(wh.End),
+		})
+	}
+	for _, h := range c.Holidays() {
+		view.Holidays = append(view.Holidays, holidayView{Date: h.Date, Name: h.Name})
+	}
+	return view
+}
+
+func newAutomationPolicyView(p *automation.Policy) automationPolicyView {
+	s := p.Schedule()
+	return automationPolicyView{
+		ID:                   p.ID(),
+		Name:                 p.Name(),
+		CategoryID:           p.CategoryID(),
+		IsGlobal:             p.IsGlobal(),
+		AutoCloseDays:        int(s.AutoCloseAfter / (24 * time.Hour)),
+		PendingReminderHours: int(s.PendingReminderAfter / time.Hour),
+		PendingResolveHours:  int(s.PendingResolveAfter / time.Hour),
+		StaleHours:           int(s.StaleAfter / time.Hour),
+		CreatedAt:            p.CreatedAt(),
+		UpdatedAt:            p.UpdatedAt(),
+	}
+}
+
+func newSLAPolicyView(p *sla.Policy) slaPolicyView {
+	return slaPolicyView{
+		ID:                   p.ID(),
+		Name:                 p.Name(),
+		Priority:             string(p.Priority()),
+		CategoryID:           p.CategoryID(),
+		IsGlobal:             p.IsGlobal(),
+		FirstResponseMinutes: int(p.Targets().FirstResponse / time.Minute),
+		ResolutionMinutes:    int(p.Targets().Resolution / time.Minute),
+		CreatedAt:            p.CreatedAt(),
+		UpdatedAt:            p.UpdatedAt(),
+	}
+}
+
+func newAgentView(a *agent.Agent, openTickets int) agentView {
+	return agentView{
+		ID:                   a.ID(),
+		Name:                 a.Name(),
+		Email:                a.Email(),
+		Role:                 a.Role(),
+		Teams:                a.Teams(),
+		Skills:               newAgentSkillViews(a.Skills()),
+		Status:               string(a.Status()),
+		MaxConcurrentTickets: a.MaxConcurrentTickets(),
+		OpenTickets:          openTickets,
+		Timezone:             a.Timezone(),
+		Signature:            a.Signature(),
+		LastAssignedAt:       a.LastAssignedAt(),
+		CreatedAt:            a.CreatedAt(),
+		UpdatedAt:            a.UpdatedAt(),
+	}
+}
+
+func newTeamView(t *team.Team, members []agentView) teamView {
+	return teamView{
+		ID:          t.ID(),
+		Key:         t.Key(),
+		Name:        t.Name(),
+		Description: t.Description(),
+		CategoryIDs: t.CategoryIDs(),
+		Members:     members,
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	}
+}
+
+func newAgentSkillViews(skills []agent.Skill) []agentSkillView {
+	views := make([]agentSkillView, 0, len(skills))
+	for _, s := range skills {
+		views = append(views, agentSkillView{Name: s.Name, Level: s.Level})
+	}
+	return views
+}
+
+func newRoutingRuleView(r *routing.Rule) routingRuleView {
+	c := r.Condition()
+	view := routingRuleView{
+		ID:       r.ID(),
+		Name:     r.Name(),
+		Position: r.Position(),
+		Enabled:  r.IsEnabled(),
+		Conditions: routingConditionView{
+			CategoryIDs: make([]uuid.UUID, 0, len(c.CategoryIDs)),
+			Tags:        make([]string, 0, len(c.Tags)),
+			Languages:   make([]string, 0, len(c.Languages)),
+			HasOrder:    c.HasOrder,
+		},
+		RequiredSkills: make([]routingSkillView, 0, len(r.RequiredSkills())),
+		FallbackTeamID: r.FallbackTeamID(),
+		CreatedAt:      r.CreatedAt(),
+		UpdatedAt:      r.UpdatedAt(),
+	}
+	view.Conditions.CategoryIDs = append(view.Conditions.CategoryIDs, c.CategoryIDs...)
+	view.Conditions.Tags = append(view.Conditions.Tags, c.Tags...)
+	view.Conditions.Languages = append(view.Conditions.Languages, c.Languages...)
+	for _, s := range r.RequiredSkills() {
+		view.RequiredSkills = append(view.RequiredSkills, routingSkillView{Name: s.Name, MinLevel: s.MinLevel})
+	}
+	return view
+}
+
+func newRoutingExplanationView(t *ticket.Ticket, e *application.RoutingExplanation) routingExplanationView {
+	view := routingExplanationView{
+		TicketID: t.ID(),
+		Strategy: e.Strategy,
+		Attributes: routingAttributesView{
+			CategoryID: e.Attributes.CategoryID,
+			Tags:       append(make([]string, 0, len(e.Attributes.Tags)), e.Attributes.Tags...),
+			Language:   e.Attributes.Language,
+			HasOrder:   e.Attributes.HasOrder,
+		},
+		Rules:          make([]ruleEvaluationView, 0, len(e.Rules)),
+		Candidates:     make([]candidateEvaluationView, 0, len(e.Candidates)),
+		AgentID:        e.AgentID,
+		FallbackTeamID: e.FallbackTeamID,
+		Reason:         e.Reason,
+	}
+	if e.MatchedRule != nil {
+		id := e.MatchedRule.ID()
+		view.MatchedRuleID = &id
+	}
+	for _, r := range e.Rules {
+		view.Rules = append(view.Rules, ruleEvaluationView{
+			RuleID:     r.Rule.ID(),
+			Name:       r.Rule.Name(),
+			Matched:    r.Matched,
+			Mismatches: append(make([]string, 0, len(r.Mismatches)), r.Mismatches...),
+		})
+	}
+	for _, c := range e.Candidates {
+		view.Candidates = append(view.Candidates, candidateEvaluationView{
+			AgentID:     c.Agent.ID(),
+			Name:        c.Agent.Name(),
+			OpenTickets: c.OpenTickets,
+			Eligible:    c.Eligible,
+			Qualified:   c.Qualified,
+			Score:       c.Score,
+		})
+	}
+	return view
+}
+
+func newTriggerRuleView(r *trigger.Rule) triggerRuleView {
+	view := triggerRuleView{
+		ID:          r.ID(),
+		Name:        r.Name(),
+		Description: r.Description(),
+		Position:    r.Position(),
+		Enabled:     r.IsEnabled(),
+		Events:      make([]string, 0, len(r.Events())),
+		Conditions:  newTriggerConditionView(r.Condition()),
+		Actions:     make([]triggerActionView, 0, len(r.Actions())),
+		CreatedAt:   r.CreatedAt(),
+		UpdatedAt:   r.UpdatedAt(),
+	}
+	for _, e := range r.Events() {
+		view.Events = append(view.Events, string(e))
+	}
+	for _, a := range r.Actions() {
+		view.Actions = append(view.Actions, triggerActionView{Type: string(a.Type), Value: a.Value})
+	}
+	return view
+}
+
+func newTriggerConditionView(c trigger.Condition) triggerConditionView {
+	view := triggerConditionView{
+		Field:    string(c.Field),
+		Operator: string(c.Operator),
+		Value:    c.Value,
+	}
+	for _, sub := range c.All {
+		view.All = append(view.All, newTriggerConditionView(sub))
+	}
+	for _, sub := range c.Any {
+		view.Any = append(view.Any, newTriggerConditionView(sub))
+	}
+	return view
+}
+
+func newTriggerActionViews(results []trigger.ActionResult) []triggerActionView {
+	views := make([]triggerActionView, 0, len(results))
+	for _, a := range results {
+		views = append(views, triggerActionView{Type: string(a.Type), Value: a.Value, Error: a.Error})
+	}
+	return views
+}
+
+func newTriggerFiringView(f trigger.Firing) triggerFiringView {
+	return triggerFiringView{
+		ID:       f.ID(),
+		RuleID:   f.RuleID(),
+		RuleName: f.RuleName(),
+		TicketID: f.TicketID(),
+		Event:    string(f.Event()),
+		Actions:  newTriggerActionViews(f.Actions()),
+		Failed:   f.Failed(),
+		FiredAt:  f.FiredAt(),
+	}
+}
+
+func newTriggerRunView(run *application.TriggerRun, t ticketView) triggerRunView {
+	view := triggerRunView{
+		Event:  string(run.Event),
+		Rules:  make([]triggerEvaluationView, 0, len(run.Rules)),
+		Ticket: t,
+	}
+	for _, e := range run.Rules {
+		view.Rules = append(view.Rules, triggerEvaluationView{
+			RuleID:   e.Rule.ID(),
+			RuleName: e.Rule.Name(),
+			Matched:  e.Matched,
+			Actions:  newTriggerActionViews(e.Actions),
+		})
+	}
+	return view
+}
+
+func newWorkflowView(w *workflow.Workflow) workflowView {
+	view := workflowView{
+		Name:        w.Name(),
+		IsDefault:   w.IsDefault(),
+		IsBuiltIn:   w.IsBuiltIn(),
+		CategoryIDs: w.CategoryIDs(),
+		States:      make([]workflowStateView, 0, len(w.States())),
+		Transitions: make([]workflowTransitionView, 0, len(w.Transitions())),
+	}
+	if !w.IsBuiltIn() {
+		id, createdAt, updatedAt := w.ID(), w.CreatedAt(), w.UpdatedAt()
+		view.ID = &id
+		view.CreatedAt = &createdAt
+		view.UpdatedAt = &updatedAt
+	}
+	for _, s := range w.States() {
+		view.States = append(view.States, workflowStateView{
+			Key:       string(s.Key),
+			Label:     s.Label,
+			Active:    s.Active,
+			Terminal:  s.Terminal,
+			PausesSLA: s.PausesSLA,
+		})
+	}
+	for _, t := range w.Transitions() {
+		view.Transitions = append(view.Transitions, workflowTransitionView{From: string(t.From), To: string(t.To)})
+	}
+	return view
+}
+
+// notificationTemplateView is the JSON representation of a notification
+// template. Built-in templates have no ID.
+type notificationTemplateView struct {
+	ID        *uuid.UUID `json:"id"`
+	Event     string     `json:"event"`
+	Locale    string     `json:"locale"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	IsBuiltIn bool       `json:"is_built_in"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func newNotificationTemplateView(t *notification.Template) notificationTemplateView {
+	view := notificationTemplateView{
+		Event:     string(t.Event()),
+		Locale:    t.Locale(),
+		Subject:   t.Subject(),
+		Body:      t.Body(),
+		IsBuiltIn: t.IsBuiltIn(),
+	}
+	if !t.IsBuiltIn() {
+		id, createdAt, updatedAt := t.ID(), t.CreatedAt(), t.UpdatedAt()
+		view.ID = &id
+		view.CreatedAt = &createdAt
+		view.UpdatedAt = &updatedAt
+	}
+	return view
+}
+
+// notificationDeliveryView is the JSON representation of a notification
+// in a ticket's send log
+type notificationDeliveryView struct {
+	ID            uuid.UUID  `json:"id"`
+	TicketID      uuid.UUID  `json:"ticket_id"`
+	Event         string     `json:"event"`
+	Locale        string     `json:"locale"`
+	To            string     `json:"to"`
+	CC            []string   `json:"cc"`
+	ReplyTo       string     `json:"reply_to,omitempty"`
+	Subject       string     `json:"subject"`
+	Body          string     `json:"body"`
+	MessageID     string     `json:"message_id"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func newNotificationDeliveryView(d *notification.Delivery) notificationDeliveryView {
+	view := notificationDeliveryView{
+		ID:        d.ID(),
+		TicketID:  d.TicketID(),
+		Event:     string(d.Event()),
+		Locale:    d.Locale(),
+		To:        d.To(),
+		CC:        d.CC(),
+		ReplyTo:   d.ReplyTo(),
+		Subject:   d.Subject(),
+		Body:      d.Body(),
+		MessageID: d.MessageID(),
+		Status:    string(d.Status()),
+		Attempts:  d.Attempts(),
+		LastError: d.LastError(),
+		SentAt:    d.SentAt(),
+		CreatedAt: d.CreatedAt(),
+	}
+	if view.CC == nil {
+		view.CC = []string{}
+	}
+	if d.IsPending() {
+		nextAttemptAt := d.NextAttemptAt()
+		view.NextAttemptAt = &nextAttemptAt
+	}
+	return view
+}
+
+// attachmentView is the JSON representation of a file stored for a ticket
+type attachmentView struct {
+	ID           uuid.UUID  `json:"id"`
+	TicketID     *uuid.UUID `json:"ticket_id"`
+	MessageID    *uuid.UUID `json:"message_id"`
+	Name         string     `json:"name"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	Checksum     string     `json:"checksum"`
+	UploadedBy   *uuid.UUID `json:"uploaded_by"`
+	UploaderType string     `json:"uploader_type"`
+	Posted       bool       `json:"posted"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func newAttachmentView(a *attachment.Attachment) attachmentView {
+	view := attachmentView{
+		ID:           a.ID(),
+		MessageID:    a.MessageID(),
+		Name:         a.Name(),
+		ContentType:  a.ContentType(),
+		Size:         a.Size(),
+		Checksum:     a.Checksum(),
+		UploadedBy:   a.UploadedBy(),
+		UploaderType: a.UploaderType().String(),
+		Posted:       a.IsPosted(),
+		CreatedAt:    a.CreatedAt(),
+	}
+	if ticketID := a.TicketID(); ticketID != uuid.Nil {
+		view.TicketID = &ticketID
+	}
+	return view
+}
+
+// attachmentDownloadView is the JSON representation of an entry in the
+// download log of an attachment
+type attachmentDownloadView struct {
+	ID           uuid.UUID `json:"id"`
+	AttachmentID uuid.UUID `json:"attachment_id"`
+	TicketID     uuid.UUID `json:"ticket_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	UserType     string    `json:"user_type"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+func newAttachmentDownloadView(d attachment.Download) attachmentDownloadView {
+	return attachmentDownloadView{
+		ID:           d.ID(),
+		AttachmentID: d.AttachmentID(),
+		TicketID:     d.TicketID(),
+		UserID:       d.UserID(),
+		UserType:     d.UserType().String(),
+		IPAddress:    d.IPAddress(),
+		UserAgent:    d.UserAgent(),
+		DownloadedAt: d.DownloadedAt(),
+	}
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+	"go.uber.org/zap"
+)
+
+// WorkflowHandler handles ticket workflow management requests
+type WorkflowHandler struct {
+	workflows    workflow.Repository
+	categoryRepo category.Repository
+	logger       *zap.Logger
+}
+
+// NewWorkflowHandler creates a new workflow handler
+func NewWorkflowHandler(workflows workflow.Repository, categoryRepo category.Repository, logger *zap.Logger) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflows:    workflows,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+	}
+}
+
+// WorkflowStateInput is one status of a workflow, e.g.
+// {"key": "awaiting_vendor", "label": "Awaiting Vendor", "active": true, "pauses_sla": true}
+type WorkflowStateInput struct {
+	Key       string `json:"key" binding:"required"`
+	Label     string `json:"label"`
+	Active    bool   `json:"active"`
+	Terminal  bool   `json:"terminal"`
+	PausesSLA bool   `json:"pauses_sla"`
+}
+
+// WorkflowTransitionInput is an allowed move between two statuses
+type WorkflowTransitionInput struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// WorkflowRequest represents the request to create or update a workflow.
+// The default workflow applies to categories without one of their own.
+type WorkflowRequest struct {
+	Name        string                    `json:"name" binding:"required"`
+	IsDefault   bool                      `json:"is_default"`
+	CategoryIDs []uuid.UUID               `json:"category_ids"`
+	States      []WorkflowStateInput      `json:"states" binding:"required"`
+	Transitions []WorkflowTransitionInput `json:"transitions" binding:"required"`
+}
+
+func (r WorkflowRequest) definition() ([]workflow.State, []workflow.Transition) {
+	states := make([]workflow.State, 0, len(r.States))
+	for _, s := range r.States {
+		label := s.Label
+		if label == "" {
+			label = s.Key
+		}
+		states = append(states, workflow.State{
+			Key:       shared.TicketStatus(s.Key),
+			Label:     label,
+			Active:    s.Active,
+			Terminal:  s.Terminal,
+			PausesSLA: s.PausesSLA,
+		})
+	}
+
+	transitions := make([]workflow.Transition, 0, len(r.Transitions))
+	for _, t := range r.Transitions {
+		transitions = append(transitions, workflow.Transition{
+			From: shared.TicketStatus(t.From),
+			To:   shared.TicketStatus(t.To),
+		})
+	}
+	return states, transitions
+}
+
+// ListWorkflows lists all workflows. The built-in workflow is listed while
+// no stored workflow is the default.
+// GET /api/v1/admin/support/workflows
+func (h *WorkflowHandler) ListWorkflows(c *gin.Context) {
+	workflows, err := h.workflows.List(c.Request.Context())
+	if err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to retrieve workflows")
+		return
+	}
+
+	views := make([]workflowView, 0, len(workflows)+1)
+	if len(workflows) == 0 || !workflows[0].IsDefault() {
+		views = append(views, newWorkflowView(workflow.Default()))
+	}
+	for _, w := range workflows {
+		views = append(views, newWorkflowView(w))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    views,
+	})
+}
+
+// GetWorkflow gets a workflow by ID
+// GET /api/v1/admin/support/workflows/:id
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	id, ok := parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	w, err := h.workflows.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to retrieve workflow")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newWorkflowView(w),
+	})
+}
+
+// CreateWorkflow creates a workflow
+// POST /api/v1/admin/support/workflows
+func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
+	var req WorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if !h.checkCategories(c, req.CategoryIDs) {
+		return
+	}
+
+	states, transitions := req.definition()
+	w, err := workflow.NewWorkflow(workflow.WorkflowParams{
+		Name:        req.Name,
+		IsDefault:   req.IsDefault,
+		CategoryIDs: req.CategoryIDs,
+		States:      states,
+		Transitions: transitions,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.workflows.Save(c.Request.Context(), w); err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to create workflow")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    newWorkflowView(w),
+		"message": "Workflow created successfully",
+	})
+}
+
+// UpdateWorkflow replaces a workflow's definition and assignments. Tickets
+// in a status the new definition drops stay on the built-in workflow.
+// PUT /api/v1/admin/support/workflows/:id
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	id, ok := parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	var req WorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": err.Error()},
+		})
+		return
+	}
+
+	w, err := h.workflows.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to retrieve workflow")
+		return
+	}
+
+	if !h.checkCategories(c, req.CategoryIDs) {
+		return
+	}
+
+	states, transitions := req.definition()
+	if err := w.Update(req.Name, states, transitions); err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to update workflow")
+		return
+	}
+	w.Assign(req.IsDefault, req.CategoryIDs)
+
+	if err := h.workflows.Save(c.Request.Context(), w); err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to update workflow")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newWorkflowView(w),
+		"message": "Workflow updated successfully",
+	})
+}
+
+// DeleteWorkflow deletes a workflow. Its categories fall back to the default
+// workflow.
+// DELETE /api/v1/admin/support/workflows/:id
+func (h *WorkflowHandler) DeleteWorkflow(c *gin.Context) {
+	id, ok := parseWorkflowID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workflows.Delete(c.Request.Context(), id); err != nil {
+		respondWorkflowError(c, h.logger, err, "Failed to delete workflow")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Workflow deleted successfully",
+	})
+}
+
+// checkCategories rejects workflows assigned to an unknown category.
+func (h *WorkflowHandler) checkCategories(c *gin.Context, categoryIDs []uuid.UUID) bool {
+	for _, id := range categoryIDs {
+		if _, err := h.categoryRepo.FindByID(c.Request.Context(), id); err != nil {
+			if errors.Is(err, category.ErrCategoryNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   gin.H{"message": "Category not found"},
+				})
+				return false
+			}
+			h.logger.Error("Failed to retrieve category", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   gin.H{"message": "Failed to retrieve category"},
+			})
+			return false
+		}
+	}
+	return true
+}
+
+func parseWorkflowID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   gin.H{"message": "Invalid workflow ID"},
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
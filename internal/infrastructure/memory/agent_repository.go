@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// AgentRepository is an in-memory agent.Repository.
+type AgentRepository struct {
+	mu     sync.RWMutex
+	agents map[uuid.UUID]*agent.Agent
+}
+
+var _ agent.Repository = (*AgentRepository)(nil)
+
+// NewAgentRepository creates an empty in-memory agent repository.
+func NewAgentRepository() *AgentRepository {
+	return &AgentRepository{agents: make(map[uuid.UUID]*agent.Agent)}
+}
+
+// FindByID returns a copy of the stored agent.
+func (r *AgentRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.agents[id]
+	if !ok {
+		return nil, agent.ErrAgentNotFound
+	}
+	return cloneAgent(a), nil
+}
+
+// FindByIDs returns copies of the stored agents with the given IDs.
+func (r *AgentRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*agent.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agents := make([]*agent.Agent, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if a, ok := r.agents[id]; ok && !seen[id] {
+			seen[id] = true
+			agents = append(agents, cloneAgent(a))
+		}
+	}
+	return agents, nil
+}
+
+// List returns the agents matching the filter, ordered by name.
+func (r *AgentRepository) List(ctx context.Context, filter agent.Filter) ([]*agent.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agents := make([]*agent.Agent, 0, len(r.agents))
+	for _, a := range r.agents {
+		if filter.Status != "" && a.Status() != filter.Status {
+			continue
+		}
+		if filter.Team != "" && !a.InTeam(filter.Team) {
+			continue
+		}
+		agents = append(agents, cloneAgent(a))
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		if agents[i].Name() != agents[j].Name() {
+			return agents[i].Name() < agents[j].Name()
+		}
+		return agents[i].ID().String() < agents[j].ID().String()
+	})
+	return agents, nil
+}
+
+// Save stores a copy of the agent.
+func (r *AgentRepository) Save(ctx context.Context, a *agent.Agent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.agents[a.ID()] = cloneAgent(a)
+	return nil
+}
+
+// RecordAssignment updates only the stored agent's last assignment time.
+func (r *AgentRepository) RecordAssignment(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.agents[id]
+	if !ok {
+		return agent.ErrAgentNotFound
+	}
+	a.RecordAssignment(at)
+	return nil
+}
+
+// Delete removes an agent.
+func (r *AgentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.agents[id]; !ok {
+		return agent.ErrAgentNotFound
+	}
+	delete(r.agents, id)
+	return nil
+}
+
+func cloneAgent(a *agent.Agent) *agent.Agent {
+	return agent.Reconstitute(agent.ReconstituteParams{
+		ID:                   a.ID(),
+		Name:                 a.Name(),
+		Email:                a.Email(),
+		Role:                 a.Role(),
+		Teams:                append([]string(nil), a.Teams()...),
+		Skills:               append([]agent.Skill(nil), a.Skills()...),
+		Status:               string(a.Status()),
+		MaxConcurrentTickets: a.MaxConcurrentTickets(),
+		Timezone:             a.Timezone(),
+		Signature:            a.Signature(),
+		LastAssignedAt:       copyTime(a.LastAssignedAt()),
+		CreatedAt:            a.CreatedAt(),
+		UpdatedAt:            a.UpdatedAt(),
+	})
+}
@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+)
+
+// AttachmentRepository is an in-memory attachment.Repository.
+type AttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[uuid.UUID]*attachment.Attachment
+}
+
+var _ attachment.Repository = (*AttachmentRepository)(nil)
+
+// NewAttachmentRepository creates an empty in-memory attachment repository.
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{attachments: make(map[uuid.UUID]*attachment.Attachment)}
+}
+
+// FindByID returns a copy of the stored attachment.
+func (r *AttachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.attachments[id]
+	if !ok {
+		return nil, attachment.ErrAttachmentNotFound
+	}
+	return cloneAttachment(a), nil
+}
+
+// ListByTicket returns copies of the ticket's attachments, oldest first.
+func (r *AttachmentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*attachment.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*attachment.Attachment, 0)
+	for _, a := range r.attachments {
+		if a.TicketID() == ticketID {
+			matches = append(matches, cloneAttachment(a))
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt().Equal(matches[j].CreatedAt()) {
+			return matches[i].CreatedAt().Before(matches[j].CreatedAt())
+		}
+		return matches[i].ID().String() < matches[j].ID().String()
+	})
+	return matches, nil
+}
+
+// TicketUsage sums the sizes of the ticket's attachments.
+func (r *AttachmentRepository) TicketUsage(ctx context.Context, ticketID uuid.UUID) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	for _, a := range r.attachments {
+		if a.TicketID() == ticketID {
+			total += a.Size()
+		}
+	}
+	return total, nil
+}
+
+// Save stores a copy of the attachment.
+func (r *AttachmentRepository) Save(ctx context.Context, a *attachment.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attachments[a.ID()] = cloneAttachment(a)
+	return nil
+}
+
+// Delete removes an attachment.
+func (r *AttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.attachments[id]; !ok {
+		return attachment.ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+// move gives the matching attachments to the ticket.
+func (r *AttachmentRepository) move(ticketID uuid.UUID, match func(*attachment.Attachment) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, a := range r.attachments {
+		if match(a) {
+			r.attachments[id] = movedAttachment(a, ticketID)
+		}
+	}
+}
+
+func cloneAttachment(a *attachment.Attachment) *attachment.Attachment {
+	return movedAttachment(a, a.TicketID())
+}
+
+// movedAttachment returns a copy of the attachment that belongs to the
+// ticket.
+func movedAttachment(a *attachment.Attachment, ticketID uuid.UUID) *attachment.Attachment {
+	return attachment.Reconstitute(attachment.ReconstituteParams{
+		ID:           a.ID(),
+		TicketID:     ticketID,
+		MessageID:    copyID(a.MessageID()),
+		Name:         a.Name(),
+		ContentType:  a.ContentType(),
+		Size:         a.Size(),
+		Checksum:     a.Checksum(),
+		StorageKey:   a.StorageKey(),
+		UploadedBy:   copyID(a.UploadedBy()),
+		UploaderType: a.UploaderType().String(),
+		CreatedAt:    a.CreatedAt(),
+	})
+}
+
+// AttachmentDownloadLog is an in-memory attachment.DownloadLog.
+type AttachmentDownloadLog struct {
+	mu        sync.RWMutex
+	downloads []attachment.Download
+}
+
+var _ attachment.DownloadLog = (*AttachmentDownloadLog)(nil)
+
+// NewAttachmentDownloadLog creates an empty in-memory attachment download
+// log.
+func NewAttachmentDownloadLog() *AttachmentDownloadLog {
+	return &AttachmentDownloadLog{}
+}
+
+// Record appends the download.
+func (l *AttachmentDownloadLog) Record(ctx context.Context, d attachment.Download) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.downloads = append(l.downloads, d)
+	return nil
+}
+
+// List returns a page of downloads matching the filter, newest first.
+func (l *AttachmentDownloadLog) List(ctx context.Context, filter attachment.DownloadFilter) ([]attachment.Download, int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	matches := make([]attachment.Download, 0)
+	for i := len(l.downloads) - 1; i >= 0; i-- {
+		d := l.downloads[i]
+		if filter.AttachmentID != nil && d.AttachmentID() != *filter.AttachmentID {
+			continue
+		}
+		if filter.TicketID != nil && d.TicketID() != *filter.TicketID {
+			continue
+		}
+		matches = append(matches, d)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].DownloadedAt().After(matches[j].DownloadedAt())
+	})
+
+	total := int64(len(matches))
+	filter.Normalize()
+	start := filter.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + filter.PerPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+	return append([]attachment.Download(nil), matches[start:end]...), total, nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+)
+
+// AutomationPolicyRepository is an in-memory automation.Repository.
+type AutomationPolicyRepository struct {
+	mu       sync.RWMutex
+	policies map[uuid.UUID]*automation.Policy
+}
+
+var _ automation.Repository = (*AutomationPolicyRepository)(nil)
+
+// NewAutomationPolicyRepository creates an empty in-memory automation policy
+// repository.
+func NewAutomationPolicyRepository() *AutomationPolicyRepository {
+	return &AutomationPolicyRepository{policies: make(map[uuid.UUID]*automation.Policy)}
+}
+
+// FindByID returns a copy of the stored policy.
+func (r *AutomationPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*automation.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.policies[id]
+	if !ok {
+		return nil, automation.ErrPolicyNotFound
+	}
+	return cloneAutomationPolicy(p), nil
+}
+
+// FindForCategory returns the category policy or the global one.
+func (r *AutomationPolicyRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*automation.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if categoryID != nil {
+		if p := r.findScope(categoryID); p != nil {
+			return cloneAutomationPolicy(p), nil
+		}
+	}
+	if p := r.findScope(nil); p != nil {
+		return cloneAutomationPolicy(p), nil
+	}
+	return nil, automation.ErrPolicyNotFound
+}
+
+// List returns all policies, the global one first.
+func (r *AutomationPolicyRepository) List(ctx context.Context) ([]*automation.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := make([]*automation.Policy, 0, len(r.policies))
+	for _, p := range r.policies {
+		policies = append(policies, cloneAutomationPolicy(p))
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		a, b := policies[i], policies[j]
+		if a.IsGlobal() != b.IsGlobal() {
+			return a.IsGlobal()
+		}
+		if !a.IsGlobal() && *a.CategoryID() != *b.CategoryID() {
+			return a.CategoryID().String() < b.CategoryID().String()
+		}
+		return a.Name() < b.Name()
+	})
+	return policies, nil
+}
+
+// Save stores a copy of the policy.
+func (r *AutomationPolicyRepository) Save(ctx context.Context, p *automation.Policy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing := r.findScope(p.CategoryID()); existing != nil && existing.ID() != p.ID() {
+		return automation.ErrPolicyConflict
+	}
+	r.policies[p.ID()] = cloneAutomationPolicy(p)
+	return nil
+}
+
+// Delete removes a policy.
+func (r *AutomationPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.policies[id]; !ok {
+		return automation.ErrPolicyNotFound
+	}
+	delete(r.policies, id)
+	return nil
+}
+
+// findScope returns the policy covering the category, or the global one for
+// nil. The caller holds the lock.
+func (r *AutomationPolicyRepository) findScope(categoryID *uuid.UUID) *automation.Policy {
+	for _, p := range r.policies {
+		if categoryID == nil && p.IsGlobal() {
+			return p
+		}
+		if equalID(categoryID, p.CategoryID()) {
+			return p
+		}
+	}
+	return nil
+}
+
+func cloneAutomationPolicy(p *automation.Policy) *automation.Policy {
+	return automation.ReconstitutePolicy(automation.PolicyReconstituteParams{
+		ID:         p.ID(),
+		Name:       p.Name(),
+		CategoryID: copyID(p.CategoryID()),
+		Schedule:   p.Schedule(),
+		CreatedAt:  p.CreatedAt(),
+		UpdatedAt:  p.UpdatedAt(),
+	})
+}
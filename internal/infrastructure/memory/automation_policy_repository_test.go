@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestAutomationPolicyRepository(t *testing.T) {
+	repotest.AutomationPolicyRepositoryContract(t, func(t *testing.T) automation.Repository {
+		return NewAutomationPolicyRepository()
+	})
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+)
+
+// CannedResponseRepository is an in-memory response.Repository.
+type CannedResponseRepository struct {
+	mu        sync.RWMutex
+	responses map[uuid.UUID]*response.CannedResponse
+}
+
+var _ response.Repository = (*CannedResponseRepository)(nil)
+
+// NewCannedResponseRepository creates an empty in-memory canned response repository.
+func NewCannedResponseRepository() *CannedResponseRepository {
+	return &CannedResponseRepository{responses: make(map[uuid.UUID]*response.CannedResponse)}
+}
+
+// FindByID returns a copy of the stored canned response.
+func (r *CannedResponseRepository) FindByID(ctx context.Context, id uuid.UUID) (*response.CannedResponse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cr, ok := r.responses[id]
+	if !ok {
+		return nil, response.ErrResponseNotFound
+	}
+	return cloneCannedResponse(cr), nil
+}
+
+// FindByShortcut returns a copy of the active canned response with the shortcut.
+func (r *CannedResponseRepository) FindByShortcut(ctx context.Context, shortcut string) (*response.CannedResponse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, cr := range r.responses {
+		if cr.IsActive() && cr.Shortcut() == shortcut {
+			return cloneCannedResponse(cr), nil
+		}
+	}
+	return nil, response.ErrResponseNotFound
+}
+
+// List returns canned responses ordered by usage and title.
+func (r *CannedResponseRepository) List(ctx context.Context, categoryID *uuid.UUID, onlyActive bool) ([]*response.CannedResponse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	responses := make([]*response.CannedResponse, 0, len(r.responses))
+	for _, cr := range r.responses {
+		if onlyActive && !cr.IsActive() {
+			continue
+		}
+		if categoryID != nil && cr.CategoryID() != nil && *cr.CategoryID() != *categoryID {
+			continue
+		}
+		responses = append(responses, cloneCannedResponse(cr))
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].UsageCount() != responses[j].UsageCount() {
+			return responses[i].UsageCount() > responses[j].UsageCount()
+		}
+		return responses[i].Title() < responses[j].Title()
+	})
+	return responses, nil
+}
+
+// Save stores a copy of the canned response.
+func (r *CannedResponseRepository) Save(ctx context.Context, cr *response.CannedResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.responses[cr.ID()] = cloneCannedResponse(cr)
+	return nil
+}
+
+// Delete removes a canned response.
+func (r *CannedResponseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.responses[id]; !ok {
+		return response.ErrResponseNotFound
+	}
+	delete(r.responses, id)
+	return nil
+}
+
+func cloneCannedResponse(cr *response.CannedResponse) *response.CannedResponse {
+	return response.Reconstitute(response.ReconstituteParams{
+		ID:         cr.ID(),
+		Title:      cr.Title(),
+		Content:    cr.Content(),
+		CategoryID: copyID(cr.CategoryID()),
+		Shortcut:   cr.Shortcut(),
+		IsActive:   cr.IsActive(),
+		UsageCount: cr.UsageCount(),
+		CreatedBy:  copyID(cr.CreatedBy()),
+		CreatedAt:  cr.CreatedAt(),
+		UpdatedAt:  cr.UpdatedAt(),
+	})
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+)
+
+// CategoryRepository is an in-memory category.Repository.
+type CategoryRepository struct {
+	mu         sync.RWMutex
+	categories map[uuid.UUID]*category.Category
+}
+
+var _ category.Repository = (*CategoryRepository)(nil)
+
+// NewCategoryRepository creates an empty in-memory category repository.
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{categories: make(map[uuid.UUID]*category.Category)}
+}
+
+// FindByID returns a copy of the stored category.
+func (r *CategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*category.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.categories[id]
+	if !ok {
+		return nil, category.ErrCategoryNotFound
+	}
+	return cloneCategory(c), nil
+}
+
+// List returns categories ordered by display priority and name.
+func (r *CategoryRepository) List(ctx context.Context, onlyActive bool) ([]*category.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*category.Category, 0, len(r.categories))
+	for _, c := range r.categories {
+		if onlyActive && !c.IsActive() {
+			continue
+		}
+		categories = append(categories, cloneCategory(c))
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Priority() != categories[j].Priority() {
+			return categories[i].Priority() < categories[j].Priority()
+		}
+		return categories[i].Name() < categories[j].Name()
+	})
+	return categories, nil
+}
+
+// Save stores a copy of the category.
+func (r *CategoryRepository) Save(ctx context.Context, c *category.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.categories[c.ID()] = cloneCategory(c)
+	return nil
+}
+
+// Delete removes a category.
+func (r *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return category.ErrCategoryNotFound
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+func cloneCategory(c *category.Category) *category.Category {
+	return category.Reconstitute(category.ReconstituteParams{
+		ID:          c.ID(),
+		Name:        c.Name(),
+		NameMS:      c.NameMS(),
+		Description: c.Description(),
+		Icon:        c.Icon(),
+		SLAHours:    c.SLAHours(),
+		Priority:    c.Priority(),
+		IsActive:    c.IsActive(),
+		CreatedAt:   c.CreatedAt(),
+		UpdatedAt:   c.UpdatedAt(),
+	})
+}
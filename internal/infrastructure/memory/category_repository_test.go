@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	repotest.CategoryRepositoryContract(t, func(t *testing.T) category.Repository {
+		return NewCategoryRepository()
+	})
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+)
+
+// CustomerRepository is an in-memory customer.Repository.
+type CustomerRepository struct {
+	mu        sync.RWMutex
+	customers map[uuid.UUID]*customer.Customer
+}
+
+var _ customer.Repository = (*CustomerRepository)(nil)
+
+// NewCustomerRepository creates an empty in-memory customer repository.
+func NewCustomerRepository() *CustomerRepository {
+	return &CustomerRepository{customers: make(map[uuid.UUID]*customer.Customer)}
+}
+
+// FindByID returns a copy of the stored customer.
+func (r *CustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.customers[id]
+	if !ok {
+		return nil, customer.ErrCustomerNotFound
+	}
+	return cloneCustomer(c), nil
+}
+
+// Save stores a copy of the customer.
+func (r *CustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.customers[c.ID()] = cloneCustomer(c)
+	return nil
+}
+
+// Delete removes a customer.
+func (r *CustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.customers[id]; !ok {
+		return customer.ErrCustomerNotFound
+	}
+	delete(r.customers, id)
+	return nil
+}
+
+func cloneCustomer(c *customer.Customer) *customer.Customer {
+	return customer.Reconstitute(customer.ReconstituteParams{
+		ID:        c.ID(),
+		Email:     c.Email(),
+		Name:      c.Name(),
+		CreatedAt: c.CreatedAt(),
+		UpdatedAt: c.UpdatedAt(),
+	})
+}
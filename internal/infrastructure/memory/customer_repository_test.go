@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestCustomerRepository(t *testing.T) {
+	repotest.CustomerRepositoryContract(t, func(t *testing.T) customer.Repository {
+		return NewCustomerRepository()
+	})
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// Locker is an in-process lock set for running background workers without a
+// database.
+type Locker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewLocker creates an empty in-memory locker.
+func NewLocker() *Locker {
+	return &Locker{held: make(map[string]bool)}
+}
+
+// TryLock acquires the named lock without waiting.
+func (l *Locker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+
+	unlock := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+)
+
+// NotificationTemplateRepository is an in-memory
+// notification.TemplateRepository.
+type NotificationTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[uuid.UUID]*notification.Template
+}
+
+var _ notification.TemplateRepository = (*NotificationTemplateRepository)(nil)
+
+// NewNotificationTemplateRepository creates an empty in-memory notification
+// template repository.
+func NewNotificationTemplateRepository() *NotificationTemplateRepository {
+	return &NotificationTemplateRepository{templates: make(map[uuid.UUID]*notification.Template)}
+}
+
+// FindByID returns a copy of the stored template.
+func (r *NotificationTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*notification.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.templates[id]
+	if !ok {
+		return nil, notification.ErrTemplateNotFound
+	}
+	return cloneNotificationTemplate(t), nil
+}
+
+// Find returns a copy of the template of the event in the locale.
+func (r *NotificationTemplateRepository) Find(ctx context.Context, event notification.Event, locale string) (*notification.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t := r.find(event, locale); t != nil {
+		return cloneNotificationTemplate(t), nil
+	}
+	return nil, notification.ErrTemplateNotFound
+}
+
+// List returns the templates matching the filter by event and locale.
+func (r *NotificationTemplateRepository) List(ctx context.Context, filter notification.TemplateFilter) ([]*notification.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]*notification.Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		if filter.Event != "" && t.Event() != filter.Event {
+			continue
+		}
+		if filter.Locale != "" && t.Locale() != filter.Locale {
+			continue
+		}
+		templates = append(templates, cloneNotificationTemplate(t))
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Event() != templates[j].Event() {
+			return templates[i].Event() < templates[j].Event()
+		}
+		return templates[i].Locale() < templates[j].Locale()
+	})
+	return templates, nil
+}
+
+// Save stores a copy of the template.
+func (r *NotificationTemplateRepository) Save(ctx context.Context, t *notification.Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing := r.find(t.Event(), t.Locale()); existing != nil && existing.ID() != t.ID() {
+		return notification.ErrTemplateConflict
+	}
+	r.templates[t.ID()] = cloneNotificationTemplate(t)
+	return nil
+}
+
+// Delete removes a template.
+func (r *NotificationTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[id]; !ok {
+		return notification.ErrTemplateNotFound
+	}
+	delete(r.templates, id)
+	return nil
+}
+
+// find returns the template of the event in the locale. The caller holds
+// the lock.
+func (r *NotificationTemplateRepository) find(event notification.Event, locale string) *notification.Template {
+	for _, t := range r.templates {
+		if t.Event() == event && t.Locale() == locale {
+			return t
+		}
+	}
+	return nil
+}
+
+func cloneNotificationTemplate(t *notification.Template) *notification.Template {
+	return notification.ReconstituteTemplate(notification.TemplateReconstituteParams{
+		ID:        t.ID(),
+		Event:     string(t.Event()),
+		Locale:    t.Locale(),
+		Subject:   t.Subject(),
+		Body:      t.Body(),
+		CreatedAt: t.CreatedAt(),
+		UpdatedAt: t.UpdatedAt(),
+	})
+}
+
+// NotificationDeliveryRepository is an in-memory
+// notification.DeliveryRepository.
+type NotificationDeliveryRepository struct {
+	mu          sync.Mutex
+	deliveries  map[uuid.UUID]*notification.Delivery
+	lockedUntil map[uuid.UUID]time.Time
+}
+
+var _ notification.DeliveryRepository = (*NotificationDeliveryRepository)(nil)
+
+// NewNotificationDeliveryRepository creates an empty in-memory notification
+// delivery repository.
+func NewNotificationDeliveryRepository() *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{
+		deliveries:  make(map[uuid.UUID]*notification.Delivery),
+		lockedUntil: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Save stores a copy of the delivery and releases its lease.
+func (r *NotificationDeliveryRepository) Save(ctx context.Context, d *notification.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[d.ID()] = cloneNotificationDelivery(d)
+	delete(r.lockedUntil, d.ID())
+	return nil
+}
+
+// ListByTicket returns copies of the ticket's deliveries, oldest first.
+func (r *NotificationDeliveryRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*notification.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*notification.Delivery, 0)
+	for _, d := range r.deliveries {
+		if d.TicketID() == ticketID {
+			deliveries = append(deliveries, cloneNotificationDelivery(d))
+		}
+	}
+	sortDeliveries(deliveries)
+	return deliveries, nil
+}
+
+// FindByMessageID returns a copy of the delivery queued with one of the
+// Message-IDs.
+func (r *NotificationDeliveryRepository) FindByMessageID(ctx context.Context, messageIDs []string) (*notification.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.deliveries {
+		if d.MessageID() == "" {
+			continue
+		}
+		for _, id := range messageIDs {
+			if d.MessageID() == id {
+				return cloneNotificationDelivery(d), nil
+			}
+		}
+	}
+	return nil, notification.ErrDeliveryNotFound
+}
+
+// ClaimDue leases the oldest due pending deliveries.
+func (r *NotificationDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*notification.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	due := make([]*notification.Delivery, 0)
+	for _, d := range r.deliveries {
+		if !d.IsPending() || d.NextAttemptAt().After(now) || r.lockedUntil[d.ID()].After(now) {
+			continue
+		}
+		due = append(due, d)
+	}
+	sortDeliveries(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*notification.Delivery, 0, len(due))
+	for _, d := range due {
+		r.lockedUntil[d.ID()] = now.Add(lease)
+		claimed = append(claimed, cloneNotificationDelivery(d))
+	}
+	return claimed, nil
+}
+
+func sortDeliveries(deliveries []*notification.Delivery) {
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt().Before(deliveries[j].CreatedAt())
+	})
+}
+
+func cloneNotificationDelivery(d *notification.Delivery) *notification.Delivery {
+	return notification.ReconstituteDelivery(notification.DeliveryReconstituteParams{
+		ID:            d.ID(),
+		TicketID:      d.TicketID(),
+		Event:         string(d.Event()),
+		Locale:        d.Locale(),
+		To:            d.To(),
+		CC:            append([]string(nil), d.CC()...),
+		ReplyTo:       d.ReplyTo(),
+		Subject:       d.Subject(),
+		Body:          d.Body(),
+		MessageID:     d.MessageID(),
+		InReplyTo:     d.InReplyTo(),
+		References:    append([]string(nil), d.References()...),
+		Status:        string(d.Status()),
+		Attempts:      d.Attempts(),
+		LastError:     d.LastError(),
+		NextAttemptAt: d.NextAttemptAt(),
+		SentAt:        copyTime(d.SentAt()),
+		CreatedAt:     d.CreatedAt(),
+		UpdatedAt:     d.UpdatedAt(),
+	})
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+)
+
+// Outbox is an in-memory events.OutboxStore.
+type Outbox struct {
+	mu      sync.Mutex
+	entries []*outboxEntry
+}
+
+type outboxEntry struct {
+	msg         events.OutboxMessage
+	dead        bool
+	published   bool
+	lastError   string
+	availableAt time.Time
+	lockedUntil time.Time
+}
+
+var _ events.OutboxStore = (*Outbox)(nil)
+
+// NewOutbox creates an empty in-memory outbox.
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+// ClaimPending leases the oldest pending message of each aggregate that is due.
+func (o *Outbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	seen := make(map[uuid.UUID]bool)
+	claimed := make([]events.OutboxMessage, 0)
+	for _, e := range o.entries {
+		if len(claimed) >= limit {
+			break
+		}
+		if e.published || e.dead || seen[e.msg.AggregateID] {
+			continue
+		}
+		seen[e.msg.AggregateID] = true
+		if e.availableAt.After(now) || e.lockedUntil.After(now) {
+			continue
+		}
+		e.lockedUntil = now.Add(lease)
+		claimed = append(claimed, cloneOutboxMessage(e.msg))
+	}
+	return claimed, nil
+}
+
+// MarkPublished records a successful publish.
+func (o *Outbox) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return o.update(id, func(e *outboxEntry) {
+		e.published = true
+	})
+}
+
+// MarkFailed records a failed attempt and schedules a retry.
+func (o *Outbox) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt time.Time) error {
+	return o.update(id, func(e *outboxEntry) {
+		e.msg.Attempts++
+		e.lastError = lastErr
+		e.availableAt = retryAt
+	})
+}
+
+// MarkDead moves a message to the dead-letter state.
+func (o *Outbox) MarkDead(ctx context.Context, id uuid.UUID, lastErr string) error {
+	return o.update(id, func(e *outboxEntry) {
+		e.msg.Attempts++
+		e.lastError = lastErr
+		e.dead = true
+	})
+}
+
+// Pending returns the messages that are neither published nor dead, oldest first.
+func (o *Outbox) Pending() []events.OutboxMessage {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	pending := make([]events.OutboxMessage, 0)
+	for _, e := range o.entries {
+		if !e.published && !e.dead {
+			pending = append(pending, cloneOutboxMessage(e.msg))
+		}
+	}
+	return pending
+}
+
+func (o *Outbox) append(messages []events.OutboxMessage) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, msg := range messages {
+		o.entries = append(o.entries, &outboxEntry{
+			msg:         cloneOutboxMessage(msg),
+			availableAt: msg.CreatedAt,
+		})
+	}
+}
+
+func (o *Outbox) update(id uuid.UUID, apply func(e *outboxEntry)) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, e := range o.entries {
+		if e.msg.ID == id {
+			apply(e)
+			e.lockedUntil = time.Time{}
+			return nil
+		}
+	}
+	return nil
+}
+
+func cloneOutboxMessage(msg events.OutboxMessage) events.OutboxMessage {
+	msg.Payload = append([]byte(nil), msg.Payload...)
+	return msg
+}
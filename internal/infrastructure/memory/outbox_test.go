@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestOutbox(t *testing.T) {
+	repotest.OutboxContract(t, func(t *testing.T) (ticket.Repository, events.OutboxStore) {
+		repo := NewTicketRepository()
+		return repo, repo.Outbox()
+	})
+}
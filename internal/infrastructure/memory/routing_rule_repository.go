@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+)
+
+// RoutingRuleRepository is an in-memory routing.Repository.
+type RoutingRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[uuid.UUID]*routing.Rule
+}
+
+var _ routing.Repository = (*RoutingRuleRepository)(nil)
+
+// NewRoutingRuleRepository creates an empty in-memory routing rule repository.
+func NewRoutingRuleRepository() *RoutingRuleRepository {
+	return &RoutingRuleRepository{rules: make(map[uuid.UUID]*routing.Rule)}
+}
+
+// FindByID returns a copy of the stored rule.
+func (r *RoutingRuleRepository) FindByID(ctx context.Context, id uuid.UUID) (*routing.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, routing.ErrRuleNotFound
+	}
+	return cloneRoutingRule(rule), nil
+}
+
+// List returns all rules by position, then name.
+func (r *RoutingRuleRepository) List(ctx context.Context) ([]*routing.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*routing.Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, cloneRoutingRule(rule))
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Position() != b.Position() {
+			return a.Position() < b.Position()
+		}
+		if a.Name() != b.Name() {
+			return a.Name() < b.Name()
+		}
+		return a.ID().String() < b.ID().String()
+	})
+	return rules, nil
+}
+
+// Save stores a copy of the rule.
+func (r *RoutingRuleRepository) Save(ctx context.Context, rule *routing.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID()] = cloneRoutingRule(rule)
+	return nil
+}
+
+// Delete removes a rule.
+func (r *RoutingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return routing.ErrRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func cloneRoutingRule(rule *routing.Rule) *routing.Rule {
+	c := rule.Condition()
+	var hasOrder *bool
+	if c.HasOrder != nil {
+		v := *c.HasOrder
+		hasOrder = &v
+	}
+
+	return routing.Reconstitute(routing.ReconstituteParams{
+		ID:       rule.ID(),
+		Name:     rule.Name(),
+		Position: rule.Position(),
+		Enabled:  rule.IsEnabled(),
+		Condition: routing.Condition{
+			CategoryIDs: append([]uuid.UUID(nil), c.CategoryIDs...),
+			Tags:        append([]string(nil), c.Tags...),
+			Languages:   append([]string(nil), c.Languages...),
+			HasOrder:    hasOrder,
+		},
+		RequiredSkills: append([]routing.SkillRequirement(nil), rule.RequiredSkills()...),
+		FallbackTeamID: copyID(rule.FallbackTeamID()),
+		CreatedAt:      rule.CreatedAt(),
+		UpdatedAt:      rule.UpdatedAt(),
+	})
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// SLACalendarRepository is an in-memory sla.Repository.
+type SLACalendarRepository struct {
+	mu        sync.RWMutex
+	calendars map[uuid.UUID]*sla.Calendar
+}
+
+var _ sla.Repository = (*SLACalendarRepository)(nil)
+
+// NewSLACalendarRepository creates an empty in-memory SLA calendar repository.
+func NewSLACalendarRepository() *SLACalendarRepository {
+	return &SLACalendarRepository{calendars: make(map[uuid.UUID]*sla.Calendar)}
+}
+
+// FindByID returns a copy of the stored calendar.
+func (r *SLACalendarRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Calendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.calendars[id]
+	if !ok {
+		return nil, sla.ErrCalendarNotFound
+	}
+	return cloneCalendar(c), nil
+}
+
+// FindForCategory returns the category calendar or the global one.
+func (r *SLACalendarRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*sla.Calendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if categoryID != nil {
+		if c := r.findScope(categoryID); c != nil {
+			return cloneCalendar(c), nil
+		}
+	}
+	if c := r.findScope(nil); c != nil {
+		return cloneCalendar(c), nil
+	}
+	return nil, sla.ErrCalendarNotFound
+}
+
+// List returns all calendars, the global one first.
+func (r *SLACalendarRepository) List(ctx context.Context) ([]*sla.Calendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	calendars := make([]*sla.Calendar, 0, len(r.calendars))
+	for _, c := range r.calendars {
+		calendars = append(calendars, cloneCalendar(c))
+	}
+	sort.Slice(calendars, func(i, j int) bool {
+		if calendars[i].IsGlobal() != calendars[j].IsGlobal() {
+			return calendars[i].IsGlobal()
+		}
+		return calendars[i].Name() < calendars[j].Name()
+	})
+	return calendars, nil
+}
+
+// Save stores a copy of the calendar.
+func (r *SLACalendarRepository) Save(ctx context.Context, c *sla.Calendar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing := r.findScope(c.CategoryID()); existing != nil && existing.ID() != c.ID() {
+		return sla.ErrCalendarConflict
+	}
+	r.calendars[c.ID()] = cloneCalendar(c)
+	return nil
+}
+
+// Delete removes a calendar.
+func (r *SLACalendarRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.calendars[id]; !ok {
+		return sla.ErrCalendarNotFound
+	}
+	delete(r.calendars, id)
+	return nil
+}
+
+// findScope returns the calendar covering the category, or the global one
+// for nil. The caller holds the lock.
+func (r *SLACalendarRepository) findScope(categoryID *uuid.UUID) *sla.Calendar {
+	for _, c := range r.calendars {
+		if categoryID == nil && c.IsGlobal() {
+			return c
+		}
+		if equalID(categoryID, c.CategoryID()) {
+			return c
+		}
+	}
+	return nil
+}
+
+func cloneCalendar(c *sla.Calendar) *sla.Calendar {
+	return sla.Reconstitute(sla.ReconstituteParams{
+		ID:           c.ID(),
+		Name:         c.Name(),
+		Timezone:     c.Timezone(),
+		CategoryID:   copyID(c.CategoryID()),
+		WorkingHours: append([]sla.WorkingHours(nil), c.WorkingHours()...),
+		Holidays:     append([]sla.Holiday(nil), c.Holidays()...),
+		CreatedAt:    c.CreatedAt(),
+		UpdatedAt:    c.UpdatedAt(),
+	})
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// SLAPolicyRepository is an in-memory sla.PolicyRepository.
+type SLAPolicyRepository struct {
+	mu       sync.RWMutex
+	policies map[uuid.UUID]*sla.Policy
+}
+
+var _ sla.PolicyRepository = (*SLAPolicyRepository)(nil)
+
+// NewSLAPolicyRepository creates an empty in-memory SLA policy repository.
+func NewSLAPolicyRepository() *SLAPolicyRepository {
+	return &SLAPolicyRepository{policies: make(map[uuid.UUID]*sla.Policy)}
+}
+
+// FindByID returns a copy of the stored policy.
+func (r *SLAPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.policies[id]
+	if !ok {
+		return nil, sla.ErrPolicyNotFound
+	}
+	return clonePolicy(p), nil
+}
+
+// FindForTicket returns the category policy of the priority or the global one.
+func (r *SLAPolicyRepository) FindForTicket(ctx context.Context, categoryID *uuid.UUID, priority shared.TicketPriority) (*sla.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if categoryID != nil {
+		if p := r.findScope(categoryID, priority); p != nil {
+			return clonePolicy(p), nil
+		}
+	}
+	if p := r.findScope(nil, priority); p != nil {
+		return clonePolicy(p), nil
+	}
+	return nil, sla.ErrPolicyNotFound
+}
+
+// List returns all policies, global ones first.
+func (r *SLAPolicyRepository) List(ctx context.Context) ([]*sla.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := make([]*sla.Policy, 0, len(r.policies))
+	for _, p := range r.policies {
+		policies = append(policies, clonePolicy(p))
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		a, b := policies[i], policies[j]
+		if a.IsGlobal() != b.IsGlobal() {
+			return a.IsGlobal()
+		}
+		if !a.IsGlobal() && *a.CategoryID() != *b.CategoryID() {
+			return a.CategoryID().String() < b.CategoryID().String()
+		}
+		if a.Priority() != b.Priority() {
+			return a.Priority() < b.Priority()
+		}
+		return a.Name() < b.Name()
+	})
+	return policies, nil
+}
+
+// Save stores a copy of the policy.
+func (r *SLAPolicyRepository) Save(ctx context.Context, p *sla.Policy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing := r.findScope(p.CategoryID(), p.Priority()); existing != nil && existing.ID() != p.ID() {
+		return sla.ErrPolicyConflict
+	}
+	r.policies[p.ID()] = clonePolicy(p)
+	return nil
+}
+
+// Delete removes a policy.
+func (r *SLAPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.policies[id]; !ok {
+		return sla.ErrPolicyNotFound
+	}
+	delete(r.policies, id)
+	return nil
+}
+
+// findScope returns the policy of the priority covering the category, or the
+// global one for nil. The caller holds the lock.
+func (r *SLAPolicyRepository) findScope(categoryID *uuid.UUID, priority shared.TicketPriority) *sla.Policy {
+	for _, p := range r.policies {
+		if p.Priority() != priority {
+			continue
+		}
+		if categoryID == nil && p.IsGlobal() {
+			return p
+		}
+		if equalID(categoryID, p.CategoryID()) {
+			return p
+		}
+	}
+	return nil
+}
+
+func clonePolicy(p *sla.Policy) *sla.Policy {
+	return sla.ReconstitutePolicy(sla.PolicyReconstituteParams{
+		ID:         p.ID(),
+		Name:       p.Name(),
+		Priority:   string(p.Priority()),
+		CategoryID: copyID(p.CategoryID()),
+		Targets:    p.Targets(),
+		CreatedAt:  p.CreatedAt(),
+		UpdatedAt:  p.UpdatedAt(),
+	})
+}
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+)
+
+// TeamRepository is an in-memory team.Repository.
+type TeamRepository struct {
+	mu    sync.RWMutex
+	teams map[uuid.UUID]*team.Team
+}
+
+var _ team.Repository = (*TeamRepository)(nil)
+
+// NewTeamRepository creates an empty in-memory team repository.
+func NewTeamRepository() *TeamRepository {
+	return &TeamRepository{teams: make(map[uuid.UUID]*team.Team)}
+}
+
+// FindByID returns a copy of the stored team.
+func (r *TeamRepository) FindByID(ctx context.Context, id uuid.UUID) (*team.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.teams[id]
+	if !ok {
+		return nil, team.ErrTeamNotFound
+	}
+	return cloneTeam(t), nil
+}
+
+// FindByKey returns a copy of the team with the key.
+func (r *TeamRepository) FindByKey(ctx context.Context, key string) (*team.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.teams {
+		if t.Key() == key {
+			return cloneTeam(t), nil
+		}
+	}
+	return nil, team.ErrTeamNotFound
+}
+
+// FindForCategory returns a copy of the team that has the category.
+func (r *TeamRepository) FindForCategory(ctx context.Context, categoryID uuid.UUID) (*team.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t := r.findCategory(categoryID); t != nil {
+		return cloneTeam(t), nil
+	}
+	return nil, team.ErrTeamNotFound
+}
+
+// List returns all teams ordered by name.
+func (r *TeamRepository) List(ctx context.Context) ([]*team.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]*team.Team, 0, len(r.teams))
+	for _, t := range r.teams {
+		teams = append(teams, cloneTeam(t))
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Name() != teams[j].Name() {
+			return teams[i].Name() < teams[j].Name()
+		}
+		return teams[i].Key() < teams[j].Key()
+	})
+	return teams, nil
+}
+
+// Save stores a copy of the team.
+func (r *TeamRepository) Save(ctx context.Context, t *team.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.teams {
+		if existing.ID() != t.ID() && existing.Key() == t.Key() {
+			return team.ErrTeamConflict
+		}
+	}
+	for _, id := range t.CategoryIDs() {
+		if existing := r.findCategory(id); existing != nil && existing.ID() != t.ID() {
+			return team.ErrTeamConflict
+		}
+	}
+
+	r.teams[t.ID()] = cloneTeam(t)
+	return nil
+}
+
+// Delete removes a team.
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[id]; !ok {
+		return team.ErrTeamNotFound
+	}
+	delete(r.teams, id)
+	return nil
+}
+
+// findCategory returns the team that has the category. The caller holds
+// the lock.
+func (r *TeamRepository) findCategory(categoryID uuid.UUID) *team.Team {
+	for _, t := range r.teams {
+		for _, id := range t.CategoryIDs() {
+			if id == categoryID {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+func cloneTeam(t *team.Team) *team.Team {
+	return team.Reconstitute(team.ReconstituteParams{
+		ID:          t.ID(),
+		Key:         t.Key(),
+		Name:        t.Name(),
+		Description: t.Description(),
+		CategoryIDs: append([]uuid.UUID(nil), t.CategoryIDs()...),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	})
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTeamRepository(t *testing.T) {
+	repotest.TeamRepositoryContract(t, func(t *testing.T) team.Repository {
+		return NewTeamRepository()
+	})
+}
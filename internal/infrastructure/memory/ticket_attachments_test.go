@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTicketAttachments(t *testing.T) {
+	repotest.TicketAttachmentsContract(t, func(t *testing.T) (ticket.Repository, attachment.Repository) {
+		repo := NewTicketRepository()
+		return repo, repo.Attachments()
+	})
+}
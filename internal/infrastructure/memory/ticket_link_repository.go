@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+)
+
+// TicketLinkRepository is an in-memory link.Repository.
+type TicketLinkRepository struct {
+	mu    sync.RWMutex
+	links map[uuid.UUID]*link.Link
+}
+
+var _ link.Repository = (*TicketLinkRepository)(nil)
+
+// NewTicketLinkRepository creates an empty in-memory ticket link repository.
+func NewTicketLinkRepository() *TicketLinkRepository {
+	return &TicketLinkRepository{links: make(map[uuid.UUID]*link.Link)}
+}
+
+// FindByID returns a copy of the stored link.
+func (r *TicketLinkRepository) FindByID(ctx context.Context, id uuid.UUID) (*link.Link, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	l, ok := r.links[id]
+	if !ok {
+		return nil, link.ErrLinkNotFound
+	}
+	return cloneTicketLink(l), nil
+}
+
+// ListForTicket returns the links of the ticket, oldest first.
+func (r *TicketLinkRepository) ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]*link.Link, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	links := make([]*link.Link, 0)
+	for _, l := range r.links {
+		if l.Involves(ticketID) {
+			links = append(links, cloneTicketLink(l))
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].CreatedAt().Before(links[j].CreatedAt())
+	})
+	return links, nil
+}
+
+// Save stores a copy of the link.
+func (r *TicketLinkRepository) Save(ctx context.Context, l *link.Link) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.links {
+		if existing.Involves(l.TicketID()) && existing.Involves(l.LinkedTicketID()) {
+			return link.ErrLinkExists
+		}
+	}
+	r.links[l.ID()] = cloneTicketLink(l)
+	return nil
+}
+
+// Delete removes a link.
+func (r *TicketLinkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.links[id]; !ok {
+		return link.ErrLinkNotFound
+	}
+	delete(r.links, id)
+	return nil
+}
+
+func cloneTicketLink(l *link.Link) *link.Link {
+	return link.Reconstitute(link.ReconstituteParams{
+		ID:             l.ID(),
+		TicketID:       l.TicketID(),
+		LinkedTicketID: l.LinkedTicketID(),
+		Type:           string(l.Type()),
+		CreatedBy:      copyID(l.CreatedBy()),
+		CreatedByName:  l.CreatedByName(),
+		CreatedAt:      l.CreatedAt(),
+	})
+}
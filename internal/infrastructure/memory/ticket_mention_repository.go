@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+)
+
+// TicketMentionRepository is an in-memory mention.Repository.
+type TicketMentionRepository struct {
+	mu       sync.RWMutex
+	mentions map[uuid.UUID]*mention.Mention
+}
+
+var _ mention.Repository = (*TicketMentionRepository)(nil)
+
+// NewTicketMentionRepository creates an empty in-memory ticket mention
+// repository.
+func NewTicketMentionRepository() *TicketMentionRepository {
+	return &TicketMentionRepository{mentions: make(map[uuid.UUID]*mention.Mention)}
+}
+
+// FindByID returns a copy of the stored mention.
+func (r *TicketMentionRepository) FindByID(ctx context.Context, id uuid.UUID) (*mention.Mention, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.mentions[id]
+	if !ok {
+		return nil, mention.ErrMentionNotFound
+	}
+	return cloneTicketMention(m), nil
+}
+
+// List returns a page of the agent's mentions, newest first.
+func (r *TicketMentionRepository) List(ctx context.Context, filter mention.Filter) ([]*mention.Mention, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*mention.Mention, 0)
+	for _, m := range r.mentions {
+		if m.AgentID() != filter.AgentID {
+			continue
+		}
+		if filter.UnreadOnly && m.IsRead() {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt().Equal(matches[j].CreatedAt()) {
+			return matches[i].CreatedAt().After(matches[j].CreatedAt())
+		}
+		return matches[i].ID().String() > matches[j].ID().String()
+	})
+
+	total := int64(len(matches))
+	filter.Normalize()
+	start := filter.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + filter.PerPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	page := make([]*mention.Mention, 0, end-start)
+	for _, m := range matches[start:end] {
+		page = append(page, cloneTicketMention(m))
+	}
+	return page, total, nil
+}
+
+// Save stores a copy of the mention.
+func (r *TicketMentionRepository) Save(ctx context.Context, m *mention.Mention) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mentions[m.ID()] = cloneTicketMention(m)
+	return nil
+}
+
+func cloneTicketMention(m *mention.Mention) *mention.Mention {
+	return mention.Reconstitute(mention.ReconstituteParams{
+		ID:              m.ID(),
+		TicketID:        m.TicketID(),
+		MessageID:       m.MessageID(),
+		AgentID:         m.AgentID(),
+		MentionedBy:     copyID(m.MentionedBy()),
+		MentionedByName: m.MentionedByName(),
+		ReadAt:          copyTime(m.ReadAt()),
+		CreatedAt:       m.CreatedAt(),
+	})
+}
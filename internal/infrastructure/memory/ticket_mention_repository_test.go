@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTicketMentionRepository(t *testing.T) {
+	repotest.TicketMentionRepositoryContract(t, func(t *testing.T) mention.Repository {
+		return NewTicketMentionRepository()
+	})
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// TicketNumberSequence is an in-memory ticket.NumberSequence.
+type TicketNumberSequence struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+var _ ticket.NumberSequence = (*TicketNumberSequence)(nil)
+
+// NewTicketNumberSequence creates an in-memory sequence with all counters at zero.
+func NewTicketNumberSequence() *TicketNumberSequence {
+	return &TicketNumberSequence{counters: make(map[string]int64)}
+}
+
+// Next increments and returns the scope's counter for the day.
+func (s *TicketNumberSequence) Next(ctx context.Context, scope string, day time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := scope + "|" + day.Format("2006-01-02")
+	s.counters[key]++
+	return s.counters[key], nil
+}
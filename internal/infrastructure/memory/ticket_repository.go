@@ -0,0 +1,628 @@
+// Package memory contains thread-safe in-memory repository implementations
+// for running the service without a database.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+)
+
+// TicketRepository is an in-memory ticket.Repository. Saved domain events
+// are queued in its Outbox; merges and splits move the files in its
+// Attachments along with their tickets' messages.
+type TicketRepository struct {
+	mu          sync.RWMutex
+	tickets     map[uuid.UUID]*ticket.Ticket
+	outbox      *Outbox
+	attachments *AttachmentRepository
+}
+
+var _ ticket.Repository = (*TicketRepository)(nil)
+
+// NewTicketRepository creates an empty in-memory ticket repository.
+func NewTicketRepository() *TicketRepository {
+	return &TicketRepository{
+		tickets:     make(map[uuid.UUID]*ticket.Ticket),
+		outbox:      NewOutbox(),
+		attachments: NewAttachmentRepository(),
+	}
+}
+
+// Outbox returns the outbox the repository writes domain events to.
+func (r *TicketRepository) Outbox() *Outbox {
+	return r.outbox
+}
+
+// Attachments returns the attachment repository holding the files of the
+// repository's tickets.
+func (r *TicketRepository) Attachments() *AttachmentRepository {
+	return r.attachments
+}
+
+// FindByID returns a copy of the stored ticket.
+func (r *TicketRepository) FindByID(ctx context.Context, id uuid.UUID) (*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tickets[id]
+	if !ok {
+		return nil, ticket.ErrTicketNotFound
+	}
+	return cloneTicket(t, true), nil
+}
+
+// FindByNumber returns a copy of the ticket with the given number.
+func (r *TicketRepository) FindByNumber(ctx context.Context, number string) (*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tickets {
+		if t.TicketNumber().Value() == number {
+			return cloneTicket(t, true), nil
+		}
+	}
+	return nil, ticket.ErrTicketNotFound
+}
+
+// FindByMessageID returns a copy of the ticket holding a message with one
+// of the Message-IDs.
+func (r *TicketRepository) FindByMessageID(ctx context.Context, messageIDs []string) (*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tickets {
+		for _, m := range t.Messages() {
+			if m.MessageID() == "" {
+				continue
+			}
+			for _, id := range messageIDs {
+				if m.MessageID() == id {
+					return cloneTicket(t, true), nil
+				}
+			}
+		}
+	}
+	return nil, ticket.ErrTicketNotFound
+}
+
+// List returns a page of tickets matching the filter, newest first.
+func (r *TicketRepository) List(ctx context.Context, filter ticket.Filter) ([]*ticket.Ticket, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*ticket.Ticket, 0)
+	for _, t := range r.tickets {
+		if matchesFilter(t, filter) {
+			matches = append(matches, t)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt().After(matches[j].CreatedAt())
+	})
+
+	total := int64(len(matches))
+	filter.Normalize()
+	start := filter.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + filter.PerPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	page := make([]*ticket.Ticket, 0, end-start)
+	for _, t := range matches[start:end] {
+		page = append(page, cloneTicket(t, false))
+	}
+	return page, total, nil
+}
+
+// Save stores a copy of the ticket. Messages and status history are
+// append-only, so entries already stored are kept as they are.
+func (r *TicketRepository) Save(ctx context.Context, t *ticket.Ticket) error {
+	outbox, err := events.Encode(t, t.Events())
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkVersion(t); err != nil {
+		return err
+	}
+	t.MarkSaved()
+	r.outbox.append(outbox)
+	stored := cloneTicket(t, true)
+	if existing, ok := r.tickets[t.ID()]; ok {
+		stored = mergeTicket(stored, existing)
+	}
+	r.tickets[t.ID()] = stored
+	return nil
+}
+
+// SaveMerged stores copies of the merged tickets. The sources replace their
+// stored copies, since their messages moved to the target.
+func (r *TicketRepository) SaveMerged(ctx context.Context, target *ticket.Ticket, sources []*ticket.Ticket) error {
+	tickets := append([]*ticket.Ticket{target}, sources...)
+	outboxes := make([][]events.OutboxMessage, 0, len(tickets))
+	for _, t := range tickets {
+		outbox, err := events.Encode(t, t.Events())
+		if err != nil {
+			return err
+		}
+		outboxes = append(outboxes, outbox)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range tickets {
+		if err := r.checkVersion(t); err != nil {
+			return err
+		}
+	}
+	for _, t := range tickets {
+		t.MarkSaved()
+	}
+	for _, outbox := range outboxes {
+		r.outbox.append(outbox)
+	}
+	stored := cloneTicket(target, true)
+	if existing, ok := r.tickets[target.ID()]; ok {
+		stored = mergeTicket(stored, existing)
+	}
+	r.tickets[target.ID()] = stored
+	for _, s := range sources {
+		r.tickets[s.ID()] = cloneTicket(s, true)
+		r.attachments.move(target.ID(), func(a *attachment.Attachment) bool {
+			return a.TicketID() == s.ID()
+		})
+	}
+	return nil
+}
+
+// SaveSplit stores copies of both tickets. Source replaces its stored copy,
+// since some of its messages moved to the split ticket.
+func (r *TicketRepository) SaveSplit(ctx context.Context, source, split *ticket.Ticket) error {
+	splitOutbox, err := events.Encode(split, split.Events())
+	if err != nil {
+		return err
+	}
+	sourceOutbox, err := events.Encode(source, source.Events())
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkVersion(split); err != nil {
+		return err
+	}
+	if err := r.checkVersion(source); err != nil {
+		return err
+	}
+	split.MarkSaved()
+	source.MarkSaved()
+	r.outbox.append(splitOutbox)
+	r.outbox.append(sourceOutbox)
+	r.tickets[split.ID()] = cloneTicket(split, true)
+	r.tickets[source.ID()] = cloneTicket(source, true)
+	moved := make(map[uuid.UUID]bool, len(split.Messages()))
+	for _, msg := range split.Messages() {
+		moved[msg.ID()] = true
+	}
+	r.attachments.move(split.ID(), func(a *attachment.Attachment) bool {
+		return a.TicketID() == source.ID() && a.MessageID() != nil && moved[*a.MessageID()]
+	})
+	return nil
+}
+
+// checkVersion returns ticket.ErrConflict unless t is new or a copy of the
+// stored version of its ticket.
+func (r *TicketRepository) checkVersion(t *ticket.Ticket) error {
+	existing, ok := r.tickets[t.ID()]
+	if (!ok && t.Version() == 0) || (ok && existing.Version() == t.Version()) {
+		return nil
+	}
+	return ticket.ErrConflict
+}
+
+// ListSLADue returns active tickets with an unrecorded breach or an unsent
+// warning on either SLA clock, earliest deadline first, then by ID.
+func (r *TicketRepository) ListSLADue(ctx context.Context, query ticket.SLADueQuery, limit int) ([]*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*ticket.Ticket, 0)
+	for _, t := range r.tickets {
+		if !t.IsActive() {
+			continue
+		}
+		firstResponseDue := t.FirstResponseAt() == nil && t.FirstResponseBreachedAt() == nil &&
+			slaClockDue(t.FirstResponseDeadline(), t.FirstResponseWarnedAt(), query.Now, query.WarnBefore)
+		resolutionDue := t.SLABreachedAt() == nil &&
+			slaClockDue(t.SLADeadline(), t.SLAWarnedAt(), query.Now, query.WarnBefore)
+		if !firstResponseDue && !resolutionDue {
+			continue
+		}
+		if query.After != nil && !dueAfter(t, *query.After) {
+			continue
+		}
+		due = append(due, t)
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return dueAfter(due[j], ticket.SLACursor{Deadline: due[i].EarliestSLADeadline(), ID: due[i].ID()})
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	tickets := make([]*ticket.Ticket, 0, len(due))
+	for _, t := range due {
+		tickets = append(tickets, cloneWithHistory(t))
+	}
+	return tickets, nil
+}
+
+// slaClockDue checks if a deadline has passed, or falls before warnBefore
+// without a warning sent.
+func slaClockDue(deadline, warnedAt *time.Time, now, warnBefore time.Time) bool {
+	if deadline == nil {
+		return false
+	}
+	return deadline.Before(now) || (warnedAt == nil && deadline.Before(warnBefore))
+}
+
+// dueAfter checks if the ticket comes after the cursor in the SLA due order.
+func dueAfter(t *ticket.Ticket, cursor ticket.SLACursor) bool {
+	deadline := t.EarliestSLADeadline()
+	if !deadline.Equal(cursor.Deadline) {
+		return deadline.After(cursor.Deadline)
+	}
+	id := t.ID()
+	return bytes.Compare(id[:], cursor.ID[:]) > 0
+}
+
+// ListIdle returns the tickets matching the query, idle longest first, then
+// by ID.
+func (r *TicketRepository) ListIdle(ctx context.Context, query ticket.IdleQuery, limit int) ([]*ticket.Ticket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	excluded := make(map[uuid.UUID]bool, len(query.ExcludeCategoryIDs))
+	for _, id := range query.ExcludeCategoryIDs {
+		excluded[id] = true
+	}
+
+	idle := make([]*ticket.Ticket, 0)
+	for _, t := range r.tickets {
+		if t.Status() != query.Status || !t.IdleSince().Before(query.IdleBefore) {
+			continue
+		}
+		if query.CategoryID != nil && !equalID(query.CategoryID, t.CategoryID()) {
+			continue
+		}
+		if query.CategoryID == nil && t.CategoryID() != nil && excluded[*t.CategoryID()] {
+			continue
+		}
+		if (query.NotReminded && t.RemindedAt() != nil) || (query.NotStale && t.StaleAt() != nil) {
+			continue
+		}
+		if query.After != nil && !idleAfter(t, *query.After) {
+			continue
+		}
+		idle = append(idle, t)
+	}
+	sort.Slice(idle, func(i, j int) bool {
+		return idleAfter(idle[j], ticket.IdleCursor{IdleSince: idle[i].IdleSince(), ID: idle[i].ID()})
+	})
+
+	if len(idle) > limit {
+		idle = idle[:limit]
+	}
+	tickets := make([]*ticket.Ticket, 0, len(idle))
+	for _, t := range idle {
+		tickets = append(tickets, cloneWithHistory(t))
+	}
+	return tickets, nil
+}
+
+// idleAfter checks if the ticket comes after the cursor in the idle order.
+func idleAfter(t *ticket.Ticket, cursor ticket.IdleCursor) bool {
+	if !t.IdleSince().Equal(cursor.IdleSince) {
+		return t.IdleSince().After(cursor.IdleSince)
+	}
+	id := t.ID()
+	return bytes.Compare(id[:], cursor.ID[:]) > 0
+}
+
+// Stats computes ticket statistics over the stored tickets.
+func (r *TicketRepository) Stats(ctx context.Context) (*ticket.Stats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &ticket.Stats{}
+	var responseHours, resolutionHours float64
+	var responded, resolved, rated, satisfied int64
+
+	for _, t := range r.tickets {
+		switch t.Status() {
+		case shared.StatusOpen:
+			stats.TotalOpen++
+		case shared.StatusPending:
+			stats.TotalPending++
+		case shared.StatusInProgress:
+			stats.TotalInProgress++
+		case shared.StatusResolved:
+			stats.TotalResolved++
+		case shared.StatusClosed:
+			stats.TotalClosed++
+		}
+		if t.IsOverdue() {
+			stats.TotalOverdue++
+		}
+		if t.FirstResponseAt() != nil {
+			responseHours += t.FirstResponseAt().Sub(t.CreatedAt()).Hours()
+			responded++
+		}
+		if t.ResolvedAt() != nil {
+			resolutionHours += t.ResolvedAt().Sub(t.CreatedAt()).Hours()
+			resolved++
+		}
+		if t.SatisfactionRating() != nil {
+			rated++
+			if *t.SatisfactionRating() >= 4 {
+				satisfied++
+			}
+		}
+	}
+
+	if responded > 0 {
+		stats.AvgResponseTime = responseHours / float64(responded)
+	}
+	if resolved > 0 {
+		stats.AvgResolutionTime = resolutionHours / float64(resolved)
+	}
+	if rated > 0 {
+		stats.SatisfactionRate = float64(satisfied) / float64(rated) * 100
+	}
+	return stats, nil
+}
+
+// CountActiveByAssignee counts the active tickets of each assigned agent.
+func (r *TicketRepository) CountActiveByAssignee(ctx context.Context) (map[uuid.UUID]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	for _, t := range r.tickets {
+		if t.AssignedTo() != nil && t.IsActive() {
+			counts[*t.AssignedTo()]++
+		}
+	}
+	return counts, nil
+}
+
+// LastAssignee returns the agent of the customer's latest assigned ticket.
+func (r *TicketRepository) LastAssignee(ctx context.Context, customerID *uuid.UUID, guestEmail string) (*uuid.UUID, error) {
+	if customerID == nil && guestEmail == "" {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *ticket.Ticket
+	for _, t := range r.tickets {
+		if t.AssignedTo() == nil {
+			continue
+		}
+		if customerID != nil {
+			if !equalID(t.CustomerID(), customerID) {
+				continue
+			}
+		} else if t.CustomerID() != nil || !strings.EqualFold(t.GuestEmail(), guestEmail) {
+			continue
+		}
+		if latest == nil || t.CreatedAt().After(latest.CreatedAt()) {
+			latest = t
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return copyID(latest.AssignedTo()), nil
+}
+
+func matchesFilter(t *ticket.Ticket, f ticket.Filter) bool {
+	if f.Status != "" && string(t.Status()) != f.Status {
+		return false
+	}
+	if f.Priority != "" && string(t.Priority()) != f.Priority {
+		return false
+	}
+	if f.CategoryID != nil && !equalID(t.CategoryID(), f.CategoryID) {
+		return false
+	}
+	if f.CustomerID != nil && !equalID(t.CustomerID(), f.CustomerID) {
+		return false
+	}
+	if f.TeamID != nil && !equalID(t.TeamID(), f.TeamID) {
+		return false
+	}
+	if f.AssignedTo != nil && !equalID(t.AssignedTo(), f.AssignedTo) {
+		return false
+	}
+	if f.Unassigned && t.AssignedTo() != nil {
+		return false
+	}
+	if f.WatchedBy != nil && !t.IsWatchedBy(*f.WatchedBy) {
+		return false
+	}
+	if f.ActiveOnly && !t.IsActive() {
+		return false
+	}
+	if f.OrderID != nil && !equalID(t.OrderID(), f.OrderID) {
+		return false
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		fields := []string{t.Subject(), t.TicketNumber().Value(), t.GuestEmail(), t.GuestName()}
+		found := false
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), search) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.IsOverdue != nil && *f.IsOverdue {
+		if t.SLADeadline() == nil || !t.IsActive() || !t.SLADeadline().Before(time.Now()) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalID(a, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// cloneTicket copies a ticket so callers cannot mutate stored state.
+func cloneTicket(t *ticket.Ticket, withChildren bool) *ticket.Ticket {
+	params := ticketParams(t)
+	if withChildren {
+		params.Messages = append([]ticket.Message(nil), t.Messages()...)
+		params.StatusHistory = append([]ticket.StatusHistory(nil), t.StatusHistory()...)
+	}
+	return ticket.Reconstitute(params)
+}
+
+// cloneWithHistory copies a ticket with its status history but without its
+// messages, as the background jobs list it.
+func cloneWithHistory(t *ticket.Ticket) *ticket.Ticket {
+	params := ticketParams(t)
+	params.StatusHistory = append([]ticket.StatusHistory(nil), t.StatusHistory()...)
+	return ticket.Reconstitute(params)
+}
+
+// mergeTicket keeps stored messages and history that the saved copy lacks,
+// such as when a ticket loaded by List is saved.
+func mergeTicket(saved, existing *ticket.Ticket) *ticket.Ticket {
+	messages := append([]ticket.Message(nil), existing.Messages()...)
+	seen := make(map[uuid.UUID]bool, len(messages))
+	for _, m := range messages {
+		seen[m.ID()] = true
+	}
+	for _, m := range saved.Messages() {
+		if !seen[m.ID()] {
+			messages = append(messages, m)
+		}
+	}
+
+	history := append([]ticket.StatusHistory(nil), existing.StatusHistory()...)
+	seen = make(map[uuid.UUID]bool, len(history))
+	for _, h := range history {
+		seen[h.ID()] = true
+	}
+	for _, h := range saved.StatusHistory() {
+		if !seen[h.ID()] {
+			history = append(history, h)
+		}
+	}
+
+	params := ticketParams(saved)
+	params.Messages = messages
+	params.StatusHistory = history
+	return ticket.Reconstitute(params)
+}
+
+// ticketParams copies the ticket fields, leaving out messages and history.
+func ticketParams(t *ticket.Ticket) ticket.ReconstituteParams {
+	return ticket.ReconstituteParams{
+		ID:                      t.ID(),
+		TicketNumber:            t.TicketNumber().Value(),
+		CustomerID:              copyID(t.CustomerID()),
+		GuestEmail:              t.GuestEmail(),
+		GuestName:               t.GuestName(),
+		GuestPhone:              t.GuestPhone(),
+		CategoryID:              copyID(t.CategoryID()),
+		Subject:                 t.Subject(),
+		Channel:                 t.Channel(),
+		Locale:                  t.Locale(),
+		Brand:                   t.Brand(),
+		Status:                  string(t.Status()),
+		Priority:                string(t.Priority()),
+		TeamID:                  copyID(t.TeamID()),
+		AssignedTo:              copyID(t.AssignedTo()),
+		AssignmentReason:        t.AssignmentReason(),
+		OrderID:                 copyID(t.OrderID()),
+		OrderNumber:             t.OrderNumber(),
+		SLADeadline:             copyTime(t.SLADeadline()),
+		SLAWarnedAt:             copyTime(t.SLAWarnedAt()),
+		SLABreachedAt:           copyTime(t.SLABreachedAt()),
+		FirstResponseTarget:     t.SLATargets().FirstResponse,
+		ResolutionTarget:        t.SLATargets().Resolution,
+		FirstResponseDeadline:   copyTime(t.FirstResponseDeadline()),
+		FirstResponseWarnedAt:   copyTime(t.FirstResponseWarnedAt()),
+		FirstResponseBreachedAt: copyTime(t.FirstResponseBreachedAt()),
+		FirstResponseAt:         copyTime(t.FirstResponseAt()),
+		ResolvedAt:              copyTime(t.ResolvedAt()),
+		ClosedAt:                copyTime(t.ClosedAt()),
+		SatisfactionRating:      copyInt(t.SatisfactionRating()),
+		SatisfactionComment:     t.SatisfactionComment(),
+		Tags:                    append([]string(nil), t.Tags()...),
+		CreatedAt:               t.CreatedAt(),
+		UpdatedAt:               t.UpdatedAt(),
+		IdleSince:               t.IdleSince(),
+		RemindedAt:              copyTime(t.RemindedAt()),
+		StaleAt:                 copyTime(t.StaleAt()),
+		MergedInto:              copyID(t.MergedInto()),
+		Watchers:                append([]uuid.UUID(nil), t.Watchers()...),
+		CC:                      append([]string(nil), t.CC()...),
+		Version:                 t.Version(),
+		// Keep the workflow so active checks match the is_active column the
+		// GORM repository stores.
+		Workflow: t.Workflow(),
+	}
+}
+
+func copyID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
+
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
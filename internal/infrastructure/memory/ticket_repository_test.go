@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTicketRepository(t *testing.T) {
+	repotest.TicketRepositoryContract(t, func(t *testing.T) ticket.Repository {
+		return NewTicketRepository()
+	})
+}
@@ -0,0 +1,17 @@
+package memory
+
+import "context"
+
+// Transactor runs units of work for the in-memory repositories. They have
+// no transactions, so writes made before fn fails are kept.
+type Transactor struct{}
+
+// NewTransactor creates an in-memory transactor.
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+// WithinTransaction runs fn.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+// TriggerRuleRepository is an in-memory trigger.Repository.
+type TriggerRuleRepository struct {
+	mu    sync.RWMutex
+	rules map[uuid.UUID]*trigger.Rule
+}
+
+var _ trigger.Repository = (*TriggerRuleRepository)(nil)
+
+// NewTriggerRuleRepository creates an empty in-memory trigger rule repository.
+func NewTriggerRuleRepository() *TriggerRuleRepository {
+	return &TriggerRuleRepository{rules: make(map[uuid.UUID]*trigger.Rule)}
+}
+
+// FindByID returns a copy of the stored rule.
+func (r *TriggerRuleRepository) FindByID(ctx context.Context, id uuid.UUID) (*trigger.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, trigger.ErrRuleNotFound
+	}
+	return cloneTriggerRule(rule), nil
+}
+
+// List returns all rules by position, then name.
+func (r *TriggerRuleRepository) List(ctx context.Context) ([]*trigger.Rule, error) {
+	return r.find(func(*trigger.Rule) bool { return true }), nil
+}
+
+// ListForEvent returns the enabled rules of the event by position, then name.
+func (r *TriggerRuleRepository) ListForEvent(ctx context.Context, event trigger.Event) ([]*trigger.Rule, error) {
+	return r.find(func(rule *trigger.Rule) bool {
+		return rule.IsEnabled() && rule.RunsOn(event)
+	}), nil
+}
+
+func (r *TriggerRuleRepository) find(keep func(*trigger.Rule) bool) []*trigger.Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*trigger.Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		if keep(rule) {
+			rules = append(rules, cloneTriggerRule(rule))
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Position() != b.Position() {
+			return a.Position() < b.Position()
+		}
+		if a.Name() != b.Name() {
+			return a.Name() < b.Name()
+		}
+		return a.ID().String() < b.ID().String()
+	})
+	return rules
+}
+
+// Save stores a copy of the rule.
+func (r *TriggerRuleRepository) Save(ctx context.Context, rule *trigger.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID()] = cloneTriggerRule(rule)
+	return nil
+}
+
+// Delete removes a rule.
+func (r *TriggerRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return trigger.ErrRuleNotFound
+	}
+	delete(r.rules, id)
+	return nil
+}
+
+func cloneTriggerRule(rule *trigger.Rule) *trigger.Rule {
+	return trigger.Reconstitute(trigger.ReconstituteParams{
+		ID:          rule.ID(),
+		Name:        rule.Name(),
+		Description: rule.Description(),
+		Position:    rule.Position(),
+		Enabled:     rule.IsEnabled(),
+		Events:      append([]trigger.Event(nil), rule.Events()...),
+		Condition:   cloneTriggerCondition(rule.Condition()),
+		Actions:     append([]trigger.Action(nil), rule.Actions()...),
+		CreatedAt:   rule.CreatedAt(),
+		UpdatedAt:   rule.UpdatedAt(),
+	})
+}
+
+func cloneTriggerCondition(c trigger.Condition) trigger.Condition {
+	clone := trigger.Condition{Field: c.Field, Operator: c.Operator, Value: c.Value}
+	for _, sub := range c.All {
+		clone.All = append(clone.All, cloneTriggerCondition(sub))
+	}
+	for _, sub := range c.Any {
+		clone.Any = append(clone.Any, cloneTriggerCondition(sub))
+	}
+	return clone
+}
+
+// TriggerFiringLog is an in-memory trigger.FiringLog.
+type TriggerFiringLog struct {
+	mu      sync.RWMutex
+	firings []trigger.Firing
+}
+
+var _ trigger.FiringLog = (*TriggerFiringLog)(nil)
+
+// NewTriggerFiringLog creates an empty in-memory trigger firing log.
+func NewTriggerFiringLog() *TriggerFiringLog {
+	return &TriggerFiringLog{}
+}
+
+// Record appends copies of the firings.
+func (l *TriggerFiringLog) Record(ctx context.Context, firings []trigger.Firing) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, f := range firings {
+		l.firings = append(l.firings, cloneTriggerFiring(f))
+	}
+	return nil
+}
+
+// List returns a page of firings matching the filter, newest first.
+func (l *TriggerFiringLog) List(ctx context.Context, filter trigger.FiringFilter) ([]trigger.Firing, int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	matches := make([]trigger.Firing, 0)
+	for i := len(l.firings) - 1; i >= 0; i-- {
+		f := l.firings[i]
+		if filter.RuleID != nil && f.RuleID() != *filter.RuleID {
+			continue
+		}
+		if filter.TicketID != nil && f.TicketID() != *filter.TicketID {
+			continue
+		}
+		matches = append(matches, f)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].FiredAt().After(matches[j].FiredAt())
+	})
+
+	total := int64(len(matches))
+	filter.Normalize()
+	start := filter.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + filter.PerPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	page := make([]trigger.Firing, 0, end-start)
+	for _, f := range matches[start:end] {
+		page = append(page, cloneTriggerFiring(f))
+	}
+	return page, total, nil
+}
+
+func cloneTriggerFiring(f trigger.Firing) trigger.Firing {
+	return trigger.ReconstituteFiring(trigger.FiringParams{
+		ID:       f.ID(),
+		RuleID:   f.RuleID(),
+		RuleName: f.RuleName(),
+		TicketID: f.TicketID(),
+		Event:    f.Event(),
+		Actions:  append([]trigger.ActionResult(nil), f.Actions()...),
+		FiredAt:  f.FiredAt(),
+	})
+}
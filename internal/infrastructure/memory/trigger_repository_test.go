@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTriggerRuleRepository(t *testing.T) {
+	repotest.TriggerRuleRepositoryContract(t, func(t *testing.T) trigger.Repository {
+		return NewTriggerRuleRepository()
+	})
+}
+
+func TestTriggerFiringLog(t *testing.T) {
+	repotest.TriggerFiringLogContract(t, func(t *testing.T) trigger.FiringLog {
+		return NewTriggerFiringLog()
+	})
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+// WorkflowRepository is an in-memory workflow.Repository.
+type WorkflowRepository struct {
+	mu        sync.RWMutex
+	workflows map[uuid.UUID]*workflow.Workflow
+}
+
+var _ workflow.Repository = (*WorkflowRepository)(nil)
+
+// NewWorkflowRepository creates an empty in-memory workflow repository.
+func NewWorkflowRepository() *WorkflowRepository {
+	return &WorkflowRepository{workflows: make(map[uuid.UUID]*workflow.Workflow)}
+}
+
+// FindByID returns a copy of the stored workflow.
+func (r *WorkflowRepository) FindByID(ctx context.Context, id uuid.UUID) (*workflow.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.workflows[id]
+	if !ok {
+		return nil, workflow.ErrWorkflowNotFound
+	}
+	return cloneWorkflow(w)
+}
+
+// FindForCategory returns the workflow assigned to the category or the default one.
+func (r *WorkflowRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*workflow.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if categoryID != nil {
+		if w := r.findCategory(*categoryID); w != nil {
+			return cloneWorkflow(w)
+		}
+	}
+	for _, w := range r.workflows {
+		if w.IsDefault() {
+			return cloneWorkflow(w)
+		}
+	}
+	return nil, workflow.ErrWorkflowNotFound
+}
+
+// List returns all workflows, the default one first.
+func (r *WorkflowRepository) List(ctx context.Context) ([]*workflow.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflows := make([]*workflow.Workflow, 0, len(r.workflows))
+	for _, w := range r.workflows {
+		c, err := cloneWorkflow(w)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, c)
+	}
+	sort.Slice(workflows, func(i, j int) bool {
+		a, b := workflows[i], workflows[j]
+		if a.IsDefault() != b.IsDefault() {
+			return a.IsDefault()
+		}
+		return a.Name() < b.Name()
+	})
+	return workflows, nil
+}
+
+// Save stores a copy of the workflow.
+func (r *WorkflowRepository) Save(ctx context.Context, w *workflow.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.workflows {
+		if existing.ID() == w.ID() {
+			continue
+		}
+		if w.IsDefault() && existing.IsDefault() {
+			return workflow.ErrWorkflowConflict
+		}
+	}
+	for _, id := range w.CategoryIDs() {
+		if existing := r.findCategory(id); existing != nil && existing.ID() != w.ID() {
+			return workflow.ErrWorkflowConflict
+		}
+	}
+
+	c, err := cloneWorkflow(w)
+	if err != nil {
+		return err
+	}
+	r.workflows[w.ID()] = c
+	return nil
+}
+
+// Delete removes a workflow.
+func (r *WorkflowRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.workflows[id]; !ok {
+		return workflow.ErrWorkflowNotFound
+	}
+	delete(r.workflows, id)
+	return nil
+}
+
+// findCategory returns the workflow assigned to the category. The caller
+// holds the lock.
+func (r *WorkflowRepository) findCategory(categoryID uuid.UUID) *workflow.Workflow {
+	for _, w := range r.workflows {
+		for _, id := range w.CategoryIDs() {
+			if id == categoryID {
+				return w
+			}
+		}
+	}
+	return nil
+}
+
+func cloneWorkflow(w *workflow.Workflow) (*workflow.Workflow, error) {
+	return workflow.Reconstitute(workflow.ReconstituteParams{
+		ID:          w.ID(),
+		Name:        w.Name(),
+		IsDefault:   w.IsDefault(),
+		CategoryIDs: append([]uuid.UUID(nil), w.CategoryIDs()...),
+		States:      append([]workflow.State(nil), w.States()...),
+		Transitions: append([]workflow.Transition(nil), w.Transitions()...),
+		CreatedAt:   w.CreatedAt(),
+		UpdatedAt:   w.UpdatedAt(),
+	})
+}
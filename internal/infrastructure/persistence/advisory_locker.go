@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AdvisoryLocker elects a single runner across replicas with PostgreSQL
+// session-level advisory locks. A lock held by a crashed replica is released
+// when its connection closes.
+type AdvisoryLocker struct {
+	db *gorm.DB
+}
+
+// NewAdvisoryLocker creates a new advisory locker
+func NewAdvisoryLocker(db *gorm.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+// TryLock acquires the named lock without waiting
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Session locks belong to a connection, so pin one until unlock.
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", name)
+		conn.Close()
+	}
+	return unlock, true, nil
+}
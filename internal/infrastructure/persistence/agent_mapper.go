@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/lib/pq"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// agentSkillRecord is the JSON form of an agent skill.
+type agentSkillRecord struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// toAgentDomain converts an AgentModel into an Agent entity.
+func toAgentDomain(m *AgentModel) (*agent.Agent, error) {
+	var records []agentSkillRecord
+	if m.Skills != "" {
+		if err := json.Unmarshal([]byte(m.Skills), &records); err != nil {
+			return nil, err
+		}
+	}
+	skills := make([]agent.Skill, 0, len(records))
+	for _, r := range records {
+		skills = append(skills, agent.Skill{Name: r.Name, Level: r.Level})
+	}
+
+	return agent.Reconstitute(agent.ReconstituteParams{
+		ID:                   m.ID,
+		Name:                 m.Name,
+		Email:                m.Email,
+		Role:                 m.Role,
+		Teams:                m.Teams,
+		Skills:               skills,
+		Status:               m.Status,
+		MaxConcurrentTickets: m.MaxConcurrentTickets,
+		Timezone:             m.Timezone,
+		Signature:            m.Signature,
+		LastAssignedAt:       m.LastAssignedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}), nil
+}
+
+// toAgentModel converts an Agent entity into its persistence model.
+func toAgentModel(a *agent.Agent) *AgentModel {
+	records := make([]agentSkillRecord, 0, len(a.Skills()))
+	for _, s := range a.Skills() {
+		records = append(records, agentSkillRecord{Name: s.Name, Level: s.Level})
+	}
+	skills, _ := json.Marshal(records)
+
+	return &AgentModel{
+		ID:                   a.ID(),
+		Name:                 a.Name(),
+		Email:                a.Email(),
+		Role:                 a.Role(),
+		Teams:                pq.StringArray(a.Teams()),
+		Skills:               string(skills),
+		Status:               string(a.Status()),
+		MaxConcurrentTickets: a.MaxConcurrentTickets(),
+		Timezone:             a.Timezone(),
+		Signature:            a.Signature(),
+		LastAssignedAt:       a.LastAssignedAt(),
+		CreatedAt:            a.CreatedAt(),
+		UpdatedAt:            a.UpdatedAt(),
+	}
+}
@@ -0,0 +1,30 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AgentModel is the GORM persistence model for a support agent.
+type AgentModel struct {
+	ID                   uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"` // the agent's user ID
+	Name                 string         `json:"name" gorm:"size:255;not null"`
+	Email                string         `json:"email" gorm:"size:255"`
+	Role                 string         `json:"role" gorm:"size:50"`
+	Teams                pq.StringArray `json:"teams" gorm:"type:text[]"`
+	Skills               string         `json:"skills" gorm:"type:jsonb;not null;default:'[]'"` // JSON array
+	Status               string         `json:"status" gorm:"size:20;not null;default:'offline'"`
+	MaxConcurrentTickets int            `json:"max_concurrent_tickets" gorm:"not null;default:0"` // 0 means no limit
+	Timezone             string         `json:"timezone" gorm:"size:64;not null;default:'UTC'"`
+	Signature            string         `json:"signature" gorm:"type:text"`
+	LastAssignedAt       *time.Time     `json:"last_assigned_at"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (AgentModel) TableName() string {
+	return "support.agents"
+}
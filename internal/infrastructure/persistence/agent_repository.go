@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"gorm.io/gorm"
+)
+
+// AgentRepository handles database operations for support agents
+type AgentRepository struct {
+	db *gorm.DB
+}
+
+var _ agent.Repository = (*AgentRepository)(nil)
+
+// NewAgentRepository creates a new agent repository
+func NewAgentRepository(db *gorm.DB) *AgentRepository {
+	return &AgentRepository{db: db}
+}
+
+// FindByID retrieves an agent by ID
+func (r *AgentRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent.Agent, error) {
+	var model AgentModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, agent.ErrAgentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toAgentDomain(&model)
+}
+
+// FindByIDs retrieves the agents with the given IDs
+func (r *AgentRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*agent.Agent, error) {
+	if len(ids) == 0 {
+		return make([]*agent.Agent, 0), nil
+	}
+
+	var models []AgentModel
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	agents := make([]*agent.Agent, 0, len(models))
+	for i := range models {
+		a, err := toAgentDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, a)
+	}
+	return agents, nil
+}
+
+// List retrieves the agents matching the filter
+func (r *AgentRepository) List(ctx context.Context, filter agent.Filter) ([]*agent.Agent, error) {
+	var models []AgentModel
+	query := r.db.WithContext(ctx).Order("name ASC, id ASC")
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Team != "" {
+		query = query.Where("? = ANY(teams)", filter.Team)
+	}
+
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	agents := make([]*agent.Agent, 0, len(models))
+	for i := range models {
+		a, err := toAgentDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, a)
+	}
+	return agents, nil
+}
+
+// Save creates or updates an agent
+func (r *AgentRepository) Save(ctx context.Context, a *agent.Agent) error {
+	return r.db.WithContext(ctx).Save(toAgentModel(a)).Error
+}
+
+// RecordAssignment updates only the agent's last assignment time
+func (r *AgentRepository) RecordAssignment(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&AgentModel{}).Where("id = ?", id).Update("last_assigned_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return agent.ErrAgentNotFound
+	}
+	return nil
+}
+
+// Delete deletes an agent
+func (r *AgentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&AgentModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return agent.ErrAgentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestAgentRepository(t *testing.T) {
+	repotest.AgentRepositoryContract(t, func(t *testing.T) agent.Repository {
+		return NewAgentRepository(testDB(t))
+	})
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+)
+
+// toAttachmentDomain converts an AttachmentModel into an Attachment entity.
+func toAttachmentDomain(m *AttachmentModel) *attachment.Attachment {
+	ticketID := uuid.Nil
+	if m.TicketID != nil {
+		ticketID = *m.TicketID
+	}
+	return attachment.Reconstitute(attachment.ReconstituteParams{
+		ID:           m.ID,
+		TicketID:     ticketID,
+		MessageID:    m.MessageID,
+		Name:         m.Name,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Checksum:     m.Checksum,
+		StorageKey:   m.StorageKey,
+		UploadedBy:   m.UploadedBy,
+		UploaderType: m.UploaderType,
+		CreatedAt:    m.CreatedAt,
+	})
+}
+
+// toAttachmentModel converts an Attachment entity into its persistence model.
+func toAttachmentModel(a *attachment.Attachment) *AttachmentModel {
+	var ticketID *uuid.UUID
+	if id := a.TicketID(); id != uuid.Nil {
+		ticketID = &id
+	}
+	return &AttachmentModel{
+		ID:           a.ID(),
+		TicketID:     ticketID,
+		MessageID:    a.MessageID(),
+		Name:         a.Name(),
+		ContentType:  a.ContentType(),
+		Size:         a.Size(),
+		Checksum:     a.Checksum(),
+		StorageKey:   a.StorageKey(),
+		UploadedBy:   a.UploadedBy(),
+		UploaderType: a.UploaderType().String(),
+		CreatedAt:    a.CreatedAt(),
+	}
+}
+
+// toAttachmentDownloadDomain converts an AttachmentDownloadModel into a
+// Download.
+func toAttachmentDownloadDomain(m *AttachmentDownloadModel) attachment.Download {
+	return attachment.ReconstituteDownload(attachment.DownloadParams{
+		ID:           m.ID,
+		AttachmentID: m.AttachmentID,
+		TicketID:     m.TicketID,
+		UserID:       m.UserID,
+		UserType:     m.UserType,
+		IPAddress:    m.IPAddress,
+		UserAgent:    m.UserAgent,
+		DownloadedAt: m.DownloadedAt,
+	})
+}
+
+// toAttachmentDownloadModel converts a Download into its persistence model.
+func toAttachmentDownloadModel(d attachment.Download) *AttachmentDownloadModel {
+	return &AttachmentDownloadModel{
+		ID:           d.ID(),
+		AttachmentID: d.AttachmentID(),
+		TicketID:     d.TicketID(),
+		UserID:       d.UserID(),
+		UserType:     d.UserType().String(),
+		IPAddress:    d.IPAddress(),
+		UserAgent:    d.UserAgent(),
+		DownloadedAt: d.DownloadedAt(),
+	}
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttachmentModel is the GORM persistence model for a stored attachment.
+// TicketID is null for email attachments not yet posted on a ticket.
+type AttachmentModel struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID     *uuid.UUID `json:"ticket_id" gorm:"type:uuid;index"`
+	MessageID    *uuid.UUID `json:"message_id" gorm:"type:uuid"`
+	Name         string     `json:"name" gorm:"size:255;not null"`
+	ContentType  string     `json:"content_type" gorm:"size:255;not null"`
+	Size         int64      `json:"size" gorm:"not null"`
+	Checksum     string     `json:"checksum" gorm:"size:64;not null"`
+	StorageKey   string     `json:"storage_key" gorm:"size:500;not null"`
+	UploadedBy   *uuid.UUID `json:"uploaded_by" gorm:"type:uuid"`
+	UploaderType string     `json:"uploader_type" gorm:"size:20;not null"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name.
+func (AttachmentModel) TableName() string {
+	return "support.attachments"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *AttachmentModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// AttachmentDownloadModel is the GORM persistence model for an entry in
+// the audit log of attachment downloads.
+type AttachmentDownloadModel struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	AttachmentID uuid.UUID `json:"attachment_id" gorm:"type:uuid;not null;index"`
+	TicketID     uuid.UUID `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	UserType     string    `json:"user_type" gorm:"size:20;not null"`
+	IPAddress    string    `json:"ip_address" gorm:"size:45"`
+	UserAgent    string    `json:"user_agent" gorm:"size:500"`
+	DownloadedAt time.Time `json:"downloaded_at" gorm:"not null"`
+}
+
+// TableName specifies the table name.
+func (AttachmentDownloadModel) TableName() string {
+	return "support.attachment_downloads"
+}
@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository handles database operations for attachment metadata
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+var _ attachment.Repository = (*AttachmentRepository)(nil)
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// FindByID retrieves an attachment by ID
+func (r *AttachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	var model AttachmentModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, attachment.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toAttachmentDomain(&model), nil
+}
+
+// ListByTicket retrieves the attachments of a ticket, oldest first
+func (r *AttachmentRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*attachment.Attachment, error) {
+	var models []AttachmentModel
+	err := r.db.WithContext(ctx).
+		Where("ticket_id = ?", ticketID).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*attachment.Attachment, 0, len(models))
+	for i := range models {
+		attachments = append(attachments, toAttachmentDomain(&models[i]))
+	}
+	return attachments, nil
+}
+
+// TicketUsage sums the sizes of a ticket's attachments
+func (r *AttachmentRepository) TicketUsage(ctx context.Context, ticketID uuid.UUID) (int64, error) {
+	var usage struct {
+		Total int64
+	}
+	err := r.db.WithContext(ctx).Model(&AttachmentModel{}).
+		Select("COALESCE(SUM(size), 0) as total").
+		Where("ticket_id = ?", ticketID).
+		Scan(&usage).Error
+	if err != nil {
+		return 0, err
+	}
+	return usage.Total, nil
+}
+
+// Save creates or updates an attachment
+func (r *AttachmentRepository) Save(ctx context.Context, a *attachment.Attachment) error {
+	return r.db.WithContext(ctx).Save(toAttachmentModel(a)).Error
+}
+
+// Delete removes an attachment
+func (r *AttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&AttachmentModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return attachment.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// AttachmentDownloadLog handles database operations for the audit log of
+// attachment downloads
+type AttachmentDownloadLog struct {
+	db *gorm.DB
+}
+
+var _ attachment.DownloadLog = (*AttachmentDownloadLog)(nil)
+
+// NewAttachmentDownloadLog creates a new attachment download log
+func NewAttachmentDownloadLog(db *gorm.DB) *AttachmentDownloadLog {
+	return &AttachmentDownloadLog{db: db}
+}
+
+// Record inserts a download
+func (l *AttachmentDownloadLog) Record(ctx context.Context, d attachment.Download) error {
+	return l.db.WithContext(ctx).Create(toAttachmentDownloadModel(d)).Error
+}
+
+// List retrieves downloads with filters
+func (l *AttachmentDownloadLog) List(ctx context.Context, filter attachment.DownloadFilter) ([]attachment.Download, int64, error) {
+	var models []AttachmentDownloadModel
+	var total int64
+
+	query := l.db.WithContext(ctx).Model(&AttachmentDownloadModel{})
+	if filter.AttachmentID != nil {
+		query = query.Where("attachment_id = ?", filter.AttachmentID)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	filter.Normalize()
+	err := query.
+		Order("downloaded_at DESC, id DESC").
+		Offset(filter.Offset()).
+		Limit(filter.PerPage).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	downloads := make([]attachment.Download, 0, len(models))
+	for i := range models {
+		downloads = append(downloads, toAttachmentDownloadDomain(&models[i]))
+	}
+	return downloads, total, nil
+}
@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestAttachmentRepository(t *testing.T) {
+	repotest.AttachmentRepositoryContract(t, func(t *testing.T) attachment.Repository {
+		return NewAttachmentRepository(testDB(t))
+	})
+}
+
+func TestAttachmentDownloadLog(t *testing.T) {
+	repotest.AttachmentDownloadLogContract(t, func(t *testing.T) attachment.DownloadLog {
+		return NewAttachmentDownloadLog(testDB(t))
+	})
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+)
+
+// toAutomationPolicyDomain converts an AutomationPolicyModel into a Policy entity.
+func toAutomationPolicyDomain(m *AutomationPolicyModel) *automation.Policy {
+	return automation.ReconstitutePolicy(automation.PolicyReconstituteParams{
+		ID:         m.ID,
+		Name:       m.Name,
+		CategoryID: m.CategoryID,
+		Schedule: automation.Schedule{
+			AutoCloseAfter:       time.Duration(m.AutoCloseMinutes) * time.Minute,
+			PendingReminderAfter: time.Duration(m.PendingReminderMinutes) * time.Minute,
+			PendingResolveAfter:  time.Duration(m.PendingResolveMinutes) * time.Minute,
+			StaleAfter:           time.Duration(m.StaleMinutes) * time.Minute,
+		},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	})
+}
+
+// toAutomationPolicyModel converts a Policy entity into its persistence model.
+func toAutomationPolicyModel(p *automation.Policy) *AutomationPolicyModel {
+	s := p.Schedule()
+	return &AutomationPolicyModel{
+		ID:                     p.ID(),
+		Name:                   p.Name(),
+		CategoryID:             p.CategoryID(),
+		AutoCloseMinutes:       int(s.AutoCloseAfter / time.Minute),
+		PendingReminderMinutes: int(s.PendingReminderAfter / time.Minute),
+		PendingResolveMinutes:  int(s.PendingResolveAfter / time.Minute),
+		StaleMinutes:           int(s.StaleAfter / time.Minute),
+		CreatedAt:              p.CreatedAt(),
+		UpdatedAt:              p.UpdatedAt(),
+	}
+}
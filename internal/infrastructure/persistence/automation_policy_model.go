@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AutomationPolicyModel is the GORM persistence model for an automation policy.
+type AutomationPolicyModel struct {
+	ID                     uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name                   string     `json:"name" gorm:"size:100;not null"`
+	CategoryID             *uuid.UUID `json:"category_id" gorm:"type:uuid"`
+	AutoCloseMinutes       int        `json:"auto_close_minutes" gorm:"not null;default:0"`
+	PendingReminderMinutes int        `json:"pending_reminder_minutes" gorm:"not null;default:0"`
+	PendingResolveMinutes  int        `json:"pending_resolve_minutes" gorm:"not null;default:0"`
+	StaleMinutes           int        `json:"stale_minutes" gorm:"not null;default:0"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (AutomationPolicyModel) TableName() string {
+	return "support.automation_policies"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *AutomationPolicyModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"gorm.io/gorm"
+)
+
+// AutomationPolicyRepository handles database operations for automation policies
+type AutomationPolicyRepository struct {
+	db *gorm.DB
+}
+
+var _ automation.Repository = (*AutomationPolicyRepository)(nil)
+
+// NewAutomationPolicyRepository creates a new automation policy repository
+func NewAutomationPolicyRepository(db *gorm.DB) *AutomationPolicyRepository {
+	return &AutomationPolicyRepository{db: db}
+}
+
+// FindByID retrieves a policy by ID
+func (r *AutomationPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*automation.Policy, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindForCategory retrieves the category policy or the global one
+func (r *AutomationPolicyRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*automation.Policy, error) {
+	if categoryID != nil {
+		p, err := r.find(ctx, "category_id = ?", *categoryID)
+		if !errors.Is(err, automation.ErrPolicyNotFound) {
+			return p, err
+		}
+	}
+	return r.find(ctx, "category_id IS NULL")
+}
+
+func (r *AutomationPolicyRepository) find(ctx context.Context, query string, args ...interface{}) (*automation.Policy, error) {
+	var model AutomationPolicyModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, automation.ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toAutomationPolicyDomain(&model), nil
+}
+
+// List retrieves all policies, the global one first
+func (r *AutomationPolicyRepository) List(ctx context.Context) ([]*automation.Policy, error) {
+	var models []AutomationPolicyModel
+	err := r.db.WithContext(ctx).
+		Order("category_id IS NOT NULL, category_id, name").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*automation.Policy, 0, len(models))
+	for i := range models {
+		policies = append(policies, toAutomationPolicyDomain(&models[i]))
+	}
+	return policies, nil
+}
+
+// Save creates or updates a policy
+func (r *AutomationPolicyRepository) Save(ctx context.Context, p *automation.Policy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one policy may cover a category
+		scope := tx.Model(&AutomationPolicyModel{}).Where("id <> ?", p.ID())
+		if p.CategoryID() != nil {
+			scope = scope.Where("category_id = ?", *p.CategoryID())
+		} else {
+			scope = scope.Where("category_id IS NULL")
+		}
+		var conflicts int64
+		if err := scope.Count(&conflicts).Error; err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return automation.ErrPolicyConflict
+		}
+
+		return tx.Save(toAutomationPolicyModel(p)).Error
+	})
+}
+
+// Delete deletes a policy
+func (r *AutomationPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&AutomationPolicyModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return automation.ErrPolicyNotFound
+	}
+	return nil
+}
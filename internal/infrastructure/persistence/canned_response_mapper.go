@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+)
+
+// toCannedResponseDomain converts a CannedResponseModel into a CannedResponse entity.
+func toCannedResponseDomain(m *CannedResponseModel) *response.CannedResponse {
+	return response.Reconstitute(response.ReconstituteParams{
+		ID:         m.ID,
+		Title:      m.Title,
+		Content:    m.Content,
+		CategoryID: m.CategoryID,
+		Shortcut:   m.Shortcut,
+		IsActive:   m.IsActive,
+		UsageCount: m.UsageCount,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	})
+}
+
+// toCannedResponseModel converts a CannedResponse entity into its persistence model.
+func toCannedResponseModel(r *response.CannedResponse) *CannedResponseModel {
+	return &CannedResponseModel{
+		ID:         r.ID(),
+		Title:      r.Title(),
+		Content:    r.Content(),
+		CategoryID: r.CategoryID(),
+		Shortcut:   r.Shortcut(),
+		IsActive:   r.IsActive(),
+		UsageCount: r.UsageCount(),
+		CreatedBy:  r.CreatedBy(),
+		CreatedAt:  r.CreatedAt(),
+		UpdatedAt:  r.UpdatedAt(),
+	}
+}
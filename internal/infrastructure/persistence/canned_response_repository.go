@@ -2,9 +2,10 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
 	"gorm.io/gorm"
 )
 
@@ -13,14 +14,16 @@ type CannedResponseRepository struct {
 	db *gorm.DB
 }
 
+var _ response.Repository = (*CannedResponseRepository)(nil)
+
 // NewCannedResponseRepository creates a new canned response repository
 func NewCannedResponseRepository(db *gorm.DB) *CannedResponseRepository {
 	return &CannedResponseRepository{db: db}
 }
 
 // List retrieves all canned responses
-func (r *CannedResponseRepository) List(ctx context.Context, categoryID *uuid.UUID, onlyActive bool) ([]domain.CannedResponse, error) {
-	var responses []domain.CannedResponse
+func (r *CannedResponseRepository) List(ctx context.Context, categoryID *uuid.UUID, onlyActive bool) ([]*response.CannedResponse, error) {
+	var models []CannedResponseModel
 	query := r.db.WithContext(ctx).Order("usage_count DESC, title ASC")
 
 	if categoryID != nil {
@@ -31,51 +34,52 @@ func (r *CannedResponseRepository) List(ctx context.Context, categoryID *uuid.UU
 		query = query.Where("is_active = ?", true)
 	}
 
-	err := query.Find(&responses).Error
-	return responses, err
-}
-
-// GetByID retrieves a canned response by ID
-func (r *CannedResponseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CannedResponse, error) {
-	var response domain.CannedResponse
-	err := r.db.WithContext(ctx).First(&response, "id = ?", id).Error
-	if err != nil {
+	if err := query.Find(&models).Error; err != nil {
 		return nil, err
 	}
-	return &response, nil
+
+	responses := make([]*response.CannedResponse, 0, len(models))
+	for i := range models {
+		responses = append(responses, toCannedResponseDomain(&models[i]))
+	}
+	return responses, nil
+}
+
+// FindByID retrieves a canned response by ID
+func (r *CannedResponseRepository) FindByID(ctx context.Context, id uuid.UUID) (*response.CannedResponse, error) {
+	return r.find(ctx, "id = ?", id)
 }
 
-// GetByShortcut retrieves a canned response by shortcut
-func (r *CannedResponseRepository) GetByShortcut(ctx context.Context, shortcut string) (*domain.CannedResponse, error) {
-	var response domain.CannedResponse
-	err := r.db.WithContext(ctx).
-		Where("shortcut = ? AND is_active = ?", shortcut, true).
-		First(&response).Error
+// FindByShortcut retrieves an active canned response by shortcut
+func (r *CannedResponseRepository) FindByShortcut(ctx context.Context, shortcut string) (*response.CannedResponse, error) {
+	return r.find(ctx, "shortcut = ? AND is_active = ?", shortcut, true)
+}
+
+func (r *CannedResponseRepository) find(ctx context.Context, query string, args ...interface{}) (*response.CannedResponse, error) {
+	var model CannedResponseModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, response.ErrResponseNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &response, nil
-}
-
-// Create creates a new canned response
-func (r *CannedResponseRepository) Create(ctx context.Context, response *domain.CannedResponse) error {
-	return r.db.WithContext(ctx).Create(response).Error
+	return toCannedResponseDomain(&model), nil
 }
 
-// Update updates a canned response
-func (r *CannedResponseRepository) Update(ctx context.Context, response *domain.CannedResponse) error {
-	return r.db.WithContext(ctx).Save(response).Error
+// Save creates or updates a canned response
+func (r *CannedResponseRepository) Save(ctx context.Context, cr *response.CannedResponse) error {
+	return r.db.WithContext(ctx).Save(toCannedResponseModel(cr)).Error
 }
 
 // Delete deletes a canned response
 func (r *CannedResponseRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.CannedResponse{}, "id = ?", id).Error
-}
-
-// IncrementUsage increments the usage count of a canned response
-func (r *CannedResponseRepository) IncrementUsage(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Model(&domain.CannedResponse{}).
-		Where("id = ?", id).
-		UpdateColumn("usage_count", gorm.Expr("usage_count + 1")).Error
+	result := r.db.WithContext(ctx).Delete(&CannedResponseModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return response.ErrResponseNotFound
+	}
+	return nil
 }
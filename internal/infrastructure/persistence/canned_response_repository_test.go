@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestCannedResponseRepository(t *testing.T) {
+	repotest.CannedResponseRepositoryContract(t, func(t *testing.T) response.Repository {
+		return NewCannedResponseRepository(testDB(t))
+	})
+}
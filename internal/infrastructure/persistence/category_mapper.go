@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+)
+
+// toCategoryDomain converts a CategoryModel into a Category entity.
+func toCategoryDomain(m *CategoryModel) *category.Category {
+	return category.Reconstitute(category.ReconstituteParams{
+		ID:          m.ID,
+		Name:        m.Name,
+		NameMS:      m.NameMS,
+		Description: m.Description,
+		Icon:        m.Icon,
+		SLAHours:    m.SLAHours,
+		Priority:    m.Priority,
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	})
+}
+
+// toCategoryModel converts a Category entity into its persistence model.
+func toCategoryModel(c *category.Category) *CategoryModel {
+	return &CategoryModel{
+		ID:          c.ID(),
+		Name:        c.Name(),
+		NameMS:      c.NameMS(),
+		Description: c.Description(),
+		Icon:        c.Icon(),
+		SLAHours:    c.SLAHours(),
+		Priority:    c.Priority(),
+		IsActive:    c.IsActive(),
+		CreatedAt:   c.CreatedAt(),
+		UpdatedAt:   c.UpdatedAt(),
+	}
+}
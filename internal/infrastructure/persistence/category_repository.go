@@ -2,9 +2,10 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
 	"gorm.io/gorm"
 )
 
@@ -13,55 +14,59 @@ type CategoryRepository struct {
 	db *gorm.DB
 }
 
+var _ category.Repository = (*CategoryRepository)(nil)
+
 // NewCategoryRepository creates a new category repository
 func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-// List retrieves all active categories
-func (r *CategoryRepository) List(ctx context.Context, onlyActive bool) ([]domain.Category, error) {
-	var categories []domain.Category
+// List retrieves all categories, optionally only the active ones
+func (r *CategoryRepository) List(ctx context.Context, onlyActive bool) ([]*category.Category, error) {
+	var models []CategoryModel
 	query := r.db.WithContext(ctx).Order("priority ASC, name ASC")
 
 	if onlyActive {
 		query = query.Where("is_active = ?", true)
 	}
 
-	err := query.Find(&categories).Error
-	return categories, err
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	categories := make([]*category.Category, 0, len(models))
+	for i := range models {
+		categories = append(categories, toCategoryDomain(&models[i]))
+	}
+	return categories, nil
 }
 
-// GetByID retrieves a category by ID
-func (r *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
-	var category domain.Category
-	err := r.db.WithContext(ctx).First(&category, "id = ?", id).Error
+// FindByID retrieves a category by ID
+func (r *CategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*category.Category, error) {
+	var model CategoryModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, category.ErrCategoryNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &category, nil
+	return toCategoryDomain(&model), nil
 }
 
-// Create creates a new category
-func (r *CategoryRepository) Create(ctx context.Context, category *domain.Category) error {
-	return r.db.WithContext(ctx).Create(category).Error
-}
-
-// Update updates a category
-func (r *CategoryRepository) Update(ctx context.Context, category *domain.Category) error {
-	return r.db.WithContext(ctx).Save(category).Error
+// Save creates or updates a category
+func (r *CategoryRepository) Save(ctx context.Context, c *category.Category) error {
+	return r.db.WithContext(ctx).Save(toCategoryModel(c)).Error
 }
 
 // Delete deletes a category
 func (r *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Category{}, "id = ?", id).Error
-}
-
-// GetTicketCount returns the number of tickets in a category
-func (r *CategoryRepository) GetTicketCount(ctx context.Context, categoryID uuid.UUID) (int64, error) {
-	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&domain.Ticket{}).
-		Where("category_id = ?", categoryID).
-		Count(&count).Error
-	return count, err
+	result := r.db.WithContext(ctx).Delete(&CategoryModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return category.ErrCategoryNotFound
+	}
+	return nil
 }
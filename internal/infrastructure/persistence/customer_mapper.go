@@ -0,0 +1,27 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+)
+
+// toCustomerDomain converts a CustomerModel into a Customer entity.
+func toCustomerDomain(m *CustomerModel) *customer.Customer {
+	return customer.Reconstitute(customer.ReconstituteParams{
+		ID:        m.ID,
+		Email:     m.Email,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	})
+}
+
+// toCustomerModel converts a Customer entity into its persistence model.
+func toCustomerModel(c *customer.Customer) *CustomerModel {
+	return &CustomerModel{
+		ID:        c.ID(),
+		Email:     c.Email(),
+		Name:      c.Name(),
+		CreatedAt: c.CreatedAt(),
+		UpdatedAt: c.UpdatedAt(),
+	}
+}
@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomerModel is the GORM persistence model for a registered customer.
+type CustomerModel struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"` // the customer's user ID
+	Email     string    `json:"email" gorm:"size:255;not null"`
+	Name      string    `json:"name" gorm:"size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (CustomerModel) TableName() string {
+	return "support.customers"
+}
@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"gorm.io/gorm"
+)
+
+// CustomerRepository handles database operations for registered customers
+type CustomerRepository struct {
+	db *gorm.DB
+}
+
+var _ customer.Repository = (*CustomerRepository)(nil)
+
+// NewCustomerRepository creates a new customer repository
+func NewCustomerRepository(db *gorm.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// FindByID retrieves a customer by ID
+func (r *CustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*customer.Customer, error) {
+	var model CustomerModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, customer.ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toCustomerDomain(&model), nil
+}
+
+// Save creates or updates a customer
+func (r *CustomerRepository) Save(ctx context.Context, c *customer.Customer) error {
+	return r.db.WithContext(ctx).Save(toCustomerModel(c)).Error
+}
+
+// Delete deletes a customer
+func (r *CustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&CustomerModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customer.ErrCustomerNotFound
+	}
+	return nil
+}
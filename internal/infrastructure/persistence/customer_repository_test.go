@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestCustomerRepository(t *testing.T) {
+	repotest.CustomerRepositoryContract(t, func(t *testing.T) customer.Repository {
+		return NewCustomerRepository(testDB(t))
+	})
+}
+
+func TestCustomerBackfill(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+
+	customerID := uuid.New()
+	tk, err := ticket.NewTicket(ticket.TicketParams{
+		TicketNumber: "TKT-20261016-0001",
+		CustomerID:   &customerID,
+		Subject:      "Where is my order?",
+	})
+	if err != nil {
+		t.Fatalf("NewTicket: %v", err)
+	}
+	msg := ticket.CreateCustomerMessage(tk.ID(), &customerID, "Jane", "jane@example.com", "first message")
+	if err := tk.AddMessage(msg); err != nil {
+		t.Fatalf("AddMessage: %v", err)
+	}
+	if err := NewTicketRepository(db).Save(ctx, tk); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// The schema is in place already, so this runs the backfill alone
+	migration, err := os.ReadFile(filepath.Join("..", "..", "..", "migrations", "024_create_customers.sql"))
+	if err != nil {
+		t.Fatalf("read migration: %v", err)
+	}
+	if err := db.Exec(string(migration)).Error; err != nil {
+		t.Fatalf("run migration: %v", err)
+	}
+
+	c, err := NewCustomerRepository(db).FindByID(ctx, customerID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if c.Email() != "jane@example.com" || c.Name() != "Jane" {
+		t.Fatalf("customer = %q <%s>, want Jane <jane@example.com>", c.Name(), c.Email())
+	}
+}
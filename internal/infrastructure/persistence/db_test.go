@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDSNEnv names the PostgreSQL database the repository tests run
+// against. The tests are skipped when it is not set; the database is
+// emptied by every test, so never point it at one holding real data.
+const testDSNEnv = "SUPPORT_TEST_DATABASE_DSN"
+
+var (
+	testDBOnce sync.Once
+	testDBConn *gorm.DB
+	testDBErr  error
+)
+
+// testDB returns a connection to the test database with every table of the
+// support schema emptied. The schema is created on first use: the tables
+// the service inherited are built from their models, the rest by running
+// the migrations in order.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	testDBOnce.Do(func() {
+		testDBConn, testDBErr = openTestDB(dsn)
+	})
+	if testDBErr != nil {
+		t.Fatalf("test database: %v", testDBErr)
+	}
+
+	var tables []string
+	if err := testDBConn.Raw("SELECT tablename FROM pg_tables WHERE schemaname = 'support'").Scan(&tables).Error; err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	for i, table := range tables {
+		tables[i] = "support." + table
+	}
+	if err := testDBConn.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("empty tables: %v", err)
+	}
+	return testDBConn
+}
+
+func openTestDB(dsn string) (*gorm.DB, error) {
+	// The simple protocol lets a migration file run as one statement batch
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: dsn, PreferSimpleProtocol: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec("CREATE SCHEMA IF NOT EXISTS support").Error; err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&CategoryModel{}, &CannedResponseModel{}, &TicketModel{}, &MessageModel{}, &StatusHistoryModel{}); err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Exec(string(migration)).Error; err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+	return db, nil
+}
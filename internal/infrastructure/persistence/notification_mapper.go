@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"github.com/lib/pq"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+)
+
+// toNotificationTemplateDomain converts a NotificationTemplateModel into a Template entity.
+func toNotificationTemplateDomain(m *NotificationTemplateModel) *notification.Template {
+	return notification.ReconstituteTemplate(notification.TemplateReconstituteParams{
+		ID:        m.ID,
+		Event:     m.Event,
+		Locale:    m.Locale,
+		Subject:   m.Subject,
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	})
+}
+
+// toNotificationTemplateModel converts a Template entity into its persistence model.
+func toNotificationTemplateModel(t *notification.Template) *NotificationTemplateModel {
+	return &NotificationTemplateModel{
+		ID:        t.ID(),
+		Event:     string(t.Event()),
+		Locale:    t.Locale(),
+		Subject:   t.Subject(),
+		Body:      t.Body(),
+		CreatedAt: t.CreatedAt(),
+		UpdatedAt: t.UpdatedAt(),
+	}
+}
+
+// toNotificationDeliveryDomain converts a NotificationDeliveryModel into a Delivery entity.
+func toNotificationDeliveryDomain(m *NotificationDeliveryModel) *notification.Delivery {
+	return notification.ReconstituteDelivery(notification.DeliveryReconstituteParams{
+		ID:            m.ID,
+		TicketID:      m.TicketID,
+		Event:         m.Event,
+		Locale:        m.Locale,
+		To:            m.ToAddress,
+		CC:            m.CCAddresses,
+		ReplyTo:       m.ReplyTo,
+		Subject:       m.Subject,
+		Body:          m.Body,
+		MessageID:     m.MessageID,
+		InReplyTo:     m.InReplyTo,
+		References:    m.References,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		SentAt:        m.SentAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	})
+}
+
+// toNotificationDeliveryModel converts a Delivery entity into its persistence
+// model. The lease is left unset, releasing it.
+func toNotificationDeliveryModel(d *notification.Delivery) *NotificationDeliveryModel {
+	return &NotificationDeliveryModel{
+		ID:            d.ID(),
+		TicketID:      d.TicketID(),
+		Event:         string(d.Event()),
+		Locale:        d.Locale(),
+		ToAddress:     d.To(),
+		CCAddresses:   append(pq.StringArray{}, d.CC()...),
+		ReplyTo:       d.ReplyTo(),
+		Subject:       d.Subject(),
+		Body:          d.Body(),
+		MessageID:     d.MessageID(),
+		InReplyTo:     d.InReplyTo(),
+		References:    append(pq.StringArray{}, d.References()...),
+		Status:        string(d.Status()),
+		Attempts:      d.Attempts(),
+		LastError:     d.LastError(),
+		NextAttemptAt: d.NextAttemptAt(),
+		SentAt:        d.SentAt(),
+		CreatedAt:     d.CreatedAt(),
+		UpdatedAt:     d.UpdatedAt(),
+	}
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// NotificationTemplateModel is the GORM persistence model for a notification template.
+type NotificationTemplateModel struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Event     string    `json:"event" gorm:"size:30;not null"`
+	Locale    string    `json:"locale" gorm:"size:35;not null"`
+	Subject   string    `json:"subject" gorm:"type:text;not null"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (NotificationTemplateModel) TableName() string {
+	return "support.notification_templates"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *NotificationTemplateModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// NotificationDeliveryModel is the GORM persistence model for a notification delivery.
+type NotificationDeliveryModel struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID      uuid.UUID      `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	Event         string         `json:"event" gorm:"size:30;not null"`
+	Locale        string         `json:"locale" gorm:"size:35;not null"`
+	ToAddress     string         `json:"to_address" gorm:"size:255;not null"`
+	CCAddresses   pq.StringArray `json:"cc_addresses" gorm:"column:cc_addresses;type:text[]"`
+	ReplyTo       string         `json:"reply_to" gorm:"size:255"`
+	Subject       string         `json:"subject" gorm:"type:text;not null"`
+	Body          string         `json:"body" gorm:"type:text;not null"`
+	MessageID     string         `json:"message_id" gorm:"type:text"`
+	InReplyTo     string         `json:"in_reply_to" gorm:"type:text"`
+	References    pq.StringArray `json:"references" gorm:"column:reference_ids;type:text[]"`
+	Status        string         `json:"status" gorm:"size:20;not null"`
+	Attempts      int            `json:"attempts" gorm:"not null;default:0"`
+	LastError     string         `json:"last_error" gorm:"type:text"`
+	NextAttemptAt time.Time      `json:"next_attempt_at" gorm:"not null"`
+	LockedUntil   *time.Time     `json:"locked_until"`
+	SentAt        *time.Time     `json:"sent_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (NotificationDeliveryModel) TableName() string {
+	return "support.notification_deliveries"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *NotificationDeliveryModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
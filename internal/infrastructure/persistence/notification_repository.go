@@ -0,0 +1,188 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"gorm.io/gorm"
+)
+
+// NotificationTemplateRepository handles database operations for notification templates
+type NotificationTemplateRepository struct {
+	db *gorm.DB
+}
+
+var _ notification.TemplateRepository = (*NotificationTemplateRepository)(nil)
+
+// NewNotificationTemplateRepository creates a new notification template repository
+func NewNotificationTemplateRepository(db *gorm.DB) *NotificationTemplateRepository {
+	return &NotificationTemplateRepository{db: db}
+}
+
+// FindByID retrieves a template by ID
+func (r *NotificationTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*notification.Template, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// Find retrieves the template of an event in a locale
+func (r *NotificationTemplateRepository) Find(ctx context.Context, event notification.Event, locale string) (*notification.Template, error) {
+	return r.find(ctx, "event = ? AND locale = ?", string(event), locale)
+}
+
+func (r *NotificationTemplateRepository) find(ctx context.Context, query string, args ...interface{}) (*notification.Template, error) {
+	var model NotificationTemplateModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notification.ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationTemplateDomain(&model), nil
+}
+
+// List retrieves templates by event and locale
+func (r *NotificationTemplateRepository) List(ctx context.Context, filter notification.TemplateFilter) ([]*notification.Template, error) {
+	query := r.db.WithContext(ctx).Model(&NotificationTemplateModel{})
+	if filter.Event != "" {
+		query = query.Where("event = ?", string(filter.Event))
+	}
+	if filter.Locale != "" {
+		query = query.Where("locale = ?", filter.Locale)
+	}
+
+	var models []NotificationTemplateModel
+	if err := query.Order("event, locale").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	templates := make([]*notification.Template, 0, len(models))
+	for i := range models {
+		templates = append(templates, toNotificationTemplateDomain(&models[i]))
+	}
+	return templates, nil
+}
+
+// Save creates or updates a template
+func (r *NotificationTemplateRepository) Save(ctx context.Context, t *notification.Template) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one template per event and locale
+		var conflicts int64
+		err := tx.Model(&NotificationTemplateModel{}).
+			Where("id <> ? AND event = ? AND locale = ?", t.ID(), string(t.Event()), t.Locale()).
+			Count(&conflicts).Error
+		if err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return notification.ErrTemplateConflict
+		}
+
+		return tx.Save(toNotificationTemplateModel(t)).Error
+	})
+}
+
+// Delete deletes a template
+func (r *NotificationTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&NotificationTemplateModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return notification.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// NotificationDeliveryRepository handles database operations for the notification send log
+type NotificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+var _ notification.DeliveryRepository = (*NotificationDeliveryRepository)(nil)
+
+// NewNotificationDeliveryRepository creates a new notification delivery repository
+func NewNotificationDeliveryRepository(db *gorm.DB) *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{db: db}
+}
+
+// Save creates or updates a delivery and releases its lease
+func (r *NotificationDeliveryRepository) Save(ctx context.Context, d *notification.Delivery) error {
+	return conn(ctx, r.db).Save(toNotificationDeliveryModel(d)).Error
+}
+
+// ListByTicket retrieves the deliveries of a ticket, oldest first
+func (r *NotificationDeliveryRepository) ListByTicket(ctx context.Context, ticketID uuid.UUID) ([]*notification.Delivery, error) {
+	var models []NotificationDeliveryModel
+	err := r.db.WithContext(ctx).
+		Where("ticket_id = ?", ticketID).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationDeliveries(models), nil
+}
+
+// FindByMessageID retrieves the delivery queued with one of the Message-IDs
+func (r *NotificationDeliveryRepository) FindByMessageID(ctx context.Context, messageIDs []string) (*notification.Delivery, error) {
+	if len(messageIDs) == 0 {
+		return nil, notification.ErrDeliveryNotFound
+	}
+	var model NotificationDeliveryModel
+	err := r.db.WithContext(ctx).
+		Where("message_id IN ?", messageIDs).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, notification.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationDeliveryDomain(&model), nil
+}
+
+// claimDueSQL leases the oldest due pending deliveries. Rows locked by
+// another sender are skipped.
+const claimDueSQL = `
+UPDATE support.notification_deliveries
+SET locked_until = ?
+WHERE id IN (
+	SELECT id FROM support.notification_deliveries
+	WHERE status = ?
+		AND next_attempt_at <= ?
+		AND (locked_until IS NULL OR locked_until <= ?)
+	ORDER BY created_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimDue leases up to limit due pending deliveries
+func (r *NotificationDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*notification.Delivery, error) {
+	now := time.Now()
+	var models []NotificationDeliveryModel
+	err := r.db.WithContext(ctx).
+		Raw(claimDueSQL, now.Add(lease), string(notification.DeliveryPending), now, now, limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].CreatedAt.Before(models[j].CreatedAt)
+	})
+	return toNotificationDeliveries(models), nil
+}
+
+func toNotificationDeliveries(models []NotificationDeliveryModel) []*notification.Delivery {
+	deliveries := make([]*notification.Delivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, toNotificationDeliveryDomain(&models[i]))
+	}
+	return deliveries
+}
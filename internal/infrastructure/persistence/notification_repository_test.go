@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestNotificationTemplateRepository(t *testing.T) {
+	repotest.NotificationTemplateRepositoryContract(t, func(t *testing.T) notification.TemplateRepository {
+		return NewNotificationTemplateRepository(testDB(t))
+	})
+}
+
+func TestNotificationDeliveryRepository(t *testing.T) {
+	repotest.NotificationDeliveryRepositoryContract(t, func(t *testing.T) notification.DeliveryRepository {
+		return NewNotificationDeliveryRepository(testDB(t))
+	})
+}
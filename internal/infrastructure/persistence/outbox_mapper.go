@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/events"
+)
+
+// toOutboxMessage converts an OutboxModel into a relay message.
+func toOutboxMessage(m *OutboxModel) events.OutboxMessage {
+	return events.OutboxMessage{
+		ID:          m.ID,
+		AggregateID: m.AggregateID,
+		EventType:   m.EventType,
+		Subject:     m.Subject,
+		Payload:     []byte(m.Payload),
+		Attempts:    m.Attempts,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// toOutboxModel converts a relay message into a pending OutboxModel.
+func toOutboxModel(msg events.OutboxMessage) OutboxModel {
+	return OutboxModel{
+		ID:          msg.ID,
+		AggregateID: msg.AggregateID,
+		EventType:   msg.EventType,
+		Subject:     msg.Subject,
+		Payload:     string(msg.Payload),
+		Status:      OutboxStatusPending,
+		Attempts:    msg.Attempts,
+		AvailableAt: msg.CreatedAt,
+		CreatedAt:   msg.CreatedAt,
+	}
+}
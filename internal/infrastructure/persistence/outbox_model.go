@@ -0,0 +1,36 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox message states.
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	OutboxStatusDead      = "dead"
+)
+
+// OutboxModel is the GORM persistence model for an outbox message.
+type OutboxModel struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Sequence    int64      `json:"sequence" gorm:"->;type:bigserial"`
+	AggregateID uuid.UUID  `json:"aggregate_id" gorm:"type:uuid;not null;index"`
+	EventType   string     `json:"event_type" gorm:"size:100;not null"`
+	Subject     string     `json:"subject" gorm:"size:255;not null"`
+	Payload     string     `json:"payload" gorm:"type:jsonb;not null"`
+	Status      string     `json:"status" gorm:"size:20;not null;default:pending;index"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	LastError   string     `json:"last_error" gorm:"type:text"`
+	AvailableAt time.Time  `json:"available_at" gorm:"not null"`
+	LockedUntil *time.Time `json:"locked_until"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name.
+func (OutboxModel) TableName() string {
+	return "support.outbox_events"
+}
@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository handles database operations for the event outbox
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+var _ events.OutboxStore = (*OutboxRepository)(nil)
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// claimPendingSQL leases the oldest pending message of each aggregate. Rows
+// locked by another relay are skipped, and a later message is never picked
+// while an earlier one of the same aggregate is still pending.
+const claimPendingSQL = `
+UPDATE support.outbox_events
+SET locked_until = ?
+WHERE id IN (
+	SELECT o.id FROM support.outbox_events o
+	WHERE o.status = ?
+		AND o.available_at <= ?
+		AND (o.locked_until IS NULL OR o.locked_until <= ?)
+		AND NOT EXISTS (
+			SELECT 1 FROM support.outbox_events p
+			WHERE p.aggregate_id = o.aggregate_id
+				AND p.status = ?
+				AND p.sequence < o.sequence
+		)
+	ORDER BY o.sequence
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// ClaimPending leases up to limit due messages, at most one per aggregate
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.OutboxMessage, error) {
+	now := time.Now()
+	var models []OutboxModel
+	err := r.db.WithContext(ctx).
+		Raw(claimPendingSQL, now.Add(lease), OutboxStatusPending, now, now, OutboxStatusPending, limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Sequence < models[j].Sequence
+	})
+	messages := make([]events.OutboxMessage, 0, len(models))
+	for i := range models {
+		messages = append(messages, toOutboxMessage(&models[i]))
+	}
+	return messages, nil
+}
+
+// MarkPublished records a successful publish
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       OutboxStatusPublished,
+			"published_at": time.Now(),
+			"locked_until": nil,
+		}).Error
+}
+
+// MarkFailed records a failed attempt and schedules a retry
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   lastErr,
+			"available_at": retryAt,
+			"locked_until": nil,
+		}).Error
+}
+
+// MarkDead moves a message to the dead-letter state
+func (r *OutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, lastErr string) error {
+	return r.db.WithContext(ctx).Model(&OutboxModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       OutboxStatusDead,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   lastErr,
+			"locked_until": nil,
+		}).Error
+}
+
+// appendOutbox writes the ticket's pending domain events to the outbox
+// within the caller's transaction.
+func appendOutbox(tx *gorm.DB, messages []events.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	models := make([]OutboxModel, 0, len(messages))
+	for _, msg := range messages {
+		models = append(models, toOutboxModel(msg))
+	}
+	return tx.Create(&models).Error
+}
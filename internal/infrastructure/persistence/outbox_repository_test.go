@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	repotest.OutboxContract(t, func(t *testing.T) (ticket.Repository, events.OutboxStore) {
+		db := testDB(t)
+		return NewTicketRepository(db), NewOutboxRepository(db)
+	})
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+)
+
+// routingConditionRecord is the JSON form of a routing rule's condition.
+type routingConditionRecord struct {
+	CategoryIDs []uuid.UUID `json:"category_ids,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	Languages   []string    `json:"languages,omitempty"`
+	HasOrder    *bool       `json:"has_order,omitempty"`
+}
+
+// routingSkillRecord is the JSON form of a required skill.
+type routingSkillRecord struct {
+	Name     string `json:"name"`
+	MinLevel int    `json:"min_level"`
+}
+
+// toRoutingRuleDomain converts a RoutingRuleModel into a Rule aggregate.
+func toRoutingRuleDomain(m *RoutingRuleModel) (*routing.Rule, error) {
+	var condition routingConditionRecord
+	if err := json.Unmarshal([]byte(m.Conditions), &condition); err != nil {
+		return nil, err
+	}
+
+	var skillRecords []routingSkillRecord
+	if err := json.Unmarshal([]byte(m.RequiredSkills), &skillRecords); err != nil {
+		return nil, err
+	}
+	skills := make([]routing.SkillRequirement, 0, len(skillRecords))
+	for _, r := range skillRecords {
+		skills = append(skills, routing.SkillRequirement{Name: r.Name, MinLevel: r.MinLevel})
+	}
+
+	return routing.Reconstitute(routing.ReconstituteParams{
+		ID:       m.ID,
+		Name:     m.Name,
+		Position: m.Position,
+		Enabled:  m.Enabled,
+		Condition: routing.Condition{
+			CategoryIDs: condition.CategoryIDs,
+			Tags:        condition.Tags,
+			Languages:   condition.Languages,
+			HasOrder:    condition.HasOrder,
+		},
+		RequiredSkills: skills,
+		FallbackTeamID: m.FallbackTeamID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}), nil
+}
+
+// toRoutingRuleModel converts a Rule aggregate into its persistence model.
+func toRoutingRuleModel(r *routing.Rule) *RoutingRuleModel {
+	c := r.Condition()
+	conditions, _ := json.Marshal(routingConditionRecord{
+		CategoryIDs: c.CategoryIDs,
+		Tags:        c.Tags,
+		Languages:   c.Languages,
+		HasOrder:    c.HasOrder,
+	})
+
+	skillRecords := make([]routingSkillRecord, 0, len(r.RequiredSkills()))
+	for _, s := range r.RequiredSkills() {
+		skillRecords = append(skillRecords, routingSkillRecord{Name: s.Name, MinLevel: s.MinLevel})
+	}
+	skills, _ := json.Marshal(skillRecords)
+
+	return &RoutingRuleModel{
+		ID:             r.ID(),
+		Name:           r.Name(),
+		Position:       r.Position(),
+		Enabled:        r.IsEnabled(),
+		Conditions:     string(conditions),
+		RequiredSkills: string(skills),
+		FallbackTeamID: r.FallbackTeamID(),
+		CreatedAt:      r.CreatedAt(),
+		UpdatedAt:      r.UpdatedAt(),
+	}
+}
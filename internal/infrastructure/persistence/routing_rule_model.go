@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoutingRuleModel is the GORM persistence model for a routing rule.
+type RoutingRuleModel struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name           string     `json:"name" gorm:"size:100;not null"`
+	Position       int        `json:"position" gorm:"not null;default:0"`
+	Enabled        bool       `json:"enabled" gorm:"not null"`
+	Conditions     string     `json:"conditions" gorm:"type:jsonb;not null;default:'{}'"`      // JSON object
+	RequiredSkills string     `json:"required_skills" gorm:"type:jsonb;not null;default:'[]'"` // JSON array
+	FallbackTeamID *uuid.UUID `json:"fallback_team_id" gorm:"type:uuid"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (RoutingRuleModel) TableName() string {
+	return "support.routing_rules"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *RoutingRuleModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
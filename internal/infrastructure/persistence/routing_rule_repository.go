@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"gorm.io/gorm"
+)
+
+// RoutingRuleRepository handles database operations for routing rules
+type RoutingRuleRepository struct {
+	db *gorm.DB
+}
+
+var _ routing.Repository = (*RoutingRuleRepository)(nil)
+
+// NewRoutingRuleRepository creates a new routing rule repository
+func NewRoutingRuleRepository(db *gorm.DB) *RoutingRuleRepository {
+	return &RoutingRuleRepository{db: db}
+}
+
+// FindByID retrieves a routing rule by ID
+func (r *RoutingRuleRepository) FindByID(ctx context.Context, id uuid.UUID) (*routing.Rule, error) {
+	var model RoutingRuleModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, routing.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRoutingRuleDomain(&model)
+}
+
+// List retrieves all routing rules in evaluation order
+func (r *RoutingRuleRepository) List(ctx context.Context) ([]*routing.Rule, error) {
+	var models []RoutingRuleModel
+	err := r.db.WithContext(ctx).
+		Order("position ASC, name ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*routing.Rule, 0, len(models))
+	for i := range models {
+		rule, err := toRoutingRuleDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Save creates or updates a routing rule
+func (r *RoutingRuleRepository) Save(ctx context.Context, rule *routing.Rule) error {
+	return r.db.WithContext(ctx).Save(toRoutingRuleModel(rule)).Error
+}
+
+// Delete deletes a routing rule
+func (r *RoutingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&RoutingRuleModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return routing.ErrRuleNotFound
+	}
+	return nil
+}
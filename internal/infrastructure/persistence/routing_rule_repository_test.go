@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestRoutingRuleRepository(t *testing.T) {
+	repotest.RoutingRuleRepositoryContract(t, func(t *testing.T) routing.Repository {
+		return NewRoutingRuleRepository(testDB(t))
+	})
+}
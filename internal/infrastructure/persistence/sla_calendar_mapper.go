@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// workingHoursRecord is the JSON form of a working window.
+type workingHoursRecord struct {
+	Weekday int `json:"weekday"`
+	Start   int `json:"start"`
+	End     int `json:"end"`
+}
+
+// toSLACalendarDomain converts an SLACalendarModel with its preloaded
+// holidays into a Calendar entity.
+func toSLACalendarDomain(m *SLACalendarModel) *sla.Calendar {
+	var records []workingHoursRecord
+	_ = json.Unmarshal([]byte(m.WorkingHours), &records)
+	hours := make([]sla.WorkingHours, 0, len(records))
+	for _, r := range records {
+		hours = append(hours, sla.WorkingHours{Weekday: time.Weekday(r.Weekday), Start: r.Start, End: r.End})
+	}
+
+	holidays := make([]sla.Holiday, 0, len(m.Holidays))
+	for _, h := range m.Holidays {
+		holidays = append(holidays, sla.Holiday{Date: normalizeDate(h.Date), Name: h.Name})
+	}
+
+	return sla.Reconstitute(sla.ReconstituteParams{
+		ID:           m.ID,
+		Name:         m.Name,
+		Timezone:     m.Timezone,
+		CategoryID:   m.CategoryID,
+		WorkingHours: hours,
+		Holidays:     holidays,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	})
+}
+
+// toSLACalendarModel converts a Calendar entity into its persistence model.
+// Holidays are mapped separately.
+func toSLACalendarModel(c *sla.Calendar) *SLACalendarModel {
+	records := make([]workingHoursRecord, 0, len(c.WorkingHours()))
+	for _, wh := range c.WorkingHours() {
+		records = append(records, workingHoursRecord{Weekday: int(wh.Weekday), Start: wh.Start, End: wh.End})
+	}
+	hours, _ := json.Marshal(records)
+
+	return &SLACalendarModel{
+		ID:           c.ID(),
+		Name:         c.Name(),
+		Timezone:     c.Timezone(),
+		CategoryID:   c.CategoryID(),
+		WorkingHours: string(hours),
+		CreatedAt:    c.CreatedAt(),
+		UpdatedAt:    c.UpdatedAt(),
+	}
+}
+
+// toSLAHolidayModels converts the calendar's holidays into persistence models.
+func toSLAHolidayModels(c *sla.Calendar) []SLAHolidayModel {
+	models := make([]SLAHolidayModel, 0, len(c.Holidays()))
+	for _, h := range c.Holidays() {
+		models = append(models, SLAHolidayModel{CalendarID: c.ID(), Date: h.Date, Name: h.Name})
+	}
+	return models
+}
+
+// normalizeDate trims the time part a DATE column may be scanned with.
+func normalizeDate(date string) string {
+	if len(date) > len(sla.DateLayout) {
+		return date[:len(sla.DateLayout)]
+	}
+	return date
+}
@@ -0,0 +1,45 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SLACalendarModel is the GORM persistence model for an SLA calendar.
+type SLACalendarModel struct {
+	ID           uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name         string            `json:"name" gorm:"size:100;not null"`
+	Timezone     string            `json:"timezone" gorm:"size:64;not null;default:'UTC'"`
+	CategoryID   *uuid.UUID        `json:"category_id" gorm:"type:uuid"`
+	WorkingHours string            `json:"working_hours" gorm:"type:jsonb;not null;default:'[]'"` // JSON array
+	Holidays     []SLAHolidayModel `json:"holidays,omitempty" gorm:"foreignKey:CalendarID"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (SLACalendarModel) TableName() string {
+	return "support.sla_calendars"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *SLACalendarModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// SLAHolidayModel is the GORM persistence model for a calendar holiday.
+type SLAHolidayModel struct {
+	CalendarID uuid.UUID `json:"calendar_id" gorm:"type:uuid;primaryKey"`
+	Date       string    `json:"date" gorm:"type:date;primaryKey"`
+	Name       string    `json:"name" gorm:"size:255"`
+}
+
+// TableName specifies the table name.
+func (SLAHolidayModel) TableName() string {
+	return "support.sla_holidays"
+}
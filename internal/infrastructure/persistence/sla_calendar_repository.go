@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"gorm.io/gorm"
+)
+
+// SLACalendarRepository handles database operations for SLA calendars
+type SLACalendarRepository struct {
+	db *gorm.DB
+}
+
+var _ sla.Repository = (*SLACalendarRepository)(nil)
+
+// NewSLACalendarRepository creates a new SLA calendar repository
+func NewSLACalendarRepository(db *gorm.DB) *SLACalendarRepository {
+	return &SLACalendarRepository{db: db}
+}
+
+// FindByID retrieves a calendar by ID
+func (r *SLACalendarRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Calendar, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindForCategory retrieves the category calendar or the global one
+func (r *SLACalendarRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*sla.Calendar, error) {
+	if categoryID != nil {
+		c, err := r.find(ctx, "category_id = ?", *categoryID)
+		if !errors.Is(err, sla.ErrCalendarNotFound) {
+			return c, err
+		}
+	}
+	return r.find(ctx, "category_id IS NULL")
+}
+
+func (r *SLACalendarRepository) find(ctx context.Context, query string, args ...interface{}) (*sla.Calendar, error) {
+	var model SLACalendarModel
+	err := r.db.WithContext(ctx).
+		Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		Where(query, args...).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, sla.ErrCalendarNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toSLACalendarDomain(&model), nil
+}
+
+// List retrieves all calendars, the global one first
+func (r *SLACalendarRepository) List(ctx context.Context) ([]*sla.Calendar, error) {
+	var models []SLACalendarModel
+	err := r.db.WithContext(ctx).
+		Preload("Holidays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		Order("category_id IS NOT NULL, name ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	calendars := make([]*sla.Calendar, 0, len(models))
+	for i := range models {
+		calendars = append(calendars, toSLACalendarDomain(&models[i]))
+	}
+	return calendars, nil
+}
+
+// Save creates or updates a calendar and replaces its holidays
+func (r *SLACalendarRepository) Save(ctx context.Context, c *sla.Calendar) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one calendar may cover a scope
+		scope := tx.Model(&SLACalendarModel{}).Where("id <> ?", c.ID())
+		if c.CategoryID() != nil {
+			scope = scope.Where("category_id = ?", *c.CategoryID())
+		} else {
+			scope = scope.Where("category_id IS NULL")
+		}
+		var conflicts int64
+		if err := scope.Count(&conflicts).Error; err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return sla.ErrCalendarConflict
+		}
+
+		if err := tx.Omit("Holidays").Save(toSLACalendarModel(c)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("calendar_id = ?", c.ID()).Delete(&SLAHolidayModel{}).Error; err != nil {
+			return err
+		}
+		if holidays := toSLAHolidayModels(c); len(holidays) > 0 {
+			return tx.Create(&holidays).Error
+		}
+		return nil
+	})
+}
+
+// Delete deletes a calendar and its holidays
+func (r *SLACalendarRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("calendar_id = ?", id).Delete(&SLAHolidayModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&SLACalendarModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sla.ErrCalendarNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestSLACalendarRepository(t *testing.T) {
+	repotest.SLACalendarRepositoryContract(t, func(t *testing.T) sla.Repository {
+		return NewSLACalendarRepository(testDB(t))
+	})
+}
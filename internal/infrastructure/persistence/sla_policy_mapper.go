@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// toSLAPolicyDomain converts an SLAPolicyModel into a Policy entity.
+func toSLAPolicyDomain(m *SLAPolicyModel) *sla.Policy {
+	return sla.ReconstitutePolicy(sla.PolicyReconstituteParams{
+		ID:         m.ID,
+		Name:       m.Name,
+		Priority:   m.Priority,
+		CategoryID: m.CategoryID,
+		Targets: sla.Targets{
+			FirstResponse: time.Duration(m.FirstResponseMinutes) * time.Minute,
+			Resolution:    time.Duration(m.ResolutionMinutes) * time.Minute,
+		},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	})
+}
+
+// toSLAPolicyModel converts a Policy entity into its persistence model.
+func toSLAPolicyModel(p *sla.Policy) *SLAPolicyModel {
+	return &SLAPolicyModel{
+		ID:                   p.ID(),
+		Name:                 p.Name(),
+		Priority:             string(p.Priority()),
+		CategoryID:           p.CategoryID(),
+		FirstResponseMinutes: int(p.Targets().FirstResponse / time.Minute),
+		ResolutionMinutes:    int(p.Targets().Resolution / time.Minute),
+		CreatedAt:            p.CreatedAt(),
+		UpdatedAt:            p.UpdatedAt(),
+	}
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SLAPolicyModel is the GORM persistence model for an SLA policy.
+type SLAPolicyModel struct {
+	ID                   uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name                 string     `json:"name" gorm:"size:100;not null"`
+	Priority             string     `json:"priority" gorm:"size:20;not null"`
+	CategoryID           *uuid.UUID `json:"category_id" gorm:"type:uuid"`
+	FirstResponseMinutes int        `json:"first_response_minutes" gorm:"not null"`
+	ResolutionMinutes    int        `json:"resolution_minutes" gorm:"not null"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (SLAPolicyModel) TableName() string {
+	return "support.sla_policies"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *SLAPolicyModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
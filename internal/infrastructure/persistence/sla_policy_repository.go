@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"gorm.io/gorm"
+)
+
+// SLAPolicyRepository handles database operations for SLA policies
+type SLAPolicyRepository struct {
+	db *gorm.DB
+}
+
+var _ sla.PolicyRepository = (*SLAPolicyRepository)(nil)
+
+// NewSLAPolicyRepository creates a new SLA policy repository
+func NewSLAPolicyRepository(db *gorm.DB) *SLAPolicyRepository {
+	return &SLAPolicyRepository{db: db}
+}
+
+// FindByID retrieves a policy by ID
+func (r *SLAPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Policy, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindForTicket retrieves the category policy of the priority or the global one
+func (r *SLAPolicyRepository) FindForTicket(ctx context.Context, categoryID *uuid.UUID, priority shared.TicketPriority) (*sla.Policy, error) {
+	if categoryID != nil {
+		p, err := r.find(ctx, "category_id = ? AND priority = ?", *categoryID, string(priority))
+		if !errors.Is(err, sla.ErrPolicyNotFound) {
+			return p, err
+		}
+	}
+	return r.find(ctx, "category_id IS NULL AND priority = ?", string(priority))
+}
+
+func (r *SLAPolicyRepository) find(ctx context.Context, query string, args ...interface{}) (*sla.Policy, error) {
+	var model SLAPolicyModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, sla.ErrPolicyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toSLAPolicyDomain(&model), nil
+}
+
+// List retrieves all policies, global ones first
+func (r *SLAPolicyRepository) List(ctx context.Context) ([]*sla.Policy, error) {
+	var models []SLAPolicyModel
+	err := r.db.WithContext(ctx).
+		Order("category_id IS NOT NULL, category_id, priority, name").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*sla.Policy, 0, len(models))
+	for i := range models {
+		policies = append(policies, toSLAPolicyDomain(&models[i]))
+	}
+	return policies, nil
+}
+
+// Save creates or updates a policy
+func (r *SLAPolicyRepository) Save(ctx context.Context, p *sla.Policy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one policy may cover a priority and category
+		scope := tx.Model(&SLAPolicyModel{}).
+			Where("id <> ? AND priority = ?", p.ID(), string(p.Priority()))
+		if p.CategoryID() != nil {
+			scope = scope.Where("category_id = ?", *p.CategoryID())
+		} else {
+			scope = scope.Where("category_id IS NULL")
+		}
+		var conflicts int64
+		if err := scope.Count(&conflicts).Error; err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return sla.ErrPolicyConflict
+		}
+
+		return tx.Save(toSLAPolicyModel(p)).Error
+	})
+}
+
+// Delete deletes a policy
+func (r *SLAPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&SLAPolicyModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sla.ErrPolicyNotFound
+	}
+	return nil
+}
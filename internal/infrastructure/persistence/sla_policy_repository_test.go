@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestSLAPolicyRepository(t *testing.T) {
+	repotest.SLAPolicyRepositoryContract(t, func(t *testing.T) sla.PolicyRepository {
+		return NewSLAPolicyRepository(testDB(t))
+	})
+}
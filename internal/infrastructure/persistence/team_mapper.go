@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+)
+
+// toTeamDomain converts a TeamModel with its preloaded categories into a
+// Team aggregate.
+func toTeamDomain(m *TeamModel) *team.Team {
+	categoryIDs := make([]uuid.UUID, 0, len(m.Categories))
+	for _, c := range m.Categories {
+		categoryIDs = append(categoryIDs, c.CategoryID)
+	}
+
+	return team.Reconstitute(team.ReconstituteParams{
+		ID:          m.ID,
+		Key:         m.Key,
+		Name:        m.Name,
+		Description: m.Description,
+		CategoryIDs: categoryIDs,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	})
+}
+
+// toTeamModel converts a Team aggregate into its persistence model, without
+// its categories.
+func toTeamModel(t *team.Team) *TeamModel {
+	return &TeamModel{
+		ID:          t.ID(),
+		Key:         t.Key(),
+		Name:        t.Name(),
+		Description: t.Description(),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	}
+}
+
+// toTeamCategoryModels converts the team's categories into their rows.
+func toTeamCategoryModels(t *team.Team) []TeamCategoryModel {
+	models := make([]TeamCategoryModel, 0, len(t.CategoryIDs()))
+	for _, id := range t.CategoryIDs() {
+		models = append(models, TeamCategoryModel{CategoryID: id, TeamID: t.ID()})
+	}
+	return models
+}
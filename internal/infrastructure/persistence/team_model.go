@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TeamModel is the GORM persistence model for a support team.
+type TeamModel struct {
+	ID          uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Key         string              `json:"key" gorm:"size:50;not null;uniqueIndex"`
+	Name        string              `json:"name" gorm:"size:100;not null"`
+	Description string              `json:"description" gorm:"type:text"`
+	Categories  []TeamCategoryModel `json:"categories,omitempty" gorm:"foreignKey:TeamID"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (TeamModel) TableName() string {
+	return "support.teams"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *TeamModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// TeamCategoryModel maps a category to the team its new tickets go to.
+type TeamCategoryModel struct {
+	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;primaryKey"`
+	TeamID     uuid.UUID `json:"team_id" gorm:"type:uuid;not null;index"`
+}
+
+// TableName specifies the table name.
+func (TeamCategoryModel) TableName() string {
+	return "support.team_categories"
+}
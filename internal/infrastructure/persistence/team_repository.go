@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"gorm.io/gorm"
+)
+
+// TeamRepository handles database operations for support teams
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+var _ team.Repository = (*TeamRepository)(nil)
+
+// NewTeamRepository creates a new team repository
+func NewTeamRepository(db *gorm.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+// FindByID retrieves a team by ID
+func (r *TeamRepository) FindByID(ctx context.Context, id uuid.UUID) (*team.Team, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindByKey retrieves a team by key
+func (r *TeamRepository) FindByKey(ctx context.Context, key string) (*team.Team, error) {
+	return r.find(ctx, "key = ?", key)
+}
+
+// FindForCategory retrieves the team mapped to the category
+func (r *TeamRepository) FindForCategory(ctx context.Context, categoryID uuid.UUID) (*team.Team, error) {
+	return r.find(ctx, "id = (SELECT team_id FROM support.team_categories WHERE category_id = ?)", categoryID)
+}
+
+func (r *TeamRepository) find(ctx context.Context, query string, args ...interface{}) (*team.Team, error) {
+	var model TeamModel
+	err := r.db.WithContext(ctx).
+		Preload("Categories").
+		Where(query, args...).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, team.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTeamDomain(&model), nil
+}
+
+// List retrieves all teams ordered by name
+func (r *TeamRepository) List(ctx context.Context) ([]*team.Team, error) {
+	var models []TeamModel
+	err := r.db.WithContext(ctx).
+		Preload("Categories").
+		Order("name ASC, key ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*team.Team, 0, len(models))
+	for i := range models {
+		teams = append(teams, toTeamDomain(&models[i]))
+	}
+	return teams, nil
+}
+
+// Save creates or updates a team and replaces its categories
+func (r *TeamRepository) Save(ctx context.Context, t *team.Team) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Keys are unique and a category goes to one team only
+		var conflicts int64
+		if err := tx.Model(&TeamModel{}).
+			Where("id <> ? AND key = ?", t.ID(), t.Key()).
+			Count(&conflicts).Error; err != nil {
+			return err
+		}
+		if conflicts == 0 && len(t.CategoryIDs()) > 0 {
+			if err := tx.Model(&TeamCategoryModel{}).
+				Where("team_id <> ? AND category_id IN ?", t.ID(), t.CategoryIDs()).
+				Count(&conflicts).Error; err != nil {
+				return err
+			}
+		}
+		if conflicts > 0 {
+			return team.ErrTeamConflict
+		}
+
+		if err := tx.Omit("Categories").Save(toTeamModel(t)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", t.ID()).Delete(&TeamCategoryModel{}).Error; err != nil {
+			return err
+		}
+		if categories := toTeamCategoryModels(t); len(categories) > 0 {
+			return tx.Create(&categories).Error
+		}
+		return nil
+	})
+}
+
+// Delete deletes a team and its categories, takes its tickets out of the
+// team's queue and clears it as a routing rule fallback
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("team_id = ?", id).Delete(&TeamCategoryModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&TicketModel{}).
+			Where("team_id = ?", id).
+			Update("team_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&RoutingRuleModel{}).
+			Where("fallback_team_id = ?", id).
+			Update("fallback_team_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&TeamModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return team.ErrTeamNotFound
+		}
+		return nil
+	})
+}
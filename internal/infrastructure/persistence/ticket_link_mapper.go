@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+)
+
+// toTicketLinkDomain converts a TicketLinkModel into a Link entity.
+func toTicketLinkDomain(m *TicketLinkModel) *link.Link {
+	return link.Reconstitute(link.ReconstituteParams{
+		ID:             m.ID,
+		TicketID:       m.TicketID,
+		LinkedTicketID: m.LinkedTicketID,
+		Type:           m.Type,
+		CreatedBy:      m.CreatedBy,
+		CreatedByName:  m.CreatedByName,
+		CreatedAt:      m.CreatedAt,
+	})
+}
+
+// toTicketLinkModel converts a Link entity into its persistence model.
+func toTicketLinkModel(l *link.Link) *TicketLinkModel {
+	return &TicketLinkModel{
+		ID:             l.ID(),
+		TicketID:       l.TicketID(),
+		LinkedTicketID: l.LinkedTicketID(),
+		Type:           string(l.Type()),
+		CreatedBy:      l.CreatedBy(),
+		CreatedByName:  l.CreatedByName(),
+		CreatedAt:      l.CreatedAt(),
+	}
+}
@@ -0,0 +1,32 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TicketLinkModel is the GORM persistence model for a ticket link.
+type TicketLinkModel struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID       uuid.UUID  `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	LinkedTicketID uuid.UUID  `json:"linked_ticket_id" gorm:"type:uuid;not null;index"`
+	Type           string     `json:"type" gorm:"size:20;not null"`
+	CreatedBy      *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedByName  string     `json:"created_by_name" gorm:"size:255"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name.
+func (TicketLinkModel) TableName() string {
+	return "support.ticket_links"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *TicketLinkModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+	"gorm.io/gorm"
+)
+
+// TicketLinkRepository handles database operations for ticket links
+type TicketLinkRepository struct {
+	db *gorm.DB
+}
+
+var _ link.Repository = (*TicketLinkRepository)(nil)
+
+// NewTicketLinkRepository creates a new ticket link repository
+func NewTicketLinkRepository(db *gorm.DB) *TicketLinkRepository {
+	return &TicketLinkRepository{db: db}
+}
+
+// FindByID retrieves a link by ID
+func (r *TicketLinkRepository) FindByID(ctx context.Context, id uuid.UUID) (*link.Link, error) {
+	var model TicketLinkModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, link.ErrLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTicketLinkDomain(&model), nil
+}
+
+// ListForTicket retrieves the links of a ticket in either direction
+func (r *TicketLinkRepository) ListForTicket(ctx context.Context, ticketID uuid.UUID) ([]*link.Link, error) {
+	var models []TicketLinkModel
+	err := r.db.WithContext(ctx).
+		Where("ticket_id = ? OR linked_ticket_id = ?", ticketID, ticketID).
+		Order("created_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*link.Link, 0, len(models))
+	for i := range models {
+		links = append(links, toTicketLinkDomain(&models[i]))
+	}
+	return links, nil
+}
+
+// Save creates a link
+func (r *TicketLinkRepository) Save(ctx context.Context, l *link.Link) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Two tickets are linked once, whichever way
+		var existing int64
+		err := tx.Model(&TicketLinkModel{}).
+			Where("(ticket_id = ? AND linked_ticket_id = ?) OR (ticket_id = ? AND linked_ticket_id = ?)",
+				l.TicketID(), l.LinkedTicketID(), l.LinkedTicketID(), l.TicketID()).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			return link.ErrLinkExists
+		}
+
+		return tx.Create(toTicketLinkModel(l)).Error
+	})
+}
+
+// Delete deletes a link
+func (r *TicketLinkRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&TicketLinkModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return link.ErrLinkNotFound
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTicketLinkRepository(t *testing.T) {
+	repotest.TicketLinkRepositoryContract(t, func(t *testing.T) link.Repository {
+		return NewTicketLinkRepository(testDB(t))
+	})
+}
@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// toTicketDomain converts a TicketModel with its preloaded messages and
+// status history into a Ticket aggregate.
+func toTicketDomain(m *TicketModel) *ticket.Ticket {
+	messages := make([]ticket.Message, 0, len(m.Messages))
+	for i := range m.Messages {
+		messages = append(messages, toMessageDomain(&m.Messages[i]))
+	}
+
+	history := make([]ticket.StatusHistory, 0, len(m.StatusHistory))
+	for i := range m.StatusHistory {
+		history = append(history, toStatusHistoryDomain(&m.StatusHistory[i]))
+	}
+
+	return ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:                      m.ID,
+		TicketNumber:            m.TicketNumber,
+		CustomerID:              m.CustomerID,
+		GuestEmail:              m.GuestEmail,
+		GuestName:               m.GuestName,
+		GuestPhone:              m.GuestPhone,
+		CategoryID:              m.CategoryID,
+		Subject:                 m.Subject,
+		Channel:                 m.Channel,
+		Locale:                  m.Locale,
+		Brand:                   m.Brand,
+		Status:                  m.Status,
+		Priority:                m.Priority,
+		TeamID:                  m.TeamID,
+		AssignedTo:              m.AssignedTo,
+		AssignmentReason:        m.AssignmentReason,
+		OrderID:                 m.OrderID,
+		OrderNumber:             m.OrderNumber,
+		SLADeadline:             m.SLADeadline,
+		SLAWarnedAt:             m.SLAWarnedAt,
+		SLABreachedAt:           m.SLABreachedAt,
+		FirstResponseTarget:     time.Duration(m.SLAFirstResponseMinutes) * time.Minute,
+		ResolutionTarget:        time.Duration(m.SLAResolutionMinutes) * time.Minute,
+		FirstResponseDeadline:   m.FirstResponseDeadline,
+		FirstResponseWarnedAt:   m.FirstResponseWarnedAt,
+		FirstResponseBreachedAt: m.FirstResponseBreachedAt,
+		FirstResponseAt:         m.FirstResponseAt,
+		ResolvedAt:              m.ResolvedAt,
+		ClosedAt:                m.ClosedAt,
+		SatisfactionRating:      m.SatisfactionRating,
+		SatisfactionComment:     m.SatisfactionComment,
+		Tags:                    m.Tags,
+		Messages:                messages,
+		StatusHistory:           history,
+		CreatedAt:               m.CreatedAt,
+		UpdatedAt:               m.UpdatedAt,
+		IdleSince:               m.IdleSince,
+		RemindedAt:              m.RemindedAt,
+		StaleAt:                 m.StaleAt,
+		MergedInto:              m.MergedIntoID,
+		Watchers:                toWatcherIDs(m.WatcherIDs),
+		CC:                      m.CCEmails,
+		Version:                 m.Version,
+	})
+}
+
+// toTicketModel converts a Ticket aggregate into its persistence model.
+// Messages and status history are mapped separately.
+func toTicketModel(t *ticket.Ticket) *TicketModel {
+	return &TicketModel{
+		ID:                      t.ID(),
+		TicketNumber:            t.TicketNumber().Value(),
+		CustomerID:              t.CustomerID(),
+		GuestEmail:              t.GuestEmail(),
+		GuestName:               t.GuestName(),
+		GuestPhone:              t.GuestPhone(),
+		CategoryID:              t.CategoryID(),
+		Subject:                 t.Subject(),
+		Channel:                 t.Channel(),
+		Locale:                  t.Locale(),
+		Brand:                   t.Brand(),
+		Status:                  string(t.Status()),
+		IsActive:                t.IsActive(),
+		Priority:                string(t.Priority()),
+		TeamID:                  t.TeamID(),
+		AssignedTo:              t.AssignedTo(),
+		AssignmentReason:        t.AssignmentReason(),
+		OrderID:                 t.OrderID(),
+		OrderNumber:             t.OrderNumber(),
+		SLADeadline:             t.SLADeadline(),
+		SLAWarnedAt:             t.SLAWarnedAt(),
+		SLABreachedAt:           t.SLABreachedAt(),
+		SLAFirstResponseMinutes: int(t.SLATargets().FirstResponse / time.Minute),
+		SLAResolutionMinutes:    int(t.SLATargets().Resolution / time.Minute),
+		FirstResponseDeadline:   t.FirstResponseDeadline(),
+		FirstResponseWarnedAt:   t.FirstResponseWarnedAt(),
+		FirstResponseBreachedAt: t.FirstResponseBreachedAt(),
+		FirstResponseAt:         t.FirstResponseAt(),
+		ResolvedAt:              t.ResolvedAt(),
+		ClosedAt:                t.ClosedAt(),
+		SatisfactionRating:      t.SatisfactionRating(),
+		SatisfactionComment:     t.SatisfactionComment(),
+		Tags:                    pq.StringArray(t.Tags()),
+		IdleSince:               t.IdleSince(),
+		RemindedAt:              t.RemindedAt(),
+		StaleAt:                 t.StaleAt(),
+		MergedIntoID:            t.MergedInto(),
+		WatcherIDs:              fromWatcherIDs(t.Watchers()),
+		CCEmails:                pq.StringArray(t.CC()),
+		Version:                 t.Version(),
+		CreatedAt:               t.CreatedAt(),
+		UpdatedAt:               t.UpdatedAt(),
+	}
+}
+
+// toMessageDomain converts a MessageModel into a Message entity.
+func toMessageDomain(m *MessageModel) ticket.Message {
+	var attachments []ticket.Attachment
+	if m.Attachments != "" {
+		_ = json.Unmarshal([]byte(m.Attachments), &attachments)
+	}
+
+	return ticket.ReconstituteMessage(ticket.MessageParams{
+		ID:          m.ID,
+		TicketID:    m.TicketID,
+		SenderType:  m.SenderType,
+		SenderID:    m.SenderID,
+		SenderName:  m.SenderName,
+		SenderEmail: m.SenderEmail,
+		Content:     m.Content,
+		Attachments: attachments,
+		IsInternal:  m.IsInternal,
+		MessageID:   m.MessageID,
+		InReplyTo:   m.InReplyTo,
+		References:  m.References,
+	}, m.ReadAt, m.CreatedAt)
+}
+
+// toMessageModel converts a Message entity into its persistence model.
+func toMessageModel(msg ticket.Message) MessageModel {
+	attachments := "[]"
+	if msg.HasAttachments() {
+		if data, err := json.Marshal(msg.Attachments()); err == nil {
+			attachments = string(data)
+		}
+	}
+
+	return MessageModel{
+		ID:          msg.ID(),
+		TicketID:    msg.TicketID(),
+		SenderType:  string(msg.SenderType()),
+		SenderID:    msg.SenderID(),
+		SenderName:  msg.SenderName(),
+		SenderEmail: msg.SenderEmail(),
+		Content:     msg.Content(),
+		Attachments: attachments,
+		IsInternal:  msg.IsInternal(),
+		MessageID:   msg.MessageID(),
+		InReplyTo:   msg.InReplyTo(),
+		References:  append(pq.StringArray{}, msg.References()...),
+		ReadAt:      msg.ReadAt(),
+		CreatedAt:   msg.CreatedAt(),
+	}
+}
+
+// toStatusHistoryDomain converts a StatusHistoryModel into a StatusHistory entry.
+func toStatusHistoryDomain(m *StatusHistoryModel) ticket.StatusHistory {
+	return ticket.ReconstituteStatusHistory(ticket.StatusHistoryParams{
+		ID:            m.ID,
+		TicketID:      m.TicketID,
+		FromStatus:    m.FromStatus,
+		ToStatus:      m.ToStatus,
+		ChangedBy:     m.ChangedBy,
+		ChangedByName: m.ChangedByName,
+		Notes:         m.Notes,
+		CreatedAt:     m.CreatedAt,
+	})
+}
+
+// toStatusHistoryModel converts a StatusHistory entry into its persistence model.
+func toStatusHistoryModel(h ticket.StatusHistory) StatusHistoryModel {
+	return StatusHistoryModel{
+		ID:            h.ID(),
+		TicketID:      h.TicketID(),
+		FromStatus:    string(h.FromStatus()),
+		ToStatus:      string(h.ToStatus()),
+		ChangedBy:     h.ChangedBy(),
+		ChangedByName: h.ChangedByName(),
+		Notes:         h.Notes(),
+		CreatedAt:     h.CreatedAt(),
+	}
+}
+
+// toWatcherIDs parses the stored watcher IDs, skipping any that are not
+// UUIDs.
+func toWatcherIDs(ids pq.StringArray) []uuid.UUID {
+	watchers := make([]uuid.UUID, 0, len(ids))
+	for _, s := range ids {
+		if id, err := uuid.Parse(s); err == nil {
+			watchers = append(watchers, id)
+		}
+	}
+	return watchers
+}
+
+func fromWatcherIDs(watchers []uuid.UUID) pq.StringArray {
+	ids := make(pq.StringArray, 0, len(watchers))
+	for _, id := range watchers {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+)
+
+// toTicketMentionDomain converts a TicketMentionModel into a Mention entity.
+func toTicketMentionDomain(m *TicketMentionModel) *mention.Mention {
+	return mention.Reconstitute(mention.ReconstituteParams{
+		ID:              m.ID,
+		TicketID:        m.TicketID,
+		MessageID:       m.MessageID,
+		AgentID:         m.AgentID,
+		MentionedBy:     m.MentionedBy,
+		MentionedByName: m.MentionedByName,
+		ReadAt:          m.ReadAt,
+		CreatedAt:       m.CreatedAt,
+	})
+}
+
+// toTicketMentionModel converts a Mention entity into its persistence model.
+func toTicketMentionModel(m *mention.Mention) *TicketMentionModel {
+	return &TicketMentionModel{
+		ID:              m.ID(),
+		TicketID:        m.TicketID(),
+		MessageID:       m.MessageID(),
+		AgentID:         m.AgentID(),
+		MentionedBy:     m.MentionedBy(),
+		MentionedByName: m.MentionedByName(),
+		ReadAt:          m.ReadAt(),
+		CreatedAt:       m.CreatedAt(),
+	}
+}
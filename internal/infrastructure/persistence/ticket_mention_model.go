@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TicketMentionModel is the GORM persistence model for an agent mentioned
+// in an internal note.
+type TicketMentionModel struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID        uuid.UUID  `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	MessageID       uuid.UUID  `json:"message_id" gorm:"type:uuid;not null"`
+	AgentID         uuid.UUID  `json:"agent_id" gorm:"type:uuid;not null;index"`
+	MentionedBy     *uuid.UUID `json:"mentioned_by" gorm:"type:uuid"`
+	MentionedByName string     `json:"mentioned_by_name" gorm:"size:255"`
+	ReadAt          *time.Time `json:"read_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name.
+func (TicketMentionModel) TableName() string {
+	return "support.ticket_mentions"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *TicketMentionModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
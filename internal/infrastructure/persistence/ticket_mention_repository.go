@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"gorm.io/gorm"
+)
+
+// TicketMentionRepository handles database operations for mentions of
+// agents in internal notes
+type TicketMentionRepository struct {
+	db *gorm.DB
+}
+
+var _ mention.Repository = (*TicketMentionRepository)(nil)
+
+// NewTicketMentionRepository creates a new ticket mention repository
+func NewTicketMentionRepository(db *gorm.DB) *TicketMentionRepository {
+	return &TicketMentionRepository{db: db}
+}
+
+// FindByID retrieves a mention by ID
+func (r *TicketMentionRepository) FindByID(ctx context.Context, id uuid.UUID) (*mention.Mention, error) {
+	var model TicketMentionModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, mention.ErrMentionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTicketMentionDomain(&model), nil
+}
+
+// List retrieves the mentions of an agent with filters
+func (r *TicketMentionRepository) List(ctx context.Context, filter mention.Filter) ([]*mention.Mention, int64, error) {
+	var models []TicketMentionModel
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&TicketMentionModel{}).Where("agent_id = ?", filter.AgentID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	filter.Normalize()
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(filter.Offset()).
+		Limit(filter.PerPage).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mentions := make([]*mention.Mention, 0, len(models))
+	for i := range models {
+		mentions = append(mentions, toTicketMentionDomain(&models[i]))
+	}
+	return mentions, total, nil
+}
+
+// Save creates or updates a mention
+func (r *TicketMentionRepository) Save(ctx context.Context, m *mention.Mention) error {
+	return r.db.WithContext(ctx).Save(toTicketMentionModel(m)).Error
+}
@@ -11,31 +11,52 @@ import (
 
 // TicketModel is the GORM persistence model for Ticket.
 type TicketModel struct {
-	ID                  uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	TicketNumber        string         `json:"ticket_number" gorm:"size:20;uniqueIndex;not null"`
-	CustomerID          *uuid.UUID     `json:"customer_id" gorm:"type:uuid"`
-	GuestEmail          string         `json:"guest_email" gorm:"size:255"`
-	GuestName           string         `json:"guest_name" gorm:"size:255"`
-	GuestPhone          string         `json:"guest_phone" gorm:"size:20"`
-	CategoryID          *uuid.UUID     `json:"category_id" gorm:"type:uuid"`
-	Category            *CategoryModel `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
-	Subject             string         `json:"subject" gorm:"size:255;not null"`
-	Status              string         `json:"status" gorm:"size:20;default:'open'"`
-	Priority            string         `json:"priority" gorm:"size:20;default:'normal'"`
-	AssignedTo          *uuid.UUID     `json:"assigned_to" gorm:"type:uuid"`
-	OrderID             *uuid.UUID     `json:"order_id" gorm:"type:uuid"`
-	OrderNumber         string         `json:"order_number" gorm:"size:50"`
-	SLADeadline         *time.Time     `json:"sla_deadline"`
-	FirstResponseAt     *time.Time     `json:"first_response_at"`
-	ResolvedAt          *time.Time     `json:"resolved_at"`
-	ClosedAt            *time.Time     `json:"closed_at"`
-	SatisfactionRating  *int           `json:"satisfaction_rating"`
-	SatisfactionComment string         `json:"satisfaction_comment" gorm:"type:text"`
-	Tags                pq.StringArray `json:"tags" gorm:"type:text[]"`
-	Messages            []MessageModel `json:"messages,omitempty" gorm:"foreignKey:TicketID"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                      uuid.UUID            `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketNumber            string               `json:"ticket_number" gorm:"size:40;uniqueIndex;not null"`
+	CustomerID              *uuid.UUID           `json:"customer_id" gorm:"type:uuid"`
+	GuestEmail              string               `json:"guest_email" gorm:"size:255"`
+	GuestName               string               `json:"guest_name" gorm:"size:255"`
+	GuestPhone              string               `json:"guest_phone" gorm:"size:20"`
+	CategoryID              *uuid.UUID           `json:"category_id" gorm:"type:uuid"`
+	Category                *CategoryModel       `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Subject                 string               `json:"subject" gorm:"size:255;not null"`
+	Channel                 string               `json:"channel" gorm:"size:20;not null;default:'web'"`
+	Locale                  string               `json:"locale" gorm:"size:35;not null;default:''"`
+	Brand                   string               `json:"brand" gorm:"size:50;not null;default:''"`
+	Status                  string               `json:"status" gorm:"size:20;default:'open'"`
+	IsActive                bool                 `json:"is_active" gorm:"not null"`
+	Priority                string               `json:"priority" gorm:"size:20;default:'normal'"`
+	TeamID                  *uuid.UUID           `json:"team_id" gorm:"type:uuid;index"`
+	AssignedTo              *uuid.UUID           `json:"assigned_to" gorm:"type:uuid"`
+	AssignmentReason        string               `json:"assignment_reason" gorm:"size:255"`
+	OrderID                 *uuid.UUID           `json:"order_id" gorm:"type:uuid"`
+	OrderNumber             string               `json:"order_number" gorm:"size:50"`
+	SLADeadline             *time.Time           `json:"sla_deadline"`
+	SLAWarnedAt             *time.Time           `json:"sla_warned_at"`
+	SLABreachedAt           *time.Time           `json:"sla_breached_at"`
+	SLAFirstResponseMinutes int                  `json:"sla_first_response_minutes"`
+	SLAResolutionMinutes    int                  `json:"sla_resolution_minutes"`
+	FirstResponseDeadline   *time.Time           `json:"first_response_deadline"`
+	FirstResponseWarnedAt   *time.Time           `json:"first_response_warned_at"`
+	FirstResponseBreachedAt *time.Time           `json:"first_response_breached_at"`
+	FirstResponseAt         *time.Time           `json:"first_response_at"`
+	ResolvedAt              *time.Time           `json:"resolved_at"`
+	ClosedAt                *time.Time           `json:"closed_at"`
+	SatisfactionRating      *int                 `json:"satisfaction_rating"`
+	SatisfactionComment     string               `json:"satisfaction_comment" gorm:"type:text"`
+	Tags                    pq.StringArray       `json:"tags" gorm:"type:text[]"`
+	Messages                []MessageModel       `json:"messages,omitempty" gorm:"foreignKey:TicketID"`
+	StatusHistory           []StatusHistoryModel `json:"status_history,omitempty" gorm:"foreignKey:TicketID"`
+	IdleSince               time.Time            `json:"idle_since" gorm:"not null"`
+	RemindedAt              *time.Time           `json:"reminded_at"`
+	StaleAt                 *time.Time           `json:"stale_at"`
+	MergedIntoID            *uuid.UUID           `json:"merged_into_id" gorm:"type:uuid"`
+	WatcherIDs              pq.StringArray       `json:"watcher_ids" gorm:"type:uuid[]"`
+	CCEmails                pq.StringArray       `json:"cc_emails" gorm:"column:cc_emails;type:text[]"`
+	Version                 int                  `json:"version" gorm:"not null;default:1"`
+	CreatedAt               time.Time            `json:"created_at"`
+	UpdatedAt               time.Time            `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt       `json:"-" gorm:"index"`
 }
 
 // TableName specifies the table name.
@@ -56,7 +77,7 @@ func (m *TicketModel) IsOverdue() bool {
 	if m.SLADeadline == nil {
 		return false
 	}
-	if m.Status == "resolved" || m.Status == "closed" {
+	if !m.IsActive {
 		return false
 	}
 	return time.Now().After(*m.SLADeadline)
@@ -64,17 +85,20 @@ func (m *TicketModel) IsOverdue() bool {
 
 // MessageModel is the GORM persistence model for Message.
 type MessageModel struct {
-	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	TicketID    uuid.UUID  `json:"ticket_id" gorm:"type:uuid;not null;index"`
-	SenderType  string     `json:"sender_type" gorm:"size:20;not null"`
-	SenderID    *uuid.UUID `json:"sender_id" gorm:"type:uuid"`
-	SenderName  string     `json:"sender_name" gorm:"size:255"`
-	SenderEmail string     `json:"sender_email" gorm:"size:255"`
-	Content     string     `json:"content" gorm:"type:text;not null"`
-	Attachments string     `json:"attachments" gorm:"type:jsonb;default:'[]'"` // JSON array
-	IsInternal  bool       `json:"is_internal" gorm:"default:false"`
-	ReadAt      *time.Time `json:"read_at"`
-	CreatedAt   time.Time  `json:"created_at"`
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TicketID    uuid.UUID      `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	SenderType  string         `json:"sender_type" gorm:"size:20;not null"`
+	SenderID    *uuid.UUID     `json:"sender_id" gorm:"type:uuid"`
+	SenderName  string         `json:"sender_name" gorm:"size:255"`
+	SenderEmail string         `json:"sender_email" gorm:"size:255"`
+	Content     string         `json:"content" gorm:"type:text;not null"`
+	Attachments string         `json:"attachments" gorm:"type:jsonb;default:'[]'"` // JSON array
+	IsInternal  bool           `json:"is_internal" gorm:"default:false"`
+	MessageID   string         `json:"message_id" gorm:"type:text"`
+	InReplyTo   string         `json:"in_reply_to" gorm:"type:text"`
+	References  pq.StringArray `json:"references" gorm:"column:reference_ids;type:text[]"`
+	ReadAt      *time.Time     `json:"read_at"`
+	CreatedAt   time.Time      `json:"created_at"`
 }
 
 // TableName specifies the table name.
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"gorm.io/gorm"
+)
+
+// TicketNumberSequence hands out per-day ticket number sequence values from
+// the support.ticket_number_counters table. The upsert takes a row lock, so
+// concurrent callers across replicas are serialized per scope and day.
+type TicketNumberSequence struct {
+	db *gorm.DB
+}
+
+var _ ticket.NumberSequence = (*TicketNumberSequence)(nil)
+
+// NewTicketNumberSequence creates a new ticket number sequence
+func NewTicketNumberSequence(db *gorm.DB) *TicketNumberSequence {
+	return &TicketNumberSequence{db: db}
+}
+
+// Next increments and returns the scope's counter for the day
+func (s *TicketNumberSequence) Next(ctx context.Context, scope string, day time.Time) (int64, error) {
+	var value int64
+	err := s.db.WithContext(ctx).Raw(`
+		INSERT INTO support.ticket_number_counters (scope, day, last_value)
+		VALUES (?, ?, 1)
+		ON CONFLICT (scope, day) DO UPDATE
+		SET last_value = support.ticket_number_counters.last_value + 1
+		RETURNING last_value`,
+		scope, day.Format("2006-01-02"),
+	).Scan(&value).Error
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
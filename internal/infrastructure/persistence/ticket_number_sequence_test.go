@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTicketNumberSequence(t *testing.T) {
+	repotest.TicketNumberSequenceContract(t, func(t *testing.T) ticket.NumberSequence {
+		return NewTicketNumberSequence(testDB(t))
+	})
+}
@@ -2,11 +2,15 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/Ecom-micro-template/service-support/internal/domain"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/events"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TicketRepository handles database operations for tickets
@@ -14,79 +18,212 @@ type TicketRepository struct {
 	db *gorm.DB
 }
 
+var _ ticket.Repository = (*TicketRepository)(nil)
+
 // NewTicketRepository creates a new ticket repository
 func NewTicketRepository(db *gorm.DB) *TicketRepository {
 	return &TicketRepository{db: db}
 }
 
-// TicketFilter represents filters for listing tickets
-type TicketFilter struct {
-	Status      string
-	Priority    string
-	CategoryID  *uuid.UUID
-	CustomerID  *uuid.UUID
-	AssignedTo  *uuid.UUID
-	OrderID     *uuid.UUID
-	Search      string
-	IsOverdue   *bool
-	Page        int
-	PerPage     int
+// FindByID loads the Ticket aggregate with its messages and status history
+func (r *TicketRepository) FindByID(ctx context.Context, id uuid.UUID) (*ticket.Ticket, error) {
+	return r.find(ctx, "id = ?", id)
 }
 
-// TicketStats represents ticket statistics
-type TicketStats struct {
-	TotalOpen       int64   `json:"total_open"`
-	TotalPending    int64   `json:"total_pending"`
-	TotalInProgress int64   `json:"total_in_progress"`
-	TotalResolved   int64   `json:"total_resolved"`
-	TotalClosed     int64   `json:"total_closed"`
-	TotalOverdue    int64   `json:"total_overdue"`
-	AvgResponseTime float64 `json:"avg_response_time_hours"`
-	AvgResolutionTime float64 `json:"avg_resolution_time_hours"`
-	SatisfactionRate float64 `json:"satisfaction_rate"`
+// FindByNumber loads the Ticket aggregate by ticket number
+func (r *TicketRepository) FindByNumber(ctx context.Context, number string) (*ticket.Ticket, error) {
+	return r.find(ctx, "ticket_number = ?", number)
 }
 
-// Create creates a new ticket
-func (r *TicketRepository) Create(ctx context.Context, ticket *domain.Ticket) error {
-	return r.db.WithContext(ctx).Create(ticket).Error
+// FindByMessageID loads the Ticket aggregate holding a message with one of
+// the Message-IDs
+func (r *TicketRepository) FindByMessageID(ctx context.Context, messageIDs []string) (*ticket.Ticket, error) {
+	if len(messageIDs) == 0 {
+		return nil, ticket.ErrTicketNotFound
+	}
+	return r.find(ctx, "id IN (SELECT ticket_id FROM support.messages WHERE message_id IN ?)", messageIDs)
 }
 
-// GetByID retrieves a ticket by ID
-func (r *TicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ticket, error) {
-	var ticket domain.Ticket
+func (r *TicketRepository) find(ctx context.Context, query string, args ...interface{}) (*ticket.Ticket, error) {
+	var model TicketModel
 	err := r.db.WithContext(ctx).
-		Preload("Category").
 		Preload("Messages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at ASC")
 		}).
-		First(&ticket, "id = ?", id).Error
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where(query, args...).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ticket.ErrTicketNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &ticket, nil
+	return toTicketDomain(&model), nil
 }
 
-// GetByTicketNumber retrieves a ticket by ticket number
-func (r *TicketRepository) GetByTicketNumber(ctx context.Context, ticketNumber string) (*domain.Ticket, error) {
-	var ticket domain.Ticket
-	err := r.db.WithContext(ctx).
-		Preload("Category").
-		Preload("Messages", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at ASC")
-		}).
-		First(&ticket, "ticket_number = ?", ticketNumber).Error
+// Save persists the Ticket aggregate. Messages and status history entries are
+// append-only, so rows that already exist are left untouched. The ticket's
+// pending domain events are written to the outbox in the same transaction,
+// which joins the one a Transactor put in ctx, if any.
+func (r *TicketRepository) Save(ctx context.Context, t *ticket.Ticket) error {
+	outbox, err := events.Encode(t, t.Events())
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return saveTicket(tx, t, outbox)
+	})
+	if err != nil {
+		return err
 	}
-	return &ticket, nil
+	t.MarkSaved()
+	return nil
+}
+
+// SaveMerged moves the sources' messages and attachments to the target and saves all tickets in one transaction
+func (r *TicketRepository) SaveMerged(ctx context.Context, target *ticket.Ticket, sources []*ticket.Ticket) error {
+	tickets := append([]*ticket.Ticket{target}, sources...)
+	outboxes := make([][]events.OutboxMessage, 0, len(tickets))
+	sourceIDs := make([]uuid.UUID, 0, len(sources))
+	for _, t := range tickets {
+		outbox, err := events.Encode(t, t.Events())
+		if err != nil {
+			return err
+		}
+		outboxes = append(outboxes, outbox)
+	}
+	for _, s := range sources {
+		sourceIDs = append(sourceIDs, s.ID())
+	}
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&MessageModel{}).
+			Where("ticket_id IN ?", sourceIDs).
+			Update("ticket_id", target.ID()).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&AttachmentModel{}).
+			Where("ticket_id IN ?", sourceIDs).
+			Update("ticket_id", target.ID()).Error; err != nil {
+			return err
+		}
+
+		for i, t := range tickets {
+			if err := saveTicket(tx, t, outboxes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, t := range tickets {
+		t.MarkSaved()
+	}
+	return nil
+}
+
+// SaveSplit creates the split ticket, moves its messages and their
+// attachments from source and saves source in one transaction
+func (r *TicketRepository) SaveSplit(ctx context.Context, source, split *ticket.Ticket) error {
+	splitOutbox, err := events.Encode(split, split.Events())
+	if err != nil {
+		return err
+	}
+	sourceOutbox, err := events.Encode(source, source.Events())
+	if err != nil {
+		return err
+	}
+	messageIDs := make([]uuid.UUID, 0, len(split.Messages()))
+	for _, msg := range split.Messages() {
+		messageIDs = append(messageIDs, msg.ID())
+	}
+
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := saveTicket(tx, split, splitOutbox); err != nil {
+			return err
+		}
+		// The moved messages are stored already, so the insert above left them
+		if err := tx.Model(&MessageModel{}).
+			Where("id IN ? AND ticket_id = ?", messageIDs, source.ID()).
+			Update("ticket_id", split.ID()).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&AttachmentModel{}).
+			Where("message_id IN ? AND ticket_id = ?", messageIDs, source.ID()).
+			Update("ticket_id", split.ID()).Error; err != nil {
+			return err
+		}
+		return saveTicket(tx, source, sourceOutbox)
+	})
+	if err != nil {
+		return err
+	}
+	split.MarkSaved()
+	source.MarkSaved()
+	return nil
+}
+
+// saveTicket writes the ticket, its new messages and status history entries
+// and its outbox messages within tx. The ticket row is inserted for a new
+// ticket and otherwise only updated if it still holds the loaded version,
+// so a stale copy fails with ErrConflict instead of overwriting a
+// concurrent save. Callers mark the ticket saved once tx commits.
+func saveTicket(tx *gorm.DB, t *ticket.Ticket, outbox []events.OutboxMessage) error {
+	model := toTicketModel(t)
+	model.Version = t.Version() + 1
+
+	var result *gorm.DB
+	if t.Version() == 0 {
+		result = tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	} else {
+		result = tx.Model(model).
+			Select("*").
+			Omit("id", "created_at", "deleted_at", clause.Associations).
+			Where("version = ?", t.Version()).
+			Updates(model)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ticket.ErrConflict
+	}
+
+	if len(t.Messages()) > 0 {
+		messages := make([]MessageModel, 0, len(t.Messages()))
+		for _, msg := range t.Messages() {
+			messages = append(messages, toMessageModel(msg))
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&messages).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(t.StatusHistory()) > 0 {
+		history := make([]StatusHistoryModel, 0, len(t.StatusHistory()))
+		for _, h := range t.StatusHistory() {
+			history = append(history, toStatusHistoryModel(h))
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&history).Error; err != nil {
+			return err
+		}
+	}
+
+	return appendOutbox(tx, outbox)
 }
 
 // List retrieves tickets with filters
-func (r *TicketRepository) List(ctx context.Context, filter TicketFilter) ([]domain.Ticket, int64, error) {
-	var tickets []domain.Ticket
+func (r *TicketRepository) List(ctx context.Context, filter ticket.Filter) ([]*ticket.Ticket, int64, error) {
+	var models []TicketModel
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Ticket{})
+	query := r.db.WithContext(ctx).Model(&TicketModel{})
 
 	// Apply filters
 	if filter.Status != "" {
@@ -101,9 +238,21 @@ func (r *TicketRepository) List(ctx context.Context, filter TicketFilter) ([]dom
 	if filter.CustomerID != nil {
 		query = query.Where("customer_id = ?", filter.CustomerID)
 	}
+	if filter.TeamID != nil {
+		query = query.Where("team_id = ?", filter.TeamID)
+	}
 	if filter.AssignedTo != nil {
 		query = query.Where("assigned_to = ?", filter.AssignedTo)
 	}
+	if filter.Unassigned {
+		query = query.Where("assigned_to IS NULL")
+	}
+	if filter.WatchedBy != nil {
+		query = query.Where("? = ANY(watcher_ids)", *filter.WatchedBy)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active")
+	}
 	if filter.OrderID != nil {
 		query = query.Where("order_id = ?", filter.OrderID)
 	}
@@ -113,7 +262,7 @@ func (r *TicketRepository) List(ctx context.Context, filter TicketFilter) ([]dom
 			search, search, search, search)
 	}
 	if filter.IsOverdue != nil && *filter.IsOverdue {
-		query = query.Where("sla_deadline < ? AND status NOT IN ('resolved', 'closed')", time.Now())
+		query = query.Where("sla_deadline < ? AND is_active", time.Now())
 	}
 
 	// Count total
@@ -121,164 +270,124 @@ func (r *TicketRepository) List(ctx context.Context, filter TicketFilter) ([]dom
 		return nil, 0, err
 	}
 
-	// Pagination
-	if filter.PerPage <= 0 {
-		filter.PerPage = 20
-	}
-	if filter.Page <= 0 {
-		filter.Page = 1
-	}
-	offset := (filter.Page - 1) * filter.PerPage
-
-	// Fetch with preloads
+	filter.Normalize()
 	err := query.
-		Preload("Category").
 		Order("created_at DESC").
-		Offset(offset).
+		Offset(filter.Offset()).
 		Limit(filter.PerPage).
-		Find(&tickets).Error
+		Find(&models).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
+	tickets := make([]*ticket.Ticket, 0, len(models))
+	for i := range models {
+		tickets = append(tickets, toTicketDomain(&models[i]))
+	}
 	return tickets, total, nil
 }
 
-// ListByCustomer retrieves tickets for a specific customer
-func (r *TicketRepository) ListByCustomer(ctx context.Context, customerID uuid.UUID, page, perPage int) ([]domain.Ticket, int64, error) {
-	return r.List(ctx, TicketFilter{
-		CustomerID: &customerID,
-		Page:       page,
-		PerPage:    perPage,
-	})
-}
-
-// ListByEmail retrieves tickets for a specific email (for guests)
-func (r *TicketRepository) ListByEmail(ctx context.Context, email string, page, perPage int) ([]domain.Ticket, int64, error) {
-	var tickets []domain.Ticket
-	var total int64
-
-	query := r.db.WithContext(ctx).Model(&domain.Ticket{}).Where("guest_email = ?", email)
-
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+// ListSLADue returns active tickets with an unrecorded breach or an unsent
+// warning on either SLA clock and their status history, earliest deadline
+// first, then by ID
+func (r *TicketRepository) ListSLADue(ctx context.Context, query ticket.SLADueQuery, limit int) ([]*ticket.Ticket, error) {
+	scope := r.db.WithContext(ctx).
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("is_active").
+		Where(r.db.
+			Where("first_response_at IS NULL AND first_response_breached_at IS NULL AND "+
+				"(first_response_deadline < ? OR (first_response_warned_at IS NULL AND first_response_deadline < ?))", query.Now, query.WarnBefore).
+			Or("sla_breached_at IS NULL AND "+
+				"(sla_deadline < ? OR (sla_warned_at IS NULL AND sla_deadline < ?))", query.Now, query.WarnBefore))
+	if query.After != nil {
+		scope = scope.Where("(LEAST(first_response_deadline, sla_deadline), id) > (?, ?)", query.After.Deadline, query.After.ID)
 	}
 
-	if perPage <= 0 {
-		perPage = 20
-	}
-	if page <= 0 {
-		page = 1
+	var models []TicketModel
+	if err := scope.Order("LEAST(first_response_deadline, sla_deadline) ASC, id ASC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
 	}
-	offset := (page - 1) * perPage
 
-	err := query.
-		Preload("Category").
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(perPage).
-		Find(&tickets).Error
-	if err != nil {
-		return nil, 0, err
+	tickets := make([]*ticket.Ticket, 0, len(models))
+	for i := range models {
+		tickets = append(tickets, toTicketDomain(&models[i]))
 	}
-
-	return tickets, total, nil
-}
-
-// Update updates a ticket
-func (r *TicketRepository) Update(ctx context.Context, ticket *domain.Ticket) error {
-	return r.db.WithContext(ctx).Save(ticket).Error
+	return tickets, nil
 }
 
-// UpdateStatus updates ticket status and records history
-func (r *TicketRepository) UpdateStatus(ctx context.Context, ticketID uuid.UUID, newStatus domain.TicketStatus, changedBy *uuid.UUID, changedByName, notes string) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Get current ticket
-		var ticket domain.Ticket
-		if err := tx.First(&ticket, "id = ?", ticketID).Error; err != nil {
-			return err
-		}
-
-		oldStatus := string(ticket.Status)
-
-		// Update ticket status
-		updates := map[string]interface{}{
-			"status":     newStatus,
-			"updated_at": time.Now(),
-		}
-
-		if newStatus == domain.TicketStatusResolved && ticket.ResolvedAt == nil {
-			now := time.Now()
-			updates["resolved_at"] = now
-		}
-		if newStatus == domain.TicketStatusClosed && ticket.ClosedAt == nil {
-			now := time.Now()
-			updates["closed_at"] = now
-		}
-
-		if err := tx.Model(&ticket).Updates(updates).Error; err != nil {
-			return err
-		}
-
-		// Create status history
-		history := &domain.StatusHistory{
-			TicketID:      ticketID,
-			FromStatus:    oldStatus,
-			ToStatus:      string(newStatus),
-			ChangedBy:     changedBy,
-			ChangedByName: changedByName,
-			Notes:         notes,
-		}
-
-		return tx.Create(history).Error
-	})
-}
+// ListIdle returns tickets idle in a status since before the cutoff and
+// their status history, idle longest first, then by ID
+func (r *TicketRepository) ListIdle(ctx context.Context, query ticket.IdleQuery, limit int) ([]*ticket.Ticket, error) {
+	scope := r.db.WithContext(ctx).
+		Preload("StatusHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("status = ? AND idle_since < ?", string(query.Status), query.IdleBefore)
+	if query.CategoryID != nil {
+		scope = scope.Where("category_id = ?", *query.CategoryID)
+	} else if len(query.ExcludeCategoryIDs) > 0 {
+		scope = scope.Where("(category_id IS NULL OR category_id NOT IN ?)", query.ExcludeCategoryIDs)
+	}
+	if query.NotReminded {
+		scope = scope.Where("reminded_at IS NULL")
+	}
+	if query.NotStale {
+		scope = scope.Where("stale_at IS NULL")
+	}
+	if query.After != nil {
+		scope = scope.Where("(idle_since, id) > (?, ?)", query.After.IdleSince, query.After.ID)
+	}
 
-// Assign assigns a ticket to an agent
-func (r *TicketRepository) Assign(ctx context.Context, ticketID, agentID uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Model(&domain.Ticket{}).
-		Where("id = ?", ticketID).
-		Update("assigned_to", agentID).Error
-}
+	var models []TicketModel
+	if err := scope.Order("idle_since ASC, id ASC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
 
-// Delete soft deletes a ticket
-func (r *TicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&domain.Ticket{}, "id = ?", id).Error
+	tickets := make([]*ticket.Ticket, 0, len(models))
+	for i := range models {
+		tickets = append(tickets, toTicketDomain(&models[i]))
+	}
+	return tickets, nil
 }
 
-// GetStats returns ticket statistics
-func (r *TicketRepository) GetStats(ctx context.Context) (*TicketStats, error) {
-	stats := &TicketStats{}
+// Stats returns ticket statistics
+func (r *TicketRepository) Stats(ctx context.Context) (*ticket.Stats, error) {
+	stats := &ticket.Stats{}
 
 	// Count by status
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("status = ?", domain.TicketStatusOpen).
-		Count(&stats.TotalOpen)
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("status = ?", domain.TicketStatusPending).
-		Count(&stats.TotalPending)
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("status = ?", domain.TicketStatusInProgress).
-		Count(&stats.TotalInProgress)
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("status = ?", domain.TicketStatusResolved).
-		Count(&stats.TotalResolved)
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("status = ?", domain.TicketStatusClosed).
-		Count(&stats.TotalClosed)
+	counts := []struct {
+		status shared.TicketStatus
+		total  *int64
+	}{
+		{shared.StatusOpen, &stats.TotalOpen},
+		{shared.StatusPending, &stats.TotalPending},
+		{shared.StatusInProgress, &stats.TotalInProgress},
+		{shared.StatusResolved, &stats.TotalResolved},
+		{shared.StatusClosed, &stats.TotalClosed},
+	}
+	for _, c := range counts {
+		if err := r.db.WithContext(ctx).Model(&TicketModel{}).
+			Where("status = ?", c.status).
+			Count(c.total).Error; err != nil {
+			return nil, err
+		}
+	}
 
 	// Count overdue
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Where("sla_deadline < ? AND status NOT IN ('resolved', 'closed')", time.Now()).
-		Count(&stats.TotalOverdue)
+	if err := r.db.WithContext(ctx).Model(&TicketModel{}).
+		Where("sla_deadline < ? AND is_active", time.Now()).
+		Count(&stats.TotalOverdue).Error; err != nil {
+		return nil, err
+	}
 
 	// Calculate average response time (hours)
 	var avgResponse struct {
 		Avg float64
 	}
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Select("AVG(EXTRACT(EPOCH FROM (first_response_at - created_at)) / 3600) as avg").
+	r.db.WithContext(ctx).Model(&TicketModel{}).
+		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (first_response_at - created_at)) / 3600), 0) as avg").
 		Where("first_response_at IS NOT NULL").
 		Scan(&avgResponse)
 	stats.AvgResponseTime = avgResponse.Avg
@@ -287,18 +396,18 @@ func (r *TicketRepository) GetStats(ctx context.Context) (*TicketStats, error) {
 	var avgResolution struct {
 		Avg float64
 	}
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
-		Select("AVG(EXTRACT(EPOCH FROM (resolved_at - created_at)) / 3600) as avg").
+	r.db.WithContext(ctx).Model(&TicketModel{}).
+		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (resolved_at - created_at)) / 3600), 0) as avg").
 		Where("resolved_at IS NOT NULL").
 		Scan(&avgResolution)
 	stats.AvgResolutionTime = avgResolution.Avg
 
 	// Calculate satisfaction rate (percentage of 4-5 ratings)
 	var satisfactionData struct {
-		Total   int64
+		Total     int64
 		Satisfied int64
 	}
-	r.db.WithContext(ctx).Model(&domain.Ticket{}).
+	r.db.WithContext(ctx).Model(&TicketModel{}).
 		Select("COUNT(*) as total, COUNT(CASE WHEN satisfaction_rating >= 4 THEN 1 END) as satisfied").
 		Where("satisfaction_rating IS NOT NULL").
 		Scan(&satisfactionData)
@@ -308,3 +417,48 @@ func (r *TicketRepository) GetStats(ctx context.Context) (*TicketStats, error) {
 
 	return stats, nil
 }
+
+// CountActiveByAssignee counts active tickets per assigned agent
+func (r *TicketRepository) CountActiveByAssignee(ctx context.Context) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		AssignedTo uuid.UUID
+		Total      int
+	}
+	err := r.db.WithContext(ctx).Model(&TicketModel{}).
+		Select("assigned_to, COUNT(*) as total").
+		Where("is_active AND assigned_to IS NOT NULL").
+		Group("assigned_to").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.AssignedTo] = row.Total
+	}
+	return counts, nil
+}
+
+// LastAssignee returns the agent of the customer's latest assigned ticket
+func (r *TicketRepository) LastAssignee(ctx context.Context, customerID *uuid.UUID, guestEmail string) (*uuid.UUID, error) {
+	query := r.db.WithContext(ctx).Model(&TicketModel{}).
+		Where("assigned_to IS NOT NULL")
+	switch {
+	case customerID != nil:
+		query = query.Where("customer_id = ?", customerID)
+	case guestEmail != "":
+		query = query.Where("customer_id IS NULL AND LOWER(guest_email) = LOWER(?)", guestEmail)
+	default:
+		return nil, nil
+	}
+
+	var models []TicketModel
+	if err := query.Order("created_at DESC").Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].AssignedTo, nil
+}
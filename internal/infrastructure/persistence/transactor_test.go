@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+func TestTransactor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rolls back every write of a failed unit of work", func(t *testing.T) {
+		db := testDB(t)
+		transactor := NewTransactor(db)
+		log := NewTriggerFiringLog(db)
+		rule, err := trigger.NewRule(trigger.RuleParams{
+			Name:    "Tag refunds",
+			Enabled: true,
+			Events:  []trigger.Event{trigger.EventTicketCreated},
+			Actions: []trigger.Action{{Type: trigger.ActionAddTag, Value: "refund"}},
+		})
+		if err != nil {
+			t.Fatalf("NewRule: %v", err)
+		}
+		firing := trigger.NewFiring(rule, uuid.New(), trigger.EventTicketCreated, nil)
+
+		failed := errors.New("ticket save failed")
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := log.Record(ctx, []trigger.Firing{firing}); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("WithinTransaction error = %v, want the unit of work's error", err)
+		}
+		if _, total, err := log.List(ctx, trigger.FiringFilter{}); err != nil || total != 0 {
+			t.Fatalf("List after rollback = %d firings (err %v), want none", total, err)
+		}
+
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return log.Record(ctx, []trigger.Firing{firing})
+		})
+		if err != nil {
+			t.Fatalf("WithinTransaction: %v", err)
+		}
+		if _, total, err := log.List(ctx, trigger.FiringFilter{}); err != nil || total != 1 {
+			t.Fatalf("List after commit = %d firings (err %v), want 1", total, err)
+		}
+	})
+
+	t.Run("queues notifications with the ticket", func(t *testing.T) {
+		db := testDB(t)
+		transactor := NewTransactor(db)
+		tickets := NewTicketRepository(db)
+		deliveries := NewNotificationDeliveryRepository(db)
+		tk, err := ticket.NewTicket(ticket.TicketParams{
+			TicketNumber: "TKT-20261016-0001",
+			GuestEmail:   "guest@example.com",
+			Subject:      "Where is my order?",
+		})
+		if err != nil {
+			t.Fatalf("NewTicket: %v", err)
+		}
+		d, err := notification.NewDelivery(notification.DeliveryParams{
+			TicketID:  tk.ID(),
+			Event:     notification.EventTicketReceived,
+			Locale:    "en",
+			To:        "guest@example.com",
+			Subject:   "[TKT-20261016-0001] Where is my order?",
+			Body:      "We received your request.",
+			MessageID: "<received@support.example.com>",
+		})
+		if err != nil {
+			t.Fatalf("NewDelivery: %v", err)
+		}
+
+		failed := errors.New("queue failed")
+		err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := tickets.Save(ctx, tk); err != nil {
+				return err
+			}
+			if err := deliveries.Save(ctx, d); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("WithinTransaction error = %v, want the unit of work's error", err)
+		}
+		if _, err := tickets.FindByID(ctx, tk.ID()); !errors.Is(err, ticket.ErrTicketNotFound) {
+			t.Fatalf("FindByID after rollback error = %v, want ErrTicketNotFound", err)
+		}
+		if got, err := deliveries.ListByTicket(ctx, tk.ID()); err != nil || len(got) != 0 {
+			t.Fatalf("ListByTicket after rollback = %d deliveries (err %v), want none", len(got), err)
+		}
+	})
+}
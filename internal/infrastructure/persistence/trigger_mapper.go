@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/lib/pq"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+// triggerConditionRecord is the JSON form of a trigger rule's condition.
+type triggerConditionRecord struct {
+	All      []triggerConditionRecord `json:"all,omitempty"`
+	Any      []triggerConditionRecord `json:"any,omitempty"`
+	Field    string                   `json:"field,omitempty"`
+	Operator string                   `json:"operator,omitempty"`
+	Value    string                   `json:"value,omitempty"`
+}
+
+// triggerActionRecord is the JSON form of a trigger action and, with an
+// error, of its outcome in a firing.
+type triggerActionRecord struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Error string `json:"error,omitempty"`
+}
+
+// toTriggerRuleDomain converts a TriggerRuleModel into a Rule aggregate.
+func toTriggerRuleDomain(m *TriggerRuleModel) (*trigger.Rule, error) {
+	var condition triggerConditionRecord
+	if err := json.Unmarshal([]byte(m.Conditions), &condition); err != nil {
+		return nil, err
+	}
+
+	var actionRecords []triggerActionRecord
+	if err := json.Unmarshal([]byte(m.Actions), &actionRecords); err != nil {
+		return nil, err
+	}
+	actions := make([]trigger.Action, 0, len(actionRecords))
+	for _, r := range actionRecords {
+		actions = append(actions, trigger.Action{Type: trigger.ActionType(r.Type), Value: r.Value})
+	}
+
+	events := make([]trigger.Event, 0, len(m.Events))
+	for _, e := range m.Events {
+		events = append(events, trigger.Event(e))
+	}
+
+	return trigger.Reconstitute(trigger.ReconstituteParams{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Position:    m.Position,
+		Enabled:     m.Enabled,
+		Events:      events,
+		Condition:   toTriggerCondition(condition),
+		Actions:     actions,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}), nil
+}
+
+// toTriggerRuleModel converts a Rule aggregate into its persistence model.
+func toTriggerRuleModel(r *trigger.Rule) *TriggerRuleModel {
+	conditions, _ := json.Marshal(toTriggerConditionRecord(r.Condition()))
+
+	actionRecords := make([]triggerActionRecord, 0, len(r.Actions()))
+	for _, a := range r.Actions() {
+		actionRecords = append(actionRecords, triggerActionRecord{Type: string(a.Type), Value: a.Value})
+	}
+	actions, _ := json.Marshal(actionRecords)
+
+	events := make(pq.StringArray, 0, len(r.Events()))
+	for _, e := range r.Events() {
+		events = append(events, string(e))
+	}
+
+	return &TriggerRuleModel{
+		ID:          r.ID(),
+		Name:        r.Name(),
+		Description: r.Description(),
+		Position:    r.Position(),
+		Enabled:     r.IsEnabled(),
+		Events:      events,
+		Conditions:  string(conditions),
+		Actions:     string(actions),
+		CreatedAt:   r.CreatedAt(),
+		UpdatedAt:   r.UpdatedAt(),
+	}
+}
+
+func toTriggerCondition(r triggerConditionRecord) trigger.Condition {
+	c := trigger.Condition{
+		Field:    trigger.Field(r.Field),
+		Operator: trigger.Operator(r.Operator),
+		Value:    r.Value,
+	}
+	for _, sub := range r.All {
+		c.All = append(c.All, toTriggerCondition(sub))
+	}
+	for _, sub := range r.Any {
+		c.Any = append(c.Any, toTriggerCondition(sub))
+	}
+	return c
+}
+
+func toTriggerConditionRecord(c trigger.Condition) triggerConditionRecord {
+	r := triggerConditionRecord{
+		Field:    string(c.Field),
+		Operator: string(c.Operator),
+		Value:    c.Value,
+	}
+	for _, sub := range c.All {
+		r.All = append(r.All, toTriggerConditionRecord(sub))
+	}
+	for _, sub := range c.Any {
+		r.Any = append(r.Any, toTriggerConditionRecord(sub))
+	}
+	return r
+}
+
+// toTriggerFiringDomain converts a TriggerFiringModel into a Firing.
+func toTriggerFiringDomain(m *TriggerFiringModel) (trigger.Firing, error) {
+	var records []triggerActionRecord
+	if err := json.Unmarshal([]byte(m.Actions), &records); err != nil {
+		return trigger.Firing{}, err
+	}
+	actions := make([]trigger.ActionResult, 0, len(records))
+	for _, r := range records {
+		actions = append(actions, trigger.ActionResult{Type: trigger.ActionType(r.Type), Value: r.Value, Error: r.Error})
+	}
+
+	return trigger.ReconstituteFiring(trigger.FiringParams{
+		ID:       m.ID,
+		RuleID:   m.RuleID,
+		RuleName: m.RuleName,
+		TicketID: m.TicketID,
+		Event:    trigger.Event(m.Event),
+		Actions:  actions,
+		FiredAt:  m.FiredAt,
+	}), nil
+}
+
+// toTriggerFiringModel converts a Firing into its persistence model.
+func toTriggerFiringModel(f trigger.Firing) TriggerFiringModel {
+	records := make([]triggerActionRecord, 0, len(f.Actions()))
+	for _, a := range f.Actions() {
+		records = append(records, triggerActionRecord{Type: string(a.Type), Value: a.Value, Error: a.Error})
+	}
+	actions, _ := json.Marshal(records)
+
+	return TriggerFiringModel{
+		ID:       f.ID(),
+		RuleID:   f.RuleID(),
+		RuleName: f.RuleName(),
+		TicketID: f.TicketID(),
+		Event:    string(f.Event()),
+		Actions:  string(actions),
+		FiredAt:  f.FiredAt(),
+	}
+}
@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// TriggerRuleModel is the GORM persistence model for a trigger rule.
+type TriggerRuleModel struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name        string         `json:"name" gorm:"size:100;not null"`
+	Description string         `json:"description" gorm:"type:text"`
+	Position    int            `json:"position" gorm:"not null;default:0"`
+	Enabled     bool           `json:"enabled" gorm:"not null"`
+	Events      pq.StringArray `json:"events" gorm:"type:text[];not null"`
+	Conditions  string         `json:"conditions" gorm:"type:jsonb;not null;default:'{}'"` // JSON object
+	Actions     string         `json:"actions" gorm:"type:jsonb;not null;default:'[]'"`    // JSON array
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (TriggerRuleModel) TableName() string {
+	return "support.trigger_rules"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *TriggerRuleModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// TriggerFiringModel is the GORM persistence model for a logged rule firing.
+type TriggerFiringModel struct {
+	ID       uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	RuleID   uuid.UUID `json:"rule_id" gorm:"type:uuid;not null;index"`
+	RuleName string    `json:"rule_name" gorm:"size:100;not null"`
+	TicketID uuid.UUID `json:"ticket_id" gorm:"type:uuid;not null;index"`
+	Event    string    `json:"event" gorm:"size:30;not null"`
+	Actions  string    `json:"actions" gorm:"type:jsonb;not null;default:'[]'"` // JSON array
+	FiredAt  time.Time `json:"fired_at" gorm:"not null"`
+}
+
+// TableName specifies the table name.
+func (TriggerFiringModel) TableName() string {
+	return "support.trigger_firings"
+}
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"gorm.io/gorm"
+)
+
+// TriggerRuleRepository handles database operations for trigger rules
+type TriggerRuleRepository struct {
+	db *gorm.DB
+}
+
+var _ trigger.Repository = (*TriggerRuleRepository)(nil)
+
+// NewTriggerRuleRepository creates a new trigger rule repository
+func NewTriggerRuleRepository(db *gorm.DB) *TriggerRuleRepository {
+	return &TriggerRuleRepository{db: db}
+}
+
+// FindByID retrieves a trigger rule by ID
+func (r *TriggerRuleRepository) FindByID(ctx context.Context, id uuid.UUID) (*trigger.Rule, error) {
+	var model TriggerRuleModel
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, trigger.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTriggerRuleDomain(&model)
+}
+
+// List retrieves all trigger rules in run order
+func (r *TriggerRuleRepository) List(ctx context.Context) ([]*trigger.Rule, error) {
+	return r.find(ctx, r.db.WithContext(ctx))
+}
+
+// ListForEvent retrieves the enabled trigger rules of an event in run order
+func (r *TriggerRuleRepository) ListForEvent(ctx context.Context, event trigger.Event) ([]*trigger.Rule, error) {
+	return r.find(ctx, r.db.WithContext(ctx).Where("enabled AND ? = ANY(events)", string(event)))
+}
+
+func (r *TriggerRuleRepository) find(ctx context.Context, query *gorm.DB) ([]*trigger.Rule, error) {
+	var models []TriggerRuleModel
+	err := query.
+		Order("position ASC, name ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*trigger.Rule, 0, len(models))
+	for i := range models {
+		rule, err := toTriggerRuleDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Save creates or updates a trigger rule
+func (r *TriggerRuleRepository) Save(ctx context.Context, rule *trigger.Rule) error {
+	return r.db.WithContext(ctx).Save(toTriggerRuleModel(rule)).Error
+}
+
+// Delete deletes a trigger rule
+func (r *TriggerRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&TriggerRuleModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return trigger.ErrRuleNotFound
+	}
+	return nil
+}
+
+// TriggerFiringLog handles database operations for the trigger firing log
+type TriggerFiringLog struct {
+	db *gorm.DB
+}
+
+var _ trigger.FiringLog = (*TriggerFiringLog)(nil)
+
+// NewTriggerFiringLog creates a new trigger firing log
+func NewTriggerFiringLog(db *gorm.DB) *TriggerFiringLog {
+	return &TriggerFiringLog{db: db}
+}
+
+// Record inserts firings
+func (l *TriggerFiringLog) Record(ctx context.Context, firings []trigger.Firing) error {
+	if len(firings) == 0 {
+		return nil
+	}
+	models := make([]TriggerFiringModel, 0, len(firings))
+	for _, f := range firings {
+		models = append(models, toTriggerFiringModel(f))
+	}
+	return conn(ctx, l.db).Create(&models).Error
+}
+
+// List retrieves firings with filters
+func (l *TriggerFiringLog) List(ctx context.Context, filter trigger.FiringFilter) ([]trigger.Firing, int64, error) {
+	var models []TriggerFiringModel
+	var total int64
+
+	query := l.db.WithContext(ctx).Model(&TriggerFiringModel{})
+	if filter.RuleID != nil {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+	if filter.TicketID != nil {
+		query = query.Where("ticket_id = ?", filter.TicketID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	filter.Normalize()
+	err := query.
+		Order("fired_at DESC, id DESC").
+		Offset(filter.Offset()).
+		Limit(filter.PerPage).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	firings := make([]trigger.Firing, 0, len(models))
+	for i := range models {
+		f, err := toTriggerFiringDomain(&models[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		firings = append(firings, f)
+	}
+	return firings, total, nil
+}
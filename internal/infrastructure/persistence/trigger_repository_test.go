@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestTriggerRuleRepository(t *testing.T) {
+	repotest.TriggerRuleRepositoryContract(t, func(t *testing.T) trigger.Repository {
+		return NewTriggerRuleRepository(testDB(t))
+	})
+}
+
+func TestTriggerFiringLog(t *testing.T) {
+	repotest.TriggerFiringLogContract(t, func(t *testing.T) trigger.FiringLog {
+		return NewTriggerFiringLog(testDB(t))
+	})
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+// workflowStateRecord is the JSON form of a workflow state.
+type workflowStateRecord struct {
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Active    bool   `json:"active"`
+	Terminal  bool   `json:"terminal"`
+	PausesSLA bool   `json:"pauses_sla"`
+}
+
+// workflowTransitionRecord is the JSON form of a workflow transition.
+type workflowTransitionRecord struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// toWorkflowDomain converts a WorkflowModel with its preloaded categories
+// into a Workflow aggregate. Definitions that fail validation are reported.
+func toWorkflowDomain(m *WorkflowModel) (*workflow.Workflow, error) {
+	var stateRecords []workflowStateRecord
+	if err := json.Unmarshal([]byte(m.States), &stateRecords); err != nil {
+		return nil, err
+	}
+	states := make([]workflow.State, 0, len(stateRecords))
+	for _, r := range stateRecords {
+		states = append(states, workflow.State{
+			Key:       shared.TicketStatus(r.Key),
+			Label:     r.Label,
+			Active:    r.Active,
+			Terminal:  r.Terminal,
+			PausesSLA: r.PausesSLA,
+		})
+	}
+
+	var transitionRecords []workflowTransitionRecord
+	if err := json.Unmarshal([]byte(m.Transitions), &transitionRecords); err != nil {
+		return nil, err
+	}
+	transitions := make([]workflow.Transition, 0, len(transitionRecords))
+	for _, r := range transitionRecords {
+		transitions = append(transitions, workflow.Transition{
+			From: shared.TicketStatus(r.From),
+			To:   shared.TicketStatus(r.To),
+		})
+	}
+
+	categoryIDs := make([]uuid.UUID, 0, len(m.Categories))
+	for _, c := range m.Categories {
+		categoryIDs = append(categoryIDs, c.CategoryID)
+	}
+
+	return workflow.Reconstitute(workflow.ReconstituteParams{
+		ID:          m.ID,
+		Name:        m.Name,
+		IsDefault:   m.IsDefault,
+		CategoryIDs: categoryIDs,
+		States:      states,
+		Transitions: transitions,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	})
+}
+
+// toWorkflowModel converts a Workflow aggregate into its persistence model.
+// Category assignments are mapped separately.
+func toWorkflowModel(w *workflow.Workflow) *WorkflowModel {
+	stateRecords := make([]workflowStateRecord, 0, len(w.States()))
+	for _, s := range w.States() {
+		stateRecords = append(stateRecords, workflowStateRecord{
+			Key:       string(s.Key),
+			Label:     s.Label,
+			Active:    s.Active,
+			Terminal:  s.Terminal,
+			PausesSLA: s.PausesSLA,
+		})
+	}
+	states, _ := json.Marshal(stateRecords)
+
+	transitionRecords := make([]workflowTransitionRecord, 0, len(w.Transitions()))
+	for _, t := range w.Transitions() {
+		transitionRecords = append(transitionRecords, workflowTransitionRecord{From: string(t.From), To: string(t.To)})
+	}
+	transitions, _ := json.Marshal(transitionRecords)
+
+	return &WorkflowModel{
+		ID:          w.ID(),
+		Name:        w.Name(),
+		IsDefault:   w.IsDefault(),
+		States:      string(states),
+		Transitions: string(transitions),
+		CreatedAt:   w.CreatedAt(),
+		UpdatedAt:   w.UpdatedAt(),
+	}
+}
+
+// toWorkflowCategoryModels converts the workflow's category assignments into
+// persistence models.
+func toWorkflowCategoryModels(w *workflow.Workflow) []WorkflowCategoryModel {
+	models := make([]WorkflowCategoryModel, 0, len(w.CategoryIDs()))
+	for _, id := range w.CategoryIDs() {
+		models = append(models, WorkflowCategoryModel{CategoryID: id, WorkflowID: w.ID()})
+	}
+	return models
+}
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkflowModel is the GORM persistence model for a ticket workflow.
+type WorkflowModel struct {
+	ID          uuid.UUID               `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name        string                  `json:"name" gorm:"size:100;not null"`
+	IsDefault   bool                    `json:"is_default" gorm:"not null"`
+	States      string                  `json:"states" gorm:"type:jsonb;not null;default:'[]'"`      // JSON array
+	Transitions string                  `json:"transitions" gorm:"type:jsonb;not null;default:'[]'"` // JSON array
+	Categories  []WorkflowCategoryModel `json:"categories,omitempty" gorm:"foreignKey:WorkflowID"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// TableName specifies the table name.
+func (WorkflowModel) TableName() string {
+	return "support.workflows"
+}
+
+// BeforeCreate hook to generate UUID if not provided.
+func (m *WorkflowModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// WorkflowCategoryModel assigns a category to a workflow.
+type WorkflowCategoryModel struct {
+	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;primaryKey"`
+	WorkflowID uuid.UUID `json:"workflow_id" gorm:"type:uuid;not null;index"`
+}
+
+// TableName specifies the table name.
+func (WorkflowCategoryModel) TableName() string {
+	return "support.workflow_categories"
+}
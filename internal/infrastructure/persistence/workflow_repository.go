@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+	"gorm.io/gorm"
+)
+
+// WorkflowRepository handles database operations for workflows
+type WorkflowRepository struct {
+	db *gorm.DB
+}
+
+var _ workflow.Repository = (*WorkflowRepository)(nil)
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(db *gorm.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// FindByID retrieves a workflow by ID
+func (r *WorkflowRepository) FindByID(ctx context.Context, id uuid.UUID) (*workflow.Workflow, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindForCategory retrieves the workflow assigned to the category or the default one
+func (r *WorkflowRepository) FindForCategory(ctx context.Context, categoryID *uuid.UUID) (*workflow.Workflow, error) {
+	if categoryID != nil {
+		w, err := r.find(ctx, "id = (SELECT workflow_id FROM support.workflow_categories WHERE category_id = ?)", *categoryID)
+		if !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			return w, err
+		}
+	}
+	return r.find(ctx, "is_default")
+}
+
+func (r *WorkflowRepository) find(ctx context.Context, query string, args ...interface{}) (*workflow.Workflow, error) {
+	var model WorkflowModel
+	err := r.db.WithContext(ctx).
+		Preload("Categories").
+		Where(query, args...).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, workflow.ErrWorkflowNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toWorkflowDomain(&model)
+}
+
+// List retrieves all workflows, the default one first
+func (r *WorkflowRepository) List(ctx context.Context) ([]*workflow.Workflow, error) {
+	var models []WorkflowModel
+	err := r.db.WithContext(ctx).
+		Preload("Categories").
+		Order("is_default DESC, name").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	workflows := make([]*workflow.Workflow, 0, len(models))
+	for i := range models {
+		w, err := toWorkflowDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, w)
+	}
+	return workflows, nil
+}
+
+// Save creates or updates a workflow and replaces its category assignments
+func (r *WorkflowRepository) Save(ctx context.Context, w *workflow.Workflow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one workflow may be the default or cover a category
+		var conflicts int64
+		if w.IsDefault() {
+			if err := tx.Model(&WorkflowModel{}).
+				Where("id <> ? AND is_default", w.ID()).
+				Count(&conflicts).Error; err != nil {
+				return err
+			}
+		}
+		if conflicts == 0 && len(w.CategoryIDs()) > 0 {
+			if err := tx.Model(&WorkflowCategoryModel{}).
+				Where("workflow_id <> ? AND category_id IN ?", w.ID(), w.CategoryIDs()).
+				Count(&conflicts).Error; err != nil {
+				return err
+			}
+		}
+		if conflicts > 0 {
+			return workflow.ErrWorkflowConflict
+		}
+
+		if err := tx.Omit("Categories").Save(toWorkflowModel(w)).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workflow_id = ?", w.ID()).Delete(&WorkflowCategoryModel{}).Error; err != nil {
+			return err
+		}
+		if categories := toWorkflowCategoryModels(w); len(categories) > 0 {
+			return tx.Create(&categories).Error
+		}
+		return nil
+	})
+}
+
+// Delete deletes a workflow and its category assignments
+func (r *WorkflowRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workflow_id = ?", id).Delete(&WorkflowCategoryModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&WorkflowModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return workflow.ErrWorkflowNotFound
+		}
+		return nil
+	})
+}
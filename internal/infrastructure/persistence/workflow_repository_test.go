@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestWorkflowRepository(t *testing.T) {
+	repotest.WorkflowRepositoryContract(t, func(t *testing.T) workflow.Repository {
+		return NewWorkflowRepository(testDB(t))
+	})
+}
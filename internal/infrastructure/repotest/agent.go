@@ -0,0 +1,197 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+)
+
+// AgentRepositoryContract runs the agent.Repository contract.
+func AgentRepositoryContract(t *testing.T, newRepo func(t *testing.T) agent.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrAgentNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, agent.ErrAgentNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrAgentNotFound", err)
+		}
+		if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, agent.ErrAgentNotFound) {
+			t.Fatalf("Delete error = %v, want ErrAgentNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and updates", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAgent("Aisyah", agent.StatusOnline, "billing")
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		err := a.UpdateProfile(agent.Profile{
+			Name:      "Aisyah Rahman",
+			Email:     "aisyah@example.com",
+			Role:      "support",
+			Teams:     []string{"billing", "returns"},
+			Timezone:  "Asia/Kuala_Lumpur",
+			Signature: "Regards, Aisyah",
+		})
+		if err != nil {
+			t.Fatalf("UpdateProfile: %v", err)
+		}
+		if err := a.SetStatus(agent.StatusAway); err != nil {
+			t.Fatalf("SetStatus: %v", err)
+		}
+		if err := a.SetMaxConcurrentTickets(3); err != nil {
+			t.Fatalf("SetMaxConcurrentTickets: %v", err)
+		}
+		if err := a.SetSkills([]agent.Skill{{Name: "Returns", Level: 4}, {Name: "ms", Level: 5}}); err != nil {
+			t.Fatalf("SetSkills: %v", err)
+		}
+		assignedAt := time.Now().Truncate(time.Second)
+		a.RecordAssignment(assignedAt)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, a.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name() != "Aisyah Rahman" || got.Status() != agent.StatusAway || got.MaxConcurrentTickets() != 3 {
+			t.Fatalf("got %q status=%s max=%d, want Aisyah Rahman away max=3", got.Name(), got.Status(), got.MaxConcurrentTickets())
+		}
+		if got.Timezone() != "Asia/Kuala_Lumpur" || got.Signature() != "Regards, Aisyah" || got.Role() != "support" {
+			t.Fatalf("got timezone %q signature %q role %q", got.Timezone(), got.Signature(), got.Role())
+		}
+		if len(got.Teams()) != 2 || !got.InTeam("returns") {
+			t.Fatalf("teams = %v, want billing and returns", got.Teams())
+		}
+		if len(got.Skills()) != 2 || got.SkillLevel("returns") != 4 || got.SkillLevel("ms") != 5 {
+			t.Fatalf("skills = %v, want returns 4 and ms 5", got.Skills())
+		}
+		if got.LastAssignedAt() == nil || !got.LastAssignedAt().Equal(assignedAt) {
+			t.Fatalf("last assigned at = %v, want %v", got.LastAssignedAt(), assignedAt)
+		}
+	})
+
+	t.Run("List orders by name and filters", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, a := range []*agent.Agent{
+			newAgent("Chen", agent.StatusOnline, "billing"),
+			newAgent("Aisyah", agent.StatusOffline, "billing"),
+			newAgent("Bala", agent.StatusOnline, "logistics"),
+		} {
+			if err := repo.Save(ctx, a); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		all, err := repo.List(ctx, agent.Filter{})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 3 || all[0].Name() != "Aisyah" || all[1].Name() != "Bala" || all[2].Name() != "Chen" {
+			t.Fatalf("List returned %d agents in the wrong order", len(all))
+		}
+
+		online, err := repo.List(ctx, agent.Filter{Status: agent.StatusOnline})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(online) != 2 {
+			t.Fatalf("online = %d, want 2", len(online))
+		}
+
+		billing, err := repo.List(ctx, agent.Filter{Status: agent.StatusOnline, Team: "billing"})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(billing) != 1 || billing[0].Name() != "Chen" {
+			t.Fatalf("online billing agents = %d, want Chen", len(billing))
+		}
+	})
+
+	t.Run("FindByIDs skips unknown agents", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAgent("Aisyah", agent.StatusOnline)
+		b := newAgent("Bala", agent.StatusOnline)
+		for _, ag := range []*agent.Agent{a, b} {
+			if err := repo.Save(ctx, ag); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		got, err := repo.FindByIDs(ctx, []uuid.UUID{a.ID(), uuid.New(), b.ID()})
+		if err != nil {
+			t.Fatalf("FindByIDs: %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("FindByIDs = %d agents, want 2", len(got))
+		}
+
+		got, err = repo.FindByIDs(ctx, nil)
+		if err != nil {
+			t.Fatalf("FindByIDs: %v", err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("FindByIDs without IDs = %d agents, want none", len(got))
+		}
+	})
+
+	t.Run("RecordAssignment keeps the rest of the agent", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAgent("Aisyah", agent.StatusOnline)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		// Another writer takes the agent offline after a was loaded
+		other, err := repo.FindByID(ctx, a.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if err := other.SetStatus(agent.StatusOffline); err != nil {
+			t.Fatalf("SetStatus: %v", err)
+		}
+		if err := repo.Save(ctx, other); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		at := time.Now().UTC().Truncate(time.Millisecond)
+		if err := repo.RecordAssignment(ctx, a.ID(), at); err != nil {
+			t.Fatalf("RecordAssignment: %v", err)
+		}
+		got, err := repo.FindByID(ctx, a.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.LastAssignedAt() == nil || !got.LastAssignedAt().Equal(at) {
+			t.Fatalf("LastAssignedAt = %v, want %v", got.LastAssignedAt(), at)
+		}
+		if got.Status() != agent.StatusOffline {
+			t.Fatalf("Status = %q, want the offline status saved by the other writer", got.Status())
+		}
+
+		if err := repo.RecordAssignment(ctx, uuid.New(), at); !errors.Is(err, agent.ErrAgentNotFound) {
+			t.Fatalf("RecordAssignment unknown agent error = %v, want ErrAgentNotFound", err)
+		}
+	})
+
+	t.Run("Delete removes the agent", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAgent("Aisyah", agent.StatusOnline)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, a.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, a.ID()); !errors.Is(err, agent.ErrAgentNotFound) {
+			t.Fatalf("FindByID after Delete error = %v, want ErrAgentNotFound", err)
+		}
+	})
+}
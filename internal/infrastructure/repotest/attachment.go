@@ -0,0 +1,204 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+)
+
+// AttachmentRepositoryContract runs the attachment.Repository contract.
+func AttachmentRepositoryContract(t *testing.T, newRepo func(t *testing.T) attachment.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrAttachmentNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, attachment.ErrAttachmentNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrAttachmentNotFound", err)
+		}
+		if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, attachment.ErrAttachmentNotFound) {
+			t.Fatalf("Delete error = %v, want ErrAttachmentNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and posts", func(t *testing.T) {
+		repo := newRepo(t)
+		ticketID := uuid.New()
+		a := newAttachment(ticketID, 1024)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		messageID := uuid.New()
+		if err := a.Post(ticketID, messageID); err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, a.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.MessageID() == nil || *got.MessageID() != messageID || got.Checksum() != a.Checksum() ||
+			got.StorageKey() != a.StorageKey() || got.Size() != 1024 || got.ContentType() != "application/pdf" {
+			t.Fatalf("attachment = %+v, want the saved posted attachment", got)
+		}
+	})
+
+	t.Run("Save keeps email attachments without a ticket", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAttachment(uuid.Nil, 10)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		got, err := repo.FindByID(ctx, a.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.TicketID() != uuid.Nil || got.IsPosted() {
+			t.Fatalf("attachment ticket = %s posted = %v, want none", got.TicketID(), got.IsPosted())
+		}
+	})
+
+	t.Run("ListByTicket and TicketUsage cover the ticket's files", func(t *testing.T) {
+		repo := newRepo(t)
+		ticketID := uuid.New()
+		older := newAttachment(ticketID, 100)
+		time.Sleep(time.Millisecond)
+		newer := newAttachment(ticketID, 250)
+		for _, a := range []*attachment.Attachment{newer, older, newAttachment(uuid.New(), 999)} {
+			if err := repo.Save(ctx, a); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		attachments, err := repo.ListByTicket(ctx, ticketID)
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(attachments) != 2 || attachments[0].ID() != older.ID() || attachments[1].ID() != newer.ID() {
+			t.Fatalf("attachments = %d, want older then newer", len(attachments))
+		}
+
+		usage, err := repo.TicketUsage(ctx, ticketID)
+		if err != nil {
+			t.Fatalf("TicketUsage: %v", err)
+		}
+		if usage != 350 {
+			t.Fatalf("usage = %d, want 350", usage)
+		}
+		if usage, _ := repo.TicketUsage(ctx, uuid.New()); usage != 0 {
+			t.Fatalf("usage of an empty ticket = %d, want 0", usage)
+		}
+	})
+
+	t.Run("Delete removes the attachment", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAttachment(uuid.New(), 10)
+		if err := repo.Save(ctx, a); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, a.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, a.ID()); !errors.Is(err, attachment.ErrAttachmentNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrAttachmentNotFound", err)
+		}
+	})
+}
+
+// BlobStoreContract runs the attachment.BlobStore contract.
+func BlobStoreContract(t *testing.T, newStore func(t *testing.T) attachment.BlobStore) {
+	ctx := context.Background()
+
+	t.Run("Open returns ErrBlobNotFound", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Open(ctx, "tickets/"+uuid.NewString()); !errors.Is(err, attachment.ErrBlobNotFound) {
+			t.Fatalf("Open error = %v, want ErrBlobNotFound", err)
+		}
+		if err := store.Delete(ctx, "tickets/"+uuid.NewString()); err != nil {
+			t.Fatalf("Delete of a missing blob: %v", err)
+		}
+	})
+
+	t.Run("Put, Open and Delete", func(t *testing.T) {
+		store := newStore(t)
+		key := "tickets/" + uuid.NewString() + "/" + uuid.NewString()
+		content := "%PDF-1.4 invoice"
+		if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		r, err := store.Open(ctx, key)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		got, err := io.ReadAll(r)
+		r.Close()
+		if err != nil || string(got) != content {
+			t.Fatalf("content = %q, %v, want %q", got, err, content)
+		}
+
+		if err := store.Delete(ctx, key); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := store.Open(ctx, key); !errors.Is(err, attachment.ErrBlobNotFound) {
+			t.Fatalf("Open error = %v, want ErrBlobNotFound", err)
+		}
+	})
+}
+
+// AttachmentDownloadLogContract runs the attachment.DownloadLog contract.
+func AttachmentDownloadLogContract(t *testing.T, newLog func(t *testing.T) attachment.DownloadLog) {
+	ctx := context.Background()
+
+	t.Run("Record and List filter by attachment and ticket", func(t *testing.T) {
+		log := newLog(t)
+		ticketID := uuid.New()
+		a := newAttachment(ticketID, 10)
+		other := newAttachment(ticketID, 10)
+		link := attachment.Link{AttachmentID: a.ID(), UserID: uuid.New(), UserType: shared.SenderAgent}
+		older := attachment.NewDownload(a, link, "203.0.113.7", "curl/8.0")
+		time.Sleep(time.Millisecond)
+		newer := attachment.NewDownload(a, link, "203.0.113.8", "curl/8.0")
+		for _, d := range []attachment.Download{
+			older,
+			newer,
+			attachment.NewDownload(other, link, "203.0.113.7", ""),
+			attachment.NewDownload(newAttachment(uuid.New(), 10), link, "203.0.113.7", ""),
+		} {
+			if err := log.Record(ctx, d); err != nil {
+				t.Fatalf("Record: %v", err)
+			}
+		}
+
+		attachmentID := a.ID()
+		got, total, err := log.List(ctx, attachment.DownloadFilter{AttachmentID: &attachmentID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 2 || len(got) != 2 || got[0].ID() != newer.ID() || got[1].ID() != older.ID() {
+			t.Fatalf("List = %d of %d downloads, want newer then older", len(got), total)
+		}
+		d := got[1]
+		if d.TicketID() != ticketID || d.UserID() != link.UserID || d.UserType() != shared.SenderAgent ||
+			d.IPAddress() != "203.0.113.7" || d.UserAgent() != "curl/8.0" {
+			t.Fatalf("download = %+v, want the recorded one", d)
+		}
+
+		page, total, err := log.List(ctx, attachment.DownloadFilter{TicketID: &ticketID, PerPage: 1})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 || len(page) != 1 {
+			t.Fatalf("List = %d of %d downloads, want 1 of 3", len(page), total)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+)
+
+// AutomationPolicyRepositoryContract runs the automation.Repository contract.
+func AutomationPolicyRepositoryContract(t *testing.T, newRepo func(t *testing.T) automation.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrPolicyNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, automation.ErrPolicyNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrPolicyNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips the schedule", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newAutomationPolicy("Default", nil)
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, p.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Schedule() != p.Schedule() || !got.IsGlobal() {
+			t.Fatalf("got %+v, want %+v", got.Schedule(), p.Schedule())
+		}
+	})
+
+	t.Run("FindForCategory falls back to the global policy", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		global := newAutomationPolicy("Default", nil)
+		scoped := newAutomationPolicy("Billing", &categoryID)
+		for _, p := range []*automation.Policy{scoped, global} {
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		if got, err := repo.FindForCategory(ctx, &categoryID); err != nil || got.ID() != scoped.ID() {
+			t.Fatalf("FindForCategory(category) = %v, %v, want the category policy", got, err)
+		}
+		other := uuid.New()
+		if got, err := repo.FindForCategory(ctx, &other); err != nil || got.ID() != global.ID() {
+			t.Fatalf("FindForCategory(other) = %v, %v, want the global policy", got, err)
+		}
+		if got, err := repo.FindForCategory(ctx, nil); err != nil || got.ID() != global.ID() {
+			t.Fatalf("FindForCategory(nil) = %v, %v, want the global policy", got, err)
+		}
+
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 2 || !all[0].IsGlobal() {
+			t.Fatalf("List = %d policies, want 2 with the global one first", len(all))
+		}
+	})
+
+	t.Run("Save rejects a second policy for the same category", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Save(ctx, newAutomationPolicy("Default", nil)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newAutomationPolicy("Another", nil)); !errors.Is(err, automation.ErrPolicyConflict) {
+			t.Fatalf("Save error = %v, want ErrPolicyConflict", err)
+		}
+	})
+
+	t.Run("Delete removes the policy", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newAutomationPolicy("Default", nil)
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID()); !errors.Is(err, automation.ErrPolicyNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrPolicyNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+)
+
+// CannedResponseRepositoryContract runs the response.Repository contract.
+func CannedResponseRepositoryContract(t *testing.T, newRepo func(t *testing.T) response.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrResponseNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, response.ErrResponseNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrResponseNotFound", err)
+		}
+	})
+
+	t.Run("Save and FindByShortcut", func(t *testing.T) {
+		repo := newRepo(t)
+		cr := newCannedResponse("Greeting", "/hi", nil, true)
+		if err := repo.Save(ctx, cr); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByShortcut(ctx, "/hi")
+		if err != nil {
+			t.Fatalf("FindByShortcut: %v", err)
+		}
+		if got.ID() != cr.ID() {
+			t.Fatalf("id = %s, want %s", got.ID(), cr.ID())
+		}
+
+		cr.Deactivate()
+		if err := repo.Save(ctx, cr); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if _, err := repo.FindByShortcut(ctx, "/hi"); !errors.Is(err, response.ErrResponseNotFound) {
+			t.Fatalf("FindByShortcut on inactive = %v, want ErrResponseNotFound", err)
+		}
+	})
+
+	t.Run("List filters by category and orders by usage", func(t *testing.T) {
+		repo := newRepo(t)
+		billing, returns := uuid.New(), uuid.New()
+		general := newCannedResponse("General", "", nil, true)
+		popular := newCannedResponse("Refund issued", "", &billing, true)
+		popular.IncrementUsage()
+		other := newCannedResponse("Return label", "", &returns, true)
+		inactive := newCannedResponse("Old", "", &billing, false)
+		for _, cr := range []*response.CannedResponse{general, popular, other, inactive} {
+			if err := repo.Save(ctx, cr); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		got, err := repo.List(ctx, &billing, true)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != popular.ID() || got[1].ID() != general.ID() {
+			t.Fatalf("billing responses = %d, want popular then general", len(got))
+		}
+
+		all, err := repo.List(ctx, nil, false)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 4 {
+			t.Fatalf("all responses = %d, want 4", len(all))
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		cr := newCannedResponse("Greeting", "", nil, true)
+		if err := repo.Save(ctx, cr); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, cr.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, cr.ID()); !errors.Is(err, response.ErrResponseNotFound) {
+			t.Fatalf("FindByID after delete = %v, want ErrResponseNotFound", err)
+		}
+	})
+}
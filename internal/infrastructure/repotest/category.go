@@ -0,0 +1,97 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+)
+
+// CategoryRepositoryContract runs the category.Repository contract.
+func CategoryRepositoryContract(t *testing.T, newRepo func(t *testing.T) category.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrCategoryNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, category.ErrCategoryNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrCategoryNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and updates", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCategory("Orders", 1, true)
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		c.SetSLAHours(8)
+		c.Deactivate()
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, c.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name() != "Orders" || got.SLAHours() != 8 || got.IsActive() {
+			t.Fatalf("got %q sla=%d active=%v, want Orders sla=8 inactive", got.Name(), got.SLAHours(), got.IsActive())
+		}
+	})
+
+	t.Run("List orders by priority and name", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, c := range []*category.Category{
+			newCategory("Returns", 2, true),
+			newCategory("Billing", 2, true),
+			newCategory("Orders", 1, true),
+			newCategory("Archived", 0, false),
+		} {
+			if err := repo.Save(ctx, c); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		active, err := repo.List(ctx, true)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		want := []string{"Orders", "Billing", "Returns"}
+		if len(active) != len(want) {
+			t.Fatalf("active categories = %d, want %d", len(active), len(want))
+		}
+		for i, name := range want {
+			if active[i].Name() != name {
+				t.Fatalf("category %d = %q, want %q", i, active[i].Name(), name)
+			}
+		}
+
+		all, err := repo.List(ctx, false)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 4 {
+			t.Fatalf("all categories = %d, want 4", len(all))
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCategory("Orders", 1, true)
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, c.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, c.ID()); !errors.Is(err, category.ErrCategoryNotFound) {
+			t.Fatalf("FindByID after delete = %v, want ErrCategoryNotFound", err)
+		}
+		if err := repo.Delete(ctx, c.ID()); !errors.Is(err, category.ErrCategoryNotFound) {
+			t.Fatalf("second Delete = %v, want ErrCategoryNotFound", err)
+		}
+	})
+}
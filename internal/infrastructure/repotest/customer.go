@@ -0,0 +1,59 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+)
+
+// CustomerRepositoryContract runs the customer.Repository contract.
+func CustomerRepositoryContract(t *testing.T, newRepo func(t *testing.T) customer.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrCustomerNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, customer.ErrCustomerNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrCustomerNotFound", err)
+		}
+		if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, customer.ErrCustomerNotFound) {
+			t.Fatalf("Delete error = %v, want ErrCustomerNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and updates", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCustomer("siti@example.com", "Siti")
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		c.SyncIdentity("siti.aminah@example.com", "")
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		got, err := repo.FindByID(ctx, c.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Email() != "siti.aminah@example.com" || got.Name() != "Siti" {
+			t.Fatalf("customer = %q <%s>, want the new email and the kept name", got.Name(), got.Email())
+		}
+	})
+
+	t.Run("Delete removes the customer", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCustomer("siti@example.com", "Siti")
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, c.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, c.ID()); !errors.Is(err, customer.ErrCustomerNotFound) {
+			t.Fatalf("FindByID after Delete error = %v, want ErrCustomerNotFound", err)
+		}
+	})
+}
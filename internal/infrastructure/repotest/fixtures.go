@@ -0,0 +1,310 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/agent"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/automation"
+	"github.com/Ecom-micro-template/service-support/internal/domain/category"
+	"github.com/Ecom-micro-template/service-support/internal/domain/customer"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+	"github.com/Ecom-micro-template/service-support/internal/domain/response"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+// must returns what a domain constructor built for a fixture. Fixtures are
+// fixed data, so a refusal is a bug in the suite and panics like
+// template.Must rather than failing the repository under test.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Sprintf("repotest: building fixture: %v", err))
+	}
+	return v
+}
+
+// ticketNumber returns the number of the seq-th fixture ticket.
+func ticketNumber(seq int) string {
+	return fmt.Sprintf("TKT-20261016-%04d", seq)
+}
+
+// newTicket returns an open ticket with the customer's opening message.
+func newTicket(t *testing.T, seq int, customerID *uuid.UUID, subject string) *ticket.Ticket {
+	t.Helper()
+	tk := must(ticket.NewTicket(ticket.TicketParams{
+		TicketNumber: ticketNumber(seq),
+		CustomerID:   customerID,
+		GuestEmail:   "guest@example.com",
+		Subject:      subject,
+	}))
+	msg := ticket.CreateCustomerMessage(tk.ID(), customerID, "Customer", "guest@example.com", "first message")
+	if err := tk.AddMessage(msg); err != nil {
+		t.Fatalf("AddMessage: %v", err)
+	}
+	return tk
+}
+
+// ticketDueAt returns an open ticket whose SLA deadline is the given time.
+func ticketDueAt(seq int, deadline time.Time) *ticket.Ticket {
+	now := time.Now()
+	return ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:           uuid.New(),
+		TicketNumber: ticketNumber(seq),
+		GuestEmail:   "guest@example.com",
+		Subject:      fmt.Sprintf("SLA ticket %d", seq),
+		Status:       string(shared.StatusOpen),
+		Priority:     string(shared.PriorityNormal),
+		SLADeadline:  &deadline,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+}
+
+// ticketFirstResponseDueAt returns an open ticket whose first response is
+// due at the given time and whose resolution is due a day later.
+func ticketFirstResponseDueAt(seq int, deadline time.Time) *ticket.Ticket {
+	now := time.Now()
+	resolution := deadline.Add(24 * time.Hour)
+	return ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:                    uuid.New(),
+		TicketNumber:          ticketNumber(seq),
+		GuestEmail:            "guest@example.com",
+		Subject:               fmt.Sprintf("First response ticket %d", seq),
+		Status:                string(shared.StatusOpen),
+		Priority:              string(shared.PriorityNormal),
+		SLADeadline:           &resolution,
+		FirstResponseDeadline: &deadline,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	})
+}
+
+// idleTicket returns a ticket in the status that has been idle since the
+// given time.
+func idleTicket(seq int, status shared.TicketStatus, categoryID *uuid.UUID, idleSince time.Time) *ticket.Ticket {
+	return ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:           uuid.New(),
+		TicketNumber: ticketNumber(seq),
+		GuestEmail:   "guest@example.com",
+		CategoryID:   categoryID,
+		Subject:      fmt.Sprintf("Idle ticket %d", seq),
+		Status:       string(status),
+		Priority:     string(shared.PriorityNormal),
+		CreatedAt:    idleSince,
+		UpdatedAt:    idleSince,
+		IdleSince:    idleSince,
+	})
+}
+
+// assignedTicket returns an in-progress ticket assigned to the agent.
+func assignedTicket(seq int, customerID *uuid.UUID, agentID uuid.UUID, createdAt time.Time) *ticket.Ticket {
+	return ticket.Reconstitute(ticket.ReconstituteParams{
+		ID:               uuid.New(),
+		TicketNumber:     ticketNumber(seq),
+		CustomerID:       customerID,
+		GuestEmail:       "guest@example.com",
+		Subject:          fmt.Sprintf("Assigned ticket %d", seq),
+		Status:           string(shared.StatusInProgress),
+		Priority:         string(shared.PriorityNormal),
+		AssignedTo:       &agentID,
+		AssignmentReason: ticket.AssignmentReasonManual,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	})
+}
+
+// addCustomerReply adds a reply from the guest to the ticket.
+func addCustomerReply(t *testing.T, tk *ticket.Ticket) {
+	t.Helper()
+	msg := ticket.CreateCustomerMessage(tk.ID(), nil, "Customer", "guest@example.com", "any update?")
+	if err := tk.AddMessage(msg); err != nil {
+		t.Fatalf("AddMessage: %v", err)
+	}
+}
+
+func mustSave(t *testing.T, repo ticket.Repository, tk *ticket.Ticket) {
+	t.Helper()
+	if err := repo.Save(context.Background(), tk); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func newCategory(name string, priority int, active bool) *category.Category {
+	return must(category.NewCategory(category.CategoryParams{
+		Name:     name,
+		Priority: priority,
+		IsActive: active,
+	}))
+}
+
+func newCannedResponse(title, shortcut string, categoryID *uuid.UUID, active bool) *response.CannedResponse {
+	return must(response.NewCannedResponse(response.CannedResponseParams{
+		Title:      title,
+		Content:    title + " content",
+		CategoryID: categoryID,
+		Shortcut:   shortcut,
+		IsActive:   active,
+	}))
+}
+
+// newCalendar builds a Monday to Friday, 09:00-17:00 calendar.
+func newCalendar(name string, categoryID *uuid.UUID) *sla.Calendar {
+	hours := make([]sla.WorkingHours, 0, 5)
+	for d := time.Monday; d <= time.Friday; d++ {
+		hours = append(hours, sla.WorkingHours{Weekday: d, Start: 9 * 60, End: 17 * 60})
+	}
+	return must(sla.NewCalendar(sla.CalendarParams{
+		Name:         name,
+		Timezone:     "Asia/Kuala_Lumpur",
+		CategoryID:   categoryID,
+		WorkingHours: hours,
+	}))
+}
+
+func newPolicy(name string, priority shared.TicketPriority, categoryID *uuid.UUID) *sla.Policy {
+	return must(sla.NewPolicy(sla.PolicyParams{
+		Name:       name,
+		Priority:   string(priority),
+		CategoryID: categoryID,
+		Targets:    sla.Targets{FirstResponse: 30 * time.Minute, Resolution: 6 * time.Hour},
+	}))
+}
+
+// newWorkflow extends the built-in workflow with an "awaiting_vendor" state
+// that pauses the SLA.
+func newWorkflow(name string, isDefault bool, categoryIDs ...uuid.UUID) *workflow.Workflow {
+	base := workflow.Default()
+	states := append(base.States(), workflow.State{Key: "awaiting_vendor", Label: "Awaiting Vendor", Active: true, PausesSLA: true})
+	transitions := append(base.Transitions(),
+		workflow.Transition{From: shared.StatusInProgress, To: "awaiting_vendor"},
+		workflow.Transition{From: "awaiting_vendor", To: shared.StatusInProgress},
+	)
+	return must(workflow.NewWorkflow(workflow.WorkflowParams{
+		Name:        name,
+		IsDefault:   isDefault,
+		CategoryIDs: categoryIDs,
+		States:      states,
+		Transitions: transitions,
+	}))
+}
+
+func newAgent(name string, status agent.Status, teams ...string) *agent.Agent {
+	return must(agent.NewAgent(agent.AgentParams{
+		ID:     uuid.New(),
+		Name:   name,
+		Email:  name + "@example.com",
+		Status: status,
+		Teams:  teams,
+	}))
+}
+
+func newCustomer(email, name string) *customer.Customer {
+	return must(customer.NewCustomer(customer.CustomerParams{ID: uuid.New(), Email: email, Name: name}))
+}
+
+func newTeam(key, name string, categoryIDs ...uuid.UUID) *team.Team {
+	return must(team.NewTeam(team.TeamParams{Key: key, Name: name, CategoryIDs: categoryIDs}))
+}
+
+func newRoutingRule(name string, position int) *routing.Rule {
+	return must(routing.NewRule(routing.RuleParams{
+		Name:           name,
+		Position:       position,
+		Enabled:        true,
+		RequiredSkills: []routing.SkillRequirement{{Name: "payments", MinLevel: 2}},
+	}))
+}
+
+func newTriggerRule(name string, position int, enabled bool, events ...trigger.Event) *trigger.Rule {
+	return must(trigger.NewRule(trigger.RuleParams{
+		Name:     name,
+		Position: position,
+		Enabled:  enabled,
+		Events:   events,
+		Actions:  []trigger.Action{{Type: trigger.ActionAddTag, Value: "triaged"}},
+	}))
+}
+
+func newAutomationPolicy(name string, categoryID *uuid.UUID) *automation.Policy {
+	return must(automation.NewPolicy(automation.PolicyParams{
+		Name:       name,
+		CategoryID: categoryID,
+		Schedule: automation.Schedule{
+			AutoCloseAfter:       7 * 24 * time.Hour,
+			PendingReminderAfter: 48 * time.Hour,
+			PendingResolveAfter:  120 * time.Hour,
+			StaleAfter:           24 * time.Hour,
+		},
+	}))
+}
+
+func newTicketLink(ticketID, otherID uuid.UUID, relation link.Relation) *link.Link {
+	return must(link.NewLink(link.LinkParams{
+		TicketID:      ticketID,
+		OtherID:       otherID,
+		Relation:      relation,
+		CreatedByName: "agent@example.com",
+	}))
+}
+
+func newTicketMention(agentID uuid.UUID) *mention.Mention {
+	return must(mention.NewMention(mention.MentionParams{
+		TicketID:        uuid.New(),
+		MessageID:       uuid.New(),
+		AgentID:         agentID,
+		MentionedByName: "agent@example.com",
+	}))
+}
+
+func newNotificationTemplate(event notification.Event, locale string) *notification.Template {
+	return must(notification.NewTemplate(notification.TemplateParams{
+		Event:   string(event),
+		Locale:  locale,
+		Subject: "[{{.TicketNumber}}] {{.Subject}}",
+		Body:    "Hello {{.CustomerName}}",
+	}))
+}
+
+func newNotificationDelivery(ticketID uuid.UUID) *notification.Delivery {
+	return must(notification.NewDelivery(notification.DeliveryParams{
+		TicketID:   ticketID,
+		Event:      notification.EventAgentReplied,
+		Locale:     "en",
+		To:         "guest@example.com",
+		CC:         []string{"partner@example.com"},
+		Subject:    "[TKT-1] Where is my order?",
+		Body:       "It shipped today.",
+		MessageID:  "<reply@support.example.com>",
+		InReplyTo:  "<question@example.com>",
+		References: []string{"<first@example.com>", "<question@example.com>"},
+	}))
+}
+
+func newAttachment(ticketID uuid.UUID, size int64) *attachment.Attachment {
+	id := uuid.New()
+	uploadedBy := uuid.New()
+	return must(attachment.NewAttachment(attachment.AttachmentParams{
+		ID:           id,
+		TicketID:     ticketID,
+		Name:         "invoice.pdf",
+		ContentType:  "application/pdf",
+		Size:         size,
+		Checksum:     strings.Repeat("ab", 32),
+		StorageKey:   "tickets/" + ticketID.String() + "/" + id.String(),
+		UploadedBy:   &uploadedBy,
+		UploaderType: "customer",
+	}))
+}
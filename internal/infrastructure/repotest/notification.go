@@ -0,0 +1,250 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/notification"
+)
+
+// NotificationTemplateRepositoryContract runs the
+// notification.TemplateRepository contract.
+func NotificationTemplateRepositoryContract(t *testing.T, newRepo func(t *testing.T) notification.TemplateRepository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrTemplateNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, notification.ErrTemplateNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrTemplateNotFound", err)
+		}
+		if _, err := repo.Find(ctx, notification.EventTicketReceived, "en"); !errors.Is(err, notification.ErrTemplateNotFound) {
+			t.Fatalf("Find error = %v, want ErrTemplateNotFound", err)
+		}
+		if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, notification.ErrTemplateNotFound) {
+			t.Fatalf("Delete error = %v, want ErrTemplateNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and updates", func(t *testing.T) {
+		repo := newRepo(t)
+		tmpl := newNotificationTemplate(notification.EventTicketResolved, "ms")
+		if err := repo.Save(ctx, tmpl); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := tmpl.Update("[{{.TicketNumber}}] Selesai", "Tiket {{.TicketNumber}} telah selesai."); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if err := repo.Save(ctx, tmpl); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.Find(ctx, notification.EventTicketResolved, "ms")
+		if err != nil {
+			t.Fatalf("Find: %v", err)
+		}
+		if got.ID() != tmpl.ID() || got.Subject() != "[{{.TicketNumber}}] Selesai" || got.Body() != "Tiket {{.TicketNumber}} telah selesai." {
+			t.Fatalf("template = %q / %q, want the updated template", got.Subject(), got.Body())
+		}
+	})
+
+	t.Run("Save rejects a second template for the event and locale", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Save(ctx, newNotificationTemplate(notification.EventAgentReplied, "de")); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		err := repo.Save(ctx, newNotificationTemplate(notification.EventAgentReplied, "de"))
+		if !errors.Is(err, notification.ErrTemplateConflict) {
+			t.Fatalf("Save error = %v, want ErrTemplateConflict", err)
+		}
+	})
+
+	t.Run("List filters by event and locale", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, tmpl := range []*notification.Template{
+			newNotificationTemplate(notification.EventTicketReceived, "ms"),
+			newNotificationTemplate(notification.EventTicketReceived, "de"),
+			newNotificationTemplate(notification.EventAgentReplied, "ms"),
+		} {
+			if err := repo.Save(ctx, tmpl); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		all, err := repo.List(ctx, notification.TemplateFilter{})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 3 || all[0].Event() != notification.EventAgentReplied || all[1].Locale() != "de" {
+			t.Fatalf("listed %d templates, want 3 ordered by event and locale", len(all))
+		}
+
+		received, err := repo.List(ctx, notification.TemplateFilter{Event: notification.EventTicketReceived})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(received) != 2 {
+			t.Fatalf("listed %d ticket_received templates, want 2", len(received))
+		}
+
+		malay, err := repo.List(ctx, notification.TemplateFilter{Locale: "ms"})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(malay) != 2 {
+			t.Fatalf("listed %d ms templates, want 2", len(malay))
+		}
+	})
+
+	t.Run("Delete removes the template", func(t *testing.T) {
+		repo := newRepo(t)
+		tmpl := newNotificationTemplate(notification.EventSurveyRequest, "en")
+		if err := repo.Save(ctx, tmpl); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, tmpl.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, tmpl.ID()); !errors.Is(err, notification.ErrTemplateNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrTemplateNotFound", err)
+		}
+	})
+}
+
+// NotificationDeliveryRepositoryContract runs the
+// notification.DeliveryRepository contract.
+func NotificationDeliveryRepositoryContract(t *testing.T, newRepo func(t *testing.T) notification.DeliveryRepository) {
+	ctx := context.Background()
+	lease := time.Minute
+
+	t.Run("Save creates and updates", func(t *testing.T) {
+		repo := newRepo(t)
+		ticketID := uuid.New()
+		d := newNotificationDelivery(ticketID)
+		if err := repo.Save(ctx, d); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		d.MarkSent()
+		if err := repo.Save(ctx, d); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.ListByTicket(ctx, ticketID)
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(got) != 1 || got[0].Status() != notification.DeliverySent || got[0].SentAt() == nil || got[0].Attempts() != 1 {
+			t.Fatalf("deliveries = %d, want the one sent delivery", len(got))
+		}
+		if got[0].To() != "guest@example.com" || len(got[0].CC()) != 1 || len(got[0].References()) != 2 {
+			t.Fatalf("delivery to %q cc %v references %v, want the saved addresses and thread", got[0].To(), got[0].CC(), got[0].References())
+		}
+	})
+
+	t.Run("ListByTicket returns the ticket's deliveries oldest first", func(t *testing.T) {
+		repo := newRepo(t)
+		ticketID := uuid.New()
+		older := newNotificationDelivery(ticketID)
+		time.Sleep(time.Millisecond)
+		newer := newNotificationDelivery(ticketID)
+		for _, d := range []*notification.Delivery{newer, older, newNotificationDelivery(uuid.New())} {
+			if err := repo.Save(ctx, d); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		got, err := repo.ListByTicket(ctx, ticketID)
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(got) != 2 || got[0].ID() != older.ID() || got[1].ID() != newer.ID() {
+			t.Fatalf("listed %d deliveries, want the older and newer in order", len(got))
+		}
+	})
+
+	t.Run("FindByMessageID finds the delivery a reply answers", func(t *testing.T) {
+		repo := newRepo(t)
+		d := newNotificationDelivery(uuid.New())
+		if err := repo.Save(ctx, d); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByMessageID(ctx, []string{"<question@example.com>", "<reply@support.example.com>"})
+		if err != nil {
+			t.Fatalf("FindByMessageID: %v", err)
+		}
+		if got.ID() != d.ID() || got.TicketID() != d.TicketID() {
+			t.Fatalf("found delivery %s, want %s", got.ID(), d.ID())
+		}
+		if _, err := repo.FindByMessageID(ctx, []string{"<question@example.com>"}); !errors.Is(err, notification.ErrDeliveryNotFound) {
+			t.Fatalf("FindByMessageID error = %v, want ErrDeliveryNotFound", err)
+		}
+		if _, err := repo.FindByMessageID(ctx, nil); !errors.Is(err, notification.ErrDeliveryNotFound) {
+			t.Fatalf("FindByMessageID without IDs error = %v, want ErrDeliveryNotFound", err)
+		}
+	})
+
+	t.Run("ClaimDue leases due pending deliveries", func(t *testing.T) {
+		repo := newRepo(t)
+		due := newNotificationDelivery(uuid.New())
+		later := newNotificationDelivery(uuid.New())
+		later.MarkRetry("mail server down", time.Now().Add(time.Hour))
+		sent := newNotificationDelivery(uuid.New())
+		sent.MarkSent()
+		failed := newNotificationDelivery(uuid.New())
+		failed.MarkFailed("mailbox unavailable")
+		for _, d := range []*notification.Delivery{due, later, sent, failed} {
+			if err := repo.Save(ctx, d); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		claimed := mustClaimDeliveries(t, repo, 10, lease)
+		if len(claimed) != 1 || claimed[0].ID() != due.ID() {
+			t.Fatalf("claimed %d deliveries, want only the due one", len(claimed))
+		}
+		if again := mustClaimDeliveries(t, repo, 10, lease); len(again) != 0 {
+			t.Fatalf("leased delivery claimed again")
+		}
+
+		claimed[0].MarkRetry("mail server down", time.Now().Add(-time.Second))
+		if err := repo.Save(ctx, claimed[0]); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		retried := mustClaimDeliveries(t, repo, 10, lease)
+		if len(retried) != 1 || retried[0].Attempts() != 1 || retried[0].LastError() != "mail server down" {
+			t.Fatalf("claimed %d deliveries, want the retried one after 1 attempt", len(retried))
+		}
+	})
+
+	t.Run("ClaimDue honours the limit oldest first", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newNotificationDelivery(uuid.New())
+		time.Sleep(time.Millisecond)
+		second := newNotificationDelivery(uuid.New())
+		for _, d := range []*notification.Delivery{second, first} {
+			if err := repo.Save(ctx, d); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		claimed := mustClaimDeliveries(t, repo, 1, lease)
+		if len(claimed) != 1 || claimed[0].ID() != first.ID() {
+			t.Fatalf("claimed %d deliveries, want the oldest", len(claimed))
+		}
+		if next := mustClaimDeliveries(t, repo, 1, lease); len(next) != 1 || next[0].ID() != second.ID() {
+			t.Fatalf("claimed %d deliveries, want the second", len(next))
+		}
+	})
+}
+
+func mustClaimDeliveries(t *testing.T, repo notification.DeliveryRepository, limit int, lease time.Duration) []*notification.Delivery {
+	t.Helper()
+	claimed, err := repo.ClaimDue(context.Background(), limit, lease)
+	if err != nil {
+		t.Fatalf("ClaimDue: %v", err)
+	}
+	return claimed
+}
@@ -0,0 +1,123 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+	"github.com/Ecom-micro-template/service-support/internal/events"
+)
+
+// OutboxContract runs the events.OutboxStore contract. newStore returns a
+// ticket repository and the outbox its Save writes to.
+func OutboxContract(t *testing.T, newStore func(t *testing.T) (ticket.Repository, events.OutboxStore)) {
+	ctx := context.Background()
+	lease := time.Minute
+
+	t.Run("Save writes events to the outbox", func(t *testing.T) {
+		repo, outbox := newStore(t)
+		tk := newTicket(t, 40, nil, "Outbox ticket")
+		mustSave(t, repo, tk)
+
+		claimed := mustClaim(t, outbox, 10, lease)
+		if len(claimed) != 1 || claimed[0].Subject != events.EventTicketCreated {
+			t.Fatalf("claimed = %v, want one %s message", subjects(claimed), events.EventTicketCreated)
+		}
+		if claimed[0].AggregateID != tk.ID() {
+			t.Fatalf("aggregate id = %s, want %s", claimed[0].AggregateID, tk.ID())
+		}
+		if len(tk.Events()) != 0 {
+			t.Fatal("Save should drain the ticket's events")
+		}
+	})
+
+	t.Run("Messages of one ticket are claimed in order", func(t *testing.T) {
+		repo, outbox := newStore(t)
+		tk := newTicket(t, 41, nil, "Ordered ticket")
+		mustSave(t, repo, tk)
+		addCustomerReply(t, tk)
+		mustSave(t, repo, tk)
+		other := newTicket(t, 42, nil, "Other ticket")
+		mustSave(t, repo, other)
+
+		first := mustClaim(t, outbox, 10, lease)
+		if len(first) != 2 {
+			t.Fatalf("claimed %v, want the head of each ticket", subjects(first))
+		}
+		if again := mustClaim(t, outbox, 10, lease); len(again) != 0 {
+			t.Fatalf("leased messages claimed again: %v", subjects(again))
+		}
+
+		for _, msg := range first {
+			if err := outbox.MarkPublished(ctx, msg.ID); err != nil {
+				t.Fatalf("MarkPublished: %v", err)
+			}
+		}
+		next := mustClaim(t, outbox, 10, lease)
+		if len(next) != 1 || next[0].AggregateID != tk.ID() || next[0].Subject != events.EventTicketReplied {
+			t.Fatalf("claimed %v, want the reply of the first ticket", subjects(next))
+		}
+	})
+
+	t.Run("Failed message blocks its ticket until retried", func(t *testing.T) {
+		repo, outbox := newStore(t)
+		tk := newTicket(t, 43, nil, "Retry ticket")
+		mustSave(t, repo, tk)
+		addCustomerReply(t, tk)
+		mustSave(t, repo, tk)
+
+		head := mustClaim(t, outbox, 10, lease)
+		if len(head) != 1 {
+			t.Fatalf("claimed %v, want 1", subjects(head))
+		}
+		if err := outbox.MarkFailed(ctx, head[0].ID, "nats down", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("MarkFailed: %v", err)
+		}
+		if got := mustClaim(t, outbox, 10, lease); len(got) != 0 {
+			t.Fatalf("claimed %v while the head waits for its retry", subjects(got))
+		}
+
+		if err := outbox.MarkFailed(ctx, head[0].ID, "nats down", time.Now().Add(-time.Second)); err != nil {
+			t.Fatalf("MarkFailed: %v", err)
+		}
+		retried := mustClaim(t, outbox, 10, lease)
+		if len(retried) != 1 || retried[0].ID != head[0].ID || retried[0].Attempts != 2 {
+			t.Fatalf("claimed %v, want the head again after 2 attempts", subjects(retried))
+		}
+	})
+
+	t.Run("Dead message unblocks its ticket", func(t *testing.T) {
+		repo, outbox := newStore(t)
+		tk := newTicket(t, 44, nil, "Dead ticket")
+		mustSave(t, repo, tk)
+		addCustomerReply(t, tk)
+		mustSave(t, repo, tk)
+
+		head := mustClaim(t, outbox, 10, lease)
+		if err := outbox.MarkDead(ctx, head[0].ID, "rejected"); err != nil {
+			t.Fatalf("MarkDead: %v", err)
+		}
+		next := mustClaim(t, outbox, 10, lease)
+		if len(next) != 1 || next[0].Subject != events.EventTicketReplied {
+			t.Fatalf("claimed %v, want the reply", subjects(next))
+		}
+	})
+}
+
+func mustClaim(t *testing.T, outbox events.OutboxStore, limit int, lease time.Duration) []events.OutboxMessage {
+	t.Helper()
+	claimed, err := outbox.ClaimPending(context.Background(), limit, lease)
+	if err != nil {
+		t.Fatalf("ClaimPending: %v", err)
+	}
+	return claimed
+}
+
+func subjects(messages []events.OutboxMessage) []string {
+	out := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		out = append(out, msg.Subject)
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/routing"
+)
+
+// RoutingRuleRepositoryContract runs the routing.Repository contract.
+func RoutingRuleRepositoryContract(t *testing.T, newRepo func(t *testing.T) routing.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrRuleNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, routing.ErrRuleNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrRuleNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips the condition and skills", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID, teamID := uuid.New(), uuid.New()
+		hasOrder := true
+		rule, err := routing.NewRule(routing.RuleParams{
+			Name:    "Malay returns",
+			Enabled: true,
+			Condition: routing.Condition{
+				CategoryIDs: []uuid.UUID{categoryID},
+				Tags:        []string{"refund"},
+				Languages:   []string{"ms"},
+				HasOrder:    &hasOrder,
+			},
+			RequiredSkills: []routing.SkillRequirement{{Name: "returns", MinLevel: 3}, {Name: "ms", MinLevel: 4}},
+			FallbackTeamID: &teamID,
+		})
+		if err != nil {
+			t.Fatalf("NewRule: %v", err)
+		}
+		if err := repo.Save(ctx, rule); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, rule.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		c := got.Condition()
+		if len(c.CategoryIDs) != 1 || c.CategoryIDs[0] != categoryID || len(c.Tags) != 1 || len(c.Languages) != 1 {
+			t.Fatalf("condition = %+v, want the saved one", c)
+		}
+		if c.HasOrder == nil || !*c.HasOrder {
+			t.Fatalf("HasOrder = %v, want true", c.HasOrder)
+		}
+		if len(got.RequiredSkills()) != 2 || got.RequiredSkills()[0] != (routing.SkillRequirement{Name: "ms", MinLevel: 4}) {
+			t.Fatalf("required skills = %v, want ms 4 and returns 3", got.RequiredSkills())
+		}
+		if got.FallbackTeamID() == nil || *got.FallbackTeamID() != teamID {
+			t.Fatalf("FallbackTeamID = %v, want %s", got.FallbackTeamID(), teamID)
+		}
+	})
+
+	t.Run("List orders by position", func(t *testing.T) {
+		repo := newRepo(t)
+		second := newRoutingRule("A second", 2)
+		first := newRoutingRule("B first", 1)
+		for _, rule := range []*routing.Rule{second, first} {
+			if err := repo.Save(ctx, rule); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		rules, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(rules) != 2 || rules[0].ID() != first.ID() || rules[1].ID() != second.ID() {
+			t.Fatalf("List = %d rules, want first then second", len(rules))
+		}
+	})
+
+	t.Run("Delete removes the rule", func(t *testing.T) {
+		repo := newRepo(t)
+		rule := newRoutingRule("Old", 0)
+		if err := repo.Save(ctx, rule); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, rule.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, rule.ID()); !errors.Is(err, routing.ErrRuleNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrRuleNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// SLACalendarRepositoryContract runs the sla.Repository contract.
+func SLACalendarRepositoryContract(t *testing.T, newRepo func(t *testing.T) sla.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrCalendarNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, sla.ErrCalendarNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrCalendarNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips hours and holidays", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCalendar("Office", nil)
+		if err := c.AddHoliday("2026-12-25", "Christmas"); err != nil {
+			t.Fatalf("AddHoliday: %v", err)
+		}
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		if err := c.RemoveHoliday("2026-12-25"); err != nil {
+			t.Fatalf("RemoveHoliday: %v", err)
+		}
+		if err := c.AddHoliday("2026-01-01", "New Year"); err != nil {
+			t.Fatalf("AddHoliday: %v", err)
+		}
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, c.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Timezone() != "Asia/Kuala_Lumpur" || len(got.WorkingHours()) != 5 {
+			t.Fatalf("got tz=%q hours=%d, want Asia/Kuala_Lumpur and 5 windows", got.Timezone(), len(got.WorkingHours()))
+		}
+		if len(got.Holidays()) != 1 || got.Holidays()[0].Date != "2026-01-01" {
+			t.Fatalf("holidays = %+v, want only 2026-01-01", got.Holidays())
+		}
+	})
+
+	t.Run("FindForCategory falls back to the global calendar", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		if _, err := repo.FindForCategory(ctx, &categoryID); !errors.Is(err, sla.ErrCalendarNotFound) {
+			t.Fatalf("FindForCategory error = %v, want ErrCalendarNotFound", err)
+		}
+
+		global := newCalendar("Global", nil)
+		scoped := newCalendar("Billing", &categoryID)
+		for _, c := range []*sla.Calendar{global, scoped} {
+			if err := repo.Save(ctx, c); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		got, err := repo.FindForCategory(ctx, &categoryID)
+		if err != nil || got.ID() != scoped.ID() {
+			t.Fatalf("FindForCategory(category) = %v, %v, want the category calendar", got, err)
+		}
+		other := uuid.New()
+		if got, err := repo.FindForCategory(ctx, &other); err != nil || got.ID() != global.ID() {
+			t.Fatalf("FindForCategory(other) = %v, %v, want the global calendar", got, err)
+		}
+		if got, err := repo.FindForCategory(ctx, nil); err != nil || got.ID() != global.ID() {
+			t.Fatalf("FindForCategory(nil) = %v, %v, want the global calendar", got, err)
+		}
+
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 2 || !all[0].IsGlobal() {
+			t.Fatalf("List = %d calendars, want 2 with the global one first", len(all))
+		}
+	})
+
+	t.Run("Save rejects a second calendar for the same scope", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Save(ctx, newCalendar("Global", nil)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newCalendar("Another", nil)); !errors.Is(err, sla.ErrCalendarConflict) {
+			t.Fatalf("Save error = %v, want ErrCalendarConflict", err)
+		}
+	})
+
+	t.Run("Delete removes the calendar", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newCalendar("Office", nil)
+		if err := repo.Save(ctx, c); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, c.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, c.ID()); !errors.Is(err, sla.ErrCalendarNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrCalendarNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/sla"
+)
+
+// SLAPolicyRepositoryContract runs the sla.PolicyRepository contract.
+func SLAPolicyRepositoryContract(t *testing.T, newRepo func(t *testing.T) sla.PolicyRepository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrPolicyNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, sla.ErrPolicyNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrPolicyNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips targets", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newPolicy("Urgent", shared.PriorityUrgent, nil)
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, p.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Targets() != p.Targets() || got.Priority() != shared.PriorityUrgent {
+			t.Fatalf("got %+v %s, want %+v urgent", got.Targets(), got.Priority(), p.Targets())
+		}
+	})
+
+	t.Run("FindForTicket falls back to the global policy", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		global := newPolicy("High", shared.PriorityHigh, nil)
+		scoped := newPolicy("Billing high", shared.PriorityHigh, &categoryID)
+		for _, p := range []*sla.Policy{global, scoped} {
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		if got, err := repo.FindForTicket(ctx, &categoryID, shared.PriorityHigh); err != nil || got.ID() != scoped.ID() {
+			t.Fatalf("FindForTicket(category) = %v, %v, want the category policy", got, err)
+		}
+		other := uuid.New()
+		if got, err := repo.FindForTicket(ctx, &other, shared.PriorityHigh); err != nil || got.ID() != global.ID() {
+			t.Fatalf("FindForTicket(other) = %v, %v, want the global policy", got, err)
+		}
+		if _, err := repo.FindForTicket(ctx, &categoryID, shared.PriorityLow); !errors.Is(err, sla.ErrPolicyNotFound) {
+			t.Fatalf("FindForTicket(low) error = %v, want ErrPolicyNotFound", err)
+		}
+
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 2 || !all[0].IsGlobal() {
+			t.Fatalf("List = %d policies, want 2 with the global one first", len(all))
+		}
+	})
+
+	t.Run("Save rejects a second policy for the same scope", func(t *testing.T) {
+		repo := newRepo(t)
+		if err := repo.Save(ctx, newPolicy("Normal", shared.PriorityNormal, nil)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newPolicy("Another", shared.PriorityNormal, nil)); !errors.Is(err, sla.ErrPolicyConflict) {
+			t.Fatalf("Save error = %v, want ErrPolicyConflict", err)
+		}
+	})
+
+	t.Run("Delete removes the policy", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newPolicy("Low", shared.PriorityLow, nil)
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID()); !errors.Is(err, sla.ErrPolicyNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrPolicyNotFound", err)
+		}
+	})
+}
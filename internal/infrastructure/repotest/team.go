@@ -0,0 +1,110 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/team"
+)
+
+// TeamRepositoryContract runs the team.Repository contract.
+func TeamRepositoryContract(t *testing.T, newRepo func(t *testing.T) team.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrTeamNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrTeamNotFound", err)
+		}
+		if _, err := repo.FindByKey(ctx, "billing"); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("FindByKey error = %v, want ErrTeamNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips the team and its categories", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		tm := newTeam("billing", "Billing", categoryID)
+		if err := repo.Save(ctx, tm); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByKey(ctx, "billing")
+		if err != nil {
+			t.Fatalf("FindByKey: %v", err)
+		}
+		if got.ID() != tm.ID() || got.Name() != "Billing" {
+			t.Fatalf("FindByKey = %s %q, want %s %q", got.ID(), got.Name(), tm.ID(), "Billing")
+		}
+		if len(got.CategoryIDs()) != 1 || got.CategoryIDs()[0] != categoryID {
+			t.Fatalf("CategoryIDs = %v, want [%s]", got.CategoryIDs(), categoryID)
+		}
+		if got, err := repo.FindForCategory(ctx, categoryID); err != nil || got.ID() != tm.ID() {
+			t.Fatalf("FindForCategory = %v, %v, want the team", got, err)
+		}
+		if _, err := repo.FindForCategory(ctx, uuid.New()); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("FindForCategory(other) error = %v, want ErrTeamNotFound", err)
+		}
+	})
+
+	t.Run("Save rejects a taken key or category", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		if err := repo.Save(ctx, newTeam("billing", "Billing", categoryID)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newTeam("billing", "Billing 2")); !errors.Is(err, team.ErrTeamConflict) {
+			t.Fatalf("Save(key) error = %v, want ErrTeamConflict", err)
+		}
+		if err := repo.Save(ctx, newTeam("logistics", "Logistics", categoryID)); !errors.Is(err, team.ErrTeamConflict) {
+			t.Fatalf("Save(category) error = %v, want ErrTeamConflict", err)
+		}
+	})
+
+	t.Run("Save replaces categories and List orders by name", func(t *testing.T) {
+		repo := newRepo(t)
+		first, second := uuid.New(), uuid.New()
+		logistics := newTeam("logistics", "Logistics", first)
+		billing := newTeam("billing", "Billing")
+		for _, tm := range []*team.Team{logistics, billing} {
+			if err := repo.Save(ctx, tm); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+		logistics.SetCategories([]uuid.UUID{second})
+		if err := repo.Save(ctx, logistics); err != nil {
+			t.Fatalf("Save after SetCategories: %v", err)
+		}
+
+		if _, err := repo.FindForCategory(ctx, first); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("FindForCategory(first) error = %v, want ErrTeamNotFound", err)
+		}
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 2 || all[0].Key() != "billing" || all[1].Key() != "logistics" {
+			t.Fatalf("List = %d teams, want billing then logistics", len(all))
+		}
+	})
+
+	t.Run("Delete removes the team", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		tm := newTeam("returns", "Returns", categoryID)
+		if err := repo.Save(ctx, tm); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, tm.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindForCategory(ctx, categoryID); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("FindForCategory after Delete error = %v, want ErrTeamNotFound", err)
+		}
+		if err := repo.Delete(ctx, tm.ID()); !errors.Is(err, team.ErrTeamNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrTeamNotFound", err)
+		}
+	})
+}
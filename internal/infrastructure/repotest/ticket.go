@@ -0,0 +1,752 @@
+// Package repotest contains contract test suites that every repository
+// implementation must pass. Each suite takes a constructor that returns an
+// empty repository so the cases do not see each other's data. The memory
+// implementations run every suite in their tests; the GORM ones run them
+// against PostgreSQL when SUPPORT_TEST_DATABASE_DSN is set.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// TicketRepositoryContract runs the ticket.Repository contract.
+func TicketRepositoryContract(t *testing.T, newRepo func(t *testing.T) ticket.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrTicketNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, ticket.ErrTicketNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrTicketNotFound", err)
+		}
+		if _, err := repo.FindByNumber(ctx, "TKT-20000101-0000"); !errors.Is(err, ticket.ErrTicketNotFound) {
+			t.Fatalf("FindByNumber error = %v, want ErrTicketNotFound", err)
+		}
+	})
+
+	t.Run("Save and FindByID round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID := uuid.New()
+		tk := newTicket(t, 1, &customerID, "Where is my order?")
+		mustSave(t, repo, tk)
+
+		got, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Subject() != tk.Subject() || got.TicketNumber().Value() != tk.TicketNumber().Value() {
+			t.Fatalf("got %q/%q, want %q/%q", got.Subject(), got.TicketNumber(), tk.Subject(), tk.TicketNumber())
+		}
+		if got.Status() != shared.StatusOpen {
+			t.Fatalf("status = %s, want open", got.Status())
+		}
+		if got.CustomerID() == nil || *got.CustomerID() != customerID {
+			t.Fatalf("customer id = %v, want %s", got.CustomerID(), customerID)
+		}
+		if len(got.Messages()) != 1 || got.Messages()[0].Content() != "first message" {
+			t.Fatalf("messages = %d, want the opening message", len(got.Messages()))
+		}
+		if len(got.Events()) != 0 {
+			t.Fatal("loaded ticket should not carry events")
+		}
+	})
+
+	t.Run("Save appends messages and status history", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID := uuid.New()
+		tk := newTicket(t, 2, &customerID, "Refund")
+		mustSave(t, repo, tk)
+
+		loaded, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		agentID := uuid.New()
+		if err := loaded.Assign(agentID, &agentID); err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		reply := ticket.CreateAgentMessage(loaded.ID(), agentID, "Agent", "agent@example.com", "on it", false)
+		if err := loaded.AddMessage(reply); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		mustSave(t, repo, loaded)
+
+		got, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status() != shared.StatusInProgress {
+			t.Fatalf("status = %s, want in_progress", got.Status())
+		}
+		if got.AssignedTo() == nil || *got.AssignedTo() != agentID {
+			t.Fatalf("assigned to = %v, want %s", got.AssignedTo(), agentID)
+		}
+		if got.FirstResponseAt() == nil {
+			t.Fatal("first response should be recorded")
+		}
+		if len(got.Messages()) != 2 {
+			t.Fatalf("messages = %d, want 2", len(got.Messages()))
+		}
+		if len(got.StatusHistory()) != 1 || got.StatusHistory()[0].ToStatus() != shared.StatusInProgress {
+			t.Fatalf("status history = %d entries, want one move to in_progress", len(got.StatusHistory()))
+		}
+	})
+
+	t.Run("Save refuses a stale copy", func(t *testing.T) {
+		repo := newRepo(t)
+		tk := newTicket(t, 95, nil, "Two agents at once")
+		mustSave(t, repo, tk)
+
+		first, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		second, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		agentID := uuid.New()
+		if err := first.Assign(agentID, &agentID); err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+		mustSave(t, repo, first)
+		if err := second.ChangeStatus(shared.StatusResolved, nil, "Customer", ""); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		if err := repo.Save(ctx, second); !errors.Is(err, ticket.ErrConflict) {
+			t.Fatalf("Save stale copy error = %v, want ErrConflict", err)
+		}
+
+		got, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status() != shared.StatusInProgress || got.AssignedTo() == nil {
+			t.Fatalf("status = %s, assignee = %v; want the first save kept", got.Status(), got.AssignedTo())
+		}
+
+		// The saved copy may be saved again
+		if err := first.ChangeStatus(shared.StatusPending, &agentID, "Agent", ""); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		mustSave(t, repo, first)
+	})
+
+	t.Run("FindByNumber", func(t *testing.T) {
+		repo := newRepo(t)
+		tk := newTicket(t, 3, nil, "Guest question")
+		mustSave(t, repo, tk)
+
+		got, err := repo.FindByNumber(ctx, tk.TicketNumber().Value())
+		if err != nil {
+			t.Fatalf("FindByNumber: %v", err)
+		}
+		if got.ID() != tk.ID() {
+			t.Fatalf("id = %s, want %s", got.ID(), tk.ID())
+		}
+	})
+
+	t.Run("FindByMessageID finds the thread of a reply", func(t *testing.T) {
+		repo := newRepo(t)
+		tk := newTicket(t, 4, nil, "Emailed question")
+		msg := ticket.NewMessage(ticket.MessageParams{
+			TicketID:    tk.ID(),
+			SenderType:  string(shared.SenderCustomer),
+			SenderEmail: "guest@example.com",
+			Content:     "by email",
+			MessageID:   "<m2@example.com>",
+			InReplyTo:   "<m1@example.com>",
+			References:  []string{"<m0@example.com>", "<m1@example.com>"},
+		})
+		if err := tk.AddMessage(msg); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		mustSave(t, repo, tk)
+		mustSave(t, repo, newTicket(t, 5, nil, "Other question"))
+
+		got, err := repo.FindByMessageID(ctx, []string{"<unknown@example.com>", "<m2@example.com>"})
+		if err != nil {
+			t.Fatalf("FindByMessageID: %v", err)
+		}
+		if got.ID() != tk.ID() {
+			t.Fatalf("id = %s, want %s", got.ID(), tk.ID())
+		}
+		stored := got.Messages()[len(got.Messages())-1]
+		if stored.InReplyTo() != "<m1@example.com>" || len(stored.References()) != 2 {
+			t.Fatalf("headers = %q/%v, want the saved headers", stored.InReplyTo(), stored.References())
+		}
+		if _, err := repo.FindByMessageID(ctx, []string{"<unknown@example.com>"}); !errors.Is(err, ticket.ErrTicketNotFound) {
+			t.Fatalf("FindByMessageID error = %v, want ErrTicketNotFound", err)
+		}
+	})
+
+	t.Run("List filters and paginates", func(t *testing.T) {
+		repo := newRepo(t)
+		customerA, customerB := uuid.New(), uuid.New()
+		for i := 0; i < 3; i++ {
+			mustSave(t, repo, newTicket(t, 10+i, &customerA, fmt.Sprintf("Delivery issue %d", i)))
+		}
+		resolved := newTicket(t, 20, &customerB, "Payment failed")
+		if err := resolved.Resolve("refunded", nil); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		mustSave(t, repo, resolved)
+
+		page, total, err := repo.List(ctx, ticket.Filter{CustomerID: &customerA, PerPage: 2})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 || len(page) != 2 {
+			t.Fatalf("page 1 = %d of %d, want 2 of 3", len(page), total)
+		}
+		page, _, err = repo.List(ctx, ticket.Filter{CustomerID: &customerA, Page: 2, PerPage: 2})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page) != 1 {
+			t.Fatalf("page 2 = %d, want 1", len(page))
+		}
+
+		_, total, err = repo.List(ctx, ticket.Filter{Status: string(shared.StatusResolved)})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 1 {
+			t.Fatalf("resolved total = %d, want 1", total)
+		}
+
+		_, total, err = repo.List(ctx, ticket.Filter{Search: "delivery"})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 {
+			t.Fatalf("search total = %d, want 3", total)
+		}
+	})
+
+	t.Run("List filters a team's unassigned active tickets", func(t *testing.T) {
+		repo := newRepo(t)
+		teamID, agentID := uuid.New(), uuid.New()
+
+		queued := newTicket(t, 30, nil, "Refund request")
+		working := assignedTicket(31, nil, agentID, time.Now())
+		resolved := newTicket(t, 32, nil, "Old refund")
+		if err := resolved.Resolve("refunded", nil); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		for _, tk := range []*ticket.Ticket{queued, working, resolved} {
+			if err := tk.AssignTeam(&teamID); err != nil {
+				t.Fatalf("AssignTeam: %v", err)
+			}
+			mustSave(t, repo, tk)
+		}
+		mustSave(t, repo, newTicket(t, 33, nil, "Other team"))
+
+		_, total, err := repo.List(ctx, ticket.Filter{TeamID: &teamID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 {
+			t.Fatalf("team total = %d, want 3", total)
+		}
+
+		page, total, err := repo.List(ctx, ticket.Filter{TeamID: &teamID, Unassigned: true, ActiveOnly: true})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 1 || page[0].ID() != queued.ID() {
+			t.Fatalf("unassigned active = %d tickets, want the queued one", total)
+		}
+		if page[0].TeamID() == nil || *page[0].TeamID() != teamID {
+			t.Fatalf("TeamID = %v, want %s", page[0].TeamID(), teamID)
+		}
+	})
+
+	t.Run("Save round-trips watchers and CC", func(t *testing.T) {
+		repo := newRepo(t)
+		agentID := uuid.New()
+		watched := newTicket(t, 90, nil, "Watched")
+		watched.Watch(agentID)
+		if err := watched.SetCC([]string{"Boss@Example.com", "boss@example.com", "team@example.com"}); err != nil {
+			t.Fatalf("SetCC: %v", err)
+		}
+		mustSave(t, repo, watched)
+		mustSave(t, repo, newTicket(t, 91, nil, "Not watched"))
+
+		got, err := repo.FindByID(ctx, watched.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if !got.IsWatchedBy(agentID) || len(got.Watchers()) != 1 {
+			t.Fatalf("watchers = %v, want [%s]", got.Watchers(), agentID)
+		}
+		if len(got.CC()) != 2 || got.CC()[0] != "boss@example.com" || got.CC()[1] != "team@example.com" {
+			t.Fatalf("cc = %v, want [boss@example.com team@example.com]", got.CC())
+		}
+
+		tickets, total, err := repo.List(ctx, ticket.Filter{WatchedBy: &agentID, Page: 1, PerPage: 20})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 1 || len(tickets) != 1 || tickets[0].ID() != watched.ID() {
+			t.Fatalf("List WatchedBy total = %d, want the watched ticket only", total)
+		}
+
+		got.Unwatch(agentID)
+		mustSave(t, repo, got)
+		tickets, _, err = repo.List(ctx, ticket.Filter{WatchedBy: &agentID, Page: 1, PerPage: 20})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(tickets) != 0 {
+			t.Fatalf("List WatchedBy after Unwatch = %d tickets, want 0", len(tickets))
+		}
+	})
+
+	t.Run("Save round-trips the brand", func(t *testing.T) {
+		repo := newRepo(t)
+		tk := must(ticket.NewTicket(ticket.TicketParams{
+			TicketNumber: ticketNumber(92),
+			GuestEmail:   "guest@example.com",
+			Subject:      "Branded",
+			Brand:        " Acme ",
+		}))
+		mustSave(t, repo, tk)
+
+		got, err := repo.FindByID(ctx, tk.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Brand() != "acme" {
+			t.Fatalf("brand = %q, want acme", got.Brand())
+		}
+	})
+
+	t.Run("ListSLADue returns unrecorded breaches and warnings", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		breached := ticketDueAt(50, now.Add(-2*time.Hour))
+		approaching := ticketDueAt(51, now.Add(30*time.Minute))
+		later := ticketDueAt(52, now.Add(5*time.Hour))
+		recorded := ticketDueAt(53, now.Add(-time.Hour))
+		recorded.RecordSLABreach(now)
+		for _, tk := range []*ticket.Ticket{breached, approaching, later, recorded} {
+			mustSave(t, repo, tk)
+		}
+
+		due, err := repo.ListSLADue(ctx, ticket.SLADueQuery{Now: now, WarnBefore: now.Add(time.Hour)}, 10)
+		if err != nil {
+			t.Fatalf("ListSLADue: %v", err)
+		}
+		if len(due) != 2 || due[0].ID() != breached.ID() || due[1].ID() != approaching.ID() {
+			t.Fatalf("due = %d tickets, want the breached then the approaching one", len(due))
+		}
+
+		due, err = repo.ListSLADue(ctx, ticket.SLADueQuery{Now: now, WarnBefore: now}, 10)
+		if err != nil {
+			t.Fatalf("ListSLADue: %v", err)
+		}
+		if len(due) != 1 || due[0].ID() != breached.ID() {
+			t.Fatalf("due without warnings = %d tickets, want only the breached one", len(due))
+		}
+
+		got, err := repo.FindByID(ctx, recorded.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.SLABreachedAt() == nil {
+			t.Fatal("recorded breach should be persisted")
+		}
+	})
+
+	t.Run("ListSLADue pages through tickets due at the same time", func(t *testing.T) {
+		repo := newRepo(t)
+		deadline := time.Now().Add(-time.Hour).UTC().Truncate(time.Millisecond)
+		want := make(map[uuid.UUID]bool)
+		for i := 0; i < 5; i++ {
+			tk := ticketDueAt(56+i, deadline)
+			mustSave(t, repo, tk)
+			want[tk.ID()] = true
+		}
+
+		now := time.Now()
+		query := ticket.SLADueQuery{Now: now, WarnBefore: now}
+		seen := make(map[uuid.UUID]bool)
+		for page := 0; page < 5; page++ {
+			due, err := repo.ListSLADue(ctx, query, 2)
+			if err != nil {
+				t.Fatalf("ListSLADue: %v", err)
+			}
+			for _, tk := range due {
+				if seen[tk.ID()] {
+					t.Fatalf("ListSLADue listed ticket %s twice", tk.TicketNumber().Value())
+				}
+				seen[tk.ID()] = true
+			}
+			if len(due) < 2 {
+				break
+			}
+			last := due[len(due)-1]
+			query.After = &ticket.SLACursor{Deadline: last.EarliestSLADeadline(), ID: last.ID()}
+		}
+		if len(seen) != len(want) {
+			t.Fatalf("paged through %d tickets, want %d", len(seen), len(want))
+		}
+	})
+
+	t.Run("ListSLADue loads the status history", func(t *testing.T) {
+		repo := newRepo(t)
+		tk := ticketDueAt(61, time.Now().Add(-time.Hour))
+		if err := tk.ChangeStatus(shared.StatusInProgress, nil, ticket.SystemActor, ""); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		mustSave(t, repo, tk)
+
+		now := time.Now()
+		due, err := repo.ListSLADue(ctx, ticket.SLADueQuery{Now: now, WarnBefore: now}, 10)
+		if err != nil {
+			t.Fatalf("ListSLADue: %v", err)
+		}
+		if len(due) != 1 || len(due[0].StatusHistory()) != 1 {
+			t.Fatal("due ticket should come with its status history")
+		}
+	})
+
+	t.Run("ListSLADue returns first response breaches until the first reply", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		unanswered := ticketFirstResponseDueAt(54, now.Add(-time.Hour))
+		answered := ticketFirstResponseDueAt(55, now.Add(-time.Hour))
+		reply := ticket.CreateAgentMessage(answered.ID(), uuid.New(), "Agent", "agent@example.com", "on it", false)
+		if err := answered.AddMessage(reply); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		for _, tk := range []*ticket.Ticket{unanswered, answered} {
+			mustSave(t, repo, tk)
+		}
+
+		due, err := repo.ListSLADue(ctx, ticket.SLADueQuery{Now: now, WarnBefore: now}, 10)
+		if err != nil {
+			t.Fatalf("ListSLADue: %v", err)
+		}
+		if len(due) != 1 || due[0].ID() != unanswered.ID() {
+			t.Fatalf("due = %d tickets, want only the unanswered one", len(due))
+		}
+
+		if !due[0].RecordSLABreach(now) {
+			t.Fatal("RecordSLABreach should record the first response breach")
+		}
+		mustSave(t, repo, due[0])
+		due, err = repo.ListSLADue(ctx, ticket.SLADueQuery{Now: now, WarnBefore: now}, 10)
+		if err != nil {
+			t.Fatalf("ListSLADue: %v", err)
+		}
+		if len(due) != 0 {
+			t.Fatalf("due after recording = %d tickets, want 0", len(due))
+		}
+
+		got, err := repo.FindByID(ctx, unanswered.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.FirstResponseBreachedAt() == nil || got.SLABreachedAt() != nil {
+			t.Fatal("only the first response breach should be persisted")
+		}
+	})
+
+	t.Run("ListIdle returns idle tickets of the scope", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		billing, other := uuid.New(), uuid.New()
+		oldest := idleTicket(70, shared.StatusPending, nil, now.Add(-3*time.Hour))
+		older := idleTicket(71, shared.StatusPending, &other, now.Add(-2*time.Hour))
+		scoped := idleTicket(72, shared.StatusPending, &billing, now.Add(-2*time.Hour))
+		recent := idleTicket(73, shared.StatusPending, nil, now)
+		inProgress := idleTicket(74, shared.StatusInProgress, nil, now.Add(-3*time.Hour))
+		reminded := idleTicket(75, shared.StatusPending, nil, now.Add(-3*time.Hour))
+		reminded.RemindPending(now, "Customer reminded")
+		for _, tk := range []*ticket.Ticket{oldest, older, scoped, recent, inProgress, reminded} {
+			mustSave(t, repo, tk)
+		}
+
+		query := ticket.IdleQuery{
+			Status:             shared.StatusPending,
+			IdleBefore:         now.Add(-time.Hour),
+			ExcludeCategoryIDs: []uuid.UUID{billing},
+			NotReminded:        true,
+		}
+		idle, err := repo.ListIdle(ctx, query, 10)
+		if err != nil {
+			t.Fatalf("ListIdle: %v", err)
+		}
+		if len(idle) != 2 || idle[0].ID() != oldest.ID() || idle[1].ID() != older.ID() {
+			t.Fatalf("idle = %d tickets, want the two unreminded tickets outside the category, oldest first", len(idle))
+		}
+
+		query.CategoryID = &billing
+		idle, err = repo.ListIdle(ctx, query, 10)
+		if err != nil {
+			t.Fatalf("ListIdle: %v", err)
+		}
+		if len(idle) != 1 || idle[0].ID() != scoped.ID() {
+			t.Fatalf("idle in category = %d tickets, want only the category ticket", len(idle))
+		}
+
+		got, err := repo.FindByID(ctx, reminded.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.RemindedAt() == nil || got.IdleSince().Sub(reminded.IdleSince()).Abs() > time.Millisecond {
+			t.Fatal("reminder and idle clock should be persisted")
+		}
+	})
+
+	t.Run("ListIdle pages through tickets idle since the same time", func(t *testing.T) {
+		repo := newRepo(t)
+		idleSince := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Millisecond)
+		want := make(map[uuid.UUID]bool)
+		for i := 0; i < 5; i++ {
+			tk := idleTicket(80+i, shared.StatusPending, nil, idleSince)
+			mustSave(t, repo, tk)
+			want[tk.ID()] = true
+		}
+
+		query := ticket.IdleQuery{Status: shared.StatusPending, IdleBefore: time.Now()}
+		seen := make(map[uuid.UUID]bool)
+		for page := 0; page < 5; page++ {
+			idle, err := repo.ListIdle(ctx, query, 2)
+			if err != nil {
+				t.Fatalf("ListIdle: %v", err)
+			}
+			for _, tk := range idle {
+				if seen[tk.ID()] {
+					t.Fatalf("ListIdle listed ticket %s twice", tk.TicketNumber().Value())
+				}
+				seen[tk.ID()] = true
+			}
+			if len(idle) < 2 {
+				break
+			}
+			last := idle[len(idle)-1]
+			query.After = &ticket.IdleCursor{IdleSince: last.IdleSince(), ID: last.ID()}
+		}
+		if len(seen) != len(want) {
+			t.Fatalf("paged through %d tickets, want %d", len(seen), len(want))
+		}
+	})
+
+	t.Run("ListIdle loads the status history", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		tk := idleTicket(85, shared.StatusOpen, nil, now.Add(-2*time.Hour))
+		if err := tk.ChangeStatus(shared.StatusPending, nil, ticket.SystemActor, ""); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		mustSave(t, repo, tk)
+
+		idle, err := repo.ListIdle(ctx, ticket.IdleQuery{Status: shared.StatusPending, IdleBefore: now.Add(time.Hour)}, 10)
+		if err != nil {
+			t.Fatalf("ListIdle: %v", err)
+		}
+		if len(idle) != 1 || len(idle[0].StatusHistory()) != 1 {
+			t.Fatal("idle ticket should come with its status history")
+		}
+	})
+
+	t.Run("SaveMerged moves messages to the target", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID := uuid.New()
+		target := newTicket(t, 80, &customerID, "Order missing")
+		source := newTicket(t, 81, &customerID, "Order still missing")
+		mustSave(t, repo, target)
+		mustSave(t, repo, source)
+
+		loadedTarget, err := repo.FindByID(ctx, target.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		loadedSource, err := repo.FindByID(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if err := ticket.Merge(loadedTarget, []*ticket.Ticket{loadedSource}, nil, "agent@example.com", false); err != nil {
+			t.Fatalf("Merge: %v", err)
+		}
+		if err := repo.SaveMerged(ctx, loadedTarget, []*ticket.Ticket{loadedSource}); err != nil {
+			t.Fatalf("SaveMerged: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, target.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		// Both opening messages and the merge note
+		if len(got.Messages()) != 3 {
+			t.Fatalf("target messages = %d, want 3", len(got.Messages()))
+		}
+
+		merged, err := repo.FindByNumber(ctx, source.TicketNumber().Value())
+		if err != nil {
+			t.Fatalf("FindByNumber: %v", err)
+		}
+		if merged.Status() != shared.StatusClosed {
+			t.Fatalf("source status = %s, want closed", merged.Status())
+		}
+		if merged.MergedInto() == nil || *merged.MergedInto() != target.ID() {
+			t.Fatalf("source merged into = %v, want %s", merged.MergedInto(), target.ID())
+		}
+		if len(merged.Messages()) != 0 {
+			t.Fatalf("source messages = %d, want none", len(merged.Messages()))
+		}
+		history := merged.StatusHistory()
+		if len(history) == 0 || history[len(history)-1].ToStatus() != shared.StatusClosed {
+			t.Fatalf("source status history = %d entries, want it to end with the closing entry", len(history))
+		}
+		if len(got.StatusHistory()) != 0 {
+			t.Fatalf("target status history = %d entries, want none", len(got.StatusHistory()))
+		}
+	})
+
+	t.Run("SaveSplit moves messages to the new ticket", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID := uuid.New()
+		source := newTicket(t, 82, &customerID, "Order missing")
+		followUp := ticket.CreateCustomerMessage(source.ID(), &customerID, "Customer", "guest@example.com", "also, my invoice is wrong")
+		if err := source.AddMessage(followUp); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		mustSave(t, repo, source)
+
+		loaded, err := repo.FindByID(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		split, err := ticket.NewTicket(ticket.TicketParams{
+			TicketNumber: ticketNumber(83),
+			CustomerID:   &customerID,
+			Subject:      "Wrong invoice",
+		})
+		if err != nil {
+			t.Fatalf("NewTicket: %v", err)
+		}
+		if err := ticket.Split(loaded, split, []uuid.UUID{followUp.ID()}); err != nil {
+			t.Fatalf("Split: %v", err)
+		}
+		if err := repo.SaveSplit(ctx, loaded, split); err != nil {
+			t.Fatalf("SaveSplit: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		// The opening message and the cross-reference
+		if len(got.Messages()) != 2 {
+			t.Fatalf("source messages = %d, want 2", len(got.Messages()))
+		}
+		for _, msg := range got.Messages() {
+			if msg.ID() == followUp.ID() {
+				t.Fatal("split message should have left the source")
+			}
+		}
+
+		got, err = repo.FindByID(ctx, split.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if len(got.Messages()) != 2 || got.Messages()[0].ID() != followUp.ID() {
+			t.Fatalf("split messages = %d, want the moved message and the cross-reference", len(got.Messages()))
+		}
+	})
+
+	t.Run("Stats counts tickets by status", func(t *testing.T) {
+		repo := newRepo(t)
+		mustSave(t, repo, newTicket(t, 30, nil, "Open one"))
+		resolved := newTicket(t, 31, nil, "Resolved one")
+		if err := resolved.Resolve("done", nil); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if err := resolved.RateSatisfaction(5, ""); err != nil {
+			t.Fatalf("RateSatisfaction: %v", err)
+		}
+		mustSave(t, repo, resolved)
+
+		stats, err := repo.Stats(ctx)
+		if err != nil {
+			t.Fatalf("Stats: %v", err)
+		}
+		if stats.TotalOpen != 1 || stats.TotalResolved != 1 {
+			t.Fatalf("open/resolved = %d/%d, want 1/1", stats.TotalOpen, stats.TotalResolved)
+		}
+		if stats.SatisfactionRate != 100 {
+			t.Fatalf("satisfaction rate = %v, want 100", stats.SatisfactionRate)
+		}
+	})
+
+	t.Run("CountActiveByAssignee counts active tickets only", func(t *testing.T) {
+		repo := newRepo(t)
+		agentA, agentB := uuid.New(), uuid.New()
+		now := time.Now()
+		mustSave(t, repo, assignedTicket(60, nil, agentA, now))
+		mustSave(t, repo, assignedTicket(61, nil, agentA, now))
+		mustSave(t, repo, assignedTicket(62, nil, agentB, now))
+		resolved := assignedTicket(63, nil, agentB, now)
+		if err := resolved.Resolve("done", nil); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		mustSave(t, repo, resolved)
+		mustSave(t, repo, newTicket(t, 64, nil, "Unassigned"))
+
+		counts, err := repo.CountActiveByAssignee(ctx)
+		if err != nil {
+			t.Fatalf("CountActiveByAssignee: %v", err)
+		}
+		if len(counts) != 2 || counts[agentA] != 2 || counts[agentB] != 1 {
+			t.Fatalf("counts = %v, want 2 for agent A and 1 for agent B", counts)
+		}
+	})
+
+	t.Run("LastAssignee returns the latest agent of the customer", func(t *testing.T) {
+		repo := newRepo(t)
+		customerID := uuid.New()
+		older, latest := uuid.New(), uuid.New()
+		now := time.Now()
+		mustSave(t, repo, assignedTicket(70, &customerID, older, now.Add(-2*time.Hour)))
+		mustSave(t, repo, assignedTicket(71, &customerID, latest, now.Add(-time.Hour)))
+		mustSave(t, repo, newTicket(t, 72, &customerID, "Not assigned yet"))
+		guestAgent := uuid.New()
+		mustSave(t, repo, assignedTicket(73, nil, guestAgent, now))
+
+		got, err := repo.LastAssignee(ctx, &customerID, "")
+		if err != nil {
+			t.Fatalf("LastAssignee: %v", err)
+		}
+		if got == nil || *got != latest {
+			t.Fatalf("last assignee = %v, want %s", got, latest)
+		}
+
+		got, err = repo.LastAssignee(ctx, nil, "GUEST@example.com")
+		if err != nil {
+			t.Fatalf("LastAssignee: %v", err)
+		}
+		if got == nil || *got != guestAgent {
+			t.Fatalf("guest last assignee = %v, want %s", got, guestAgent)
+		}
+
+		other := uuid.New()
+		got, err = repo.LastAssignee(ctx, &other, "")
+		if err != nil {
+			t.Fatalf("LastAssignee: %v", err)
+		}
+		if got != nil {
+			t.Fatalf("last assignee of a new customer = %s, want none", got)
+		}
+	})
+}
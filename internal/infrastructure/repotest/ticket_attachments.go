@@ -0,0 +1,140 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// TicketAttachmentsContract runs the part of the ticket.Repository contract
+// covering attachments: merges and splits move the files along with the
+// messages they were posted in. newStore returns a ticket repository and
+// the attachment repository holding its tickets' files.
+func TicketAttachmentsContract(t *testing.T, newStore func(t *testing.T) (ticket.Repository, attachment.Repository)) {
+	ctx := context.Background()
+
+	t.Run("SaveMerged moves the sources' attachments to the target", func(t *testing.T) {
+		repo, attachments := newStore(t)
+		customerID := uuid.New()
+		target := newTicket(t, 84, &customerID, "Order missing")
+		source := newTicket(t, 85, &customerID, "Order still missing")
+		mustSave(t, repo, target)
+		mustSave(t, repo, source)
+
+		posted := newAttachment(source.ID(), 100)
+		if err := posted.Post(source.ID(), source.Messages()[0].ID()); err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		mustSaveAttachment(t, attachments, posted)
+		mustSaveAttachment(t, attachments, newAttachment(source.ID(), 200))
+		kept := newAttachment(target.ID(), 300)
+		mustSaveAttachment(t, attachments, kept)
+
+		loadedTarget, err := repo.FindByID(ctx, target.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		loadedSource, err := repo.FindByID(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if err := ticket.Merge(loadedTarget, []*ticket.Ticket{loadedSource}, nil, "agent@example.com", false); err != nil {
+			t.Fatalf("Merge: %v", err)
+		}
+		if err := repo.SaveMerged(ctx, loadedTarget, []*ticket.Ticket{loadedSource}); err != nil {
+			t.Fatalf("SaveMerged: %v", err)
+		}
+
+		got, err := attachments.ListByTicket(ctx, target.ID())
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("target attachments = %d, want 3", len(got))
+		}
+		left, err := attachments.ListByTicket(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(left) != 0 {
+			t.Fatalf("source attachments = %d, want none", len(left))
+		}
+
+		moved, err := attachments.FindByID(ctx, posted.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if moved.TicketID() != target.ID() || moved.MessageID() == nil || *moved.MessageID() != source.Messages()[0].ID() {
+			t.Fatalf("moved attachment ticket = %s message = %v, want the target and the same message", moved.TicketID(), moved.MessageID())
+		}
+		if used, err := attachments.TicketUsage(ctx, target.ID()); err != nil || used != 600 {
+			t.Fatalf("TicketUsage = %d (err %v), want 600", used, err)
+		}
+	})
+
+	t.Run("SaveSplit moves the attachments of the split messages", func(t *testing.T) {
+		repo, attachments := newStore(t)
+		customerID := uuid.New()
+		source := newTicket(t, 86, &customerID, "Order missing")
+		followUp := ticket.CreateCustomerMessage(source.ID(), &customerID, "Customer", "guest@example.com", "also, my invoice is wrong")
+		if err := source.AddMessage(followUp); err != nil {
+			t.Fatalf("AddMessage: %v", err)
+		}
+		mustSave(t, repo, source)
+
+		invoice := newAttachment(source.ID(), 100)
+		if err := invoice.Post(source.ID(), followUp.ID()); err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		mustSaveAttachment(t, attachments, invoice)
+		photo := newAttachment(source.ID(), 200)
+		if err := photo.Post(source.ID(), source.Messages()[0].ID()); err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		mustSaveAttachment(t, attachments, photo)
+
+		loaded, err := repo.FindByID(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		split, err := ticket.NewTicket(ticket.TicketParams{
+			TicketNumber: ticketNumber(87),
+			CustomerID:   &customerID,
+			Subject:      "Wrong invoice",
+		})
+		if err != nil {
+			t.Fatalf("NewTicket: %v", err)
+		}
+		if err := ticket.Split(loaded, split, []uuid.UUID{followUp.ID()}); err != nil {
+			t.Fatalf("Split: %v", err)
+		}
+		if err := repo.SaveSplit(ctx, loaded, split); err != nil {
+			t.Fatalf("SaveSplit: %v", err)
+		}
+
+		got, err := attachments.ListByTicket(ctx, split.ID())
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(got) != 1 || got[0].ID() != invoice.ID() {
+			t.Fatalf("split attachments = %d, want the follow-up's file", len(got))
+		}
+		left, err := attachments.ListByTicket(ctx, source.ID())
+		if err != nil {
+			t.Fatalf("ListByTicket: %v", err)
+		}
+		if len(left) != 1 || left[0].ID() != photo.ID() {
+			t.Fatalf("source attachments = %d, want the opening message's file", len(left))
+		}
+	})
+}
+
+func mustSaveAttachment(t *testing.T, repo attachment.Repository, a *attachment.Attachment) {
+	t.Helper()
+	if err := repo.Save(context.Background(), a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
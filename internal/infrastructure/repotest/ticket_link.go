@@ -0,0 +1,73 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/link"
+)
+
+// TicketLinkRepositoryContract runs the link.Repository contract.
+func TicketLinkRepositoryContract(t *testing.T, newRepo func(t *testing.T) link.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrLinkNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, link.ErrLinkNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrLinkNotFound", err)
+		}
+		if err := repo.Delete(ctx, uuid.New()); !errors.Is(err, link.ErrLinkNotFound) {
+			t.Fatalf("Delete error = %v, want ErrLinkNotFound", err)
+		}
+	})
+
+	t.Run("ListForTicket returns links in either direction", func(t *testing.T) {
+		repo := newRepo(t)
+		parent, child, related := uuid.New(), uuid.New(), uuid.New()
+		parentLink := newTicketLink(parent, child, link.RelationParent)
+		relatedLink := newTicketLink(child, related, link.RelationRelated)
+		for _, l := range []*link.Link{parentLink, relatedLink, newTicketLink(parent, related, link.RelationBlocks)} {
+			if err := repo.Save(ctx, l); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		links, err := repo.ListForTicket(ctx, child)
+		if err != nil {
+			t.Fatalf("ListForTicket: %v", err)
+		}
+		if len(links) != 2 || links[0].ID() != parentLink.ID() || links[1].ID() != relatedLink.ID() {
+			t.Fatalf("links = %d, want the parent and related links in order", len(links))
+		}
+		if got := links[0].RelationFor(child); got != link.RelationChild {
+			t.Fatalf("relation = %s, want child", got)
+		}
+	})
+
+	t.Run("Save refuses a second link between two tickets", func(t *testing.T) {
+		repo := newRepo(t)
+		a, b := uuid.New(), uuid.New()
+		if err := repo.Save(ctx, newTicketLink(a, b, link.RelationDuplicateOf)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newTicketLink(b, a, link.RelationRelated)); !errors.Is(err, link.ErrLinkExists) {
+			t.Fatalf("Save error = %v, want ErrLinkExists", err)
+		}
+	})
+
+	t.Run("Delete removes the link", func(t *testing.T) {
+		repo := newRepo(t)
+		l := newTicketLink(uuid.New(), uuid.New(), link.RelationRelated)
+		if err := repo.Save(ctx, l); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, l.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, l.ID()); !errors.Is(err, link.ErrLinkNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrLinkNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/mention"
+)
+
+// TicketMentionRepositoryContract runs the mention.Repository contract.
+func TicketMentionRepositoryContract(t *testing.T, newRepo func(t *testing.T) mention.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrMentionNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, mention.ErrMentionNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrMentionNotFound", err)
+		}
+	})
+
+	t.Run("Save creates and marks read", func(t *testing.T) {
+		repo := newRepo(t)
+		m := newTicketMention(uuid.New())
+		if err := repo.Save(ctx, m); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		m.MarkRead()
+		if err := repo.Save(ctx, m); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, m.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if !got.IsRead() || got.MessageID() != m.MessageID() || got.MentionedByName() != "agent@example.com" {
+			t.Fatalf("mention = %+v, want the saved read mention", got)
+		}
+	})
+
+	t.Run("List returns the agent's mentions newest first", func(t *testing.T) {
+		repo := newRepo(t)
+		agentID := uuid.New()
+		older := newTicketMention(agentID)
+		time.Sleep(time.Millisecond)
+		newer := newTicketMention(agentID)
+		read := newTicketMention(agentID)
+		read.MarkRead()
+		for _, m := range []*mention.Mention{older, newer, read, newTicketMention(uuid.New())} {
+			if err := repo.Save(ctx, m); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		mentions, total, err := repo.List(ctx, mention.Filter{AgentID: agentID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 || len(mentions) != 3 {
+			t.Fatalf("total = %d, want 3", total)
+		}
+
+		mentions, total, err = repo.List(ctx, mention.Filter{AgentID: agentID, UnreadOnly: true})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 2 || mentions[0].ID() != newer.ID() || mentions[1].ID() != older.ID() {
+			t.Fatalf("unread total = %d, want the newer and older mentions in order", total)
+		}
+	})
+}
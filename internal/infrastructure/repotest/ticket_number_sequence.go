@@ -0,0 +1,74 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/ticket"
+)
+
+// TicketNumberSequenceContract runs the ticket.NumberSequence contract.
+func TicketNumberSequenceContract(t *testing.T, newSequence func(t *testing.T) ticket.NumberSequence) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Next counts from one per scope and day", func(t *testing.T) {
+		seq := newSequence(t)
+		for want := int64(1); want <= 3; want++ {
+			got, err := seq.Next(ctx, "TKT-{date}-{seq}", day)
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			if got != want {
+				t.Fatalf("Next = %d, want %d", got, want)
+			}
+		}
+
+		if got, err := seq.Next(ctx, "TKT-{date}-{seq}", day.Add(3*time.Hour)); err != nil || got != 4 {
+			t.Fatalf("Next later that day = %d, %v, want 4", got, err)
+		}
+		if got, err := seq.Next(ctx, "TKT-{date}-{seq}", day.AddDate(0, 0, 1)); err != nil || got != 1 {
+			t.Fatalf("Next on the next day = %d, %v, want 1", got, err)
+		}
+		if got, err := seq.Next(ctx, "ACME-{date}-{seq}", day); err != nil || got != 1 {
+			t.Fatalf("Next for another scope = %d, %v, want 1", got, err)
+		}
+	})
+
+	t.Run("Next never hands out a value twice", func(t *testing.T) {
+		seq := newSequence(t)
+		const callers, calls = 8, 25
+
+		var mu sync.Mutex
+		seen := make(map[int64]bool, callers*calls)
+		var wg sync.WaitGroup
+		errs := make(chan error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < calls; j++ {
+					v, err := seq.Next(ctx, "TKT-{date}-{seq}", day)
+					if err != nil {
+						errs <- err
+						return
+					}
+					mu.Lock()
+					seen[v] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Fatalf("Next: %v", err)
+		}
+
+		if len(seen) != callers*calls {
+			t.Fatalf("got %d distinct values, want %d", len(seen), callers*calls)
+		}
+	})
+}
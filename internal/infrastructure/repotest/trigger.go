@@ -0,0 +1,157 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/trigger"
+)
+
+// TriggerRuleRepositoryContract runs the trigger.Repository contract.
+func TriggerRuleRepositoryContract(t *testing.T, newRepo func(t *testing.T) trigger.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrRuleNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, trigger.ErrRuleNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrRuleNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips nested conditions and actions", func(t *testing.T) {
+		repo := newRepo(t)
+		teamID := uuid.New()
+		rule, err := trigger.NewRule(trigger.RuleParams{
+			Name:        "Refunds",
+			Description: "Send refund requests to Billing",
+			Enabled:     true,
+			Events:      []trigger.Event{trigger.EventTicketCreated, trigger.EventCustomerReplied},
+			Condition: trigger.Condition{All: []trigger.Condition{
+				{Field: trigger.FieldChannel, Operator: trigger.OperatorEquals, Value: "web"},
+				{Any: []trigger.Condition{
+					{Field: trigger.FieldSubject, Operator: trigger.OperatorContains, Value: "refund"},
+					{Field: trigger.FieldContent, Operator: trigger.OperatorContains, Value: "money back"},
+				}},
+			}},
+			Actions: []trigger.Action{
+				{Type: trigger.ActionSetPriority, Value: "high"},
+				{Type: trigger.ActionAddTag, Value: "refund"},
+				{Type: trigger.ActionAssignTeam, Value: teamID.String()},
+			},
+		})
+		if err != nil {
+			t.Fatalf("NewRule: %v", err)
+		}
+		if err := repo.Save(ctx, rule); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, rule.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name() != "Refunds" || got.Description() != rule.Description() || len(got.Events()) != 2 {
+			t.Fatalf("got %q with events %v, want the saved rule", got.Name(), got.Events())
+		}
+		c := got.Condition()
+		if len(c.All) != 2 || len(c.All[1].Any) != 2 || c.All[1].Any[1].Value != "money back" {
+			t.Fatalf("condition = %+v, want the saved one", c)
+		}
+		if len(got.Actions()) != 3 || got.Actions()[2].TargetID() != teamID {
+			t.Fatalf("actions = %v, want the saved ones", got.Actions())
+		}
+	})
+
+	t.Run("ListForEvent keeps enabled rules of the event in order", func(t *testing.T) {
+		repo := newRepo(t)
+		second := newTriggerRule("A second", 2, true, trigger.EventTicketCreated)
+		first := newTriggerRule("B first", 1, true, trigger.EventTicketCreated, trigger.EventStatusChanged)
+		disabled := newTriggerRule("Disabled", 0, false, trigger.EventTicketCreated)
+		other := newTriggerRule("Replies", 0, true, trigger.EventCustomerReplied)
+		for _, rule := range []*trigger.Rule{second, first, disabled, other} {
+			if err := repo.Save(ctx, rule); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		rules, err := repo.ListForEvent(ctx, trigger.EventTicketCreated)
+		if err != nil {
+			t.Fatalf("ListForEvent: %v", err)
+		}
+		if len(rules) != 2 || rules[0].ID() != first.ID() || rules[1].ID() != second.ID() {
+			t.Fatalf("ListForEvent = %d rules, want first then second", len(rules))
+		}
+
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 4 || all[0].Position() != 0 {
+			t.Fatalf("List = %d rules, want all 4 by position", len(all))
+		}
+	})
+
+	t.Run("Delete removes the rule", func(t *testing.T) {
+		repo := newRepo(t)
+		rule := newTriggerRule("Old", 0, true, trigger.EventTicketCreated)
+		if err := repo.Save(ctx, rule); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, rule.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, rule.ID()); !errors.Is(err, trigger.ErrRuleNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrRuleNotFound", err)
+		}
+	})
+}
+
+// TriggerFiringLogContract runs the trigger.FiringLog contract.
+func TriggerFiringLogContract(t *testing.T, newLog func(t *testing.T) trigger.FiringLog) {
+	ctx := context.Background()
+
+	t.Run("Record and List filter by rule and ticket", func(t *testing.T) {
+		log := newLog(t)
+		rule := newTriggerRule("Tag refunds", 0, true, trigger.EventTicketCreated)
+		other := newTriggerRule("Other", 1, true, trigger.EventTicketCreated)
+		ticketID := uuid.New()
+		results := []trigger.ActionResult{
+			{Type: trigger.ActionAddTag, Value: "refund"},
+			{Type: trigger.ActionSetStatus, Value: "closed", Error: "ticket cannot be modified in current state"},
+		}
+		firings := []trigger.Firing{
+			trigger.NewFiring(rule, ticketID, trigger.EventTicketCreated, results),
+			trigger.NewFiring(other, ticketID, trigger.EventTicketCreated, nil),
+			trigger.NewFiring(rule, uuid.New(), trigger.EventTicketCreated, nil),
+		}
+		if err := log.Record(ctx, firings); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+
+		ruleID := rule.ID()
+		got, total, err := log.List(ctx, trigger.FiringFilter{RuleID: &ruleID, TicketID: &ticketID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 1 || len(got) != 1 || got[0].ID() != firings[0].ID() {
+			t.Fatalf("List = %d of %d firings, want the first firing", len(got), total)
+		}
+		f := got[0]
+		if f.RuleName() != "Tag refunds" || f.Event() != trigger.EventTicketCreated || !f.Failed() {
+			t.Fatalf("firing = %q %s failed=%v, want the recorded one", f.RuleName(), f.Event(), f.Failed())
+		}
+		if len(f.Actions()) != 2 || f.Actions()[1] != results[1] {
+			t.Fatalf("actions = %v, want %v", f.Actions(), results)
+		}
+
+		page, total, err := log.List(ctx, trigger.FiringFilter{TicketID: &ticketID, PerPage: 1})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 2 || len(page) != 1 {
+			t.Fatalf("List = %d of %d firings, want 1 of 2", len(page), total)
+		}
+	})
+}
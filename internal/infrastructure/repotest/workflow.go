@@ -0,0 +1,130 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/Ecom-micro-template/service-support/internal/domain/shared"
+	"github.com/Ecom-micro-template/service-support/internal/domain/workflow"
+)
+
+// WorkflowRepositoryContract runs the workflow.Repository contract.
+func WorkflowRepositoryContract(t *testing.T, newRepo func(t *testing.T) workflow.Repository) {
+	ctx := context.Background()
+
+	t.Run("FindByID returns ErrWorkflowNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, uuid.New()); !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			t.Fatalf("FindByID error = %v, want ErrWorkflowNotFound", err)
+		}
+	})
+
+	t.Run("Save round-trips states and transitions", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		w := newWorkflow("Vendor", false, categoryID)
+		if err := repo.Save(ctx, w); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, w.ID())
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if len(got.States()) != len(w.States()) || len(got.Transitions()) != len(w.Transitions()) {
+			t.Fatalf("got %d states %d transitions, want %d and %d",
+				len(got.States()), len(got.Transitions()), len(w.States()), len(w.Transitions()))
+		}
+		if !got.PausesSLA("awaiting_vendor") || !got.CanTransition(shared.StatusInProgress, "awaiting_vendor") {
+			t.Fatal("custom state lost its flags or transitions")
+		}
+		if len(got.CategoryIDs()) != 1 || got.CategoryIDs()[0] != categoryID {
+			t.Fatalf("CategoryIDs = %v, want [%s]", got.CategoryIDs(), categoryID)
+		}
+	})
+
+	t.Run("FindForCategory falls back to the default workflow", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		if _, err := repo.FindForCategory(ctx, &categoryID); !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			t.Fatalf("FindForCategory on empty repo error = %v, want ErrWorkflowNotFound", err)
+		}
+
+		def := newWorkflow("Default", true)
+		scoped := newWorkflow("Billing", false, categoryID)
+		for _, w := range []*workflow.Workflow{scoped, def} {
+			if err := repo.Save(ctx, w); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		if got, err := repo.FindForCategory(ctx, &categoryID); err != nil || got.ID() != scoped.ID() {
+			t.Fatalf("FindForCategory(category) = %v, %v, want the category workflow", got, err)
+		}
+		other := uuid.New()
+		if got, err := repo.FindForCategory(ctx, &other); err != nil || got.ID() != def.ID() {
+			t.Fatalf("FindForCategory(other) = %v, %v, want the default workflow", got, err)
+		}
+		if got, err := repo.FindForCategory(ctx, nil); err != nil || got.ID() != def.ID() {
+			t.Fatalf("FindForCategory(nil) = %v, %v, want the default workflow", got, err)
+		}
+
+		all, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 2 || !all[0].IsDefault() {
+			t.Fatalf("List = %d workflows, want 2 with the default one first", len(all))
+		}
+	})
+
+	t.Run("Save rejects overlapping scopes", func(t *testing.T) {
+		repo := newRepo(t)
+		categoryID := uuid.New()
+		if err := repo.Save(ctx, newWorkflow("Default", true, categoryID)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Save(ctx, newWorkflow("Another default", true)); !errors.Is(err, workflow.ErrWorkflowConflict) {
+			t.Fatalf("Save(default) error = %v, want ErrWorkflowConflict", err)
+		}
+		if err := repo.Save(ctx, newWorkflow("Same category", false, categoryID)); !errors.Is(err, workflow.ErrWorkflowConflict) {
+			t.Fatalf("Save(category) error = %v, want ErrWorkflowConflict", err)
+		}
+	})
+
+	t.Run("Save replaces category assignments", func(t *testing.T) {
+		repo := newRepo(t)
+		first, second := uuid.New(), uuid.New()
+		w := newWorkflow("Returns", false, first)
+		if err := repo.Save(ctx, w); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		w.Assign(false, []uuid.UUID{second})
+		if err := repo.Save(ctx, w); err != nil {
+			t.Fatalf("Save after Assign: %v", err)
+		}
+
+		if _, err := repo.FindForCategory(ctx, &first); !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			t.Fatalf("FindForCategory(first) error = %v, want ErrWorkflowNotFound", err)
+		}
+		if got, err := repo.FindForCategory(ctx, &second); err != nil || got.ID() != w.ID() {
+			t.Fatalf("FindForCategory(second) = %v, %v, want the workflow", got, err)
+		}
+	})
+
+	t.Run("Delete removes the workflow", func(t *testing.T) {
+		repo := newRepo(t)
+		w := newWorkflow("Old", false)
+		if err := repo.Save(ctx, w); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if err := repo.Delete(ctx, w.ID()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, w.ID()); !errors.Is(err, workflow.ErrWorkflowNotFound) {
+			t.Fatalf("second Delete error = %v, want ErrWorkflowNotFound", err)
+		}
+	})
+}
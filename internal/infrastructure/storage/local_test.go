@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/Ecom-micro-template/service-support/internal/domain/attachment"
+	"github.com/Ecom-micro-template/service-support/internal/infrastructure/repotest"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	repotest.BlobStoreContract(t, func(t *testing.T) attachment.BlobStore {
+		store, err := NewLocalBlobStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewLocalBlobStore: %v", err)
+		}
+		return store
+	})
+}
@@ -0,0 +1,24 @@
+-- Transactional outbox for ticket domain events. Rows are written in the same
+-- transaction as the ticket change and relayed to NATS by the outbox relay.
+CREATE TABLE IF NOT EXISTS support.outbox_events (
+    id           UUID PRIMARY KEY,
+    sequence     BIGSERIAL NOT NULL UNIQUE,
+    aggregate_id UUID NOT NULL,
+    event_type   VARCHAR(100) NOT NULL,
+    subject      VARCHAR(255) NOT NULL,
+    payload      JSONB NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts     INT NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    published_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON support.outbox_events (aggregate_id, sequence)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_status
+    ON support.outbox_events (status, available_at);
@@ -0,0 +1,8 @@
+-- Track SLA warnings and breaches so the monitor records each only once.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS sla_warned_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS sla_breached_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_due
+    ON support.tickets (sla_deadline)
+    WHERE sla_breached_at IS NULL AND status NOT IN ('resolved', 'closed');
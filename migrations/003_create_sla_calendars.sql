@@ -0,0 +1,26 @@
+-- Business-hours calendars for SLA deadlines. A calendar without a category
+-- is the global calendar; a category calendar overrides it.
+CREATE TABLE IF NOT EXISTS support.sla_calendars (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          VARCHAR(100) NOT NULL,
+    timezone      VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    category_id   UUID REFERENCES support.categories (id) ON DELETE CASCADE,
+    working_hours JSONB NOT NULL DEFAULT '[]',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_calendars_category
+    ON support.sla_calendars (category_id)
+    WHERE category_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_calendars_global
+    ON support.sla_calendars ((category_id IS NULL))
+    WHERE category_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS support.sla_holidays (
+    calendar_id UUID NOT NULL REFERENCES support.sla_calendars (id) ON DELETE CASCADE,
+    date        DATE NOT NULL,
+    name        VARCHAR(255),
+    PRIMARY KEY (calendar_id, date)
+);
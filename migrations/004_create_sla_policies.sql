@@ -0,0 +1,28 @@
+-- First response and resolution targets per priority. A policy without a
+-- category applies to categories that have no policy of their own.
+CREATE TABLE IF NOT EXISTS support.sla_policies (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                   VARCHAR(100) NOT NULL,
+    priority               VARCHAR(20) NOT NULL,
+    category_id            UUID REFERENCES support.categories (id) ON DELETE CASCADE,
+    first_response_minutes INTEGER NOT NULL CHECK (first_response_minutes > 0),
+    resolution_minutes     INTEGER NOT NULL CHECK (resolution_minutes > 0),
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_policies_category
+    ON support.sla_policies (category_id, priority)
+    WHERE category_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_policies_global
+    ON support.sla_policies (priority)
+    WHERE category_id IS NULL;
+
+-- Targets a ticket was opened with, and its first response deadline.
+-- sla_deadline remains the resolution deadline and is cleared while the
+-- ticket is pending.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS sla_first_response_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS sla_resolution_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS first_response_deadline TIMESTAMPTZ;
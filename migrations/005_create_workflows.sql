@@ -0,0 +1,39 @@
+-- Ticket workflows: the statuses a category uses and the transitions between
+-- them. Categories without a workflow use the default one, or the built-in
+-- open/pending/in_progress/resolved/closed workflow when none is stored.
+CREATE TABLE IF NOT EXISTS support.workflows (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        VARCHAR(100) NOT NULL,
+    is_default  BOOLEAN NOT NULL DEFAULT FALSE,
+    states      JSONB NOT NULL DEFAULT '[]',
+    transitions JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflows_default
+    ON support.workflows (is_default)
+    WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS support.workflow_categories (
+    category_id UUID PRIMARY KEY REFERENCES support.categories (id) ON DELETE CASCADE,
+    workflow_id UUID NOT NULL REFERENCES support.workflows (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_categories_workflow
+    ON support.workflow_categories (workflow_id);
+
+-- Whether the ticket's status is active in its workflow. Overdue filters and
+-- the SLA monitor use it, since custom workflows define their own statuses.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+UPDATE support.tickets
+SET is_active = FALSE
+WHERE status IN ('resolved', 'closed');
+
+DROP INDEX IF EXISTS support.idx_tickets_sla_due;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_sla_due
+    ON support.tickets (sla_deadline)
+    WHERE sla_breached_at IS NULL AND is_active;
@@ -0,0 +1,20 @@
+-- Per-day counters ticket numbers are built from. The scope is the number
+-- pattern, so brands sharing a pattern share a counter and never collide.
+CREATE TABLE IF NOT EXISTS support.ticket_number_counters (
+    scope      VARCHAR(100) NOT NULL,
+    day        DATE NOT NULL,
+    last_value BIGINT NOT NULL,
+    PRIMARY KEY (scope, day)
+);
+
+-- Seed today's default counter past numbers already issued, so the switch
+-- from clock-based numbers does not collide with them.
+INSERT INTO support.ticket_number_counters (scope, day, last_value)
+SELECT 'TKT-{date}-{seq}', CURRENT_DATE, COALESCE(MAX(CAST(SPLIT_PART(ticket_number, '-', 3) AS BIGINT)), 0)
+FROM support.tickets
+WHERE ticket_number LIKE 'TKT-' || TO_CHAR(CURRENT_DATE, 'YYYYMMDD') || '-%'
+ON CONFLICT (scope, day) DO NOTHING;
+
+-- Custom prefixes and sequences past 9999 need more room.
+ALTER TABLE support.tickets
+    ALTER COLUMN ticket_number TYPE VARCHAR(40);
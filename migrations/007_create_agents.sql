@@ -0,0 +1,26 @@
+-- Support agents tickets are routed to automatically. The ID is the agent's
+-- user ID; a capacity of 0 means no limit on open tickets.
+CREATE TABLE IF NOT EXISTS support.agents (
+    id               UUID PRIMARY KEY,
+    name             VARCHAR(255) NOT NULL,
+    email            VARCHAR(255),
+    is_available     BOOLEAN NOT NULL DEFAULT TRUE,
+    capacity         INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    last_assigned_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Why the ticket went to its agent: "manual" or the strategy's reason.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS assignment_reason VARCHAR(255);
+
+-- Agent workload for least-loaded assignment.
+CREATE INDEX IF NOT EXISTS idx_tickets_active_assignee
+    ON support.tickets (assigned_to)
+    WHERE is_active AND assigned_to IS NOT NULL;
+
+-- Previous agent lookup for sticky assignment.
+CREATE INDEX IF NOT EXISTS idx_tickets_customer_assigned
+    ON support.tickets (customer_id, created_at DESC)
+    WHERE assigned_to IS NOT NULL;
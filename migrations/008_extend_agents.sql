@@ -0,0 +1,19 @@
+-- Agent directory profiles. Presence replaces the availability flag: only
+-- online agents are given new tickets.
+ALTER TABLE support.agents
+    ADD COLUMN IF NOT EXISTS role      VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS teams     TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS status    VARCHAR(20) NOT NULL DEFAULT 'offline'
+        CHECK (status IN ('online', 'away', 'offline')),
+    ADD COLUMN IF NOT EXISTS timezone  VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS signature TEXT;
+
+UPDATE support.agents
+SET status = CASE WHEN is_available THEN 'online' ELSE 'offline' END;
+
+ALTER TABLE support.agents DROP COLUMN IF EXISTS is_available;
+
+ALTER TABLE support.agents RENAME COLUMN capacity TO max_concurrent_tickets;
+
+CREATE INDEX IF NOT EXISTS idx_agents_teams
+    ON support.agents USING GIN (teams);
@@ -0,0 +1,27 @@
+-- Support teams such as Billing or Logistics. Agents list the keys of their
+-- teams; new tickets in a team's categories join its queue.
+CREATE TABLE IF NOT EXISTS support.teams (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key         VARCHAR(50) NOT NULL UNIQUE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS support.team_categories (
+    category_id UUID PRIMARY KEY REFERENCES support.categories (id) ON DELETE CASCADE,
+    team_id     UUID NOT NULL REFERENCES support.teams (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_categories_team
+    ON support.team_categories (team_id);
+
+-- The team whose queue the ticket is in, if any.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS team_id UUID;
+
+-- Team queues: unassigned and in-progress tickets per team.
+CREATE INDEX IF NOT EXISTS idx_tickets_team_queue
+    ON support.tickets (team_id, created_at DESC)
+    WHERE team_id IS NOT NULL AND is_active;
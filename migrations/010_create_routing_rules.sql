@@ -0,0 +1,22 @@
+-- Agent skills, e.g. [{"name": "malay", "level": 4}], levels 1 to 5.
+ALTER TABLE support.agents
+    ADD COLUMN IF NOT EXISTS skills JSONB NOT NULL DEFAULT '[]';
+
+-- Skills-based routing rules, evaluated in position order. The first
+-- enabled rule whose conditions match a ticket picks the highest-scoring
+-- agent holding its required skills, or sends the ticket to the fallback
+-- team's queue when nobody qualifies.
+CREATE TABLE IF NOT EXISTS support.routing_rules (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name             VARCHAR(100) NOT NULL,
+    position         INTEGER NOT NULL DEFAULT 0,
+    enabled          BOOLEAN NOT NULL DEFAULT TRUE,
+    conditions       JSONB NOT NULL DEFAULT '{}',
+    required_skills  JSONB NOT NULL DEFAULT '[]',
+    fallback_team_id UUID,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_routing_rules_position
+    ON support.routing_rules (position, name);
@@ -0,0 +1,40 @@
+-- The channel a ticket arrived through: web or email.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS channel VARCHAR(20) NOT NULL DEFAULT 'web';
+
+-- Trigger rules: when one of the events happens to a ticket and the
+-- conditions hold, the actions are applied. Rules run in position order.
+CREATE TABLE IF NOT EXISTS support.trigger_rules (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+    position    INTEGER NOT NULL DEFAULT 0,
+    enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    events      TEXT[] NOT NULL,
+    conditions  JSONB NOT NULL DEFAULT '{}',
+    actions     JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trigger_rules_events
+    ON support.trigger_rules USING GIN (events)
+    WHERE enabled;
+
+-- Log of rule firings and the outcome of each action. Entries outlive
+-- their rule.
+CREATE TABLE IF NOT EXISTS support.trigger_firings (
+    id        UUID PRIMARY KEY,
+    rule_id   UUID NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    ticket_id UUID NOT NULL,
+    event     VARCHAR(30) NOT NULL,
+    actions   JSONB NOT NULL DEFAULT '[]',
+    fired_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trigger_firings_rule
+    ON support.trigger_firings (rule_id, fired_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_trigger_firings_ticket
+    ON support.trigger_firings (ticket_id, fired_at DESC);
@@ -0,0 +1,38 @@
+-- Time-based automations per category. A policy without a category applies
+-- to categories that have no policy of their own; zero turns an automation
+-- off.
+CREATE TABLE IF NOT EXISTS support.automation_policies (
+    id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                     VARCHAR(100) NOT NULL,
+    category_id              UUID REFERENCES support.categories (id) ON DELETE CASCADE,
+    auto_close_minutes       INTEGER NOT NULL DEFAULT 0 CHECK (auto_close_minutes >= 0),
+    pending_reminder_minutes INTEGER NOT NULL DEFAULT 0 CHECK (pending_reminder_minutes >= 0),
+    pending_resolve_minutes  INTEGER NOT NULL DEFAULT 0 CHECK (pending_resolve_minutes >= 0),
+    stale_minutes            INTEGER NOT NULL DEFAULT 0 CHECK (stale_minutes >= 0),
+    created_at               TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at               TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_automation_policies_category
+    ON support.automation_policies (category_id)
+    WHERE category_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_automation_policies_global
+    ON support.automation_policies ((category_id IS NULL))
+    WHERE category_id IS NULL;
+
+-- When the ticket last moved (status change, agent activity or, while
+-- pending, a customer reply), and what the automations did since.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS idle_since TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS stale_at TIMESTAMPTZ;
+
+UPDATE support.tickets SET idle_since = updated_at WHERE idle_since IS NULL;
+
+ALTER TABLE support.tickets
+    ALTER COLUMN idle_since SET NOT NULL,
+    ALTER COLUMN idle_since SET DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_tickets_idle
+    ON support.tickets (status, idle_since);
@@ -0,0 +1,8 @@
+-- The ticket a merged ticket was merged into. Lookups of the merged ticket
+-- follow it to the target.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS merged_into_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_merged_into
+    ON support.tickets (merged_into_id)
+    WHERE merged_into_id IS NOT NULL;
@@ -0,0 +1,28 @@
+-- Links between tickets. A link points from ticket_id to linked_ticket_id:
+-- from the parent to its child, the duplicate to the original and the
+-- blocking ticket to the blocked one; related links read both ways. Two
+-- tickets are linked at most once.
+CREATE TABLE IF NOT EXISTS support.ticket_links (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id        UUID NOT NULL,
+    linked_ticket_id UUID NOT NULL,
+    type             VARCHAR(20) NOT NULL,
+    created_by       UUID,
+    created_by_name  VARCHAR(255),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ticket_id <> linked_ticket_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_links_pair
+    ON support.ticket_links (LEAST(ticket_id, linked_ticket_id), GREATEST(ticket_id, linked_ticket_id));
+
+CREATE INDEX IF NOT EXISTS idx_ticket_links_ticket
+    ON support.ticket_links (ticket_id);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_links_linked_ticket
+    ON support.ticket_links (linked_ticket_id);
+
+-- A ticket has at most one parent
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_links_parent
+    ON support.ticket_links (linked_ticket_id)
+    WHERE type = 'parent';
@@ -0,0 +1,9 @@
+-- Agents following a ticket and the extra addresses the customer copies on
+-- it. Watchers are told about replies and status changes; CC addresses
+-- receive replies.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS watcher_ids UUID[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS cc_emails TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tickets_watcher_ids
+    ON support.tickets USING GIN (watcher_ids);
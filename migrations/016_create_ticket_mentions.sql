@@ -0,0 +1,22 @@
+-- Agents mentioned with @handles in internal notes. read_at is set once the
+-- agent has seen the mention.
+CREATE TABLE IF NOT EXISTS support.ticket_mentions (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id         UUID NOT NULL,
+    message_id        UUID NOT NULL,
+    agent_id          UUID NOT NULL,
+    mentioned_by      UUID,
+    mentioned_by_name VARCHAR(255),
+    read_at           TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_mentions_agent
+    ON support.ticket_mentions (agent_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_mentions_agent_unread
+    ON support.ticket_mentions (agent_id)
+    WHERE read_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_ticket_mentions_ticket
+    ON support.ticket_mentions (ticket_id);
@@ -0,0 +1,10 @@
+-- RFC 5322 threading headers of messages sent or received by email. Inbound
+-- mail is matched to its ticket by the Message-IDs it replies to.
+ALTER TABLE support.messages
+    ADD COLUMN IF NOT EXISTS message_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS in_reply_to TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reference_ids TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_messages_message_id
+    ON support.messages (message_id)
+    WHERE message_id <> '';
@@ -0,0 +1,47 @@
+-- Customer email notifications: admin-written templates per event and
+-- locale, and the send log of every notification queued for a ticket.
+CREATE TABLE IF NOT EXISTS support.notification_templates (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event      VARCHAR(30) NOT NULL,
+    locale     VARCHAR(35) NOT NULL,
+    subject    TEXT NOT NULL,
+    body       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event, locale)
+);
+
+CREATE TABLE IF NOT EXISTS support.notification_deliveries (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id       UUID NOT NULL,
+    event           VARCHAR(30) NOT NULL,
+    locale          VARCHAR(35) NOT NULL,
+    to_address      VARCHAR(255) NOT NULL,
+    cc_addresses    TEXT[] NOT NULL DEFAULT '{}',
+    reply_to        VARCHAR(255) NOT NULL DEFAULT '',
+    subject         TEXT NOT NULL,
+    body            TEXT NOT NULL,
+    message_id      TEXT NOT NULL DEFAULT '',
+    in_reply_to     TEXT NOT NULL DEFAULT '',
+    reference_ids   TEXT[] NOT NULL DEFAULT '{}',
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMPTZ,
+    sent_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_ticket
+    ON support.notification_deliveries (ticket_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due
+    ON support.notification_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+-- Language customer email is written in
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '';
@@ -0,0 +1,24 @@
+-- Files uploaded to tickets or received by email. ticket_id is null for email
+-- attachments until their message is posted; message_id is null until the
+-- file is posted in a message. Contents live in the blob store under
+-- storage_key.
+CREATE TABLE IF NOT EXISTS support.attachments (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id     UUID,
+    message_id    UUID,
+    name          VARCHAR(255) NOT NULL,
+    content_type  VARCHAR(255) NOT NULL,
+    size          BIGINT NOT NULL CHECK (size > 0),
+    checksum      VARCHAR(64) NOT NULL,
+    storage_key   VARCHAR(500) NOT NULL UNIQUE,
+    uploaded_by   UUID,
+    uploader_type VARCHAR(20) NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_ticket
+    ON support.attachments (ticket_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_message
+    ON support.attachments (message_id)
+    WHERE message_id IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Audit log of attachment downloads through signed links: who the link was
+-- issued to and where the download came from.
+CREATE TABLE IF NOT EXISTS support.attachment_downloads (
+    id            UUID PRIMARY KEY,
+    attachment_id UUID NOT NULL,
+    ticket_id     UUID NOT NULL,
+    user_id       UUID NOT NULL,
+    user_type     VARCHAR(20) NOT NULL,
+    ip_address    VARCHAR(45),
+    user_agent    VARCHAR(500),
+    downloaded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_downloads_attachment
+    ON support.attachment_downloads (attachment_id, downloaded_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_downloads_ticket
+    ON support.attachment_downloads (ticket_id, downloaded_at DESC);
@@ -0,0 +1,4 @@
+-- Save counter for optimistic locking. A save only updates the row holding
+-- the version it loaded, so concurrent writers cannot overwrite each other.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
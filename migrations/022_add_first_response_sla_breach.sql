@@ -0,0 +1,9 @@
+-- Track first response warnings and breaches so the SLA monitor records
+-- each once, like those of the resolution deadline.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS first_response_warned_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS first_response_breached_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_first_response_due
+    ON support.tickets (first_response_deadline)
+    WHERE first_response_breached_at IS NULL AND first_response_at IS NULL AND is_active;
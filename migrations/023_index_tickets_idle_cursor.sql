@@ -0,0 +1,6 @@
+-- Automations page through idle tickets on (idle_since, id), so ties in
+-- idle_since are neither skipped nor listed twice.
+DROP INDEX IF EXISTS support.idx_tickets_idle;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_idle
+    ON support.tickets (status, idle_since, id);
@@ -0,0 +1,30 @@
+-- Registered customers, kept in line with the identity service's user
+-- events. Account tickets only hold customer_id, so notifications and the
+-- merge check look the customer's email address up here.
+CREATE TABLE IF NOT EXISTS support.customers (
+    id         UUID PRIMARY KEY,
+    email      VARCHAR(255) NOT NULL,
+    name       VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Backfill the customers who opened tickets before the directory existed:
+-- the address they last wrote from on one of their tickets, else the guest
+-- email of their latest ticket. The identity service's next event about
+-- each customer brings the entry up to date.
+INSERT INTO support.customers (id, email, name)
+SELECT DISTINCT ON (id) id, email, name
+FROM (
+    SELECT m.sender_id AS id, m.sender_email AS email, COALESCE(m.sender_name, '') AS name,
+           1 AS source, m.created_at AS seen_at
+    FROM support.messages m
+    JOIN support.tickets t ON t.id = m.ticket_id AND t.customer_id = m.sender_id
+    WHERE m.sender_type = 'customer' AND COALESCE(m.sender_email, '') <> ''
+    UNION ALL
+    SELECT customer_id, guest_email, COALESCE(guest_name, ''), 2, created_at
+    FROM support.tickets
+    WHERE customer_id IS NOT NULL AND COALESCE(guest_email, '') <> ''
+) seen
+ORDER BY id, source, seen_at DESC
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,4 @@
+-- Keep the brand a ticket was opened for so tickets split from it are
+-- numbered in the same format.
+ALTER TABLE support.tickets
+    ADD COLUMN IF NOT EXISTS brand VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- Inbound mail is threaded by the Message-IDs of the notifications it
+-- replies to.
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_message_id
+    ON support.notification_deliveries (message_id)
+    WHERE message_id <> '';